@@ -58,8 +58,51 @@ type DisposableRequestParameters struct {
 
 	// SecretInjectionConfig specifies the secrets receiving patches from response data.
 	SecretInjectionConfigs []SecretInjectionConfig `json:"secretInjectionConfigs,omitempty"`
+
+	// SecretInjectionConflicts decides what happens when several secret injections write the same key of a
+	// secret: Warn logs the conflict and writes the value of the last of them, in the order of
+	// secretInjectionConfigs, while Error writes none of them and reports the conflict. Defaults to Warn.
+	// +kubebuilder:validation:Enum=Warn;Error
+	// +kubebuilder:default=Warn
+	// +optional
+	SecretInjectionConflicts SecretInjectionConflictPolicy `json:"secretInjectionConflicts,omitempty"`
+
+	// OwnInjectedSecrets sets this resource as the controller owner of the secrets its injections create, so
+	// that other Requests and DisposableRequests don't write to them, and they are deleted along with it.
+	// +optional
+	OwnInjectedSecrets bool `json:"ownInjectedSecrets,omitempty"`
+
+	// ResponseFormat defines how the response body is parsed for the expected response and the secret injections.
+	// JSON, the default, parses the body as a single document. NDJSON parses newline-delimited JSON, and exposes
+	// .body as the list of its records. Text exposes .body as a string. Sniff parses the body according to its
+	// Content-Type header, and detects JSON by a leading { or [ when there is none.
+	// +kubebuilder:validation:Enum=JSON;NDJSON;Text;Sniff
+	ResponseFormat ResponseFormat `json:"responseFormat,omitempty"`
+
+	// OnDelete is an optional request sent when the DisposableRequest is deleted, e.g. to revoke what the
+	// original request created. Its url, body and headers are jq expressions evaluated against the forProvider
+	// fields and the response captured in status. When omitted, deletion doesn't send any request.
+	OnDelete *Mapping `json:"onDelete,omitempty"`
 }
 
+// ResponseFormat defines how a response body is parsed.
+type ResponseFormat string
+
+const (
+	// ResponseFormatJSON parses the body as a single JSON document.
+	ResponseFormatJSON ResponseFormat = "JSON"
+
+	// ResponseFormatNDJSON parses the body as newline-delimited JSON records.
+	ResponseFormatNDJSON ResponseFormat = "NDJSON"
+
+	// ResponseFormatText exposes the body as a string, even when it is valid JSON.
+	ResponseFormatText ResponseFormat = "Text"
+
+	// ResponseFormatSniff parses the body according to its Content-Type header, or to its first character when
+	// the response has none.
+	ResponseFormatSniff ResponseFormat = "Sniff"
+)
+
 // A DisposableRequestSpec defines the desired state of a DisposableRequest.
 type DisposableRequestSpec struct {
 	xpv1.ResourceSpec `json:",inline"`
@@ -72,12 +115,50 @@ type SecretInjectionConfig struct {
 	SecretRef SecretRef `json:"secretRef"`
 
 	// SecretKey is the key within the Kubernetes secret where the data will be injected.
-	SecretKey string `json:"secretKey"`
+	// When omitted, ResponsePath must return an object and each of its fields is injected in a key named after
+	// the field, transformed with KeyTransform.
+	// +optional
+	SecretKey string `json:"secretKey,omitempty"`
 
 	// ResponsePath is is a jq filter expression represents the path in the response where the secret value will be extracted from.
 	ResponsePath string `json:"responsePath"`
+
+	// Condition is an optional jq filter expression evaluated against the response that must return a boolean.
+	// If it returns false, the injection is skipped and the existing secret is left untouched.
+	// Example: '.body.status == "active"'
+	Condition string `json:"condition,omitempty"`
+
+	// KeyTransform is the casing of the keys named after the fields of the object returned by ResponsePath,
+	// when SecretKey is omitted: asIs keeps the field names, snakeUpper turns apiKey into API_KEY and kebab
+	// turns it into api-key.
+	// +kubebuilder:validation:Enum=asIs;snakeUpper;kebab
+	// +kubebuilder:default=asIs
+	// +optional
+	KeyTransform KeyTransform `json:"keyTransform,omitempty"`
 }
 
+// SecretInjectionConflictPolicy decides what happens when several secret injections write the same key.
+type SecretInjectionConflictPolicy string
+
+const (
+	// SecretInjectionConflictWarn logs the conflict and writes the value of the last injection.
+	SecretInjectionConflictWarn SecretInjectionConflictPolicy = "Warn"
+	// SecretInjectionConflictError writes none of the conflicting values and reports the conflict.
+	SecretInjectionConflictError SecretInjectionConflictPolicy = "Error"
+)
+
+// KeyTransform is the casing of the secret keys named after response fields.
+type KeyTransform string
+
+const (
+	// KeyTransformAsIs keeps the names of the fields.
+	KeyTransformAsIs KeyTransform = "asIs"
+	// KeyTransformSnakeUpper names the keys in upper snake case, e.g. API_KEY.
+	KeyTransformSnakeUpper KeyTransform = "snakeUpper"
+	// KeyTransformKebab names the keys in kebab case, e.g. api-key.
+	KeyTransformKebab KeyTransform = "kebab"
+)
+
 // SecretRef contains the name and namespace of a Kubernetes secret.
 type SecretRef struct {
 	// Name is the name of the Kubernetes secret.
@@ -109,6 +190,10 @@ type DisposableRequestStatus struct {
 	Synced              bool     `json:"synced,omitempty"`
 	RequestDetails      Mapping  `json:"requestDetails,omitempty"`
 
+	// OnDeleteFailed counts the failed attempts to send the onDelete request. Once it reaches the rollback
+	// retries limit, deletion finishes without the onDelete request succeeding.
+	OnDeleteFailed int32 `json:"onDeleteFailed,omitempty"`
+
 	// LastReconcileTime records the last time the resource was reconciled.
 	LastReconcileTime metav1.Time `json:"lastReconcileTime,omitempty"`
 }
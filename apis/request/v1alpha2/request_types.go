@@ -36,22 +36,784 @@ type RequestParameters struct {
 	// Headers defines default headers for each request.
 	Headers map[string][]string `json:"headers,omitempty"`
 
+	// BaseURL is the URL the mappings whose URL resolves to a path, such as "/users/" + .response.body.id, are
+	// sent to. The path is appended to the path of the base URL, whether it starts with a slash or not, and its
+	// query parameters are added to the ones of the base URL. Mappings whose URL resolves to an absolute URL
+	// ignore it.
+	// +optional
+	BaseURL string `json:"baseURL,omitempty"`
+
 	// WaitTimeout specifies the maximum time duration for waiting.
 	WaitTimeout *metav1.Duration `json:"waitTimeout,omitempty"`
 
 	// InsecureSkipTLSVerify, when set to true, skips TLS certificate checks for the HTTP request
 	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
 
+	// CABundle is a PEM encoded bundle of CA certificates trusted for the TLS connections of this Request, on
+	// top of the system ones. It replaces the CA bundle of the ProviderConfig, and must hold at least one
+	// valid certificate.
+	// +optional
+	CABundle string `json:"caBundle,omitempty"`
+
 	// SecretInjectionConfig specifies the secrets receiving patches for response data.
 	SecretInjectionConfigs []SecretInjectionConfig `json:"secretInjectionConfigs,omitempty"`
+
+	// SecretInjectionConflicts decides what happens when several secret injections write the same key of a
+	// secret: Warn logs the conflict and writes the value of the last of them, in the order of
+	// secretInjectionConfigs, while Error writes none of them and reports the conflict. Defaults to Warn.
+	// +kubebuilder:validation:Enum=Warn;Error
+	// +kubebuilder:default=Warn
+	// +optional
+	SecretInjectionConflicts SecretInjectionConflictPolicy `json:"secretInjectionConflicts,omitempty"`
+
+	// OwnInjectedSecrets sets this resource as the controller owner of the secrets its injections create, so
+	// that other Requests and DisposableRequests don't write to them, and they are deleted along with it.
+	// +optional
+	OwnInjectedSecrets bool `json:"ownInjectedSecrets,omitempty"`
+
+	// ConnectionDetails are the values of the latest successful response published to the connection secret of
+	// the Request, set with writeConnectionSecretToRef or publishConnectionDetailsTo.
+	// +optional
+	ConnectionDetails []ConnectionDetail `json:"connectionDetails,omitempty"`
+
+	// AnnotationInjectionConfigs specifies the annotations of the Request receiving values from successful responses,
+	// for other tooling to read. An annotation is only updated when its value changes.
+	AnnotationInjectionConfigs []AnnotationInjectionConfig `json:"annotationInjectionConfigs,omitempty"`
+
+	// RetryableResponse is a jq filter expression used to evaluate successful HTTP responses and determine
+	// whether they carry a transient application-level error. The expression should return a boolean; if true,
+	// the request is marked as failed and retried even though the status code indicates success.
+	// Example: '.body.error.code == "RATE_LIMITED"'
+	RetryableResponse string `json:"retryableResponse,omitempty"`
+
+	// ResetFailuresCondition is a jq filter expression evaluated against the responses that fail the request. The
+	// expression should return a boolean; if true, the failure is still reported but the failures counter is reset
+	// instead of incremented, so the responses of a transient class don't add up.
+	// Example: '.headers["X-Retry-Fresh"][0] == "true"'
+	ResetFailuresCondition string `json:"resetFailuresCondition,omitempty"`
+
+	// ExistsCondition is a jq filter expression evaluated against the GET response that decides whether the object
+	// exists, overriding the status code and empty body heuristics. The expression should return a boolean.
+	// The drift comparison against the desired state is unaffected.
+	// Example: '.body.items | length > 0'
+	ExistsCondition string `json:"existsCondition,omitempty"`
+
+	// ReadyCondition is a jq filter expression evaluated against the GET response that decides whether the object
+	// is operationally ready. The resource only reports Ready when it returns true, while still being observed as
+	// existing and up to date. The expression should return a boolean.
+	// Example: '.body.state == "running"'
+	ReadyCondition string `json:"readyCondition,omitempty"`
+
+	// JQObject customizes the root keys of the object the mapping templates are evaluated against.
+	// When omitted, the forProvider fields are merged at the root alongside the response.
+	JQObject *JQObjectConfig `json:"jqObject,omitempty"`
+
+	// Defaults is a JSON object of default values for the forProvider fields, deep merged beneath them before the
+	// mapping templates are evaluated, so that a family of similar Requests can share most of their fields and
+	// only set what differs. Nested objects are merged, including the body of the payload, while any other value
+	// set by the Request, arrays included, replaces the default.
+	// Example: '{"payload": {"body": {"region": "eu-west-1", "tier": "standard"}}}'
+	// +optional
+	Defaults string `json:"defaults,omitempty"`
+
+	// URLNormalization configures how generated URLs are normalized before a request is sent.
+	// When omitted, URLs are sent exactly as the mapping templates produce them.
+	URLNormalization *URLNormalization `json:"urlNormalization,omitempty"`
+
+	// DebugArtifact, when set, exports the sanitized request and response of the most recent HTTP call,
+	// to help capture reproductions without running the provider in debug mode.
+	DebugArtifact *DebugArtifact `json:"debugArtifact,omitempty"`
+
+	// CreateStrategy defines how the existence of the object is established before it is created.
+	// observeFirst, the default, creates the object when it can't be observed. createOrConflict sends the POST
+	// request first and treats a conflict response as the object already existing, for create-idempotent APIs.
+	// +kubebuilder:validation:Enum=observeFirst;createOrConflict
+	CreateStrategy CreateStrategy `json:"createStrategy,omitempty"`
+
+	// ConflictStatusCode is the status code of a POST response meaning the object already exists,
+	// when the create strategy is createOrConflict. Defaults to 409.
+	// +kubebuilder:validation:Minimum=400
+	// +kubebuilder:validation:Maximum=599
+	ConflictStatusCode *int32 `json:"conflictStatusCode,omitempty"`
+
+	// Upsert chooses the method of every write by whether the object exists, for APIs whose endpoint both
+	// creates and updates it. Before writing, the GET request is sent: the PUT request is sent when it finds the
+	// object, and the POST request when it doesn't, whether the object is being created or updated. The usual
+	// method is kept when there is no GET mapping or its response doesn't tell.
+	// +optional
+	Upsert bool `json:"upsert,omitempty"`
+
+	// SkipNoOpUpdates skips the PUT requests whose body is identical, after canonicalization, to the body of
+	// the last successful one, for APIs whose responses the drift check misreads as out of date. Drift made
+	// outside of the Request is then only corrected once the desired body changes.
+	// +optional
+	SkipNoOpUpdates bool `json:"skipNoOpUpdates,omitempty"`
+
+	// InitialDelay is how long to wait after the creation of the resource before sending the first request,
+	// for external systems that need a settling period after a dependency is created.
+	InitialDelay *metav1.Duration `json:"initialDelay,omitempty"`
+
+	// DependsOn lists the names of the Requests this one waits for. Nothing is sent until each of them is
+	// ready, and the Request reports a WaitingForDependency condition meanwhile. Deleting the Request doesn't
+	// wait for them.
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// ObserveRetries configures quick retries of the GET request observing the object when it fails to get a
+	// response or gets a 5xx one, so that a transient failure doesn't fail the reconcile. The retries are sent
+	// within the reconcile, and are independent from the rollback retries.
+	// +optional
+	ObserveRetries *ObserveRetries `json:"observeRetries,omitempty"`
+
+	// SubResources are child objects observed along with the object of the GET mapping, each with a GET request
+	// of its own. The Request is up to date only when the object and every sub-resource match their desired
+	// state, and the sub-resources that drifted are listed in status.driftedSubResources.
+	// +optional
+	SubResources []SubResource `json:"subResources,omitempty"`
+
+	// ForEach creates an external resource for every element of an array, rather than a single one. The mappings
+	// are rendered for each element, exposed as .item, with the response that created its external resource as
+	// .response, and the state of every external resource is recorded in status.items. Operations, pagination and
+	// sub-resources don't apply to the elements.
+	// +optional
+	ForEach *ForEach `json:"forEach,omitempty"`
+
+	// TypeComparison controls how the types of the fields are compared when checking the GET response against
+	// the desired state. Strict fails the observation when a field has a different JSON type in the response,
+	// to surface schema mismatches. Lenient coerces comparable scalars, so "5" equals 5 and "true" equals true.
+	// When omitted, values are compared as-is and a type difference is reported as drift.
+	// +kubebuilder:validation:Enum=Strict;Lenient
+	TypeComparison TypeComparisonMode `json:"typeComparison,omitempty"`
+
+	// ResponseClassification overrides how responses are interpreted. Rules are evaluated in order and the first
+	// matching one decides the outcome of the response. Responses matching no rule keep the default interpretation:
+	// 2xx responses are successes, 404 means not found, and other 4xx and 5xx responses are terminal errors.
+	ResponseClassification []ResponseClassificationRule `json:"responseClassification,omitempty"`
+
+	// LatencyMeasurement defines what the latency reported in the status covers. Total, the default, measures a
+	// successful request from the first of the failed attempts preceding it, so retries are included.
+	// PerAttempt measures every attempt on its own, whether it failed or not.
+	// +kubebuilder:validation:Enum=Total;PerAttempt
+	LatencyMeasurement LatencyMeasurement `json:"latencyMeasurement,omitempty"`
+
+	// PreRequest is sent before each request to the server, typically to obtain a short lived token. Its
+	// response is exposed to the mappings as .preRequest, e.g. .preRequest.body.token. Only its method, URL,
+	// body and headers are used. Values derived from its response are redacted from the status and logs.
+	// +optional
+	PreRequest *Mapping `json:"preRequest,omitempty"`
+
+	// DriftDetection defines how the state of the object is observed. With get, the default, the GET mapping is
+	// sent and its response compared against the desired state. With none, for APIs without a GET endpoint, no
+	// request is sent to observe: the object exists once a POST request succeeded, and is up to date until the
+	// spec changes after the last successful POST or PUT request.
+	// +kubebuilder:validation:Enum=get;none
+	DriftDetection DriftDetectionMode `json:"driftDetection,omitempty"`
+
+	// AsyncOperation tracks the long running operations started by POST, PUT and DELETE requests, for APIs that
+	// answer with the URL of an operation to poll instead of completing the request synchronously.
+	// +optional
+	AsyncOperation *AsyncOperation `json:"asyncOperation,omitempty"`
+
+	// PollInterval is how long to wait between the observations of this Request, in place of the poll interval
+	// of the provider, to poll critical objects more often and noisy ones less. Adaptive polling takes
+	// precedence over it.
+	// +optional
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+
+	// AdaptivePolling derives the interval until the next observation from the GET response, to poll more often
+	// while the object is provisioning and less once it is stable.
+	// +optional
+	AdaptivePolling *AdaptivePolling `json:"adaptivePolling,omitempty"`
+
+	// UnorderedArrays designates the arrays compared regardless of the order of their elements when checking the
+	// GET response against the desired state, for servers that reorder lists.
+	// +optional
+	UnorderedArrays []UnorderedArray `json:"unorderedArrays,omitempty"`
+
+	// UpToDateCondition is a jq filter expression returning whether the object is up to date, in place of the
+	// built-in check that the GET response contains the desired state. It is evaluated against .desired, the
+	// body of the PUT mapping, and .observed, the body of the GET response, e.g. '.observed.tags == .desired.tags'.
+	// The GET request must still succeed for the object to be up to date.
+	// +optional
+	UpToDateCondition string `json:"upToDateCondition,omitempty"`
+
+	// ResponseTransform is a jq expression reshaping the successful responses of the mappings before anything
+	// else sees them. It is evaluated against the response, e.g. .body | {id, name, status}, and its result
+	// replaces the body in the status, in the comparison against the desired state, in secret injections and in
+	// the mappings that read .response. The raw body is discarded.
+	// +optional
+	ResponseTransform string `json:"responseTransform,omitempty"`
+
+	// SerializationKey is a jq expression returning the key the reconciles of this Request are serialized by,
+	// e.g. "orders-" + .payload.body.orderId. Requests with the same key are never reconciled concurrently, so
+	// that Requests touching the same backend object don't conflict; Requests with different keys, or none,
+	// still run in parallel. It is evaluated against the same object as the mappings.
+	// +optional
+	SerializationKey string `json:"serializationKey,omitempty"`
+
+	// MultiStatus interprets 207 Multi-Status responses, whose sub-operations may fail while the request as a
+	// whole returns 207. The request fails when one of the sub-operations did.
+	// +optional
+	MultiStatus *MultiStatus `json:"multiStatus,omitempty"`
+
+	// ErrorDetails extracts the code and message of the error from failure responses into status.errorCode and
+	// status.errorMessage, making failures machine-readable.
+	// +optional
+	ErrorDetails *ErrorDetails `json:"errorDetails,omitempty"`
+
+	// CompressStatusBodyAboveBytes is the size of the response body above which it is stored in the status
+	// gzipped and base64 encoded, prefixed with gzip+base64:, to keep large responses from bloating etcd. The
+	// body is decompressed whenever the provider reads it back. Smaller bodies are stored as is, and bodies are
+	// never compressed when unset.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	CompressStatusBodyAboveBytes *int64 `json:"compressStatusBodyAboveBytes,omitempty"`
+}
+
+// UnorderedArray designates an array compared regardless of the order of its elements.
+type UnorderedArray struct {
+	// Path is the path of the array in the desired state, made of object fields such as .tags or .spec.rules.
+	// Arrays along the path apply the rest of it to each of their elements, so .rules.ports designates the
+	// ports of every rule.
+	Path string `json:"path"`
+
+	// Key is the field identifying the elements of an array of objects, such as id. Elements are paired by key,
+	// and the desired element only has to be contained in the element of the response it is paired with.
+	// When omitted, elements are compared by value.
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// MultiStatus configures how the sub-operations of a 207 Multi-Status response are checked.
+type MultiStatus struct {
+	// Failures is a jq expression evaluated against the response that returns the array of the failed
+	// sub-operations, e.g. [.body.responses[] | select(.status >= 400)]. An empty array or null means that
+	// every sub-operation succeeded.
+	Failures string `json:"failures"`
+}
+
+// ErrorDetails configures how the details of an error are extracted from a failure response.
+type ErrorDetails struct {
+	// Code is a jq expression evaluated against the response that returns the code of the error,
+	// e.g. .body.error.code.
+	// +optional
+	Code string `json:"code,omitempty"`
+
+	// Message is a jq expression evaluated against the response that returns the message of the error,
+	// e.g. .body.error.message. The raw body is used as the message when it can't be extracted.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// AdaptivePolling configures how the poll interval is derived from the GET response.
+type AdaptivePolling struct {
+	// Interval is a jq filter expression evaluated against the GET response that returns the interval until the
+	// next observation: a duration such as "30s", a number of seconds, or a state listed in states.
+	// Example: '.body.status'
+	Interval string `json:"interval"`
+
+	// States maps the states returned by interval to poll intervals.
+	// Example: '{"Provisioning": "5s", "Ready": "10m"}'
+	// +optional
+	States map[string]metav1.Duration `json:"states,omitempty"`
+
+	// Default is the poll interval used when interval returns nothing usable. The poll interval of the provider
+	// is used when omitted.
+	// +optional
+	Default *metav1.Duration `json:"default,omitempty"`
+}
+
+// AsyncOperation configures how long running operations are tracked. An operation is polled once per reconcile,
+// and the resource is requeued until it is done or times out, so that no reconcile blocks while it runs.
+type AsyncOperation struct {
+	// URL is a jq filter expression evaluated against the response of a POST, PUT or DELETE request that returns
+	// the URL of the operation to poll. No operation is tracked when it returns an empty string or null.
+	// Example: '.headers.Location[0]'
+	URL string `json:"url"`
+
+	// DoneCondition is a jq filter expression evaluated against the response of the operation URL that returns
+	// true once the operation finished.
+	// Example: '.body.status == "Succeeded" or .body.status == "Failed"'
+	DoneCondition string `json:"doneCondition"`
+
+	// FailedCondition is an optional jq filter expression evaluated against the response of a finished operation
+	// that returns true if it failed.
+	// Example: '.body.status == "Failed"'
+	// +optional
+	FailedCondition string `json:"failedCondition,omitempty"`
+
+	// Timeout is how long the operation may run, from the request that started it, before it is given up on.
+	// Defaults to 10m.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// PollInterval is how long to wait between two polls of the operation. Defaults to 10s.
+	// +optional
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+}
+
+// ObserveRetries configures the retries of the GET request observing the object.
+type ObserveRetries struct {
+	// Limit is how many times a failed GET request is sent again before the failure is reported.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	Limit int32 `json:"limit"`
+
+	// Delay is how long to wait before each retry. Defaults to 1s.
+	// +optional
+	Delay *metav1.Duration `json:"delay,omitempty"`
+}
+
+// DriftDetectionMode defines how the state of the object is observed.
+type DriftDetectionMode string
+
+const (
+	// DriftDetectionGet observes the object with the GET mapping.
+	DriftDetectionGet DriftDetectionMode = "get"
+
+	// DriftDetectionNone observes the object from the last successful write recorded in the status.
+	DriftDetectionNone DriftDetectionMode = "none"
+)
+
+// LatencyMeasurement defines what the reported request latency covers.
+type LatencyMeasurement string
+
+const (
+	// LatencyMeasurementTotal measures successful requests, including the failed attempts preceding them.
+	LatencyMeasurementTotal LatencyMeasurement = "Total"
+
+	// LatencyMeasurementPerAttempt measures every attempt on its own.
+	LatencyMeasurementPerAttempt LatencyMeasurement = "PerAttempt"
+)
+
+// ResponseClassificationRule maps the responses it matches to an outcome.
+type ResponseClassificationRule struct {
+	// StatusCodes are the status codes of the responses the rule matches.
+	// +kubebuilder:validation:MinItems=1
+	StatusCodes []int32 `json:"statusCodes"`
+
+	// Methods restricts the rule to responses of requests sent with these methods. Matches all methods when omitted.
+	// +kubebuilder:validation:items:Pattern="^[-!#$%&'*+.^_|~0-9A-Za-z\\x60]+$"
+	Methods []string `json:"methods,omitempty"`
+
+	// Condition is an optional jq filter evaluated against the response, which must return true for the rule to match.
+	// Example: '.body.error.code == "ALREADY_DELETED"'
+	Condition string `json:"condition,omitempty"`
+
+	// Outcome is how the matched responses are interpreted. Success records the response as successful.
+	// TerminalError stores the response and marks the request as failed. RetryableError discards the response,
+	// so the request is sent again on the next reconcile. NotFound means the object doesn't exist.
+	// +kubebuilder:validation:Enum=Success;TerminalError;RetryableError;NotFound
+	Outcome ResponseOutcome `json:"outcome"`
+}
+
+// ResponseOutcome is how a response is interpreted.
+type ResponseOutcome string
+
+const (
+	// ResponseOutcomeSuccess records the response as successful.
+	ResponseOutcomeSuccess ResponseOutcome = "Success"
+
+	// ResponseOutcomeTerminalError stores the response and marks the request as failed.
+	ResponseOutcomeTerminalError ResponseOutcome = "TerminalError"
+
+	// ResponseOutcomeRetryableError discards the response and sends the request again.
+	ResponseOutcomeRetryableError ResponseOutcome = "RetryableError"
+
+	// ResponseOutcomeNotFound means the object doesn't exist.
+	ResponseOutcomeNotFound ResponseOutcome = "NotFound"
+)
+
+// TypeComparisonMode defines how field types are compared when detecting drift.
+type TypeComparisonMode string
+
+const (
+	// TypeComparisonStrict fails the observation when a field has a different JSON type in the response.
+	TypeComparisonStrict TypeComparisonMode = "Strict"
+
+	// TypeComparisonLenient coerces comparable scalars before comparing them.
+	TypeComparisonLenient TypeComparisonMode = "Lenient"
+)
+
+// CreateStrategy defines how the existence of the object is established before it is created.
+type CreateStrategy string
+
+const (
+	// CreateStrategyObserveFirst creates the object when it can't be observed.
+	CreateStrategyObserveFirst CreateStrategy = "observeFirst"
+
+	// CreateStrategyCreateOrConflict creates the object and treats a conflict response as the object already existing.
+	CreateStrategyCreateOrConflict CreateStrategy = "createOrConflict"
+)
+
+// DebugArtifact configures where the most recent request and response are exported.
+type DebugArtifact struct {
+	// Target is where the artifact is written: the http.crossplane.io/debug-artifact annotation
+	// of the Request, or a ConfigMap key.
+	// +kubebuilder:validation:Enum=Annotation;ConfigMap
+	Target DebugArtifactTarget `json:"target"`
+
+	// ConfigMapRef references the ConfigMap key the artifact is written to. Required when target is ConfigMap.
+	ConfigMapRef *ConfigMapKeyRef `json:"configMapRef,omitempty"`
+
+	// MaxSizeBytes bounds the size of the artifact; bodies are truncated to fit. Defaults to 16384.
+	// +kubebuilder:validation:Minimum=1024
+	// +kubebuilder:validation:Maximum=262144
+	MaxSizeBytes *int32 `json:"maxSizeBytes,omitempty"`
+}
+
+// DebugArtifactTarget defines where a debug artifact is written.
+type DebugArtifactTarget string
+
+const (
+	// DebugArtifactTargetAnnotation writes the artifact to an annotation of the Request.
+	DebugArtifactTargetAnnotation DebugArtifactTarget = "Annotation"
+
+	// DebugArtifactTargetConfigMap writes the artifact to a ConfigMap key.
+	DebugArtifactTargetConfigMap DebugArtifactTarget = "ConfigMap"
+)
+
+// URLNormalization defines the normalization steps applied to the path of generated URLs.
+// The scheme, host and query string are left untouched.
+type URLNormalization struct {
+	// CollapseSlashes, when set to true, replaces repeated slashes in the URL path with a single slash.
+	CollapseSlashes bool `json:"collapseSlashes,omitempty"`
+
+	// TrailingSlash controls the trailing slash of the URL path. Enforce appends one when missing,
+	// Strip removes it when present. When omitted, the trailing slash is left as generated.
+	// +kubebuilder:validation:Enum=Enforce;Strip
+	TrailingSlash TrailingSlashPolicy `json:"trailingSlash,omitempty"`
+}
+
+// TrailingSlashPolicy defines how the trailing slash of a URL path is handled.
+type TrailingSlashPolicy string
+
+const (
+	// TrailingSlashEnforce appends a trailing slash to the URL path when missing.
+	TrailingSlashEnforce TrailingSlashPolicy = "Enforce"
+
+	// TrailingSlashStrip removes the trailing slash of the URL path when present.
+	TrailingSlashStrip TrailingSlashPolicy = "Strip"
+)
+
+// JQObjectConfig defines the layout of the object exposed to jq templates.
+type JQObjectConfig struct {
+	// SpecRoot is the key under which the forProvider fields are exposed. Defaults to "spec".
+	SpecRoot string `json:"specRoot,omitempty"`
+
+	// ResponseRoot is the key under which the latest response is exposed. Defaults to "response".
+	ResponseRoot string `json:"responseRoot,omitempty"`
+
+	// LegacyRootFields, when set to true, also merges the forProvider fields at the root of the object,
+	// so templates referencing root-level fields keep working. The namespaced roots take precedence on collision.
+	LegacyRootFields bool `json:"legacyRootFields,omitempty"`
 }
 
 type Mapping struct {
-	// +kubebuilder:validation:Enum=POST;GET;PUT;DELETE
+	// Method is the HTTP method of the request. Besides the standard methods, any HTTP token is accepted, such
+	// as PURGE or the WebDAV methods, in which case action designates when the mapping runs.
+	// +kubebuilder:validation:Pattern="^[-!#$%&'*+.^_|~0-9A-Za-z\\x60]+$"
 	Method  string              `json:"method"`
 	Body    string              `json:"body,omitempty"`
 	URL     string              `json:"url"`
 	Headers map[string][]string `json:"headers,omitempty"`
+
+	// TemplateEngine is the engine the URL, body and headers of the mapping are rendered with: jq, the default,
+	// evaluates them as jq filters, while gotemplate renders them as Go text/templates against the same object,
+	// e.g. {{ .payload.baseUrl }}/{{ .response.body.id }}. Secret placeholders apply with either engine.
+	// +kubebuilder:validation:Enum=jq;gotemplate
+	// +optional
+	TemplateEngine TemplateEngine `json:"templateEngine,omitempty"`
+
+	// BodyEncoding defines how the body of the mapping is authored: json, the default, or yaml-to-json, a YAML
+	// document whose string values are rendered one by one with the template engine and that is sent as JSON.
+	// Numbers and booleans of the document keep their type.
+	// +kubebuilder:validation:Enum=json;yaml-to-json
+	// +optional
+	BodyEncoding BodyEncoding `json:"bodyEncoding,omitempty"`
+
+	// TrimBody trims the whitespace and newlines surrounding the rendered body, such as the trailing newline of
+	// a multi-line template, before it is sent. The body is sent as rendered by default.
+	// +optional
+	TrimBody bool `json:"trimBody,omitempty"`
+
+	// MultipleResults defines how a jq filter of the URL or body yielding several results is handled: Error,
+	// the default, fails the request, First keeps the first result, and Join sends every result, each on its own
+	// line, such as a newline-delimited JSON body. A filter yielding no result always fails the request.
+	// +kubebuilder:validation:Enum=Error;First;Join
+	// +optional
+	MultipleResults MultipleResults `json:"multipleResults,omitempty"`
+
+	// Encryption encrypts the body of the requests of the mapping before they're sent, for endpoints requiring an
+	// encrypted payload, and optionally decrypts their responses before they're parsed.
+	// +optional
+	Encryption *PayloadEncryption `json:"encryption,omitempty"`
+
+	// Sensitive keeps the bodies of the requests of the mapping and of their responses out of the status, for
+	// endpoints whose whole payload is sensitive such as a password change. Only the status code, URL, method
+	// and timing are recorded; the response is still checked in memory.
+	// +optional
+	Sensitive bool `json:"sensitive,omitempty"`
+
+	// SensitiveHeaders keeps the headers of the requests of a sensitive mapping and of their responses out of
+	// the status as well.
+	// +optional
+	SensitiveHeaders bool `json:"sensitiveHeaders,omitempty"`
+
+	// RedactPaths lists the fields of the request body, such as .password or .user.ssn, whose values are
+	// replaced by **** wherever the body is logged or recorded in the status, whether or not they come from
+	// secrets. Arrays along a path apply the rest of it to each of their elements. The body sent is left intact.
+	// +optional
+	RedactPaths []string `json:"redactPaths,omitempty"`
+
+	// BodySchema is an optional JSON Schema the generated body is validated against before the request is sent.
+	BodySchema *BodySchema `json:"bodySchema,omitempty"`
+
+	// ExpectedStatus is an optional jq filter expression evaluated against the request and the response, as
+	// .request and .response, that returns the status code, or the array of status codes, a successful response
+	// to this mapping has. Responses with any other status code are marked as failed. Response classification
+	// rules take precedence.
+	// Example: 'if .request.body.upsert then [200, 201] else 201 end'
+	// +optional
+	ExpectedStatus string `json:"expectedStatus,omitempty"`
+
+	// ExpectedHeaders maps response header names to jq filter expressions that must return true for the
+	// response to be accepted. Each expression is evaluated against the array of all values received for the
+	// header (matched case-insensitively), which is empty if the header is missing. A failing assertion marks the request as failed.
+	// Example: 'X-RateLimit-Remaining: length > 0 and (.[0] | tonumber > 0)'
+	ExpectedHeaders map[string]string `json:"expectedHeaders,omitempty"`
+
+	// HeaderDriftChecks maps the names of response headers holding state of the object to their desired
+	// values, for the GET mapping. The values are generated like the headers of the mapping, and the object is
+	// out of date when a header, matched case-insensitively, doesn't hold its desired value.
+	// Example: 'X-Config-Version: .payload.body.configVersion'
+	HeaderDriftChecks map[string]string `json:"headerDriftChecks,omitempty"`
+
+	// HeaderDriftOnly, when set to true on the GET mapping, skips the comparison of the response body with the
+	// desired state, so that only the header drift checks decide whether the object is up to date.
+	HeaderDriftOnly bool `json:"headerDriftOnly,omitempty"`
+
+	// QueryParamsFromBody, when set to true, sends the generated body as query params instead of a request body.
+	// The body must be a JSON object; nested objects and arrays are flattened into bracketed keys,
+	// such as filter[status]=active or ids[0]=1. Secret placeholders are not resolved in query params.
+	QueryParamsFromBody bool `json:"queryParamsFromBody,omitempty"`
+
+	// EmptyBodyMeans defines how a successful GET response with an empty body is interpreted: notFound creates
+	// the object again, exists treats it as existing and up to date. Only applies to the GET mapping.
+	// When omitted, the empty body is compared against the desired state like any other response.
+	// +kubebuilder:validation:Enum=notFound;exists
+	EmptyBodyMeans EmptyBodyInterpretation `json:"emptyBodyMeans,omitempty"`
+
+	// SendBody controls whether the generated body is sent with the request. It defaults to false for GET,
+	// since many servers reject a GET request with a body, and to true for all other methods.
+	// Set it to true on the GET mapping for query-style APIs that expect a JSON body.
+	SendBody *bool `json:"sendBody,omitempty"`
+
+	// EmptyBodyValue is the body sent when the mapping has no body or its body generates an empty string, for APIs
+	// that require an explicit body such as {}. When it is valid JSON and the headers set no Content-Type,
+	// Content-Type: application/json is sent with it. It is ignored when the body isn't sent.
+	EmptyBodyValue string `json:"emptyBodyValue,omitempty"`
+
+	// TLSServerName overrides the TLS server name of the ProviderConfig for the requests of this mapping: it is
+	// sent as the SNI and the server certificate is verified against it, instead of the host of the URL.
+	TLSServerName string `json:"tlsServerName,omitempty"`
+
+	// InsecureSkipTLSVerify overrides the insecureSkipTLSVerify setting of the Request for the requests of this
+	// mapping, e.g. to skip the certificate checks of an internal host only.
+	// +optional
+	InsecureSkipTLSVerify *bool `json:"insecureSkipTLSVerify,omitempty"`
+
+	// BearerTokenFile overrides the bearer token file of the ProviderConfig for the requests of this mapping: the
+	// token held by the file is sent as their bearer token when their headers set no Authorization header.
+	// +optional
+	BearerTokenFile string `json:"bearerTokenFile,omitempty"`
+
+	// ResponseFormat defines how the body of the responses to this mapping is parsed when extracting values
+	// into secrets. JSON, the default, parses the body as a single document. NDJSON parses newline-delimited
+	// JSON, and exposes .body as the list of its records. Text exposes .body as a string. Sniff parses the
+	// body according to its Content-Type header, and detects JSON by a leading { or [ when there is none.
+	// +kubebuilder:validation:Enum=JSON;NDJSON;Text;Sniff
+	ResponseFormat ResponseFormat `json:"responseFormat,omitempty"`
+
+	// ItemsPath is the path to the array of items in the response body of a GET mapping that queries a collection,
+	// made of object fields such as .items or .data.results, or . when the body is the array. When set, the object
+	// is up to date if one of the items contains the desired state. Only applies to the GET mapping.
+	// +optional
+	ItemsPath string `json:"itemsPath,omitempty"`
+
+	// StreamingThresholdBytes is the size of the response body above which the items are decoded one at a time,
+	// rather than the whole body at once, so that large collections are compared without being held in memory as
+	// a whole. Only applies when itemsPath is set. Defaults to 1048576.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	StreamingThresholdBytes *int64 `json:"streamingThresholdBytes,omitempty"`
+
+	// Action designates the action the mapping runs for: Create, Observe, Update or Delete. It is required for
+	// custom methods, and takes precedence over the mappings of the standard methods, which run for Create
+	// (POST), Observe (GET), Update (PUT) and Delete (DELETE) when omitted.
+	// +kubebuilder:validation:Enum=Create;Observe;Update;Delete
+	// +optional
+	Action MappingAction `json:"action,omitempty"`
+
+	// Pagination follows the next pages of the response and aggregates their items into it, so that the
+	// comparison against the desired state and the secret injections see the items of every page. Only applies
+	// to the GET mapping.
+	// +optional
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+// Pagination configures how the pages of a response are followed and aggregated.
+type Pagination struct {
+	// NextURL is a jq filter expression evaluated against each page that returns the URL of the next page.
+	// Relative URLs are resolved against the URL of the page. Pagination stops when it returns null or an empty string.
+	// Example: '.body.next'
+	NextURL string `json:"nextURL"`
+
+	// ItemsPath is the path to the array of items of each page, made of object fields such as .items or
+	// .data.results, or . when the body is the array. The items of every page replace the ones of the first page.
+	ItemsPath string `json:"itemsPath"`
+
+	// MaxPages bounds the number of pages fetched, including the first one. Defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxPages *int32 `json:"maxPages,omitempty"`
+
+	// MaxStoredItems caps the number of aggregated items kept in the response body stored in the status, to
+	// bound its size. The items past the cap are still compared and injected into secrets, and
+	// status.responseTruncated is set when some were left out. Every item is stored when omitted.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxStoredItems *int32 `json:"maxStoredItems,omitempty"`
+}
+
+// TemplateEngine defines the engine the templates of a mapping are rendered with.
+type TemplateEngine string
+
+const (
+	// TemplateEngineJQ evaluates the templates as jq filters.
+	TemplateEngineJQ TemplateEngine = "jq"
+
+	// TemplateEngineGoTemplate renders the templates as Go text/templates.
+	TemplateEngineGoTemplate TemplateEngine = "gotemplate"
+)
+
+// PayloadEncryption configures how the payloads of a mapping are encrypted.
+type PayloadEncryption struct {
+	// Scheme is the encryption scheme. AES-GCM encrypts the body with AES in Galois/Counter Mode and sends the
+	// base64 encoding of a random 12-byte nonce followed by the ciphertext and its authentication tag.
+	// +kubebuilder:validation:Enum=AES-GCM
+	Scheme EncryptionScheme `json:"scheme"`
+
+	// SecretRef contains the name and namespace of the Kubernetes secret holding the key.
+	SecretRef SecretRef `json:"secretRef"`
+
+	// SecretKey is the key of the secret whose value is the base64 encoding of the 128, 192 or 256-bit key.
+	SecretKey string `json:"secretKey"`
+
+	// DecryptResponse decrypts the body of successful responses with the same scheme and key before it's
+	// parsed, for endpoints answering with an encrypted payload as well.
+	// +optional
+	DecryptResponse bool `json:"decryptResponse,omitempty"`
+}
+
+// EncryptionScheme defines how a payload is encrypted.
+type EncryptionScheme string
+
+const (
+	// EncryptionSchemeAESGCM encrypts payloads with AES in Galois/Counter Mode.
+	EncryptionSchemeAESGCM EncryptionScheme = "AES-GCM"
+)
+
+// MultipleResults defines how a jq filter yielding several results is handled.
+type MultipleResults string
+
+const (
+	// MultipleResultsError fails on a filter yielding several results.
+	MultipleResultsError MultipleResults = "Error"
+
+	// MultipleResultsFirst keeps the first result of the filter.
+	MultipleResultsFirst MultipleResults = "First"
+
+	// MultipleResultsJoin joins the results of the filter with newlines.
+	MultipleResultsJoin MultipleResults = "Join"
+)
+
+// BodyEncoding defines how the body of a mapping is authored.
+type BodyEncoding string
+
+const (
+	// BodyEncodingJSON renders the body as is.
+	BodyEncodingJSON BodyEncoding = "json"
+
+	// BodyEncodingYAMLToJSON renders the body as a YAML document and sends it as JSON.
+	BodyEncodingYAMLToJSON BodyEncoding = "yaml-to-json"
+)
+
+// MappingAction defines the action of the managed resource lifecycle a mapping runs for.
+type MappingAction string
+
+const (
+	// MappingActionCreate runs the mapping to create the object, in place of POST.
+	MappingActionCreate MappingAction = "Create"
+
+	// MappingActionObserve runs the mapping to observe the object, in place of GET.
+	MappingActionObserve MappingAction = "Observe"
+
+	// MappingActionUpdate runs the mapping to update the object, in place of PUT.
+	MappingActionUpdate MappingAction = "Update"
+
+	// MappingActionDelete runs the mapping to delete the object, in place of DELETE.
+	MappingActionDelete MappingAction = "Delete"
+)
+
+// ResponseFormat defines how a response body is parsed.
+type ResponseFormat string
+
+const (
+	// ResponseFormatJSON parses the body as a single JSON document.
+	ResponseFormatJSON ResponseFormat = "JSON"
+
+	// ResponseFormatNDJSON parses the body as newline-delimited JSON records.
+	ResponseFormatNDJSON ResponseFormat = "NDJSON"
+
+	// ResponseFormatText exposes the body as a string, even when it is valid JSON.
+	ResponseFormatText ResponseFormat = "Text"
+
+	// ResponseFormatSniff parses the body according to its Content-Type header, or to its first character when
+	// the response has none.
+	ResponseFormatSniff ResponseFormat = "Sniff"
+)
+
+// EmptyBodyInterpretation defines how a successful response with an empty body is interpreted.
+type EmptyBodyInterpretation string
+
+const (
+	// EmptyBodyMeansNotFound interprets an empty body as the object not existing.
+	EmptyBodyMeansNotFound EmptyBodyInterpretation = "notFound"
+
+	// EmptyBodyMeansExists interprets an empty body as the object existing and being up to date.
+	EmptyBodyMeansExists EmptyBodyInterpretation = "exists"
+)
+
+// BodySchema references a JSON Schema, either inline or stored in a ConfigMap.
+type BodySchema struct {
+	// Inline is the JSON Schema document.
+	Inline string `json:"inline,omitempty"`
+
+	// ConfigMapRef references a ConfigMap key holding the JSON Schema document.
+	ConfigMapRef *ConfigMapKeyRef `json:"configMapRef,omitempty"`
+}
+
+// ConfigMapKeyRef references a key of a Kubernetes ConfigMap.
+type ConfigMapKeyRef struct {
+	// Name is the name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+
+	// Key is the key within the ConfigMap.
+	Key string `json:"key"`
 }
 
 type Payload struct {
@@ -65,16 +827,125 @@ type RequestSpec struct {
 	ForProvider       RequestParameters `json:"forProvider"`
 }
 
+// AnnotationInjectionConfig represents the configuration for injecting response data into an annotation of the Request.
+type AnnotationInjectionConfig struct {
+	// Annotation is the key of the annotation receiving the value.
+	Annotation string `json:"annotation"`
+
+	// ResponsePath is a jq filter expression representing the path in the response the value is extracted from.
+	// It must return a string, a number or a boolean; the annotation is left untouched when it returns nothing else.
+	ResponsePath string `json:"responsePath"`
+}
+
 // SecretInjectionConfig represents the configuration for injecting secret data into a Kubernetes secret.
 type SecretInjectionConfig struct {
 	// SecretRef contains the name and namespace of the Kubernetes secret where the data will be injected.
 	SecretRef SecretRef `json:"secretRef"`
 
 	// SecretKey is the key within the Kubernetes secret where the data will be injected.
-	SecretKey string `json:"secretKey"`
+	// When omitted, ResponsePath must return an object and each of its fields is injected in a key named after
+	// the field, transformed with KeyTransform.
+	// +optional
+	SecretKey string `json:"secretKey,omitempty"`
 
 	// ResponsePath is is a jq filter expression represents the path in the response where the secret value will be extracted from.
 	ResponsePath string `json:"responsePath"`
+
+	// Condition is an optional jq filter expression evaluated against the response that must return a boolean.
+	// If it returns false, the injection is skipped and the existing secret is left untouched.
+	// Example: '.body.status == "active"'
+	Condition string `json:"condition,omitempty"`
+
+	// KeyTransform is the casing of the keys named after the fields of the object returned by ResponsePath,
+	// when SecretKey is omitted: asIs keeps the field names, snakeUpper turns apiKey into API_KEY and kebab
+	// turns it into api-key.
+	// +kubebuilder:validation:Enum=asIs;snakeUpper;kebab
+	// +kubebuilder:default=asIs
+	// +optional
+	KeyTransform KeyTransform `json:"keyTransform,omitempty"`
+}
+
+// SecretInjectionConflictPolicy decides what happens when several secret injections write the same key.
+type SecretInjectionConflictPolicy string
+
+const (
+	// SecretInjectionConflictWarn logs the conflict and writes the value of the last injection.
+	SecretInjectionConflictWarn SecretInjectionConflictPolicy = "Warn"
+	// SecretInjectionConflictError writes none of the conflicting values and reports the conflict.
+	SecretInjectionConflictError SecretInjectionConflictPolicy = "Error"
+)
+
+// KeyTransform is the casing of the secret keys named after response fields.
+type KeyTransform string
+
+const (
+	// KeyTransformAsIs keeps the names of the fields.
+	KeyTransformAsIs KeyTransform = "asIs"
+	// KeyTransformSnakeUpper names the keys in upper snake case, e.g. API_KEY.
+	KeyTransformSnakeUpper KeyTransform = "snakeUpper"
+	// KeyTransformKebab names the keys in kebab case, e.g. api-key.
+	KeyTransformKebab KeyTransform = "kebab"
+)
+
+// ForEach selects the elements an external resource is created for.
+type ForEach struct {
+	// Items is a jq filter expression returning the array of elements, evaluated against the same object as the
+	// mappings, e.g. '.payload.body.users'. A null result means there are no elements.
+	Items string `json:"items"`
+
+	// Key is a jq filter expression evaluated against each element, returning the key that identifies its
+	// external resource in status.items, e.g. '.name'. Keys must be unique. Defaults to the index of the element,
+	// in which case reordering the elements updates their external resources instead of recreating them.
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// ItemStatus is the state of the external resource of an element of forEach.
+type ItemStatus struct {
+	// Key identifies the element, see ForEach.Key.
+	Key string `json:"key"`
+
+	// Response is the response of the POST request that created the external resource.
+	Response Response `json:"response,omitempty"`
+
+	// Item is the element, as JSON, when its external resource was last created or updated. The DELETE mapping
+	// reads it as .item, including once the element is removed from forEach.
+	Item string `json:"item,omitempty"`
+
+	// Synced tells whether the external resource matched its desired state when it was last observed.
+	Synced bool `json:"synced,omitempty"`
+
+	// Error is the error of the last request sent for the element, if it failed.
+	Error string `json:"error,omitempty"`
+}
+
+// SubResource is a child object observed along with the object of the GET mapping.
+type SubResource struct {
+	// Name identifies the sub-resource when it drifted or failed to be observed.
+	Name string `json:"name"`
+
+	// URL is a jq filter expression of the URL of the GET request observing the sub-resource, evaluated against
+	// the same object as the mappings, e.g. '(.payload.baseUrl + "/" + .response.body.id + "/members")'.
+	URL string `json:"url"`
+
+	// Headers are the headers of the GET request, rendered like the headers of the mappings.
+	// +optional
+	Headers map[string][]string `json:"headers,omitempty"`
+
+	// DesiredState is a jq filter expression of the desired state of the sub-resource, rendered like the body of
+	// a mapping. The response body must contain it, like the GET response must contain the body of the PUT mapping.
+	DesiredState string `json:"desiredState"`
+}
+
+// ConnectionDetail is a value of the response published as a connection detail.
+type ConnectionDetail struct {
+	// Key is the key of the connection detail.
+	Key string `json:"key"`
+
+	// ResponsePath is a jq filter expression evaluated against the response, as .body, .headers and .statusCode,
+	// that returns the value of the connection detail. Strings are published as is, other values as JSON.
+	// Example: '.body.credentials.password'
+	ResponsePath string `json:"responsePath"`
 }
 
 // SecretRef contains the name and namespace of a Kubernetes secret.
@@ -101,6 +972,103 @@ type RequestStatus struct {
 	Failed              int32    `json:"failed,omitempty"`
 	Error               string   `json:"error,omitempty"`
 	RequestDetails      Mapping  `json:"requestDetails,omitempty"`
+
+	// ErrorCode is the code of the error extracted from the last failure response, when errorDetails is set.
+	ErrorCode string `json:"errorCode,omitempty"`
+
+	// ErrorMessage is the message of the error extracted from the last failure response, when errorDetails is
+	// set. It is the raw body of the response when the message can't be extracted.
+	ErrorMessage string `json:"errorMessage,omitempty"`
+
+	// ResponseTruncated is true when items of the aggregated response were left out of the stored response
+	// body, because there were more than the maxStoredItems of the pagination of the GET mapping.
+	ResponseTruncated bool `json:"responseTruncated,omitempty"`
+
+	// DriftedSubResources are the names of the sub-resources that didn't match their desired state when the
+	// Request was last observed.
+	DriftedSubResources []string `json:"driftedSubResources,omitempty"`
+
+	// Items is the state of the external resource of every element of forEach.
+	Items []ItemStatus `json:"items,omitempty"`
+
+	// LastAppliedBody is the canonical form of the body of the last successful PUT request.
+	LastAppliedBody string `json:"lastAppliedBody,omitempty"`
+
+	// SecretsFingerprint is a hash of the values of the secrets referenced by placeholders when the last
+	// successful POST or PUT request was sent. A change means a secret was rotated and the request is re-sent.
+	SecretsFingerprint string `json:"secretsFingerprint,omitempty"`
+
+	// LastAppliedGeneration is the generation of the resource when the last successful POST or PUT request was
+	// sent. It tells whether the spec changed since, when drift detection is disabled.
+	LastAppliedGeneration int64 `json:"lastAppliedGeneration,omitempty"`
+
+	// Latency reports how long the requests sent for this resource took.
+	Latency *RequestLatency `json:"latency,omitempty"`
+
+	// Operation is the long running operation in progress, if any. It survives across reconciles until the
+	// operation is done or times out.
+	Operation *OperationStatus `json:"operation,omitempty"`
+
+	// PollInterval is the interval until the next observation, derived from the last GET response when adaptive
+	// polling is configured.
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+
+	// MultiStatusFailures are the failed sub-operations of the last 207 Multi-Status response, in their JSON form.
+	// Only the first 10 are kept.
+	MultiStatusFailures []string `json:"multiStatusFailures,omitempty"`
+
+	// LastAction is what the last reconcile did to the external resource: created, updated or deleted it, or
+	// noop when it only observed it.
+	LastAction RequestAction `json:"lastAction,omitempty"`
+
+	// LastActionTime is when the last action was taken. Consecutive noop reconciles keep the time of the first.
+	LastActionTime *metav1.Time `json:"lastActionTime,omitempty"`
+}
+
+// RequestAction is what a reconcile did to the external resource.
+// +kubebuilder:validation:Enum=created;updated;deleted;noop
+type RequestAction string
+
+const (
+	// RequestActionCreated means a POST request created the external resource.
+	RequestActionCreated RequestAction = "created"
+	// RequestActionUpdated means a PUT request updated the external resource.
+	RequestActionUpdated RequestAction = "updated"
+	// RequestActionDeleted means a DELETE request deleted the external resource.
+	RequestActionDeleted RequestAction = "deleted"
+	// RequestActionNoop means the external resource was observed up to date and left unchanged.
+	RequestActionNoop RequestAction = "noop"
+)
+
+// OperationStatus is the state of a long running operation in progress.
+type OperationStatus struct {
+	// URL is the URL the operation is polled at.
+	URL string `json:"url"`
+
+	// Method is the method of the request that started the operation.
+	Method string `json:"method"`
+
+	// StartTime is when the request that started the operation was sent.
+	StartTime metav1.Time `json:"startTime"`
+
+	// LastPollTime is when the operation was last polled.
+	LastPollTime *metav1.Time `json:"lastPollTime,omitempty"`
+}
+
+// RequestLatency reports the latency of the requests sent for a resource.
+type RequestLatency struct {
+	// Last is the latency of the last measured request.
+	Last metav1.Duration `json:"last,omitempty"`
+
+	// Average is a rolling average of the latency over roughly the last 10 measured requests.
+	Average metav1.Duration `json:"average,omitempty"`
+
+	// Samples is the number of requests the average is computed from, up to the size of the window.
+	Samples int32 `json:"samples,omitempty"`
+
+	// RetryingSince is when the first of the failed attempts since the last successful request was sent.
+	// It is used to include retries in the total latency.
+	RetryingSince *metav1.Time `json:"retryingSince,omitempty"`
 }
 
 type Cache struct {
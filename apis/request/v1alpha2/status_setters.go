@@ -1,6 +1,13 @@
 package v1alpha2
 
-import "time"
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// latencyWindow is the number of requests the rolling average latency approximately covers.
+const latencyWindow = 10
 
 func (d *Request) SetStatusCode(statusCode int) {
 	d.Status.Response.StatusCode = statusCode
@@ -14,6 +21,11 @@ func (d *Request) SetBody(body string) {
 	d.Status.Response.Body = body
 }
 
+// StatusBodyCompressionThreshold returns the size of the response body above which it is stored compressed.
+func (d *Request) StatusBodyCompressionThreshold() *int64 {
+	return d.Spec.ForProvider.CompressStatusBodyAboveBytes
+}
+
 func (d *Request) SetError(err error) {
 	d.Status.Failed++
 	if err != nil {
@@ -24,6 +36,18 @@ func (d *Request) SetError(err error) {
 func (d *Request) ResetFailures() {
 	d.Status.Failed = 0
 	d.Status.Error = ""
+	d.Status.ErrorCode = ""
+	d.Status.ErrorMessage = ""
+}
+
+// ResetFailureCount resets the failures counter, keeping the error reported.
+func (d *Request) ResetFailureCount() {
+	d.Status.Failed = 0
+}
+
+func (d *Request) SetErrorDetails(code, message string) {
+	d.Status.ErrorCode = code
+	d.Status.ErrorMessage = message
 }
 
 func (d *Request) SetRequestDetails(url, method, body string, headers map[string][]string) {
@@ -33,9 +57,89 @@ func (d *Request) SetRequestDetails(url, method, body string, headers map[string
 	d.Status.RequestDetails.Method = method
 }
 
+// SetRequestAction records the action of the mapping the last request was sent for.
+func (d *Request) SetRequestAction(action string) {
+	d.Status.RequestDetails.Action = MappingAction(action)
+}
+
+func (d *Request) SetLastAppliedBody(body string) {
+	d.Status.LastAppliedBody = body
+}
+
+func (d *Request) SetSecretsFingerprint(fingerprint string) {
+	d.Status.SecretsFingerprint = fingerprint
+}
+
+func (d *Request) SetLastAppliedGeneration(generation int64) {
+	d.Status.LastAppliedGeneration = generation
+}
+
+func (d *Request) SetOperation(url, method string) {
+	d.Status.Operation = &OperationStatus{
+		URL:       url,
+		Method:    method,
+		StartTime: metav1.Now(),
+	}
+}
+
+func (d *Request) SetMultiStatusFailures(failures []string) {
+	d.Status.MultiStatusFailures = failures
+}
+
+// SetLastAction records the action taken by the reconcile. A noop following a noop keeps the time of the first,
+// so that unchanged resources don't update their status on every observation.
+func (d *Request) SetLastAction(action string) {
+	if RequestAction(action) == RequestActionNoop && d.Status.LastAction == RequestActionNoop {
+		return
+	}
+
+	now := metav1.Now()
+	d.Status.LastAction = RequestAction(action)
+	d.Status.LastActionTime = &now
+}
+
 func (d *Request) SetCache(statusCode int, headers map[string][]string, body string) {
 	d.Status.Cache.Response.StatusCode = statusCode
 	d.Status.Cache.Response.Headers = headers
 	d.Status.Cache.Response.Body = body
 	d.Status.Cache.LastUpdated = time.Now().UTC().Format(time.RFC3339)
 }
+
+// RecordLatency records the latency of an attempt that finished at the given time. With the Total measurement,
+// failed attempts are not measured on their own; the next successful one is measured from the first of them.
+func (d *Request) RecordLatency(attempt time.Duration, succeeded bool, now time.Time) {
+	if d.Status.Latency == nil {
+		d.Status.Latency = &RequestLatency{}
+	}
+	latency := d.Status.Latency
+
+	if d.Spec.ForProvider.LatencyMeasurement == LatencyMeasurementPerAttempt {
+		latency.RetryingSince = nil
+		latency.record(attempt)
+		return
+	}
+
+	if !succeeded {
+		if latency.RetryingSince == nil {
+			start := metav1.NewTime(now.Add(-attempt))
+			latency.RetryingSince = &start
+		}
+		return
+	}
+
+	if latency.RetryingSince != nil {
+		attempt = now.Sub(latency.RetryingSince.Time)
+		latency.RetryingSince = nil
+	}
+	latency.record(attempt)
+}
+
+// record adds a measured latency to the rolling average.
+func (l *RequestLatency) record(latency time.Duration) {
+	if l.Samples < latencyWindow {
+		l.Samples++
+	}
+
+	l.Last = metav1.Duration{Duration: latency}
+	l.Average = metav1.Duration{Duration: l.Average.Duration + (latency-l.Average.Duration)/time.Duration(l.Samples)}
+}
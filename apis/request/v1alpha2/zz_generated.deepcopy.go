@@ -25,6 +25,93 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdaptivePolling) DeepCopyInto(out *AdaptivePolling) {
+	*out = *in
+	if in.States != nil {
+		in, out := &in.States, &out.States
+		*out = make(map[string]v1.Duration, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdaptivePolling.
+func (in *AdaptivePolling) DeepCopy() *AdaptivePolling {
+	if in == nil {
+		return nil
+	}
+	out := new(AdaptivePolling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnnotationInjectionConfig) DeepCopyInto(out *AnnotationInjectionConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnnotationInjectionConfig.
+func (in *AnnotationInjectionConfig) DeepCopy() *AnnotationInjectionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AnnotationInjectionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AsyncOperation) DeepCopyInto(out *AsyncOperation) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AsyncOperation.
+func (in *AsyncOperation) DeepCopy() *AsyncOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(AsyncOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BodySchema) DeepCopyInto(out *BodySchema) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(ConfigMapKeyRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BodySchema.
+func (in *BodySchema) DeepCopy() *BodySchema {
+	if in == nil {
+		return nil
+	}
+	out := new(BodySchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Cache) DeepCopyInto(out *Cache) {
 	*out = *in
@@ -41,6 +128,122 @@ func (in *Cache) DeepCopy() *Cache {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapKeyRef) DeepCopyInto(out *ConfigMapKeyRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapKeyRef.
+func (in *ConfigMapKeyRef) DeepCopy() *ConfigMapKeyRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapKeyRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionDetail) DeepCopyInto(out *ConnectionDetail) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionDetail.
+func (in *ConnectionDetail) DeepCopy() *ConnectionDetail {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionDetail)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DebugArtifact) DeepCopyInto(out *DebugArtifact) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(ConfigMapKeyRef)
+		**out = **in
+	}
+	if in.MaxSizeBytes != nil {
+		in, out := &in.MaxSizeBytes, &out.MaxSizeBytes
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebugArtifact.
+func (in *DebugArtifact) DeepCopy() *DebugArtifact {
+	if in == nil {
+		return nil
+	}
+	out := new(DebugArtifact)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ErrorDetails) DeepCopyInto(out *ErrorDetails) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ErrorDetails.
+func (in *ErrorDetails) DeepCopy() *ErrorDetails {
+	if in == nil {
+		return nil
+	}
+	out := new(ErrorDetails)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForEach) DeepCopyInto(out *ForEach) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForEach.
+func (in *ForEach) DeepCopy() *ForEach {
+	if in == nil {
+		return nil
+	}
+	out := new(ForEach)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ItemStatus) DeepCopyInto(out *ItemStatus) {
+	*out = *in
+	in.Response.DeepCopyInto(&out.Response)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ItemStatus.
+func (in *ItemStatus) DeepCopy() *ItemStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ItemStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JQObjectConfig) DeepCopyInto(out *JQObjectConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JQObjectConfig.
+func (in *JQObjectConfig) DeepCopy() *JQObjectConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(JQObjectConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Mapping) DeepCopyInto(out *Mapping) {
 	*out = *in
@@ -60,6 +263,55 @@ func (in *Mapping) DeepCopyInto(out *Mapping) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(PayloadEncryption)
+		**out = **in
+	}
+	if in.RedactPaths != nil {
+		in, out := &in.RedactPaths, &out.RedactPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BodySchema != nil {
+		in, out := &in.BodySchema, &out.BodySchema
+		*out = new(BodySchema)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExpectedHeaders != nil {
+		in, out := &in.ExpectedHeaders, &out.ExpectedHeaders
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.HeaderDriftChecks != nil {
+		in, out := &in.HeaderDriftChecks, &out.HeaderDriftChecks
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SendBody != nil {
+		in, out := &in.SendBody, &out.SendBody
+		*out = new(bool)
+		**out = **in
+	}
+	if in.InsecureSkipTLSVerify != nil {
+		in, out := &in.InsecureSkipTLSVerify, &out.InsecureSkipTLSVerify
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StreamingThresholdBytes != nil {
+		in, out := &in.StreamingThresholdBytes, &out.StreamingThresholdBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Pagination != nil {
+		in, out := &in.Pagination, &out.Pagination
+		*out = new(Pagination)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Mapping.
@@ -72,6 +324,86 @@ func (in *Mapping) DeepCopy() *Mapping {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiStatus) DeepCopyInto(out *MultiStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiStatus.
+func (in *MultiStatus) DeepCopy() *MultiStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObserveRetries) DeepCopyInto(out *ObserveRetries) {
+	*out = *in
+	if in.Delay != nil {
+		in, out := &in.Delay, &out.Delay
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObserveRetries.
+func (in *ObserveRetries) DeepCopy() *ObserveRetries {
+	if in == nil {
+		return nil
+	}
+	out := new(ObserveRetries)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperationStatus) DeepCopyInto(out *OperationStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.LastPollTime != nil {
+		in, out := &in.LastPollTime, &out.LastPollTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperationStatus.
+func (in *OperationStatus) DeepCopy() *OperationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OperationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Pagination) DeepCopyInto(out *Pagination) {
+	*out = *in
+	if in.MaxPages != nil {
+		in, out := &in.MaxPages, &out.MaxPages
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxStoredItems != nil {
+		in, out := &in.MaxStoredItems, &out.MaxStoredItems
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pagination.
+func (in *Pagination) DeepCopy() *Pagination {
+	if in == nil {
+		return nil
+	}
+	out := new(Pagination)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Payload) DeepCopyInto(out *Payload) {
 	*out = *in
@@ -87,6 +419,22 @@ func (in *Payload) DeepCopy() *Payload {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PayloadEncryption) DeepCopyInto(out *PayloadEncryption) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PayloadEncryption.
+func (in *PayloadEncryption) DeepCopy() *PayloadEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(PayloadEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Request) DeepCopyInto(out *Request) {
 	*out = *in
@@ -114,6 +462,27 @@ func (in *Request) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestLatency) DeepCopyInto(out *RequestLatency) {
+	*out = *in
+	out.Last = in.Last
+	out.Average = in.Average
+	if in.RetryingSince != nil {
+		in, out := &in.RetryingSince, &out.RetryingSince
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestLatency.
+func (in *RequestLatency) DeepCopy() *RequestLatency {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestLatency)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RequestList) DeepCopyInto(out *RequestList) {
 	*out = *in
@@ -183,6 +552,110 @@ func (in *RequestParameters) DeepCopyInto(out *RequestParameters) {
 		*out = make([]SecretInjectionConfig, len(*in))
 		copy(*out, *in)
 	}
+	if in.ConnectionDetails != nil {
+		in, out := &in.ConnectionDetails, &out.ConnectionDetails
+		*out = make([]ConnectionDetail, len(*in))
+		copy(*out, *in)
+	}
+	if in.AnnotationInjectionConfigs != nil {
+		in, out := &in.AnnotationInjectionConfigs, &out.AnnotationInjectionConfigs
+		*out = make([]AnnotationInjectionConfig, len(*in))
+		copy(*out, *in)
+	}
+	if in.JQObject != nil {
+		in, out := &in.JQObject, &out.JQObject
+		*out = new(JQObjectConfig)
+		**out = **in
+	}
+	if in.URLNormalization != nil {
+		in, out := &in.URLNormalization, &out.URLNormalization
+		*out = new(URLNormalization)
+		**out = **in
+	}
+	if in.DebugArtifact != nil {
+		in, out := &in.DebugArtifact, &out.DebugArtifact
+		*out = new(DebugArtifact)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConflictStatusCode != nil {
+		in, out := &in.ConflictStatusCode, &out.ConflictStatusCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.InitialDelay != nil {
+		in, out := &in.InitialDelay, &out.InitialDelay
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ObserveRetries != nil {
+		in, out := &in.ObserveRetries, &out.ObserveRetries
+		*out = new(ObserveRetries)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SubResources != nil {
+		in, out := &in.SubResources, &out.SubResources
+		*out = make([]SubResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ForEach != nil {
+		in, out := &in.ForEach, &out.ForEach
+		*out = new(ForEach)
+		**out = **in
+	}
+	if in.ResponseClassification != nil {
+		in, out := &in.ResponseClassification, &out.ResponseClassification
+		*out = make([]ResponseClassificationRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PreRequest != nil {
+		in, out := &in.PreRequest, &out.PreRequest
+		*out = new(Mapping)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AsyncOperation != nil {
+		in, out := &in.AsyncOperation, &out.AsyncOperation
+		*out = new(AsyncOperation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.AdaptivePolling != nil {
+		in, out := &in.AdaptivePolling, &out.AdaptivePolling
+		*out = new(AdaptivePolling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UnorderedArrays != nil {
+		in, out := &in.UnorderedArrays, &out.UnorderedArrays
+		*out = make([]UnorderedArray, len(*in))
+		copy(*out, *in)
+	}
+	if in.MultiStatus != nil {
+		in, out := &in.MultiStatus, &out.MultiStatus
+		*out = new(MultiStatus)
+		**out = **in
+	}
+	if in.ErrorDetails != nil {
+		in, out := &in.ErrorDetails, &out.ErrorDetails
+		*out = new(ErrorDetails)
+		**out = **in
+	}
+	if in.CompressStatusBodyAboveBytes != nil {
+		in, out := &in.CompressStatusBodyAboveBytes, &out.CompressStatusBodyAboveBytes
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestParameters.
@@ -219,6 +692,42 @@ func (in *RequestStatus) DeepCopyInto(out *RequestStatus) {
 	in.Response.DeepCopyInto(&out.Response)
 	in.Cache.DeepCopyInto(&out.Cache)
 	in.RequestDetails.DeepCopyInto(&out.RequestDetails)
+	if in.DriftedSubResources != nil {
+		in, out := &in.DriftedSubResources, &out.DriftedSubResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ItemStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Latency != nil {
+		in, out := &in.Latency, &out.Latency
+		*out = new(RequestLatency)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Operation != nil {
+		in, out := &in.Operation, &out.Operation
+		*out = new(OperationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MultiStatusFailures != nil {
+		in, out := &in.MultiStatusFailures, &out.MultiStatusFailures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastActionTime != nil {
+		in, out := &in.LastActionTime, &out.LastActionTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestStatus.
@@ -262,6 +771,31 @@ func (in *Response) DeepCopy() *Response {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResponseClassificationRule) DeepCopyInto(out *ResponseClassificationRule) {
+	*out = *in
+	if in.StatusCodes != nil {
+		in, out := &in.StatusCodes, &out.StatusCodes
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResponseClassificationRule.
+func (in *ResponseClassificationRule) DeepCopy() *ResponseClassificationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ResponseClassificationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretInjectionConfig) DeepCopyInto(out *SecretInjectionConfig) {
 	*out = *in
@@ -292,3 +826,64 @@ func (in *SecretRef) DeepCopy() *SecretRef {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubResource) DeepCopyInto(out *SubResource) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubResource.
+func (in *SubResource) DeepCopy() *SubResource {
+	if in == nil {
+		return nil
+	}
+	out := new(SubResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *URLNormalization) DeepCopyInto(out *URLNormalization) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new URLNormalization.
+func (in *URLNormalization) DeepCopy() *URLNormalization {
+	if in == nil {
+		return nil
+	}
+	out := new(URLNormalization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnorderedArray) DeepCopyInto(out *UnorderedArray) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnorderedArray.
+func (in *UnorderedArray) DeepCopy() *UnorderedArray {
+	if in == nil {
+		return nil
+	}
+	out := new(UnorderedArray)
+	in.DeepCopyInto(out)
+	return out
+}
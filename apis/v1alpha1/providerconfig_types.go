@@ -29,6 +29,213 @@ import (
 type ProviderConfigSpec struct {
 	// Credentials required to authenticate to this provider.
 	Credentials ProviderCredentials `json:"credentials"`
+
+	// MaxInFlightRequestsPerHost limits the number of concurrent requests sent to a single host.
+	// When a host is saturated, the resource is requeued instead of blocking a worker. Unlimited when omitted.
+	// +kubebuilder:validation:Minimum=1
+	MaxInFlightRequestsPerHost *int32 `json:"maxInFlightRequestsPerHost,omitempty"`
+
+	// ResponseCacheTTL is how long the response of a GET request is reused for identical GET requests,
+	// with the same URL and headers, sent by other resources. Defaults to 1s; set to 0s to disable.
+	ResponseCacheTTL *metav1.Duration `json:"responseCacheTTL,omitempty"`
+
+	// SourceAddress is the local IP address outbound connections are bound to, for hosts with several
+	// network interfaces where egress must leave from a specific address. The OS picks it when omitted.
+	SourceAddress string `json:"sourceAddress,omitempty"`
+
+	// UnixSocketPath is the absolute path of a Unix domain socket all requests are sent through instead of TCP,
+	// for local daemons. The host and path of the request URL are still used for the HTTP request itself.
+	// Cannot be combined with a source address.
+	UnixSocketPath string `json:"unixSocketPath,omitempty"`
+
+	// TLSServerName is the name sent as the SNI of TLS connections and the server certificates are verified
+	// against, instead of the host of the request URL. Useful when connecting through a proxy or load balancer
+	// whose address differs from the name of the server. Mappings may override it.
+	TLSServerName string `json:"tlsServerName,omitempty"`
+
+	// TLSPinnedPublicKeys are the base64 encoded SHA-256 hashes of the SubjectPublicKeyInfo of the certificates
+	// trusted for TLS connections. When set, connections are rejected unless the server certificate or one of
+	// its issuers has one of these public keys, even if the certificate chain is otherwise valid.
+	TLSPinnedPublicKeys []PublicKeyPin `json:"tlsPinnedPublicKeys,omitempty"`
+
+	// CABundle is a PEM encoded bundle of CA certificates trusted for TLS connections, on top of the system
+	// ones, e.g. for servers with certificates issued by a private CA that isn't sensitive. It must hold at
+	// least one valid certificate. Requests may replace it.
+	CABundle string `json:"caBundle,omitempty"`
+
+	// BearerTokenFile is the path of a file holding a bearer token, sent as the Authorization header of the
+	// requests that set none. The file is read again whenever it changes, for tokens rotated on disk such as
+	// projected service account tokens.
+	BearerTokenFile string `json:"bearerTokenFile,omitempty"`
+
+	// CompressionNegotiation controls whether requests advertise that they accept gzipped responses, and have
+	// them decompressed. When disabled, no Accept-Encoding header is added and response bodies are taken as
+	// sent; a body that isn't text, such as one gzipped by the server anyway, is recorded base64 encoded.
+	// Defaults to true.
+	// +optional
+	CompressionNegotiation *bool `json:"compressionNegotiation,omitempty"`
+
+	// MaxRequestHeaderBytes fails the requests whose headers exceed this many bytes before sending them, for
+	// servers rejecting large header sets. The headers are counted as sent, including the Authorization header
+	// taken from the bearer token file, but not the ones added by the HTTP client itself, such as Host or
+	// User-Agent. Unlimited when omitted.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxRequestHeaderBytes *int32 `json:"maxRequestHeaderBytes,omitempty"`
+
+	// Timeouts bound the phases of the requests sent using this ProviderConfig separately, so that connection
+	// issues fail fast while slow bodies are still given a long total. Unset timeouts derive from the
+	// waitTimeout of the resource.
+	Timeouts *Timeouts `json:"timeouts,omitempty"`
+
+	// BaseProviderConfigRef references a ProviderConfig this one inherits its settings from.
+	// Settings set on this ProviderConfig take precedence over the inherited ones; credentials are never inherited.
+	// Base ProviderConfigs may themselves reference a base, as long as the chain doesn't form a cycle.
+	BaseProviderConfigRef *xpv1.Reference `json:"baseProviderConfigRef,omitempty"`
+
+	// Canary stages a behavioral change on a labeled subset of the Requests using this ProviderConfig,
+	// so that it can be tested on a cohort before being rolled out to the whole fleet.
+	Canary *CanaryRollout `json:"canary,omitempty"`
+
+	// Notifications configure a webhook notified of the outcome of the requests sent using this ProviderConfig.
+	// Notifications are sent asynchronously; failing to deliver one is logged and never fails the reconcile.
+	Notifications *Notifications `json:"notifications,omitempty"`
+
+	// RequestLog records every request sent using this ProviderConfig to an audit log kept apart from the logs
+	// of the provider. Entries hold the request as recorded in the status of the resource, so values taken from
+	// secrets are never written, and only the status code of the response.
+	RequestLog *RequestLog `json:"requestLog,omitempty"`
+
+	// KillSwitch pauses the writes of every resource using this ProviderConfig, for an emergency stop. While
+	// it is engaged, Requests are still observed, but no request creating, updating or deleting an object is
+	// sent, nor any DisposableRequest, and the held resources report a Paused condition.
+	KillSwitch *KillSwitch `json:"killSwitch,omitempty"`
+}
+
+// KillSwitch configures when the writes of the resources are paused. It is engaged when either of its
+// settings is.
+type KillSwitch struct {
+	// Engaged pauses the writes.
+	// +optional
+	Engaged bool `json:"engaged,omitempty"`
+
+	// ConfigMapRef references a ConfigMap key pausing the writes while its value is true. Referencing the same
+	// key from several ProviderConfigs stops all of them at once. A missing ConfigMap or key doesn't pause
+	// anything, while a value that isn't a boolean pauses the writes until it is fixed. The value is read
+	// again at most every few seconds.
+	// +optional
+	ConfigMapRef *ConfigMapKeyRef `json:"configMapRef,omitempty"`
+}
+
+// ConfigMapKeyRef references a key of a Kubernetes ConfigMap.
+type ConfigMapKeyRef struct {
+	// Name is the name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+
+	// Key is the key within the ConfigMap.
+	Key string `json:"key"`
+}
+
+// RequestLogSink is where the entries of a request log are written.
+// +kubebuilder:validation:Enum=Stdout;Events
+type RequestLogSink string
+
+const (
+	// RequestLogSinkStdout writes each entry as a JSON line to the standard output of the provider, while the
+	// provider logs to the standard error.
+	RequestLogSinkStdout RequestLogSink = "Stdout"
+	// RequestLogSinkEvents records each entry as a Kubernetes event of the resource that sent the request.
+	RequestLogSinkEvents RequestLogSink = "Events"
+)
+
+// RequestLog configures the audit log of the requests sent.
+type RequestLog struct {
+	// Enabled turns the request log on.
+	Enabled bool `json:"enabled"`
+
+	// Sink is where the entries are written. Defaults to Stdout.
+	// +kubebuilder:default=Stdout
+	// +optional
+	Sink RequestLogSink `json:"sink,omitempty"`
+}
+
+// NotificationEvent is an event a webhook can be notified of.
+// +kubebuilder:validation:Enum=CreateSucceeded;CreateFailed;UpdateSucceeded;UpdateFailed;DeleteSucceeded;DeleteFailed;RetriesExhausted
+type NotificationEvent string
+
+const (
+	// NotificationEventCreateSucceeded is sent when a create request succeeds.
+	NotificationEventCreateSucceeded NotificationEvent = "CreateSucceeded"
+	// NotificationEventCreateFailed is sent when a create request fails.
+	NotificationEventCreateFailed NotificationEvent = "CreateFailed"
+	// NotificationEventUpdateSucceeded is sent when an update request succeeds.
+	NotificationEventUpdateSucceeded NotificationEvent = "UpdateSucceeded"
+	// NotificationEventUpdateFailed is sent when an update request fails.
+	NotificationEventUpdateFailed NotificationEvent = "UpdateFailed"
+	// NotificationEventDeleteSucceeded is sent when a delete request succeeds.
+	NotificationEventDeleteSucceeded NotificationEvent = "DeleteSucceeded"
+	// NotificationEventDeleteFailed is sent when a delete request fails.
+	NotificationEventDeleteFailed NotificationEvent = "DeleteFailed"
+	// NotificationEventRetriesExhausted is sent when a DisposableRequest fails for the last time its
+	// retries limit allows.
+	NotificationEventRetriesExhausted NotificationEvent = "RetriesExhausted"
+)
+
+// Notifications configure the webhook notified of the outcome of requests.
+type Notifications struct {
+	// URL is the webhook the events are sent to, in the body of a POST request.
+	URL string `json:"url"`
+
+	// Events are the events the webhook is notified of.
+	// +kubebuilder:validation:MinItems=1
+	Events []NotificationEvent `json:"events"`
+
+	// AuthorizationSecretRef selects the key of a secret holding the value of the Authorization header sent
+	// with the notifications, such as "Bearer <token>".
+	// +optional
+	AuthorizationSecretRef *xpv1.SecretKeySelector `json:"authorizationSecretRef,omitempty"`
+}
+
+// A PublicKeyPin is the base64 encoded SHA-256 hash of a DER encoded SubjectPublicKeyInfo, as printed by
+// openssl x509 -pubkey -noout | openssl pkey -pubin -outform der | openssl dgst -sha256 -binary | base64.
+// +kubebuilder:validation:Pattern="^[A-Za-z0-9+/]{43}=$"
+type PublicKeyPin string
+
+// Timeouts bound the phases of a request. Each of them defaults to the waitTimeout of the resource when omitted.
+type Timeouts struct {
+	// Connect bounds establishing the connection to the server.
+	Connect *metav1.Duration `json:"connect,omitempty"`
+
+	// TLSHandshake bounds the TLS handshake once connected.
+	TLSHandshake *metav1.Duration `json:"tlsHandshake,omitempty"`
+
+	// ResponseHeader bounds waiting for the response headers once the request is written.
+	ResponseHeader *metav1.Duration `json:"responseHeader,omitempty"`
+
+	// Total bounds the whole request, including reading the response body.
+	Total *metav1.Duration `json:"total,omitempty"`
+}
+
+// A CanaryRollout applies behavioral overrides to the Requests in a canary cohort.
+type CanaryRollout struct {
+	// Enabled turns the canary on. Disabling it reverts the cohort to the behavior of all other Requests.
+	Enabled bool `json:"enabled"`
+
+	// Selector selects the Requests eligible for the cohort by their labels. An empty selector selects all Requests.
+	Selector metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Weight is the percentage of the selected Requests placed in the cohort. Requests are picked by a hash
+	// of their UID, so a Request stays in the cohort as the weight is raised. Defaults to 100.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Weight *int32 `json:"weight,omitempty"`
+
+	// TypeComparison is the type comparison mode used by the Requests in the cohort that don't set their own.
+	// +kubebuilder:validation:Enum=Strict;Lenient
+	TypeComparison string `json:"typeComparison,omitempty"`
 }
 
 // ProviderCredentials required to authenticate.
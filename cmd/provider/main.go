@@ -35,6 +35,7 @@ import (
 
 	"github.com/crossplane-contrib/provider-http/apis"
 	template "github.com/crossplane-contrib/provider-http/internal/controller"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
 )
 
 func main() {
@@ -46,6 +47,8 @@ func main() {
 		syncInterval     = app.Flag("sync", "How often all resources will be double-checked for drift from the desired state.").Short('s').Default("1h").Duration()
 		pollInterval     = app.Flag("poll", "How often individual resources will be checked for drift from the desired state").Default("1m").Duration()
 		maxReconcileRate = app.Flag("max-reconcile-rate", "The global maximum rate per second at which resources may checked for drift from the desired state.").Default("10").Int()
+		maxConcurrency   = app.Flag("max-concurrent-reconciles", "The maximum number of resources of each kind that may be reconciled concurrently.").Default("10").Int()
+		gracePeriod      = app.Flag("shutdown-grace-period", "How long requests in flight when the provider shuts down may take to complete. No new reconciles start meanwhile.").Default("30s").Duration()
 
 		// namespace = app.Flag("namespace", "Namespace used to set as default scope in default secret store config.").Default("crossplane-system").Envar("POD_NAMESPACE").String()
 	)
@@ -79,18 +82,21 @@ func main() {
 		LeaderElectionResourceLock: resourcelock.LeasesResourceLock,
 		LeaseDuration:              func() *time.Duration { d := 60 * time.Second; return &d }(),
 		RenewDeadline:              func() *time.Duration { d := 50 * time.Second; return &d }(),
+		// In-flight reconciles are waited for while their requests drain.
+		GracefulShutdownTimeout: gracePeriod,
 	})
 	kingpin.FatalIfError(err, "Cannot create controller manager")
 	kingpin.FatalIfError(apis.AddToScheme(mgr.GetScheme()), "Cannot add Http APIs to scheme")
 
 	o := controller.Options{
 		Logger:                  log,
-		MaxConcurrentReconciles: *maxReconcileRate,
+		MaxConcurrentReconciles: *maxConcurrency,
 		PollInterval:            *pollInterval,
 		GlobalRateLimiter:       ratelimiter.NewGlobal(*maxReconcileRate),
 		Features:                &feature.Flags{},
 	}
 
 	kingpin.FatalIfError(template.Setup(mgr, o, *timeout), "Cannot setup Template controllers")
-	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
+	ctx := utils.WithShutdownGracePeriod(ctrl.SetupSignalHandler(), *gracePeriod)
+	kingpin.FatalIfError(mgr.Start(ctx), "Cannot start controller manager")
 }
@@ -0,0 +1,175 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command render prints the requests the mappings of a Request generate against a sample response, so that
+// their templates can be iterated on without a running provider.
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+)
+
+func main() {
+	var (
+		app         = kingpin.New(filepath.Base(os.Args[0]), "Render the requests generated by the mappings of a Request against a sample response.")
+		requestFile = app.Arg("request", "Path of the Request manifest.").Required().ExistingFile()
+		response    = app.Flag("response", "Path of a JSON file holding the sample response body. Defaults to status.response of the manifest.").ExistingFile()
+		statusCode  = app.Flag("status-code", "Status code of the sample response.").Default("200").Int()
+		secrets     = app.Flag("secrets", "Path of a YAML file holding the Secrets referenced by placeholders, separated by ---.").ExistingFile()
+		showSecrets = app.Flag("show-secrets", "Print the requests with the secret placeholders resolved.").Bool()
+	)
+	kingpin.MustParse(app.Parse(os.Args[1:]))
+
+	cr := &v1alpha2.Request{}
+	kingpin.FatalIfError(decodeFile(*requestFile, cr), "Cannot read the Request manifest")
+
+	sample := cr.Status.Response
+	if *response != "" {
+		body, err := os.ReadFile(*response)
+		kingpin.FatalIfError(err, "Cannot read the sample response")
+		sample = v1alpha2.Response{StatusCode: *statusCode, Body: string(body)}
+	}
+
+	localKube, err := secretsClient(*secrets)
+	kingpin.FatalIfError(err, "Cannot read the secrets")
+
+	failed := false
+	for _, rendered := range requestgen.RenderMappings(requestgen.WithMetadata(context.Background(), cr), localKube, cr.Spec.ForProvider, sample) {
+		printRendered(os.Stdout, rendered, *showSecrets)
+		failed = failed || rendered.Err != nil
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// decodeFile decodes the YAML or JSON document in the file at path into into.
+func decodeFile(path string, into interface{}) error {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+
+	return yaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), len(data)).Decode(into)
+}
+
+// secretsClient returns a client serving the Secrets of the YAML documents in the file at path, if any.
+func secretsClient(path string) (client.Client, error) {
+	builder := fake.NewClientBuilder().WithScheme(scheme.Scheme)
+	if path == "" {
+		return builder.Build(), nil
+	}
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), len(data))
+	for {
+		secret := &corev1.Secret{}
+		if err := decoder.Decode(secret); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if secret.Name == "" {
+			continue
+		}
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		for key, value := range secret.StringData {
+			secret.Data[key] = []byte(value)
+		}
+		builder = builder.WithObjects(secret)
+	}
+
+	return builder.Build(), nil
+}
+
+// printRendered prints the request generated by a mapping, or why it couldn't be generated.
+func printRendered(w io.Writer, rendered requestgen.RenderedMapping, showSecrets bool) {
+	fmt.Fprintf(w, "# %s\n", rendered.Method)
+	if rendered.Err != nil {
+		fmt.Fprintf(w, "error: %s\n", rendered.Err)
+		if kerrors.IsNotFound(rendered.Err) {
+			fmt.Fprintln(w, "hint: pass the Secrets referenced by placeholders with --secrets")
+		}
+		fmt.Fprintln(w)
+		return
+	}
+	if rendered.Incomplete {
+		fmt.Fprintln(w, "warning: the request contains null values or has no URL, the provider wouldn't send it")
+	}
+
+	body, headers := rendered.Details.Body, rendered.Details.Headers
+	fmt.Fprintf(w, "URL: %s\n", rendered.Details.Url)
+	printHeaders(w, dataValue(headers, showSecrets))
+	if value := dataValue(body, showSecrets); value != "" {
+		fmt.Fprintf(w, "Body:\n%s\n", value)
+	}
+	fmt.Fprintln(w)
+}
+
+// printHeaders prints the headers sorted by name, if any.
+func printHeaders(w io.Writer, value interface{}) {
+	headers, _ := value.(map[string][]string)
+	if len(headers) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "Headers:")
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, v := range headers[name] {
+			fmt.Fprintf(w, "  %s: %s\n", name, v)
+		}
+	}
+}
+
+// dataValue returns the value of the data with its secret placeholders resolved, or kept when secrets are hidden.
+func dataValue(data httpClient.Data, showSecrets bool) interface{} {
+	if showSecrets {
+		return data.Decrypted
+	}
+	return data.Encrypted
+}
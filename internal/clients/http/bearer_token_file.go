@@ -0,0 +1,135 @@
+package http
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	authorizationHeader = "Authorization"
+
+	// bearerTokenFileRetries is how many more times a missing token file is read, since the file may briefly
+	// be missing while it is rotated.
+	bearerTokenFileRetries       = 3
+	bearerTokenFileRetryInterval = 100 * time.Millisecond
+
+	// bearerTokenFileCacheSize is the number of token files whose token is kept across reconciles at most.
+	bearerTokenFileCacheSize = 256
+
+	errReadBearerTokenFile = "cannot read bearer token file %s"
+	errEmptyBearerToken    = "bearer token file %s is empty"
+)
+
+// WithBearerTokenFile sends the token held by the file at path as the bearer token of the requests that set
+// no Authorization header. The file is read again whenever it changes.
+func WithBearerTokenFile(path string) ClientOption {
+	return func(c *client) {
+		c.bearerTokenFile = cachedBearerTokenFile(path)
+	}
+}
+
+type bearerTokenFileKey struct{}
+
+// ContextWithBearerTokenFile returns a context whose requests send the token held by the file at path as their
+// bearer token, overriding the file the client is configured with. An empty path leaves the context unchanged.
+func ContextWithBearerTokenFile(ctx context.Context, path string) context.Context {
+	if path == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, bearerTokenFileKey{}, path)
+}
+
+// tokenFile returns the bearer token file of a request sent with the given context, or nil if there is none.
+func (hc *client) tokenFile(ctx context.Context) *bearerTokenFile {
+	path, ok := ctx.Value(bearerTokenFileKey{}).(string)
+	if !ok || (hc.bearerTokenFile != nil && hc.bearerTokenFile.path == path) {
+		return hc.bearerTokenFile
+	}
+
+	return cachedBearerTokenFile(path)
+}
+
+// bearerTokenFiles are the token files read by the clients, shared across reconciles since a client only lives
+// for one, so that a file is only read again once it changes.
+var bearerTokenFiles = NewLRUCache[*bearerTokenFile](bearerTokenFileCacheSize, 0)
+
+// cachedBearerTokenFile returns the shared token file of the given path.
+func cachedBearerTokenFile(path string) *bearerTokenFile {
+	if f, ok := bearerTokenFiles.Get(path); ok {
+		return f
+	}
+
+	f := &bearerTokenFile{path: path}
+	bearerTokenFiles.Add(path, f)
+	return f
+}
+
+// bearerTokenFile caches the token of a file until the file changes.
+type bearerTokenFile struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	cached  string
+}
+
+// token returns the token of the file, retrying briefly while the file is missing.
+func (f *bearerTokenFile) token(ctx context.Context) (string, error) {
+	for attempt := 0; ; attempt++ {
+		token, err := f.read()
+		if err == nil || !errors.Is(err, fs.ErrNotExist) || attempt == bearerTokenFileRetries {
+			return token, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", errors.Wrapf(ctx.Err(), errReadBearerTokenFile, f.path)
+		case <-time.After(bearerTokenFileRetryInterval):
+		}
+	}
+}
+
+// pathOrEmpty returns the path of the file, or an empty string if there is no file.
+func (f *bearerTokenFile) pathOrEmpty() string {
+	if f == nil {
+		return ""
+	}
+
+	return f.path
+}
+
+// read returns the token of the file, reading the file only if it changed since it was last read.
+func (f *bearerTokenFile) read() (string, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return "", errors.Wrapf(err, errReadBearerTokenFile, f.path)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.cached != "" && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.cached, nil
+	}
+
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return "", errors.Wrapf(err, errReadBearerTokenFile, f.path)
+	}
+
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", errors.Errorf(errEmptyBearerToken, f.path)
+	}
+
+	f.cached, f.modTime, f.size = token, info.ModTime(), info.Size()
+	return token, nil
+}
@@ -0,0 +1,165 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SendRequest_BearerTokenFile(t *testing.T) {
+	var gotAuthorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuthorization = r.Header.Get(authorizationHeader)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	type want struct {
+		authorization string
+		err           bool
+	}
+	cases := map[string]struct {
+		token      string
+		writeAfter time.Duration
+		headers    map[string][]string
+		want       want
+	}{
+		"TokenFromFile": {
+			token: "abc\n",
+			want:  want{authorization: "Bearer abc"},
+		},
+		"ExplicitAuthorizationHeader": {
+			token:   "abc",
+			headers: map[string][]string{authorizationHeader: {"Basic dXNlcjpwYXNz"}},
+			want:    want{authorization: "Basic dXNlcjpwYXNz"},
+		},
+		"FileBrieflyMissingWhileRotated": {
+			token:      "abc",
+			writeAfter: bearerTokenFileRetryInterval / 2,
+			want:       want{authorization: "Bearer abc"},
+		},
+		"FileMissing": {
+			want: want{err: true},
+		},
+		"EmptyFile": {
+			token: "\n",
+			want:  want{err: true},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gotAuthorization = ""
+			path := filepath.Join(t.TempDir(), "token")
+			write := func() {
+				if err := os.WriteFile(path, []byte(tc.token), 0o600); err != nil {
+					t.Errorf("cannot write token file: %s", err)
+				}
+			}
+
+			switch {
+			case tc.writeAfter > 0:
+				time.AfterFunc(tc.writeAfter, write)
+			case tc.token != "":
+				write()
+			}
+
+			c, err := NewClient(logging.NewNopLogger(), time.Second, WithBearerTokenFile(path))
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			headers := tc.headers
+			if headers == nil {
+				headers = map[string][]string{}
+			}
+			_, err = c.SendRequest(context.Background(), http.MethodGet, server.URL, Data{Encrypted: "", Decrypted: ""}, Data{Encrypted: headers, Decrypted: headers}, false)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("SendRequest(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.authorization, gotAuthorization); diff != "" {
+				t.Errorf("SendRequest(...): -want Authorization, +got Authorization: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_bearerTokenFile_ReloadsOnChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("first"), 0o600); err != nil {
+		t.Fatalf("cannot write token file: %s", err)
+	}
+
+	f := &bearerTokenFile{path: path}
+	if got, err := f.token(context.Background()); err != nil || got != "first" {
+		t.Fatalf("token(...): want first, got %q, %v", got, err)
+	}
+
+	if err := os.WriteFile(path, []byte("second-token"), 0o600); err != nil {
+		t.Fatalf("cannot write token file: %s", err)
+	}
+	if got, err := f.token(context.Background()); err != nil || got != "second-token" {
+		t.Errorf("token(...): want second-token after rotation, got %q, %v", got, err)
+	}
+}
+
+func Test_SendRequest_ContextWithBearerTokenFile(t *testing.T) {
+	var gotAuthorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuthorization = r.Header.Get(authorizationHeader)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	clientPath, mappingPath := filepath.Join(dir, "client-token"), filepath.Join(dir, "mapping-token")
+	for path, token := range map[string]string{clientPath: "client", mappingPath: "mapping"} {
+		if err := os.WriteFile(path, []byte(token), 0o600); err != nil {
+			t.Fatalf("cannot write token file: %s", err)
+		}
+	}
+
+	cases := map[string]struct {
+		opts []ClientOption
+		path string
+		want string
+	}{
+		"OverridesClientFile": {
+			opts: []ClientOption{WithBearerTokenFile(clientPath)},
+			path: mappingPath,
+			want: "Bearer mapping",
+		},
+		"WithoutClientFile": {
+			path: mappingPath,
+			want: "Bearer mapping",
+		},
+		"EmptyPathKeepsClientFile": {
+			opts: []ClientOption{WithBearerTokenFile(clientPath)},
+			want: "Bearer client",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gotAuthorization = ""
+			c, err := NewClient(logging.NewNopLogger(), time.Second, tc.opts...)
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			headers := map[string][]string{}
+			ctx := ContextWithBearerTokenFile(context.Background(), tc.path)
+			if _, err := c.SendRequest(ctx, http.MethodGet, server.URL, Data{Encrypted: "", Decrypted: ""}, Data{Encrypted: headers, Decrypted: headers}, false); err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, gotAuthorization); diff != "" {
+				t.Errorf("SendRequest(...): -want Authorization, +got Authorization: %s", diff)
+			}
+		})
+	}
+}
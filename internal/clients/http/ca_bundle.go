@@ -0,0 +1,38 @@
+package http
+
+import (
+	"crypto/x509"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errInvalidCABundle = "CA bundle contains no valid PEM encoded certificate"
+)
+
+// WithCABundle trusts the PEM encoded CA certificates of the bundle for TLS connections, on top of the system
+// certificate pool, e.g. for servers whose certificates are issued by a private CA.
+func WithCABundle(bundle string) ClientOption {
+	return func(c *client) {
+		c.caBundle = bundle
+	}
+}
+
+// parseCABundle returns the root certificate pool made of the system certificates and the ones of the bundle, or
+// nil to use the system pool when the bundle is empty.
+func parseCABundle(bundle string) (*x509.CertPool, error) {
+	if bundle == "" {
+		return nil, nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM([]byte(bundle)) {
+		return nil, errors.New(errInvalidCABundle)
+	}
+
+	return pool, nil
+}
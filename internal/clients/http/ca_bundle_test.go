@@ -0,0 +1,86 @@
+package http
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func Test_NewClient_CABundle(t *testing.T) {
+	cases := map[string]struct {
+		bundle string
+		want   error
+	}{
+		"NoBundle": {
+			bundle: "",
+			want:   nil,
+		},
+		"NotPEM": {
+			bundle: "not a certificate",
+			want:   errors.New(errInvalidCABundle),
+		},
+		"NoCertificate": {
+			bundle: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("garbage")})),
+			want:   errors.New(errInvalidCABundle),
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			_, err := NewClient(logging.NewNopLogger(), 5*time.Second, WithCABundle(tc.bundle))
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("NewClient(...): -want error, +got error: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequest_CABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// The test server's certificate is self-signed, so only a bundle holding it makes the server trusted.
+	bundle := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	headers := map[string][]string{}
+
+	cases := map[string]struct {
+		opts    []ClientOption
+		wantErr bool
+	}{
+		"RejectedBySystemPool": {
+			wantErr: true,
+		},
+		"TrustedWithCABundle": {
+			opts: []ClientOption{WithCABundle(bundle)},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			c, err := NewClient(logging.NewNopLogger(), 5*time.Second, tc.opts...)
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			details, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, Data{Encrypted: "", Decrypted: ""}, Data{Encrypted: headers, Decrypted: headers}, false)
+			if diff := cmp.Diff(tc.wantErr, err != nil); diff != "" {
+				t.Fatalf("SendRequest(...): -want error, +got error: %s (error: %v)", diff, err)
+			}
+			if !tc.wantErr && details.HttpResponse.StatusCode != http.StatusOK {
+				t.Errorf("SendRequest(...): want status code %d, got %d", http.StatusOK, details.HttpResponse.StatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package http
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// CacheResponsesRequest is the name of the metrics of the GET responses cached by the Request controller.
+	CacheResponsesRequest = "request_responses"
+	// CacheResponsesDisposableRequest is the name of the metrics of the GET responses cached by the
+	// DisposableRequest controller.
+	CacheResponsesDisposableRequest = "disposablerequest_responses"
+	// CacheBearerTokenFiles is the name of the metrics of the bearer token files shared by the clients.
+	CacheBearerTokenFiles = "bearer_token_files"
+
+	errRegisterCacheMetrics = "cannot register the metrics of cache %s"
+)
+
+// RegisterCacheMetrics exports the lookups and evictions counted by a cache as the
+// provider_http_cache_{hits,misses,evictions}_total counters, labelled with the name of the cache.
+func RegisterCacheMetrics(registerer prometheus.Registerer, name string, stats func() CacheStats) error {
+	counters := []struct {
+		name  string
+		help  string
+		value func(CacheStats) uint64
+	}{
+		{name: "hits_total", help: "Lookups of the cache that found an entry.", value: func(s CacheStats) uint64 { return s.Hits }},
+		{name: "misses_total", help: "Lookups of the cache that found no entry.", value: func(s CacheStats) uint64 { return s.Misses }},
+		{name: "evictions_total", help: "Entries evicted from the cache to make room for new ones.", value: func(s CacheStats) uint64 { return s.Evictions }},
+	}
+
+	for _, c := range counters {
+		value := c.value
+		counter := prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace:   "provider_http",
+			Subsystem:   "cache",
+			Name:        c.name,
+			Help:        c.help,
+			ConstLabels: prometheus.Labels{"cache": name},
+		}, func() float64 { return float64(value(stats())) })
+
+		if err := registerer.Register(counter); err != nil {
+			return errors.Wrapf(err, errRegisterCacheMetrics, name)
+		}
+	}
+
+	return nil
+}
+
+// BearerTokenFileCacheStats returns the lookups and evictions of the bearer token files shared by the clients.
+func BearerTokenFileCacheStats() CacheStats {
+	return bearerTokenFiles.Stats()
+}
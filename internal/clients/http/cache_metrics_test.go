@@ -0,0 +1,64 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// gatherCacheCounters returns the values of the cache counters exported by the registry, by metric and cache names.
+func gatherCacheCounters(t *testing.T, registry *prometheus.Registry) map[string]float64 {
+	t.Helper()
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather(): unexpected error: %v", err)
+	}
+
+	counters := map[string]float64{}
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "cache" {
+					counters[family.GetName()+"/"+label.GetValue()] = metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+
+	return counters
+}
+
+func Test_RegisterCacheMetrics(t *testing.T) {
+	c := NewLRUCache[string](1, 0)
+	c.Add("a", "a")
+	c.Get("a")
+	c.Get("b")
+	c.Add("b", "b")
+
+	registry := prometheus.NewRegistry()
+	if err := RegisterCacheMetrics(registry, "test", c.Stats); err != nil {
+		t.Fatalf("RegisterCacheMetrics(...): unexpected error: %v", err)
+	}
+
+	want := map[string]float64{
+		"provider_http_cache_hits_total/test":      1,
+		"provider_http_cache_misses_total/test":    1,
+		"provider_http_cache_evictions_total/test": 1,
+	}
+	if diff := cmp.Diff(want, gatherCacheCounters(t, registry)); diff != "" {
+		t.Errorf("Gather(): -want counters, +got counters: %s", diff)
+	}
+
+	// The counters read the stats of the cache whenever they are collected.
+	c.Get("b")
+	want["provider_http_cache_hits_total/test"] = 2
+	if diff := cmp.Diff(want, gatherCacheCounters(t, registry)); diff != "" {
+		t.Errorf("Gather(): -want counters, +got counters: %s", diff)
+	}
+
+	if err := RegisterCacheMetrics(registry, "other", c.Stats); err != nil {
+		t.Errorf("RegisterCacheMetrics(...): unexpected error registering a second cache: %v", err)
+	}
+}
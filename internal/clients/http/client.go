@@ -4,13 +4,18 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	neturl "net/url"
 	"time"
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/pkg/errors"
+	"golang.org/x/exp/maps"
 )
 
 // Client is the interface to interact with Http
@@ -19,8 +24,57 @@ type Client interface {
 }
 
 type client struct {
-	log     logging.Logger
-	timeout time.Duration
+	log         logging.Logger
+	timeout     time.Duration
+	hostLimiter *HostLimiter
+	hostLimit   int
+
+	connectTimeout        time.Duration
+	tlsHandshakeTimeout   time.Duration
+	responseHeaderTimeout time.Duration
+
+	responseCache    *ResponseCache
+	responseCacheTTL time.Duration
+
+	sourceAddress string
+	localAddr     *net.TCPAddr
+
+	unixSocketPath   string
+	tlsServerName    string
+	caBundle         string
+	rootCAs          *x509.CertPool
+	pinnedPublicKeys map[string]bool
+	bearerTokenFile  *bearerTokenFile
+
+	// disableCompression keeps gzipped responses from being asked for and decompressed.
+	disableCompression bool
+
+	// maxHeaderBytes bounds the size of the headers of the requests sent, unbounded when not positive.
+	maxHeaderBytes int
+
+	// resolver resolves the hosts of the requests instead of the dialer when set.
+	resolver hostResolver
+}
+
+// ClientOption configures optional behaviour of a client.
+type ClientOption func(*client)
+
+// WithHostLimit bounds the number of concurrent in-flight requests per host.
+// The limiter is shared so the limit applies across every client using it.
+func WithHostLimit(limiter *HostLimiter, limit int) ClientOption {
+	return func(c *client) {
+		c.hostLimiter = limiter
+		c.hostLimit = limit
+	}
+}
+
+// WithResponseCache deduplicates identical GET requests sent within ttl of each
+// other through the given cache, which is shared across every client using it.
+func WithResponseCache(cache *ResponseCache, ttl time.Duration) ClientOption {
+	return func(c *client) {
+		c.responseCache = cache
+		c.responseCacheTTL = ttl
+	}
 }
 
 type HttpResponse struct {
@@ -39,16 +93,21 @@ type HttpRequest struct {
 	Body    string              `json:"body,omitempty"`
 	URL     string              `json:"url"`
 	Headers map[string][]string `json:"headers,omitempty"`
+
+	// Action is the action of the mapping the request was sent for, which tells apart the requests of mappings
+	// sharing a method. It isn't part of the request sent.
+	Action string `json:"action,omitempty"`
 }
 
 type HttpDetails struct {
 	HttpResponse HttpResponse
 	HttpRequest  HttpRequest
+
+	// Duration is how long it took to get the response.
+	Duration time.Duration
 }
 
 func (hc *client) SendRequest(ctx context.Context, method string, url string, body Data, headers Data, skipTLSVerify bool) (details HttpDetails, err error) {
-	requestBody := []byte(body.Decrypted.(string))
-	request, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(requestBody))
 	requestDetails := HttpRequest{
 		URL:     url,
 		Body:    body.Encrypted.(string),
@@ -56,38 +115,91 @@ func (hc *client) SendRequest(ctx context.Context, method string, url string, bo
 		Method:  method,
 	}
 
+	send := func() (HttpResponse, error) {
+		return hc.sendRequest(ctx, requestDetails, body, headers, skipTLSVerify)
+	}
+
+	start := time.Now()
+
+	var response HttpResponse
+	switch {
+	case hc.responseCache == nil:
+		response, err = send()
+	case method == http.MethodGet && body.Decrypted.(string) == "":
+		key := requestFingerprint(method, url, headers.Decrypted.(map[string][]string), skipTLSVerify, hc.serverName(ctx), hc.tokenFile(ctx).pathOrEmpty(), hc.caBundle, maps.Keys(hc.pinnedPublicKeys), hc.disableCompression, hc.sourceAddress, hc.unixSocketPath)
+		response, err = hc.responseCache.Do(key, hostOf(url), hc.responseCacheTTL, send)
+	default:
+		response, err = send()
+		hc.responseCache.Invalidate(hostOf(url))
+	}
+
 	if err != nil {
 		return HttpDetails{
 			HttpRequest: requestDetails,
+			Duration:    time.Since(start),
 		}, err
 	}
 
+	return HttpDetails{
+		HttpResponse: response,
+		HttpRequest:  requestDetails,
+		Duration:     time.Since(start),
+	}, nil
+}
+
+func (hc *client) sendRequest(ctx context.Context, requestDetails HttpRequest, body Data, headers Data, skipTLSVerify bool) (HttpResponse, error) {
+	requestBody := []byte(body.Decrypted.(string))
+	request, err := http.NewRequestWithContext(ctx, requestDetails.Method, requestDetails.URL, bytes.NewBuffer(requestBody))
+	if err != nil {
+		return HttpResponse{}, err
+	}
+
+	if hc.hostLimiter != nil {
+		host := request.URL.Host
+		if !hc.hostLimiter.Acquire(host, hc.hostLimit) {
+			return HttpResponse{}, errors.Wrap(ErrHostSaturated, host)
+		}
+		defer hc.hostLimiter.Release(host)
+	}
+
 	for key, values := range headers.Decrypted.(map[string][]string) {
 		for _, value := range values {
 			request.Header.Add(key, value)
 		}
 	}
 
+	if tokenFile := hc.tokenFile(ctx); tokenFile != nil && request.Header.Get(authorizationHeader) == "" {
+		token, err := tokenFile.token(ctx)
+		if err != nil {
+			return HttpResponse{}, err
+		}
+		request.Header.Set(authorizationHeader, "Bearer "+token)
+	}
+
+	if err := checkHeaderSize(request.Header, hc.maxHeaderBytes); err != nil {
+		return HttpResponse{}, err
+	}
+
 	client := &http.Client{
 		Transport: &http.Transport{
 			// #nosec G402
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: skipTLSVerify},
+			TLSClientConfig:       &tls.Config{InsecureSkipVerify: skipTLSVerify, ServerName: hc.serverName(ctx), RootCAs: hc.rootCAs, VerifyConnection: hc.verifyPinnedPublicKeys()},
+			DialContext:           hc.dialer(),
+			TLSHandshakeTimeout:   hc.tlsHandshakeTimeout,
+			ResponseHeaderTimeout: hc.responseHeaderTimeout,
+			DisableCompression:    hc.disableCompression,
 		},
 		Timeout: hc.timeout,
 	}
 
 	response, err := client.Do(request)
 	if err != nil {
-		return HttpDetails{
-			HttpRequest: requestDetails,
-		}, err
+		return HttpResponse{}, classifyDNSError(err)
 	}
 
 	responsebody, err := io.ReadAll(response.Body)
 	if err != nil {
-		return HttpDetails{
-			HttpRequest: requestDetails,
-		}, err
+		return HttpResponse{}, err
 	}
 
 	beautifiedResponse := HttpResponse{
@@ -95,28 +207,60 @@ func (hc *client) SendRequest(ctx context.Context, method string, url string, bo
 		Headers:    response.Header,
 		StatusCode: response.StatusCode,
 	}
+	if hc.disableCompression {
+		beautifiedResponse = identityResponse(beautifiedResponse)
+	} else {
+		beautifiedResponse = DecodeResponse(beautifiedResponse)
+	}
 
 	err = response.Body.Close()
 	if err != nil {
-		return HttpDetails{
-			HttpRequest: requestDetails,
-		}, err
+		return HttpResponse{}, err
 	}
 
 	hc.log.Info(fmt.Sprint("http request sent: ", toJSON(requestDetails)))
 
-	return HttpDetails{
-		HttpResponse: beautifiedResponse,
-		HttpRequest:  requestDetails,
-	}, nil
+	return beautifiedResponse, nil
 }
 
 // NewClient returns a new Http Client
-func NewClient(log logging.Logger, timeout time.Duration) (Client, error) {
-	return &client{
+func NewClient(log logging.Logger, timeout time.Duration, opts ...ClientOption) (Client, error) {
+	c := &client{
 		log:     log,
 		timeout: timeout,
-	}, nil
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.defaultTimeouts()
+
+	localAddr, err := parseSourceAddress(c.sourceAddress)
+	if err != nil {
+		return nil, err
+	}
+	c.localAddr = localAddr
+
+	if err := validateUnixSocketPath(c.unixSocketPath, c.sourceAddress); err != nil {
+		return nil, err
+	}
+
+	rootCAs, err := parseCABundle(c.caBundle)
+	if err != nil {
+		return nil, err
+	}
+	c.rootCAs = rootCAs
+
+	return c, nil
+}
+
+// hostOf returns the host of the given URL, or the URL itself if it cannot be parsed.
+func hostOf(rawURL string) string {
+	u, err := neturl.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return u.Host
 }
 
 func toJSON(request HttpRequest) string {
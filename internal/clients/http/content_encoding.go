@@ -0,0 +1,63 @@
+package http
+
+import (
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	contentEncodingHeader = "Content-Encoding"
+	contentLengthHeader   = "Content-Length"
+	gzipEncoding          = "gzip"
+)
+
+// WithoutCompressionNegotiation keeps requests from advertising that they accept gzipped responses, and their
+// responses from being decompressed.
+func WithoutCompressionNegotiation() ClientOption {
+	return func(c *client) {
+		c.disableCompression = true
+	}
+}
+
+// identityResponse returns the response as sent, with a body that isn't valid UTF-8, such as one gzipped
+// although it wasn't asked for, base64 encoded so that it can be recorded. Its headers are kept, so its
+// Content-Encoding still describes the encoded bytes.
+func identityResponse(response HttpResponse) HttpResponse {
+	if !utf8.ValidString(response.Body) {
+		response.Body = base64.StdEncoding.EncodeToString([]byte(response.Body))
+	}
+
+	return response
+}
+
+// DecodeResponse returns the response with its body decompressed when its Content-Encoding header says it is
+// gzipped, which it is when the request asked for it with its own Accept-Encoding header. The Content-Encoding
+// and Content-Length headers no longer describe a decompressed body, so they are dropped. Responses with any
+// other encoding, or whose body isn't valid gzip, are returned as is.
+func DecodeResponse(response HttpResponse) HttpResponse {
+	if !strings.EqualFold(strings.TrimSpace(http.Header(response.Headers).Get(contentEncodingHeader)), gzipEncoding) {
+		return response
+	}
+
+	zr, err := gzip.NewReader(strings.NewReader(response.Body))
+	if err != nil {
+		return response
+	}
+
+	body, err := io.ReadAll(zr)
+	if err != nil {
+		return response
+	}
+
+	headers := http.Header(response.Headers).Clone()
+	headers.Del(contentEncodingHeader)
+	headers.Del(contentLengthHeader)
+
+	response.Body = string(body)
+	response.Headers = headers
+	return response
+}
@@ -0,0 +1,192 @@
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+func gzipped(t *testing.T, body string) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(body)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func Test_DecodeResponse(t *testing.T) {
+	cases := map[string]struct {
+		response HttpResponse
+		want     HttpResponse
+	}{
+		"ShouldDecompressGzippedBody": {
+			response: HttpResponse{
+				StatusCode: http.StatusInternalServerError,
+				Headers:    map[string][]string{"Content-Encoding": {"gzip"}, "Content-Length": {"42"}, "Content-Type": {"application/json"}},
+				Body:       gzipped(t, `{"error":"internal"}`),
+			},
+			want: HttpResponse{
+				StatusCode: http.StatusInternalServerError,
+				Headers:    map[string][]string{"Content-Type": {"application/json"}},
+				Body:       `{"error":"internal"}`,
+			},
+		},
+		"ShouldIgnoreCaseOfEncoding": {
+			response: HttpResponse{
+				Headers: map[string][]string{"Content-Encoding": {"GZIP"}},
+				Body:    gzipped(t, "ok"),
+			},
+			want: HttpResponse{
+				Headers: map[string][]string{},
+				Body:    "ok",
+			},
+		},
+		"ShouldKeepUnencodedBody": {
+			response: HttpResponse{
+				Headers: map[string][]string{"Content-Type": {"application/json"}},
+				Body:    `{"error":"internal"}`,
+			},
+			want: HttpResponse{
+				Headers: map[string][]string{"Content-Type": {"application/json"}},
+				Body:    `{"error":"internal"}`,
+			},
+		},
+		"ShouldKeepOtherEncodings": {
+			response: HttpResponse{
+				Headers: map[string][]string{"Content-Encoding": {"br"}},
+				Body:    "compressed",
+			},
+			want: HttpResponse{
+				Headers: map[string][]string{"Content-Encoding": {"br"}},
+				Body:    "compressed",
+			},
+		},
+		"ShouldKeepInvalidGzipBody": {
+			response: HttpResponse{
+				Headers: map[string][]string{"Content-Encoding": {"gzip"}},
+				Body:    "not gzipped",
+			},
+			want: HttpResponse{
+				Headers: map[string][]string{"Content-Encoding": {"gzip"}},
+				Body:    "not gzipped",
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+		t.Run(name, func(t *testing.T) {
+			got := DecodeResponse(tc.response)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("DecodeResponse(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequest_DecodesGzippedResponse(t *testing.T) {
+	body := gzipped(t, `{"error":"internal"}`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(logging.NewNopLogger(), 0)
+	if err != nil {
+		t.Fatalf("NewClient(...): %s", err)
+	}
+
+	// Asking for gzip explicitly keeps the transport from decompressing the response itself.
+	headers := map[string][]string{"Accept-Encoding": {"gzip"}}
+	details, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, Data{Encrypted: "", Decrypted: ""}, Data{Encrypted: headers, Decrypted: headers}, false)
+	if err != nil {
+		t.Fatalf("SendRequest(...): %s", err)
+	}
+
+	if diff := cmp.Diff(`{"error":"internal"}`, details.HttpResponse.Body); diff != "" {
+		t.Errorf("SendRequest(...): -want body, +got body: %s", diff)
+	}
+
+	if got := details.HttpResponse.Headers["Content-Encoding"]; len(got) > 0 {
+		t.Errorf("SendRequest(...): Content-Encoding header should be dropped, got %v", got)
+	}
+}
+
+func Test_SendRequest_CompressionNegotiation(t *testing.T) {
+	body := gzipped(t, `{"id":"123"}`)
+	type want struct {
+		acceptEncoding  string
+		body            string
+		contentEncoding string
+	}
+	cases := map[string]struct {
+		opts []ClientOption
+		want want
+	}{
+		"Enabled": {
+			want: want{acceptEncoding: "gzip", body: `{"id":"123"}`},
+		},
+		"Disabled": {
+			// The server gzips the body although it wasn't asked to, so the bytes are recorded base64 encoded.
+			opts: []ClientOption{WithoutCompressionNegotiation()},
+			want: want{body: base64.StdEncoding.EncodeToString([]byte(body)), contentEncoding: "gzip"},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var acceptEncoding string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				acceptEncoding = r.Header.Get("Accept-Encoding")
+				w.Header().Set("Content-Encoding", "gzip")
+				_, _ = w.Write([]byte(body))
+			}))
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), 0, tc.opts...)
+			if err != nil {
+				t.Fatalf("NewClient(...): %s", err)
+			}
+
+			headers := map[string][]string{}
+			details, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, Data{Encrypted: "", Decrypted: ""}, Data{Encrypted: headers, Decrypted: headers}, false)
+			if err != nil {
+				t.Fatalf("SendRequest(...): %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want.acceptEncoding, acceptEncoding); diff != "" {
+				t.Errorf("SendRequest(...): -want Accept-Encoding, +got Accept-Encoding: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.body, details.HttpResponse.Body); diff != "" {
+				t.Errorf("SendRequest(...): -want body, +got body: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.contentEncoding, http.Header(details.HttpResponse.Headers).Get("Content-Encoding")); diff != "" {
+				t.Errorf("SendRequest(...): -want Content-Encoding, +got Content-Encoding: %s", diff)
+			}
+			if !utf8.ValidString(details.HttpResponse.Body) {
+				t.Errorf("SendRequest(...): the body can't be recorded, it isn't valid UTF-8")
+			}
+
+			// Decoding the response again, as its status handler does, leaves the recorded body intact.
+			if diff := cmp.Diff(details.HttpResponse, DecodeResponse(details.HttpResponse)); diff != "" {
+				t.Errorf("DecodeResponse(...): -want response, +got response: %s", diff)
+			}
+		})
+	}
+}
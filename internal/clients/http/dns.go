@@ -0,0 +1,72 @@
+package http
+
+import (
+	"context"
+	"net"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrTemporaryDNSFailure is returned when the host of a request couldn't be resolved because of a transient
+	// DNS failure, such as a timeout or a misbehaving server. The request was never sent and can be retried.
+	ErrTemporaryDNSFailure = errors.New("temporary DNS resolution failure")
+
+	// ErrHostNotFound is returned when the host of a request doesn't exist, such as for an NXDOMAIN answer.
+	ErrHostNotFound = errors.New("host not found")
+)
+
+// hostResolver resolves the host of a request to its addresses, like a net.Resolver.
+type hostResolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// IsTemporaryDNSFailure checks if the provided error indicates that the request was
+// not sent because its host couldn't be resolved temporarily.
+func IsTemporaryDNSFailure(err error) bool {
+	return errors.Cause(err) == ErrTemporaryDNSFailure
+}
+
+// IsHostNotFound checks if the provided error indicates that the host of the
+// request doesn't exist.
+func IsHostNotFound(err error) bool {
+	return errors.Cause(err) == ErrHostNotFound
+}
+
+// classifyDNSError tells DNS resolution failures apart from the other errors of a request, wrapping them in
+// ErrTemporaryDNSFailure or ErrHostNotFound. Other errors are returned as they are.
+func classifyDNSError(err error) error {
+	var dnsErr *net.DNSError
+	if !errors.As(err, &dnsErr) {
+		return err
+	}
+
+	switch {
+	case dnsErr.IsTemporary || dnsErr.IsTimeout:
+		return errors.Wrap(ErrTemporaryDNSFailure, dnsErr.Error())
+	case dnsErr.IsNotFound:
+		return errors.Wrap(ErrHostNotFound, dnsErr.Error())
+	default:
+		return err
+	}
+}
+
+// resolvingDialer resolves the host of the address with the resolver before dialing its first address with dial.
+func resolvingDialer(resolver hostResolver, dial func(ctx context.Context, network, addr string) (net.Conn, error)) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		addrs, err := resolver.LookupHost(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		if len(addrs) == 0 {
+			return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+		}
+
+		return dial(ctx, network, net.JoinHostPort(addrs[0], port))
+	}
+}
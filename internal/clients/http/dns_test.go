@@ -0,0 +1,127 @@
+package http
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+type fakeResolver struct {
+	addrs []string
+	err   error
+}
+
+func (r fakeResolver) LookupHost(_ context.Context, _ string) ([]string, error) {
+	return r.addrs, r.err
+}
+
+func Test_SendRequest_DNSFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+	emptyBody := Data{Encrypted: "", Decrypted: ""}
+	emptyHeaders := Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}}
+
+	type want struct {
+		temporary bool
+		notFound  bool
+		err       bool
+	}
+	cases := map[string]struct {
+		resolver fakeResolver
+		want     want
+	}{
+		"Resolved": {
+			resolver: fakeResolver{addrs: []string{"127.0.0.1"}},
+			want:     want{},
+		},
+		"Temporary": {
+			resolver: fakeResolver{err: &net.DNSError{Err: "server misbehaving", Name: "api.example.com", IsTemporary: true}},
+			want:     want{temporary: true, err: true},
+		},
+		"Timeout": {
+			resolver: fakeResolver{err: &net.DNSError{Err: "i/o timeout", Name: "api.example.com", IsTimeout: true}},
+			want:     want{temporary: true, err: true},
+		},
+		"NotFound": {
+			resolver: fakeResolver{err: &net.DNSError{Err: "no such host", Name: "api.example.com", IsNotFound: true}},
+			want:     want{notFound: true, err: true},
+		},
+		"NoAddresses": {
+			resolver: fakeResolver{},
+			want:     want{notFound: true, err: true},
+		},
+		"OtherDNSFailure": {
+			resolver: fakeResolver{err: &net.DNSError{Err: "unrecognized address", Name: "api.example.com"}},
+			want:     want{err: true},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			c, _ := NewClient(logging.NewNopLogger(), 5*time.Second)
+			c.(*client).resolver = tc.resolver
+
+			_, err := c.SendRequest(context.Background(), http.MethodGet, "http://api.example.com:"+serverURL.Port(), emptyBody, emptyHeaders, false)
+
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("SendRequest(...): -want error, +got error: %s (error: %v)", diff, err)
+			}
+			if diff := cmp.Diff(tc.want.temporary, IsTemporaryDNSFailure(err)); diff != "" {
+				t.Errorf("SendRequest(...): -want temporary DNS failure, +got temporary DNS failure: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.notFound, IsHostNotFound(err)); diff != "" {
+				t.Errorf("SendRequest(...): -want host not found, +got host not found: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_classifyDNSError(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		err  error
+		want error
+	}{
+		"NoError": {
+			err:  nil,
+			want: nil,
+		},
+		"NotDNSError": {
+			err:  errBoom,
+			want: errBoom,
+		},
+		"WrappedTemporary": {
+			err:  &url.Error{Op: "Get", URL: "http://api.example.com", Err: &net.OpError{Op: "dial", Err: &net.DNSError{Err: "server misbehaving", Name: "api.example.com", IsTemporary: true}}},
+			want: ErrTemporaryDNSFailure,
+		},
+		"WrappedNotFound": {
+			err:  &url.Error{Op: "Get", URL: "http://api.example.com", Err: &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "api.example.com", IsNotFound: true}}},
+			want: ErrHostNotFound,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got := classifyDNSError(tc.err)
+			if diff := cmp.Diff(tc.want, errors.Cause(got), test.EquateErrors()); diff != "" {
+				t.Errorf("classifyDNSError(...): -want cause, +got cause: %s", diff)
+			}
+		})
+	}
+}
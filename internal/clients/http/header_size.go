@@ -0,0 +1,53 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+const errHeaderSize = "request headers are %d bytes, over the limit of %d bytes"
+
+// ErrHeadersTooLarge is returned when the headers of a request exceed the maximum size allowed, either before
+// the request is sent, for the limit configured on the client, or when the server answers with a 431 Request
+// Header Fields Too Large.
+var ErrHeadersTooLarge = errors.New("request headers too large")
+
+// IsHeadersTooLarge checks if the provided error indicates that the headers of the request were too large.
+func IsHeadersTooLarge(err error) bool {
+	return errors.Cause(err) == ErrHeadersTooLarge
+}
+
+// WithMaxHeaderBytes fails the requests whose headers exceed limit bytes before sending them. A non-positive
+// limit leaves the headers unbounded.
+func WithMaxHeaderBytes(limit int) ClientOption {
+	return func(c *client) {
+		c.maxHeaderBytes = limit
+	}
+}
+
+// checkHeaderSize returns an error wrapping ErrHeadersTooLarge if the headers exceed the limit.
+func checkHeaderSize(header http.Header, limit int) error {
+	if limit <= 0 {
+		return nil
+	}
+
+	if size := headerSize(header); size > limit {
+		return errors.Wrapf(ErrHeadersTooLarge, errHeaderSize, size, limit)
+	}
+
+	return nil
+}
+
+// headerSize returns the size of the headers as written on the wire, each field as a "Name: value" line ended
+// by CRLF. The headers the transport adds itself, such as Host, User-Agent or Content-Length, aren't counted.
+func headerSize(header http.Header) int {
+	size := 0
+	for name, values := range header {
+		for _, value := range values {
+			size += len(name) + len(": ") + len(value) + len("\r\n")
+		}
+	}
+
+	return size
+}
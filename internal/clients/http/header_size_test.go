@@ -0,0 +1,65 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func Test_SendRequest_MaxHeaderBytes(t *testing.T) {
+	// "X-Token: abc\r\n" is 14 bytes.
+	headers := map[string][]string{"X-Token": {"abc"}}
+	type want struct {
+		sent bool
+		err  error
+	}
+	cases := map[string]struct {
+		opts []ClientOption
+		want want
+	}{
+		"Unbounded": {
+			want: want{sent: true},
+		},
+		"AtLimit": {
+			opts: []ClientOption{WithMaxHeaderBytes(14)},
+			want: want{sent: true},
+		},
+		"OverLimit": {
+			opts: []ClientOption{WithMaxHeaderBytes(13)},
+			want: want{err: errors.Wrapf(ErrHeadersTooLarge, errHeaderSize, 14, 13)},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			sent := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				sent = true
+			}))
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), 0, tc.opts...)
+			if err != nil {
+				t.Fatalf("NewClient(...): %s", err)
+			}
+
+			_, err = c.SendRequest(context.Background(), http.MethodPost, server.URL, Data{Encrypted: "", Decrypted: ""}, Data{Encrypted: headers, Decrypted: headers}, false)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("SendRequest(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.sent, sent); diff != "" {
+				t.Errorf("SendRequest(...): -want sent, +got sent: %s", diff)
+			}
+			if tc.want.err != nil && !IsHeadersTooLarge(err) {
+				t.Errorf("IsHeadersTooLarge(...): want true for %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ErrHostSaturated is returned when a request is not sent because its host
+// already has the maximum number of requests in flight.
+var ErrHostSaturated = errors.New("host has reached the maximum number of in-flight requests")
+
+// HostLimiter tracks in-flight requests per host. It is shared between the
+// clients of a controller so a single slow host cannot hold every worker.
+type HostLimiter struct {
+	mu       sync.Mutex
+	inFlight map[string]int
+}
+
+// NewHostLimiter returns a new HostLimiter with no requests in flight.
+func NewHostLimiter() *HostLimiter {
+	return &HostLimiter{
+		inFlight: map[string]int{},
+	}
+}
+
+// Acquire reserves an in-flight slot for the given host. It returns false
+// without reserving when the host already has limit requests in flight.
+// A non-positive limit never saturates.
+func (l *HostLimiter) Acquire(host string, limit int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if limit > 0 && l.inFlight[host] >= limit {
+		return false
+	}
+
+	l.inFlight[host]++
+	return true
+}
+
+// Release frees an in-flight slot previously reserved for the given host.
+func (l *HostLimiter) Release(host string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inFlight[host]--
+	if l.inFlight[host] <= 0 {
+		delete(l.inFlight, host)
+	}
+}
+
+// InFlight returns the number of requests currently in flight for the given host.
+func (l *HostLimiter) InFlight(host string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.inFlight[host]
+}
+
+// IsHostSaturated checks if the provided error indicates that the request was
+// not sent because its host is saturated.
+func IsHostSaturated(err error) bool {
+	return errors.Cause(err) == ErrHostSaturated
+}
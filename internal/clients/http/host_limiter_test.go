@@ -0,0 +1,132 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_HostLimiter(t *testing.T) {
+	type args struct {
+		limit    int
+		acquired int
+	}
+	type want struct {
+		ok bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"BelowLimit": {
+			args: args{
+				limit:    2,
+				acquired: 1,
+			},
+			want: want{
+				ok: true,
+			},
+		},
+		"Saturated": {
+			args: args{
+				limit:    2,
+				acquired: 2,
+			},
+			want: want{
+				ok: false,
+			},
+		},
+		"Unlimited": {
+			args: args{
+				limit:    0,
+				acquired: 10,
+			},
+			want: want{
+				ok: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			l := NewHostLimiter()
+			for i := 0; i < tc.args.acquired; i++ {
+				l.Acquire("example.com", tc.args.limit)
+			}
+
+			got := l.Acquire("example.com", tc.args.limit)
+			if diff := cmp.Diff(tc.want.ok, got); diff != "" {
+				t.Fatalf("Acquire(...): -want ok, +got ok: %s", diff)
+			}
+
+			if !l.Acquire("other.com", tc.args.limit) {
+				t.Fatalf("Acquire(...): other hosts should not be affected by a saturated host")
+			}
+		})
+	}
+}
+
+func Test_SendRequest_HostLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+	emptyBody := Data{Encrypted: "", Decrypted: ""}
+	emptyHeaders := Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}}
+
+	type args struct {
+		acquired int
+	}
+	type want struct {
+		saturated bool
+		inFlight  int
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"SendsWhenBelowLimit": {
+			args: args{
+				acquired: 0,
+			},
+			want: want{
+				saturated: false,
+				inFlight:  0,
+			},
+		},
+		"YieldsWhenSaturated": {
+			args: args{
+				acquired: 1,
+			},
+			want: want{
+				saturated: true,
+				inFlight:  1,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			limiter := NewHostLimiter()
+			for i := 0; i < tc.args.acquired; i++ {
+				limiter.Acquire(serverURL.Host, 1)
+			}
+
+			c, _ := NewClient(logging.NewNopLogger(), 5*time.Second, WithHostLimit(limiter, 1))
+			_, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, emptyBody, emptyHeaders, false)
+
+			if diff := cmp.Diff(tc.want.saturated, IsHostSaturated(err)); diff != "" {
+				t.Fatalf("SendRequest(...): -want saturated, +got saturated: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.inFlight, limiter.InFlight(serverURL.Host)); diff != "" {
+				t.Fatalf("SendRequest(...): -want in-flight, +got in-flight: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package http
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// LRUCache is a concurrency-safe cache shared across reconciles. It holds at most size entries, evicting the
+// least recently used one to make room for a new one, and forgets entries once their TTL elapses. A size of
+// zero or less leaves the cache unbounded, and a TTL of zero or less keeps entries until they are evicted.
+type LRUCache[V any] struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	entries map[string]*list.Element
+	order   *list.List
+	stats   CacheStats
+	now     func() time.Time
+}
+
+type lruEntry[V any] struct {
+	key     string
+	value   V
+	expires time.Time
+}
+
+// CacheStats counts the lookups of a cache that found an entry, the ones that didn't, and the entries evicted
+// to make room for new ones.
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// HitRate returns the share of the lookups that found an entry, or zero if there were none.
+func (s CacheStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// NewLRUCache returns a new, empty LRUCache holding at most size entries for at most ttl.
+func NewLRUCache[V any](size int, ttl time.Duration) *LRUCache[V] {
+	return &LRUCache[V]{
+		size:    size,
+		ttl:     ttl,
+		entries: map[string]*list.Element{},
+		order:   list.New(),
+		now:     time.Now,
+	}
+}
+
+// Get returns the value cached for key, marking it as the most recently used, and reports whether it was found.
+// An expired entry is removed and reported as missing.
+func (c *LRUCache[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if ok && c.expired(element.Value.(*lruEntry[V])) {
+		c.remove(element)
+		ok = false
+	}
+
+	if !ok {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+
+	c.stats.Hits++
+	c.order.MoveToFront(element)
+	return element.Value.(*lruEntry[V]).value, true
+}
+
+// Add caches value for key for the TTL of the cache.
+func (c *LRUCache[V]) Add(key string, value V) {
+	c.AddWithTTL(key, value, c.ttl)
+}
+
+// AddWithTTL caches value for key for the given TTL, replacing any value cached for it. A TTL of zero or less
+// keeps the entry until it is evicted.
+func (c *LRUCache[V]) AddWithTTL(key string, value V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &lruEntry[V]{key: key, value: value}
+	if ttl > 0 {
+		entry.expires = c.now().Add(ttl)
+	}
+
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(entry)
+	for c.size > 0 && c.order.Len() > c.size {
+		c.remove(c.order.Back())
+		c.stats.Evictions++
+	}
+}
+
+// Remove forgets the value cached for key, if any.
+func (c *LRUCache[V]) Remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+}
+
+// RemoveFunc forgets every value for which remove returns true.
+func (c *LRUCache[V]) RemoveFunc(remove func(key string, value V) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, element := range c.entries {
+		if remove(key, element.Value.(*lruEntry[V]).value) {
+			c.remove(element)
+		}
+	}
+}
+
+// Len returns the number of entries currently held by the cache, including the expired ones not yet removed.
+func (c *LRUCache[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// Stats returns the lookups and evictions counted since the cache was created.
+func (c *LRUCache[V]) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.stats
+}
+
+// expired reports whether the TTL of the entry has elapsed. The caller must hold the lock.
+func (c *LRUCache[V]) expired(entry *lruEntry[V]) bool {
+	return !entry.expires.IsZero() && !c.now().Before(entry.expires)
+}
+
+// remove forgets the entry of the element. The caller must hold the lock.
+func (c *LRUCache[V]) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*lruEntry[V]).key)
+}
@@ -0,0 +1,164 @@
+package http
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_LRUCache(t *testing.T) {
+	type op struct {
+		add     string
+		ttl     time.Duration
+		get     string
+		remove  string
+		elapsed time.Duration
+	}
+	type want struct {
+		found []string
+		stats CacheStats
+		len   int
+	}
+	cases := map[string]struct {
+		size int
+		ttl  time.Duration
+		ops  []op
+		want want
+	}{
+		"Found": {
+			size: 2,
+			ops:  []op{{add: "a"}, {get: "a"}, {get: "b"}},
+			want: want{found: []string{"a"}, stats: CacheStats{Hits: 1, Misses: 1}, len: 1},
+		},
+		"LeastRecentlyUsedEvicted": {
+			size: 2,
+			ops:  []op{{add: "a"}, {add: "b"}, {get: "a"}, {add: "c"}, {get: "a"}, {get: "b"}, {get: "c"}},
+			want: want{found: []string{"a", "a", "c"}, stats: CacheStats{Hits: 3, Misses: 1, Evictions: 1}, len: 2},
+		},
+		"ReplacedNotEvicted": {
+			size: 2,
+			ops:  []op{{add: "a"}, {add: "b"}, {add: "a"}, {get: "a"}, {get: "b"}},
+			want: want{found: []string{"a", "b"}, stats: CacheStats{Hits: 2}, len: 2},
+		},
+		"Unbounded": {
+			ops:  []op{{add: "a"}, {add: "b"}, {add: "c"}, {get: "a"}},
+			want: want{found: []string{"a"}, stats: CacheStats{Hits: 1}, len: 3},
+		},
+		"ExpiredAfterDefaultTTL": {
+			size: 2,
+			ttl:  time.Second,
+			ops:  []op{{add: "a"}, {elapsed: 500 * time.Millisecond}, {get: "a"}, {elapsed: time.Second}, {get: "a"}},
+			want: want{found: []string{"a"}, stats: CacheStats{Hits: 1, Misses: 1}, len: 0},
+		},
+		"EntryTTLOverridesDefault": {
+			size: 2,
+			ttl:  time.Minute,
+			ops:  []op{{add: "a", ttl: time.Second}, {elapsed: 2 * time.Second}, {get: "a"}},
+			want: want{stats: CacheStats{Misses: 1}, len: 0},
+		},
+		"Removed": {
+			size: 2,
+			ops:  []op{{add: "a"}, {remove: "a"}, {get: "a"}},
+			want: want{stats: CacheStats{Misses: 1}, len: 0},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			now := time.Now()
+			c := NewLRUCache[string](tc.size, tc.ttl)
+			c.now = func() time.Time { return now }
+
+			var found []string
+			for _, o := range tc.ops {
+				switch {
+				case o.add != "" && o.ttl != 0:
+					c.AddWithTTL(o.add, o.add, o.ttl)
+				case o.add != "":
+					c.Add(o.add, o.add)
+				case o.get != "":
+					if value, ok := c.Get(o.get); ok {
+						found = append(found, value)
+					}
+				case o.remove != "":
+					c.Remove(o.remove)
+				}
+				now = now.Add(o.elapsed)
+			}
+
+			if diff := cmp.Diff(tc.want.found, found); diff != "" {
+				t.Errorf("Get(...): -want values, +got values: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.stats, c.Stats()); diff != "" {
+				t.Errorf("Stats(): -want stats, +got stats: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.len, c.Len()); diff != "" {
+				t.Errorf("Len(): -want len, +got len: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_LRUCache_RemoveFunc(t *testing.T) {
+	c := NewLRUCache[int](0, 0)
+	for i := 0; i < 4; i++ {
+		c.Add(strconv.Itoa(i), i)
+	}
+
+	c.RemoveFunc(func(_ string, value int) bool { return value%2 == 0 })
+
+	if diff := cmp.Diff(2, c.Len()); diff != "" {
+		t.Fatalf("RemoveFunc(...): -want len, +got len: %s", diff)
+	}
+	if _, ok := c.Get("1"); !ok {
+		t.Errorf("RemoveFunc(...): removed an entry it shouldn't have")
+	}
+}
+
+func Test_LRUCache_Concurrent(t *testing.T) {
+	c := NewLRUCache[int](8, time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				key := strconv.Itoa((i + j) % 12)
+				c.Add(key, j)
+				c.Get(key)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if c.Len() > 8 {
+		t.Errorf("Len(): got %d entries, want at most 8", c.Len())
+	}
+	if diff := cmp.Diff(uint64(1600), c.Stats().Hits+c.Stats().Misses); diff != "" {
+		t.Errorf("Stats(): -want lookups, +got lookups: %s", diff)
+	}
+}
+
+func Test_CacheStats_HitRate(t *testing.T) {
+	cases := map[string]struct {
+		stats CacheStats
+		want  float64
+	}{
+		"NoLookups": {stats: CacheStats{}, want: 0},
+		"Mixed":     {stats: CacheStats{Hits: 3, Misses: 1}, want: 0.75},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.stats.HitRate()); diff != "" {
+				t.Errorf("HitRate(): -want rate, +got rate: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// responseCacheSize is the number of responses a ResponseCache holds at most, the least recently used ones
+// being evicted first.
+const responseCacheSize = 1024
+
+// ResponseCache deduplicates identical GET requests sent within a short
+// window. It is shared between the clients of a controller, so concurrent
+// requests for the same fingerprint share a single network call, and
+// requests arriving shortly after reuse its response until it expires.
+type ResponseCache struct {
+	mu        sync.Mutex
+	inFlight  map[string]*inFlightRequest
+	responses *LRUCache[cachedResponse]
+	now       func() time.Time
+}
+
+type inFlightRequest struct {
+	done     chan struct{}
+	host     string
+	response HttpResponse
+	err      error
+}
+
+type cachedResponse struct {
+	host     string
+	response HttpResponse
+}
+
+// NewResponseCache returns a new, empty ResponseCache.
+func NewResponseCache() *ResponseCache {
+	c := &ResponseCache{
+		inFlight:  map[string]*inFlightRequest{},
+		responses: NewLRUCache[cachedResponse](responseCacheSize, 0),
+		now:       time.Now,
+	}
+	c.responses.now = func() time.Time { return c.now() }
+
+	return c
+}
+
+// Do returns the response cached for key if it has not expired. Otherwise it
+// calls send, sharing its result with every concurrent caller for the same
+// key, and caches the response for ttl if it is successful. Errors and other
+// responses, such as a 5xx or a 429, are shared with concurrent callers but
+// never cached, so that a retry reaches the server. The host is recorded so the entry can be
+// invalidated by a later request modifying resources of the same host.
+func (c *ResponseCache) Do(key, host string, ttl time.Duration, send func() (HttpResponse, error)) (HttpResponse, error) {
+	c.mu.Lock()
+	if cached, ok := c.responses.Get(key); ok {
+		c.mu.Unlock()
+		return copyResponse(cached.response), nil
+	}
+
+	if r, ok := c.inFlight[key]; ok {
+		c.mu.Unlock()
+		<-r.done
+		return copyResponse(r.response), r.err
+	}
+
+	r := &inFlightRequest{done: make(chan struct{}), host: host}
+	c.inFlight[key] = r
+	c.mu.Unlock()
+
+	r.response, r.err = send()
+
+	c.mu.Lock()
+	// A request invalidated while in flight isn't cached, since its response may predate the modification.
+	if c.inFlight[key] == r {
+		delete(c.inFlight, key)
+		if r.err == nil && isSuccessfulResponse(r.response) {
+			c.responses.AddWithTTL(key, cachedResponse{host: host, response: r.response}, ttl)
+		}
+	}
+	close(r.done)
+	c.mu.Unlock()
+
+	return copyResponse(r.response), r.err
+}
+
+// Invalidate removes every entry recorded for the given host, so requests
+// sent after a modification don't observe a response from before it.
+func (c *ResponseCache) Invalidate(host string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, r := range c.inFlight {
+		if r.host == host {
+			delete(c.inFlight, key)
+		}
+	}
+	c.responses.RemoveFunc(func(_ string, cached cachedResponse) bool {
+		return cached.host == host
+	})
+}
+
+// Len returns the number of entries currently held by the cache.
+func (c *ResponseCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.inFlight) + c.responses.Len()
+}
+
+// Stats returns the lookups of cached responses counted since the cache was created.
+func (c *ResponseCache) Stats() CacheStats {
+	return c.responses.Stats()
+}
+
+// requestFingerprint identifies a request by its method, URL, headers, TLS
+// settings, compression negotiation and the source address or Unix socket it is sent through. The sent header
+// values are hashed rather than kept.
+func requestFingerprint(method, url string, headers map[string][]string, skipTLSVerify bool, tlsServerName, bearerTokenFile, caBundle string, pinnedPublicKeys []string, disableCompression bool, sourceAddress, unixSocketPath string) string {
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pins := append([]string(nil), pinnedPublicKeys...)
+	sort.Strings(pins)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n%t\n%s\n%s\n%q\n%s\n%t\n%s\n%s\n", method, url, skipTLSVerify, tlsServerName, bearerTokenFile, caBundle, strings.Join(pins, ","), disableCompression, sourceAddress, unixSocketPath)
+	for _, key := range keys {
+		// Each value is written on its own line, so repeated values don't collide with a single comma-joined one.
+		fmt.Fprintf(h, "%s: %d\n", strings.ToLower(key), len(headers[key]))
+		for _, value := range headers[key] {
+			fmt.Fprintf(h, "%q\n", value)
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// isSuccessfulResponse reports whether the response has a 2xx status code.
+func isSuccessfulResponse(response HttpResponse) bool {
+	return response.StatusCode >= http.StatusOK && response.StatusCode < http.StatusMultipleChoices
+}
+
+func copyResponse(response HttpResponse) HttpResponse {
+	if response.Headers == nil {
+		return response
+	}
+
+	headers := make(map[string][]string, len(response.Headers))
+	for key, values := range response.Headers {
+		headers[key] = append([]string(nil), values...)
+	}
+	response.Headers = headers
+
+	return response
+}
@@ -0,0 +1,243 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func Test_ResponseCache(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		ttl        time.Duration
+		statusCode int
+		sendErr    error
+		elapsed    time.Duration
+		invalidate bool
+	}
+	type want struct {
+		sends int32
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ReusedWithinTTL": {
+			args: args{
+				ttl:     time.Second,
+				elapsed: 500 * time.Millisecond,
+			},
+			want: want{
+				sends: 1,
+			},
+		},
+		"SentAgainAfterTTL": {
+			args: args{
+				ttl:     time.Second,
+				elapsed: 2 * time.Second,
+			},
+			want: want{
+				sends: 2,
+			},
+		},
+		"ErrorsNotCached": {
+			args: args{
+				ttl:     time.Second,
+				sendErr: errBoom,
+			},
+			want: want{
+				sends: 2,
+			},
+		},
+		"ServerErrorsNotCached": {
+			args: args{
+				ttl:        time.Second,
+				statusCode: http.StatusServiceUnavailable,
+			},
+			want: want{
+				sends: 2,
+			},
+		},
+		"TooManyRequestsNotCached": {
+			args: args{
+				ttl:        time.Second,
+				statusCode: http.StatusTooManyRequests,
+			},
+			want: want{
+				sends: 2,
+			},
+		},
+		"SentAgainAfterInvalidate": {
+			args: args{
+				ttl:        time.Second,
+				invalidate: true,
+			},
+			want: want{
+				sends: 2,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			now := time.Now()
+			c := NewResponseCache()
+			c.now = func() time.Time { return now }
+
+			var sends int32
+			send := func() (HttpResponse, error) {
+				atomic.AddInt32(&sends, 1)
+				statusCode := tc.args.statusCode
+				if statusCode == 0 {
+					statusCode = http.StatusOK
+				}
+				return HttpResponse{StatusCode: statusCode}, tc.args.sendErr
+			}
+
+			_, _ = c.Do("key", "example.com", tc.args.ttl, send)
+			now = now.Add(tc.args.elapsed)
+			if tc.args.invalidate {
+				c.Invalidate("example.com")
+			}
+			_, _ = c.Do("key", "example.com", tc.args.ttl, send)
+
+			if diff := cmp.Diff(tc.want.sends, sends); diff != "" {
+				t.Fatalf("Do(...): -want sends, +got sends: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_ResponseCache_ConcurrentRequestsShareOneCall(t *testing.T) {
+	c := NewResponseCache()
+
+	var sends int32
+	release := make(chan struct{})
+	send := func() (HttpResponse, error) {
+		atomic.AddInt32(&sends, 1)
+		<-release
+		return HttpResponse{StatusCode: http.StatusOK, Headers: map[string][]string{"A": {"b"}}}, nil
+	}
+
+	var wg sync.WaitGroup
+	responses := make([]HttpResponse, 5)
+	for i := range responses {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			responses[i], _ = c.Do("key", "example.com", time.Minute, send)
+		}(i)
+	}
+
+	// Give the goroutines time to join the in-flight call before it completes.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if diff := cmp.Diff(int32(1), sends); diff != "" {
+		t.Fatalf("Do(...): -want sends, +got sends: %s", diff)
+	}
+
+	for _, response := range responses {
+		if diff := cmp.Diff(http.StatusOK, response.StatusCode); diff != "" {
+			t.Fatalf("Do(...): -want status code, +got status code: %s", diff)
+		}
+	}
+}
+
+func Test_ResponseCache_Stats(t *testing.T) {
+	c := NewResponseCache()
+	send := func() (HttpResponse, error) {
+		return HttpResponse{StatusCode: http.StatusOK}, nil
+	}
+
+	for i := 0; i < 3; i++ {
+		_, _ = c.Do("key", "example.com", time.Minute, send)
+	}
+
+	if diff := cmp.Diff(CacheStats{Hits: 2, Misses: 1}, c.Stats()); diff != "" {
+		t.Errorf("Stats(): -want stats, +got stats: %s", diff)
+	}
+}
+
+func Test_SendRequest_ResponseCache(t *testing.T) {
+	type args struct {
+		methods []string
+		headers [][]string
+	}
+	type want struct {
+		hits int32
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"IdenticalGETsShareResponse": {
+			args: args{
+				methods: []string{http.MethodGet, http.MethodGet},
+				headers: [][]string{{"a"}, {"a"}},
+			},
+			want: want{
+				hits: 1,
+			},
+		},
+		"DifferentHeadersNotShared": {
+			args: args{
+				methods: []string{http.MethodGet, http.MethodGet},
+				headers: [][]string{{"a"}, {"b"}},
+			},
+			want: want{
+				hits: 2,
+			},
+		},
+		"RepeatedValuesNotSharedWithCommaJoinedValue": {
+			args: args{
+				methods: []string{http.MethodGet, http.MethodGet},
+				headers: [][]string{{"a,b"}, {"a", "b"}},
+			},
+			want: want{
+				hits: 2,
+			},
+		},
+		"ModificationInvalidates": {
+			args: args{
+				methods: []string{http.MethodGet, http.MethodPut, http.MethodGet},
+				headers: [][]string{{"a"}, {"a"}, {"a"}},
+			},
+			want: want{
+				hits: 3,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var hits int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&hits, 1)
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			c, _ := NewClient(logging.NewNopLogger(), 5*time.Second, WithResponseCache(NewResponseCache(), time.Minute))
+			for i, method := range tc.args.methods {
+				headers := map[string][]string{"X-Test": tc.args.headers[i]}
+				_, err := c.SendRequest(context.Background(), method, server.URL, Data{Encrypted: "", Decrypted: ""}, Data{Encrypted: headers, Decrypted: headers}, false)
+				if err != nil {
+					t.Fatalf("SendRequest(...): unexpected error: %s", err)
+				}
+			}
+
+			if diff := cmp.Diff(tc.want.hits, atomic.LoadInt32(&hits)); diff != "" {
+				t.Fatalf("SendRequest(...): -want server hits, +got server hits: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package http
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errInvalidSourceAddress = "invalid source address %q, must be an IP address"
+	errBindSourceAddress    = "cannot dial from source address %s"
+)
+
+// WithSourceAddress binds outbound connections to the given local IP address,
+// for multi-homed hosts where egress must leave through a specific interface.
+func WithSourceAddress(address string) ClientOption {
+	return func(c *client) {
+		c.sourceAddress = address
+	}
+}
+
+// parseSourceAddress returns the local TCP address connections are bound to,
+// or nil when no source address is configured.
+func parseSourceAddress(address string) (*net.TCPAddr, error) {
+	if address == "" {
+		return nil, nil
+	}
+
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return nil, errors.Errorf(errInvalidSourceAddress, address)
+	}
+
+	return &net.TCPAddr{IP: ip}, nil
+}
+
+// dialContext returns a dial function bounded by the connect timeout and binding connections to localAddr,
+// if not nil.
+func dialContext(localAddr *net.TCPAddr, timeout time.Duration) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	dialer := &net.Dialer{LocalAddr: localAddr, Timeout: timeout}
+	if localAddr == nil {
+		return dialer.DialContext
+	}
+
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dialer.DialContext(ctx, network, addr)
+		return conn, errors.Wrapf(err, errBindSourceAddress, localAddr.IP)
+	}
+}
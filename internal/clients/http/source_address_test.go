@@ -0,0 +1,125 @@
+package http
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_NewClient_SourceAddress(t *testing.T) {
+	type want struct {
+		err bool
+	}
+	cases := map[string]struct {
+		address string
+		want    want
+	}{
+		"IPv4": {
+			address: "127.0.0.1",
+		},
+		"IPv6": {
+			address: "::1",
+		},
+		"NotAnIP": {
+			address: "eth0",
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewClient(logging.NewNopLogger(), time.Second, WithSourceAddress(tc.address))
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("NewClient(...): -want error, +got error: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequest_SourceAddress(t *testing.T) {
+	type want struct {
+		errContains string
+	}
+	cases := map[string]struct {
+		address string
+		want    want
+	}{
+		"Loopback": {
+			address: "127.0.0.1",
+		},
+		"NotLocal": {
+			// TEST-NET-1 is never assigned to a local interface.
+			address: "192.0.2.1",
+			want: want{
+				errContains: "cannot dial from source address 192.0.2.1",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var remoteIP string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				remoteIP, _, _ = net.SplitHostPort(r.RemoteAddr)
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), 5*time.Second, WithSourceAddress(tc.address))
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			headers := map[string][]string{}
+			_, err = c.SendRequest(context.Background(), http.MethodGet, server.URL, Data{Encrypted: "", Decrypted: ""}, Data{Encrypted: headers, Decrypted: headers}, false)
+			if tc.want.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.want.errContains) {
+					t.Fatalf("SendRequest(...): want error containing %q, got %v", tc.want.errContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.address, remoteIP); diff != "" {
+				t.Fatalf("SendRequest(...): -want source address, +got source address: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequest_ResponseCacheSourceAddress(t *testing.T) {
+	var remoteIPs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		remoteIP, _, _ := net.SplitHostPort(r.RemoteAddr)
+		remoteIPs = append(remoteIPs, remoteIP)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// The clients of ProviderConfigs binding different source addresses share the response cache, but not their
+	// responses, since the server may answer each address differently.
+	cache := NewResponseCache()
+	for _, address := range []string{"127.0.0.1", "127.0.0.2"} {
+		c, err := NewClient(logging.NewNopLogger(), 5*time.Second, WithSourceAddress(address), WithResponseCache(cache, time.Minute))
+		if err != nil {
+			t.Fatalf("NewClient(...): unexpected error: %s", err)
+		}
+
+		headers := map[string][]string{}
+		if _, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, Data{Encrypted: "", Decrypted: ""}, Data{Encrypted: headers, Decrypted: headers}, false); err != nil {
+			t.Fatalf("SendRequest(...): unexpected error: %s", err)
+		}
+	}
+
+	if diff := cmp.Diff([]string{"127.0.0.1", "127.0.0.2"}, remoteIPs); diff != "" {
+		t.Fatalf("SendRequest(...): -want source addresses, +got source addresses: %s", diff)
+	}
+}
@@ -0,0 +1,28 @@
+package http
+
+import (
+	"time"
+)
+
+// WithTimeouts bounds the phases of the requests separately. A zero timeout derives from the timeout the client
+// was created with, which the total timeout replaces when set.
+func WithTimeouts(connect, tlsHandshake, responseHeader, total time.Duration) ClientOption {
+	return func(c *client) {
+		c.connectTimeout = connect
+		c.tlsHandshakeTimeout = tlsHandshake
+		c.responseHeaderTimeout = responseHeader
+		if total > 0 {
+			c.timeout = total
+		}
+	}
+}
+
+// defaultTimeouts derives the phase timeouts left unset from the total timeout of the client, so that a client
+// configured with a single timeout bounds every phase by it as before.
+func (hc *client) defaultTimeouts() {
+	for _, timeout := range []*time.Duration{&hc.connectTimeout, &hc.tlsHandshakeTimeout, &hc.responseHeaderTimeout} {
+		if *timeout == 0 {
+			*timeout = hc.timeout
+		}
+	}
+}
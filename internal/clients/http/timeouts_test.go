@@ -0,0 +1,151 @@
+package http
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_NewClient_Timeouts(t *testing.T) {
+	type want struct {
+		connect        time.Duration
+		tlsHandshake   time.Duration
+		responseHeader time.Duration
+		total          time.Duration
+	}
+
+	cases := map[string]struct {
+		opts []ClientOption
+		want want
+	}{
+		"ShouldDeriveEveryTimeoutFromWaitTimeout": {
+			want: want{connect: 5 * time.Second, tlsHandshake: 5 * time.Second, responseHeader: 5 * time.Second, total: 5 * time.Second},
+		},
+		"ShouldUseConnectTimeout": {
+			opts: []ClientOption{WithTimeouts(time.Second, 0, 0, 0)},
+			want: want{connect: time.Second, tlsHandshake: 5 * time.Second, responseHeader: 5 * time.Second, total: 5 * time.Second},
+		},
+		"ShouldUseTLSHandshakeTimeout": {
+			opts: []ClientOption{WithTimeouts(0, time.Second, 0, 0)},
+			want: want{connect: 5 * time.Second, tlsHandshake: time.Second, responseHeader: 5 * time.Second, total: 5 * time.Second},
+		},
+		"ShouldUseResponseHeaderTimeout": {
+			opts: []ClientOption{WithTimeouts(0, 0, time.Second, 0)},
+			want: want{connect: 5 * time.Second, tlsHandshake: 5 * time.Second, responseHeader: time.Second, total: 5 * time.Second},
+		},
+		"ShouldDerivePhaseTimeoutsFromTotalTimeout": {
+			opts: []ClientOption{WithTimeouts(0, 0, 0, time.Minute)},
+			want: want{connect: time.Minute, tlsHandshake: time.Minute, responseHeader: time.Minute, total: time.Minute},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			c, err := NewClient(logging.NewNopLogger(), 5*time.Second, tc.opts...)
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			hc := c.(*client)
+			got := want{connect: hc.connectTimeout, tlsHandshake: hc.tlsHandshakeTimeout, responseHeader: hc.responseHeaderTimeout, total: hc.timeout}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("NewClient(...): -want timeouts, +got timeouts: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequest_Timeouts(t *testing.T) {
+	// A TCP server that never answers the TLS handshake.
+	silent, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen(...): unexpected error: %s", err)
+	}
+	defer silent.Close()
+	go func() {
+		var conns []net.Conn
+		defer func() {
+			for _, conn := range conns {
+				conn.Close()
+			}
+		}()
+		for {
+			conn, err := silent.Accept()
+			if err != nil {
+				return
+			}
+			conns = append(conns, conn)
+		}
+	}()
+
+	slowHeaders := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		waitOrDisconnect(r)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer slowHeaders.Close()
+
+	slowBody := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		waitOrDisconnect(r)
+	}))
+	defer slowBody.Close()
+
+	cases := map[string]struct {
+		url     string
+		opts    []ClientOption
+		wantErr string
+	}{
+		"TLSHandshakeTimeout": {
+			url:     "https://" + silent.Addr().String(),
+			opts:    []ClientOption{WithTimeouts(0, 100*time.Millisecond, 0, 0)},
+			wantErr: "TLS handshake timeout",
+		},
+		"ResponseHeaderTimeout": {
+			url:     slowHeaders.URL,
+			opts:    []ClientOption{WithTimeouts(0, 0, 100*time.Millisecond, 0)},
+			wantErr: "timeout awaiting response headers",
+		},
+		"TotalTimeout": {
+			url:     slowBody.URL,
+			opts:    []ClientOption{WithTimeouts(0, 0, 0, 100*time.Millisecond)},
+			wantErr: "Client.Timeout",
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			c, err := NewClient(logging.NewNopLogger(), 5*time.Second, tc.opts...)
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			headers := map[string][]string{}
+			start := time.Now()
+			_, err = c.SendRequest(context.Background(), http.MethodGet, tc.url, Data{Encrypted: "", Decrypted: ""}, Data{Encrypted: headers, Decrypted: headers}, true)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("SendRequest(...): want an error containing %q, got: %v", tc.wantErr, err)
+			}
+			if elapsed := time.Since(start); elapsed > 2*time.Second {
+				t.Errorf("SendRequest(...): want the request to fail fast, took %s", elapsed)
+			}
+		})
+	}
+}
+
+// waitOrDisconnect blocks until the client of the request disconnects, or a few seconds went by.
+func waitOrDisconnect(r *http.Request) {
+	select {
+	case <-r.Context().Done():
+	case <-time.After(3 * time.Second):
+	}
+}
@@ -0,0 +1,49 @@
+package http
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errPublicKeyPinMismatch = "TLS certificate pinning failed: no certificate presented by %s has a pinned public key"
+)
+
+// WithPinnedPublicKeys only accepts TLS connections to servers presenting a certificate whose public key is one
+// of the given base64 encoded SHA-256 hashes of a DER encoded SubjectPublicKeyInfo, on top of the usual
+// verification of the certificate chain.
+func WithPinnedPublicKeys(pins []string) ClientOption {
+	return func(c *client) {
+		c.pinnedPublicKeys = map[string]bool{}
+		for _, pin := range pins {
+			c.pinnedPublicKeys[pin] = true
+		}
+	}
+}
+
+// publicKeyPin returns the base64 encoded SHA-256 hash of the DER encoded SubjectPublicKeyInfo of a certificate.
+func publicKeyPin(rawSubjectPublicKeyInfo []byte) string {
+	sum := sha256.Sum256(rawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// verifyPinnedPublicKeys returns a function verifying that one of the certificates presented by the server, the
+// leaf or one of its issuers, has a pinned public key. It returns nil when no public key is pinned.
+func (hc *client) verifyPinnedPublicKeys() func(tls.ConnectionState) error {
+	if len(hc.pinnedPublicKeys) == 0 {
+		return nil
+	}
+
+	return func(cs tls.ConnectionState) error {
+		for _, cert := range cs.PeerCertificates {
+			if hc.pinnedPublicKeys[publicKeyPin(cert.RawSubjectPublicKeyInfo)] {
+				return nil
+			}
+		}
+
+		return errors.Errorf(errPublicKeyPinMismatch, cs.ServerName)
+	}
+}
@@ -0,0 +1,58 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SendRequest_PinnedPublicKeys(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	serverPin := publicKeyPin(server.Certificate().RawSubjectPublicKeyInfo)
+	otherPin := "47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU="
+
+	cases := map[string]struct {
+		pins     []string
+		wantPass bool
+	}{
+		"NoPins": {
+			wantPass: true,
+		},
+		"MatchingPin": {
+			pins:     []string{otherPin, serverPin},
+			wantPass: true,
+		},
+		"MismatchingPin": {
+			pins:     []string{otherPin},
+			wantPass: false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, err := NewClient(logging.NewNopLogger(), 5*time.Second, WithPinnedPublicKeys(tc.pins))
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			headers := map[string][]string{}
+			// The test server's certificate isn't trusted, so the pins are the only verification.
+			_, err = c.SendRequest(context.Background(), http.MethodGet, server.URL, Data{Encrypted: "", Decrypted: ""}, Data{Encrypted: headers, Decrypted: headers}, true)
+			if diff := cmp.Diff(tc.wantPass, err == nil); diff != "" {
+				t.Fatalf("SendRequest(...): -want success, +got success: %s (error: %v)", diff, err)
+			}
+			if err != nil && !strings.Contains(err.Error(), "TLS certificate pinning failed") {
+				t.Errorf("SendRequest(...): want a pinning error, got: %s", err)
+			}
+		})
+	}
+}
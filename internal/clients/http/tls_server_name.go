@@ -0,0 +1,33 @@
+package http
+
+import "context"
+
+// WithTLSServerName sends the given name as the SNI of TLS connections and verifies the server certificate
+// against it, instead of the host of the request URL, e.g. when connecting through a proxy or load balancer.
+func WithTLSServerName(name string) ClientOption {
+	return func(c *client) {
+		c.tlsServerName = name
+	}
+}
+
+type tlsServerNameKey struct{}
+
+// ContextWithTLSServerName returns a context whose requests use the given TLS server name, overriding the one
+// the client is configured with. An empty name leaves the context unchanged.
+func ContextWithTLSServerName(ctx context.Context, name string) context.Context {
+	if name == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, tlsServerNameKey{}, name)
+}
+
+// serverName returns the TLS server name of a request sent with the given context, or an empty string to use
+// the host of the request URL.
+func (hc *client) serverName(ctx context.Context) string {
+	if name, ok := ctx.Value(tlsServerNameKey{}).(string); ok {
+		return name
+	}
+
+	return hc.tlsServerName
+}
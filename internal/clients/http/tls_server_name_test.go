@@ -0,0 +1,73 @@
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SendRequest_TLSServerName(t *testing.T) {
+	cases := map[string]struct {
+		clientName  string
+		requestName string
+		want        string
+	}{
+		"DefaultsToURLHost": {
+			// Go doesn't send IP addresses as SNI, so the server sees none for the 127.0.0.1 test server.
+			want: "",
+		},
+		"ClientOverride": {
+			clientName: "api.example.com",
+			want:       "api.example.com",
+		},
+		"RequestOverridesClient": {
+			clientName:  "api.example.com",
+			requestName: "internal.example.com",
+			want:        "internal.example.com",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var mu sync.Mutex
+			var serverName string
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+			server.TLS = &tls.Config{
+				GetConfigForClient: func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+					mu.Lock()
+					defer mu.Unlock()
+					serverName = hello.ServerName
+					return nil, nil
+				},
+			}
+			server.StartTLS()
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), 5*time.Second, WithTLSServerName(tc.clientName))
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			ctx := ContextWithTLSServerName(context.Background(), tc.requestName)
+			headers := map[string][]string{}
+			// The test server's certificate isn't trusted, so only the SNI is checked, not the verification.
+			if _, err := c.SendRequest(ctx, http.MethodGet, server.URL, Data{Encrypted: "", Decrypted: ""}, Data{Encrypted: headers, Decrypted: headers}, true); err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %s", err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if diff := cmp.Diff(tc.want, serverName); diff != "" {
+				t.Fatalf("SendRequest(...): -want server name, +got server name: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errInvalidUnixSocketPath = "invalid unix socket path %q, must be an absolute path"
+	errUnixSocketWithSource  = "a unix socket path cannot be combined with a source address"
+	errUnixSocketNotFound    = "unix socket %s does not exist"
+	errNotUnixSocket         = "%s is not a unix socket"
+	errDialUnixSocket        = "cannot dial unix socket %s"
+	unixSocketNetwork        = "unix"
+)
+
+// WithUnixSocket sends requests through the Unix domain socket at the given path instead of TCP,
+// for local daemons. The host and path of the request URL are still used for the HTTP request itself.
+func WithUnixSocket(path string) ClientOption {
+	return func(c *client) {
+		c.unixSocketPath = path
+	}
+}
+
+// validateUnixSocketPath checks the configured socket path is absolute and not combined with a source address.
+// The socket itself is only checked when dialing, since the daemon serving it may start later.
+func validateUnixSocketPath(path, sourceAddress string) error {
+	if path == "" {
+		return nil
+	}
+
+	if !filepath.IsAbs(path) {
+		return errors.Errorf(errInvalidUnixSocketPath, path)
+	}
+
+	if sourceAddress != "" {
+		return errors.New(errUnixSocketWithSource)
+	}
+
+	return nil
+}
+
+// dialUnixSocket returns a dial function connecting to the Unix socket at path, whatever the address of the request.
+func dialUnixSocket(path string, timeout time.Duration) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: timeout}
+	return func(ctx context.Context, _, _ string) (net.Conn, error) {
+		info, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			return nil, errors.Errorf(errUnixSocketNotFound, path)
+		}
+		if err == nil && info.Mode()&os.ModeSocket == 0 {
+			return nil, errors.Errorf(errNotUnixSocket, path)
+		}
+
+		conn, err := dialer.DialContext(ctx, unixSocketNetwork, path)
+		return conn, errors.Wrapf(err, errDialUnixSocket, path)
+	}
+}
+
+// dialer returns the dial function of the client's transport.
+func (hc *client) dialer() func(ctx context.Context, network, addr string) (net.Conn, error) {
+	if hc.unixSocketPath != "" {
+		return dialUnixSocket(hc.unixSocketPath, hc.connectTimeout)
+	}
+
+	dial := dialContext(hc.localAddr, hc.connectTimeout)
+	if hc.resolver != nil {
+		return resolvingDialer(hc.resolver, dial)
+	}
+
+	return dial
+}
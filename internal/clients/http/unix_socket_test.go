@@ -0,0 +1,165 @@
+package http
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_NewClient_UnixSocket(t *testing.T) {
+	type want struct {
+		err bool
+	}
+	cases := map[string]struct {
+		path          string
+		sourceAddress string
+		want          want
+	}{
+		"AbsolutePath": {
+			path: "/var/run/agent.sock",
+		},
+		"RelativePath": {
+			path: "agent.sock",
+			want: want{
+				err: true,
+			},
+		},
+		"WithSourceAddress": {
+			path:          "/var/run/agent.sock",
+			sourceAddress: "127.0.0.1",
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewClient(logging.NewNopLogger(), time.Second, WithUnixSocket(tc.path), WithSourceAddress(tc.sourceAddress))
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("NewClient(...): -want error, +got error: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequest_UnixSocket(t *testing.T) {
+	dir := t.TempDir()
+	socketPath := filepath.Join(dir, "agent.sock")
+	regularFile := filepath.Join(dir, "agent.txt")
+	if err := os.WriteFile(regularFile, nil, 0o600); err != nil {
+		t.Fatalf("cannot create file: %s", err)
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("cannot listen on unix socket: %s", err)
+	}
+
+	var gotHost, gotPath string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHost, gotPath = r.Host, r.URL.Path
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.Listener = listener
+	server.Start()
+	defer server.Close()
+
+	type want struct {
+		errContains string
+	}
+	cases := map[string]struct {
+		path string
+		want want
+	}{
+		"Socket": {
+			path: socketPath,
+		},
+		"MissingSocket": {
+			path: filepath.Join(dir, "missing.sock"),
+			want: want{
+				errContains: "unix socket " + filepath.Join(dir, "missing.sock") + " does not exist",
+			},
+		},
+		"NotASocket": {
+			path: regularFile,
+			want: want{
+				errContains: regularFile + " is not a unix socket",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, err := NewClient(logging.NewNopLogger(), 5*time.Second, WithUnixSocket(tc.path))
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			headers := map[string][]string{}
+			_, err = c.SendRequest(context.Background(), http.MethodGet, "http://agent.local/v1/status", Data{Encrypted: "", Decrypted: ""}, Data{Encrypted: headers, Decrypted: headers}, false)
+			if tc.want.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.want.errContains) {
+					t.Fatalf("SendRequest(...): want error containing %q, got %v", tc.want.errContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff("agent.local /v1/status", gotHost+" "+gotPath); diff != "" {
+				t.Fatalf("SendRequest(...): -want host and path, +got host and path: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequest_ResponseCacheUnixSocket(t *testing.T) {
+	dir := t.TempDir()
+	var sockets []string
+	for _, name := range []string{"first.sock", "second.sock"} {
+		socketPath := filepath.Join(dir, name)
+		listener, err := net.Listen("unix", socketPath)
+		if err != nil {
+			t.Fatalf("cannot listen on unix socket: %s", err)
+		}
+
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(name))
+		}))
+		server.Listener = listener
+		server.Start()
+		defer server.Close()
+
+		sockets = append(sockets, socketPath)
+	}
+
+	// The clients of ProviderConfigs dialing different sockets share the response cache, but not their responses,
+	// even though they send the same URL.
+	cache := NewResponseCache()
+	var bodies []string
+	for _, socketPath := range sockets {
+		c, err := NewClient(logging.NewNopLogger(), 5*time.Second, WithUnixSocket(socketPath), WithResponseCache(cache, time.Minute))
+		if err != nil {
+			t.Fatalf("NewClient(...): unexpected error: %s", err)
+		}
+
+		headers := map[string][]string{}
+		details, err := c.SendRequest(context.Background(), http.MethodGet, "http://agent.local/v1/status", Data{Encrypted: "", Decrypted: ""}, Data{Encrypted: headers, Decrypted: headers}, false)
+		if err != nil {
+			t.Fatalf("SendRequest(...): unexpected error: %s", err)
+		}
+		bodies = append(bodies, details.HttpResponse.Body)
+	}
+
+	if diff := cmp.Diff([]string{"first.sock", "second.sock"}, bodies); diff != "" {
+		t.Fatalf("SendRequest(...): -want bodies, +got bodies: %s", diff)
+	}
+}
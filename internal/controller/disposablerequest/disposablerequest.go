@@ -19,7 +19,6 @@ package disposablerequest
 import (
 	"context"
 	"fmt"
-	"strconv"
 	"time"
 
 	datapatcher "github.com/crossplane-contrib/provider-http/internal/data-patcher"
@@ -29,11 +28,13 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	json_util "github.com/crossplane-contrib/provider-http/internal/json"
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
@@ -41,6 +42,9 @@ import (
 	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
 	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/killswitch"
+	"github.com/crossplane-contrib/provider-http/internal/notifications"
+	"github.com/crossplane-contrib/provider-http/internal/requestlog"
 	"github.com/crossplane-contrib/provider-http/internal/utils"
 )
 
@@ -59,12 +63,21 @@ const (
 	errConvertResToMap                   = "failed to convert response to map"
 	errGetLatestVersion                  = "failed to get the latest version of the resource"
 	errResponseFormat                    = "Response does not match the expected format, retries limit "
+	errFailedToSendOnDeleteRequest       = "failed to send onDelete http request"
+	errOnDeleteRetriesExhausted          = "onDelete request failed %d times, finishing deletion without it, error: %s"
+	infoWritesPaused                     = "the kill switch is engaged, skipping %s request"
 )
 
 // Setup adds a controller that reconciles DisposableRequest managed resources.
 func Setup(mgr ctrl.Manager, o controller.Options, timeout time.Duration) error {
 	name := managed.ControllerName(v1alpha2.DisposableRequestGroupKind)
 	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
+	responseCache := httpClient.NewResponseCache()
+	if err := httpClient.RegisterCacheMetrics(metrics.Registry, httpClient.CacheResponsesDisposableRequest, responseCache.Stats); err != nil {
+		return err
+	}
 
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha2.DisposableRequestGroupVersionKind),
@@ -73,12 +86,16 @@ func Setup(mgr ctrl.Manager, o controller.Options, timeout time.Duration) error
 			kube:            mgr.GetClient(),
 			usage:           resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
 			newHttpClientFn: httpClient.NewClient,
+			hostLimiter:     httpClient.NewHostLimiter(),
+			responseCache:   responseCache,
+			killSwitches:    killswitch.NewCache(),
+			recorder:        recorder,
 		}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
 		WithCustomPollIntervalHook(),
 		managed.WithTimeout(timeout),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithRecorder(recorder),
 		managed.WithConnectionPublishers(cps...))
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -93,7 +110,13 @@ type connector struct {
 	logger          logging.Logger
 	kube            client.Client
 	usage           resource.Tracker
-	newHttpClientFn func(log logging.Logger, timeout time.Duration) (httpClient.Client, error)
+	newHttpClientFn func(log logging.Logger, timeout time.Duration, opts ...httpClient.ClientOption) (httpClient.Client, error)
+	hostLimiter     *httpClient.HostLimiter
+	responseCache   *httpClient.ResponseCache
+	// recorder records the entries of the request logs writing to events.
+	recorder event.Recorder
+	// killSwitches caches the state of the kill switches of the ProviderConfigs across reconciles.
+	killSwitches *killswitch.Cache
 }
 
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
@@ -108,28 +131,40 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errTrackPCUsage)
 	}
 
-	pc := &apisv1alpha1.ProviderConfig{}
-	n := types.NamespacedName{Name: cr.GetProviderConfigReference().Name}
-	if err := c.kube.Get(ctx, n, pc); err != nil {
+	pc, err := utils.ResolveProviderConfig(ctx, c.kube, cr.GetProviderConfigReference().Name)
+	if err != nil {
 		return nil, errors.Wrap(err, errProviderNotRetrieved)
 	}
 
-	h, err := c.newHttpClientFn(l, utils.WaitTimeout(cr.Spec.ForProvider.WaitTimeout))
+	h, err := c.newHttpClientFn(l, utils.WaitTimeout(cr.Spec.ForProvider.WaitTimeout), utils.ClientOptions(pc, c.hostLimiter, c.responseCache)...)
 	if err != nil {
 		return nil, errors.Wrap(err, errNewHttpClient)
 	}
+	h = requestlog.WrapClient(h, requestlog.New(pc.Spec.RequestLog, c.recorder), l, pc.Name, v1alpha2.DisposableRequestGroupVersionKind, cr)
+
+	notifier, err := notifications.New(ctx, c.kube, l, pc.Spec.Notifications)
+	if err != nil {
+		l.Info(fmt.Sprintf(errNotificationsDisabled, err.Error()))
+	}
 
 	return &external{
-		localKube: c.kube,
-		logger:    l,
-		http:      h,
+		localKube:  c.kube,
+		logger:     l,
+		http:       h,
+		objectRefs: datapatcher.NewObjectRefCache(),
+		notifier:   notifier,
+		killSwitch: killswitch.New(c.kube, pc.Spec.KillSwitch, c.killSwitches),
 	}, nil
 }
 
 type external struct {
-	localKube client.Client
-	logger    logging.Logger
-	http      httpClient.Client
+	localKube  client.Client
+	logger     logging.Logger
+	http       httpClient.Client
+	objectRefs *datapatcher.ObjectRefCache
+	notifier   *notifications.Notifier
+	// killSwitch pauses the requests of the DisposableRequest, if the ProviderConfig configures one.
+	killSwitch *killswitch.Switch
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -138,7 +173,10 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotDisposableRequest)
 	}
 
-	if !cr.Status.Synced {
+	ctx = datapatcher.WithObjectRefCache(ctx, c.objectRefs)
+
+	// Once deleted, the resource only exists while its onDelete request is still pending.
+	if !cr.Status.Synced || (meta.WasDeleted(cr) && cr.Spec.ForProvider.OnDelete == nil) {
 		return managed.ExternalObservation{
 			ResourceExists: false,
 		}, nil
@@ -171,6 +209,13 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 }
 
 func (c *external) deployAction(ctx context.Context, cr *v1alpha2.DisposableRequest) error {
+	if held, err := c.holdWrites(ctx, cr, cr.Spec.ForProvider.Method); held || err != nil {
+		return err
+	}
+
+	ctx, cancel := utils.DrainOnShutdown(ctx)
+	defer cancel()
+
 	sensitiveBody, err := datapatcher.PatchSecretsIntoBody(ctx, c.localKube, cr.Spec.ForProvider.Body)
 	if err != nil {
 		return err
@@ -184,6 +229,10 @@ func (c *external) deployAction(ctx context.Context, cr *v1alpha2.DisposableRequ
 	bodyData := httpClient.Data{Encrypted: cr.Spec.ForProvider.Body, Decrypted: sensitiveBody}
 	headersData := httpClient.Data{Encrypted: cr.Spec.ForProvider.Headers, Decrypted: sensitiveHeaders}
 	details, err := c.http.SendRequest(ctx, cr.Spec.ForProvider.Method, cr.Spec.ForProvider.URL, bodyData, headersData, cr.Spec.ForProvider.InsecureSkipTLSVerify)
+	if httpClient.IsHostSaturated(err) || httpClient.IsTemporaryDNSFailure(err) {
+		// The request was never sent, requeue without recording a failure.
+		return err
+	}
 
 	sensitiveResponse := details.HttpResponse
 	resource := &utils.RequestResource{
@@ -214,7 +263,7 @@ func (c *external) deployAction(ctx context.Context, cr *v1alpha2.DisposableRequ
 			return errors.Wrap(settingError, utils.ErrFailedToSetStatus)
 		}
 
-		return errors.Errorf(utils.ErrStatusCode, cr.Spec.ForProvider.Method, strconv.Itoa(resource.HttpResponse.StatusCode))
+		return utils.StatusCodeError(cr.Spec.ForProvider.Method, resource.HttpResponse.StatusCode)
 	}
 
 	isExpectedResponse, err := c.isResponseAsExpected(cr, sensitiveResponse)
@@ -231,6 +280,17 @@ func (c *external) deployAction(ctx context.Context, cr *v1alpha2.DisposableRequ
 	return utils.SetRequestResourceStatus(*resource, resource.SetStatusCode(), resource.SetLastReconcileTime(), resource.SetHeaders(), resource.SetBody(), resource.SetSynced(), resource.SetRequestDetails())
 }
 
+// holdWrites reports whether the kill switch holds back the requests of the DisposableRequest. Nothing is sent
+// then, and the request is attempted again once the resource is next reconciled.
+func (c *external) holdWrites(ctx context.Context, cr *v1alpha2.DisposableRequest, method string) (bool, error) {
+	held, err := c.killSwitch.Hold(ctx, cr)
+	if held {
+		c.logger.Debug(fmt.Sprintf(infoWritesPaused, method))
+	}
+
+	return held, err
+}
+
 func (c *external) isResponseAsExpected(cr *v1alpha2.DisposableRequest, res httpClient.HttpResponse) (bool, error) {
 	// If no expected response is defined, consider it as expected.
 	if cr.Spec.ForProvider.ExpectedResponse == "" {
@@ -241,13 +301,11 @@ func (c *external) isResponseAsExpected(cr *v1alpha2.DisposableRequest, res http
 		return false, nil
 	}
 
-	responseMap, err := json_util.StructToMap(res)
+	responseMap, err := json_util.ResponseToMap(res, string(cr.Spec.ForProvider.ResponseFormat))
 	if err != nil {
 		return false, errors.Wrap(err, errConvertResToMap)
 	}
 
-	json_util.ConvertJSONStringsToMaps(&responseMap)
-
 	isExpected, err := jq.ParseBool(cr.Spec.ForProvider.ExpectedResponse, responseMap)
 	if err != nil {
 		return false, errors.Errorf(ErrExpectedFormat, err.Error())
@@ -262,11 +320,13 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNotDisposableRequest)
 	}
 
+	ctx = datapatcher.WithObjectRefCache(ctx, c.objectRefs)
+
 	if err := utils.IsRequestValid(cr.Spec.ForProvider.Method, cr.Spec.ForProvider.URL); err != nil {
 		return managed.ExternalCreation{}, err
 	}
 
-	return managed.ExternalCreation{}, errors.Wrap(c.deployAction(ctx, cr), errFailedToSendHttpDisposableRequest)
+	return managed.ExternalCreation{}, errors.Wrap(c.deployActionAndNotify(ctx, cr), errFailedToSendHttpDisposableRequest)
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
@@ -275,22 +335,54 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotDisposableRequest)
 	}
 
+	ctx = datapatcher.WithObjectRefCache(ctx, c.objectRefs)
+
 	if err := utils.IsRequestValid(cr.Spec.ForProvider.Method, cr.Spec.ForProvider.URL); err != nil {
 		return managed.ExternalUpdate{}, err
 	}
 
-	return managed.ExternalUpdate{}, errors.Wrap(c.deployAction(ctx, cr), errFailedToSendHttpDisposableRequest)
+	return managed.ExternalUpdate{}, errors.Wrap(c.deployActionAndNotify(ctx, cr), errFailedToSendHttpDisposableRequest)
 }
 
-func (c *external) Delete(_ context.Context, _ resource.Managed) error {
-	return nil
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha2.DisposableRequest)
+	if !ok {
+		return errors.New(errNotDisposableRequest)
+	}
+
+	ctx = datapatcher.WithObjectRefCache(ctx, c.objectRefs)
+
+	if cr.Spec.ForProvider.OnDelete == nil {
+		return nil
+	}
+
+	return errors.Wrap(c.deleteAction(ctx, cr), errFailedToSendOnDeleteRequest)
 }
 
 func (c *external) patchResponseToSecret(ctx context.Context, cr *v1alpha2.DisposableRequest, response *httpClient.HttpResponse) {
-	for _, ref := range cr.Spec.ForProvider.SecretInjectionConfigs {
-		err := datapatcher.PatchResponseToSecret(ctx, c.localKube, c.logger, response, ref.ResponsePath, ref.SecretKey, ref.SecretRef.Name, ref.SecretRef.Namespace)
+	refs := cr.Spec.ForProvider.SecretInjectionConfigs
+	injections := make([]datapatcher.SecretInjection, len(refs))
+	for i, ref := range refs {
+		injections[i] = datapatcher.SecretInjection{
+			ResponsePath:    ref.ResponsePath,
+			Condition:       ref.Condition,
+			SecretKey:       ref.SecretKey,
+			SecretName:      ref.SecretRef.Name,
+			SecretNamespace: ref.SecretRef.Namespace,
+			KeyTransform:    string(ref.KeyTransform),
+			ResponseFormat:  string(cr.Spec.ForProvider.ResponseFormat),
+		}
+	}
+
+	ctx = datapatcher.WithInjectionPolicy(ctx, datapatcher.InjectionPolicy{
+		Owner:             datapatcher.ControllerReference(v1alpha2.DisposableRequestGroupVersionKind, cr),
+		OwnCreatedSecrets: cr.Spec.ForProvider.OwnInjectedSecrets,
+		FailOnConflict:    cr.Spec.ForProvider.SecretInjectionConflicts == v1alpha2.SecretInjectionConflictError,
+	})
+
+	for i, err := range datapatcher.PatchResponseToSecrets(ctx, c.localKube, c.logger, response, injections) {
 		if err != nil {
-			c.logger.Info(fmt.Sprintf(errPatchDataToSecret, ref.SecretRef.Name, ref.SecretRef.Namespace, ref.SecretKey, err.Error()))
+			c.logger.Info(fmt.Sprintf(errPatchDataToSecret, refs[i].SecretRef.Name, refs[i].SecretRef.Namespace, refs[i].SecretKey, err.Error()))
 		}
 	}
 }
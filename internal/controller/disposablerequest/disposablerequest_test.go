@@ -268,6 +268,196 @@ func Test_httpExternal_Update(t *testing.T) {
 	}
 }
 
+func Test_httpExternal_Delete(t *testing.T) {
+	testOnDelete := &v1alpha2.Mapping{
+		Method: "DELETE",
+		URL:    `(.url + "/tokens/" + .response.body.id)`,
+		Headers: map[string][]string{
+			"X-Token-Id": {".response.body.id"},
+		},
+	}
+	withOnDelete := func(r *v1alpha2.DisposableRequest) {
+		r.Spec.ForProvider.OnDelete = testOnDelete
+		r.Status.Synced = true
+		r.Status.Response = v1alpha2.Response{
+			StatusCode: 201,
+			Body:       `{"id": "42"}`,
+		}
+	}
+	retriesLimit := int32(3)
+	withOnDeleteRetries := func(failed int32) func(r *v1alpha2.DisposableRequest) {
+		return func(r *v1alpha2.DisposableRequest) {
+			withOnDelete(r)
+			r.Spec.ForProvider.RollbackRetriesLimit = &retriesLimit
+			r.Status.OnDeleteFailed = failed
+		}
+	}
+
+	type args struct {
+		http      httpClient.Client
+		localKube client.Client
+		mg        resource.Managed
+	}
+	type want struct {
+		err    error
+		url    string
+		synced bool
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NotDisposableRequestResource": {
+			args: args{
+				mg: notHttpDisposableRequest{},
+			},
+			want: want{
+				err: errors.New(errNotDisposableRequest),
+			},
+		},
+		"NoOnDeleteMapping": {
+			args: args{
+				mg: httpDisposableRequest(func(r *v1alpha2.DisposableRequest) {
+					r.Status.Synced = true
+				}),
+			},
+			want: want{
+				err:    nil,
+				synced: true,
+			},
+		},
+		"OnDeleteFailed": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{}, errBoom
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				mg: httpDisposableRequest(withOnDeleteRetries(0)),
+			},
+			want: want{
+				err:    errors.Wrap(errBoom, errFailedToSendOnDeleteRequest),
+				synced: true,
+			},
+		},
+		"OnDeleteFailedDefaultRetriesLimitReached": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{}, errBoom
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				mg: httpDisposableRequest(withOnDelete),
+			},
+			want: want{
+				err:    nil,
+				synced: false,
+			},
+		},
+		"OnDeleteStatusCodeError": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{StatusCode: 500},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				mg: httpDisposableRequest(withOnDeleteRetries(1)),
+			},
+			want: want{
+				err:    errors.Wrap(errors.Errorf(utils.ErrStatusCode, "DELETE", "500"), errFailedToSendOnDeleteRequest),
+				synced: true,
+			},
+		},
+		"OnDeleteStatusCodeErrorRetriesLimitReached": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{StatusCode: 500},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				mg: httpDisposableRequest(withOnDeleteRetries(2)),
+			},
+			want: want{
+				err:    nil,
+				synced: false,
+			},
+		},
+		"Success": {
+			args: args{
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				mg: httpDisposableRequest(withOnDelete),
+			},
+			want: want{
+				err:    nil,
+				url:    testURL + "/tokens/42",
+				synced: false,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var sentURL string
+			h := tc.args.http
+			if h == nil {
+				h = &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						sentURL = url
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{StatusCode: 204},
+							HttpRequest:  httpClient.HttpRequest{Method: method, URL: url},
+						}, nil
+					},
+				}
+			}
+
+			e := &external{
+				localKube: tc.args.localKube,
+				logger:    logging.NewNopLogger(),
+				http:      h}
+			gotErr := e.Delete(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("e.Delete(...): -want error, +got error: %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.url, sentURL); diff != "" {
+				t.Fatalf("e.Delete(...): -want url, +got url: %s", diff)
+			}
+
+			if cr, ok := tc.args.mg.(*v1alpha2.DisposableRequest); ok {
+				if diff := cmp.Diff(tc.want.synced, cr.Status.Synced); diff != "" {
+					t.Fatalf("e.Delete(...): -want synced, +got synced: %s", diff)
+				}
+			}
+		})
+	}
+}
+
 func Test_deployAction(t *testing.T) {
 	type args struct {
 		cr        *v1alpha2.DisposableRequest
@@ -432,3 +622,46 @@ func Test_deployAction(t *testing.T) {
 		})
 	}
 }
+
+func Test_retriesExhausted(t *testing.T) {
+	limit := int32(3)
+
+	cases := map[string]struct {
+		forProvider v1alpha2.DisposableRequestParameters
+		failed      int32
+		want        bool
+	}{
+		"ShouldExhaustAtLimit": {
+			forProvider: v1alpha2.DisposableRequestParameters{RollbackRetriesLimit: &limit},
+			failed:      3,
+			want:        true,
+		},
+		"ShouldNotExhaustBelowLimit": {
+			forProvider: v1alpha2.DisposableRequestParameters{RollbackRetriesLimit: &limit},
+			failed:      2,
+			want:        false,
+		},
+		"ShouldExhaustAfterSingleFailureWithoutLimit": {
+			failed: 1,
+			want:   true,
+		},
+		"ShouldNeverExhaustWhenLoopingInfinitely": {
+			forProvider: v1alpha2.DisposableRequestParameters{ShouldLoopInfinitely: true},
+			failed:      1,
+			want:        false,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha2.DisposableRequest{
+				Spec:   v1alpha2.DisposableRequestSpec{ForProvider: tc.forProvider},
+				Status: v1alpha2.DisposableRequestStatus{Failed: tc.failed},
+			}
+			if diff := cmp.Diff(tc.want, retriesExhausted(cr)); diff != "" {
+				t.Errorf("retriesExhausted(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
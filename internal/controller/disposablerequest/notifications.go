@@ -0,0 +1,39 @@
+package disposablerequest
+
+import (
+	"context"
+
+	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-http/internal/notifications"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const errNotificationsDisabled = "Warning, notifications are disabled for this reconcile, error: %s"
+
+// deployActionAndNotify sends the request, then notifies the webhook of the ProviderConfig when the failure it
+// recorded is the last one the rollback retries limit allows.
+func (c *external) deployActionAndNotify(ctx context.Context, cr *v1alpha2.DisposableRequest) error {
+	failed := cr.Status.Failed
+	err := c.deployAction(ctx, cr)
+
+	if cr.Status.Failed > failed && retriesExhausted(cr) {
+		outcome := notifications.Outcome{Method: cr.Spec.ForProvider.Method, StatusCode: cr.Status.Response.StatusCode, Error: cr.Status.Error}
+		if err != nil {
+			outcome.Error = err.Error()
+		}
+		c.notifier.Notify(apisv1alpha1.NotificationEventRetriesExhausted, v1alpha2.DisposableRequestGroupVersionKind, cr, outcome)
+	}
+
+	return err
+}
+
+// retriesExhausted reports whether the DisposableRequest has just failed as many times as its rollback retries
+// limit allows. A DisposableRequest looping infinitely without a limit never exhausts its retries.
+func retriesExhausted(cr *v1alpha2.DisposableRequest) bool {
+	if cr.Spec.ForProvider.ShouldLoopInfinitely && cr.Spec.ForProvider.RollbackRetriesLimit == nil {
+		return false
+	}
+
+	return cr.Status.Failed == utils.GetRollbackRetriesLimit(cr.Spec.ForProvider.RollbackRetriesLimit)
+}
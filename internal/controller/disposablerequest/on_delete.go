@@ -0,0 +1,164 @@
+package disposablerequest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"golang.org/x/exp/maps"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestprocessing"
+	datapatcher "github.com/crossplane-contrib/provider-http/internal/data-patcher"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+// deleteAction sends the onDelete request of the given DisposableRequest. On success the resource is
+// marked as no longer synced, so the next observation reports it as gone and its finalizer is removed.
+// A failing onDelete request is sent again until it fails as many times as the rollback retries limit
+// allows; the failure is then recorded in status and deletion finishes anyway.
+func (c *external) deleteAction(ctx context.Context, cr *v1alpha2.DisposableRequest) error {
+	if held, err := c.holdWrites(ctx, cr, cr.Spec.ForProvider.OnDelete.Method); held || err != nil {
+		return err
+	}
+
+	ctx, cancel := utils.DrainOnShutdown(ctx)
+	defer cancel()
+
+	mapping := cr.Spec.ForProvider.OnDelete
+	jqObject := generateOnDeleteObject(cr)
+
+	url, err := requestprocessing.ApplyJQOnStr(mapping.URL, jqObject)
+	if err != nil {
+		return err
+	}
+
+	if err := utils.IsRequestValid(mapping.Method, url); err != nil {
+		return err
+	}
+
+	bodyData, err := generateOnDeleteBody(ctx, c.localKube, mapping.Body, jqObject)
+	if err != nil {
+		return err
+	}
+
+	headersData, err := generateOnDeleteHeaders(ctx, c.localKube, mapping.Headers, jqObject)
+	if err != nil {
+		return err
+	}
+
+	details, err := c.http.SendRequest(ctx, mapping.Method, url, bodyData, headersData, cr.Spec.ForProvider.InsecureSkipTLSVerify)
+	if httpClient.IsHostSaturated(err) || httpClient.IsTemporaryDNSFailure(err) {
+		// The request was never sent, requeue without recording a failure.
+		return err
+	}
+
+	resource := &utils.RequestResource{
+		Resource:       cr,
+		RequestContext: ctx,
+		HttpResponse:   details.HttpResponse,
+		LocalClient:    c.localKube,
+		HttpRequest:    details.HttpRequest,
+	}
+
+	// Get the latest version of the resource before updating
+	if getErr := c.localKube.Get(ctx, types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, cr); getErr != nil {
+		return errors.Wrap(getErr, errGetLatestVersion)
+	}
+
+	unsetSynced := func() {
+		cr.Status.Synced = false
+	}
+
+	limit := utils.GetRollbackRetriesLimit(cr.Spec.ForProvider.RollbackRetriesLimit)
+	recordFailure := func() {
+		cr.Status.OnDeleteFailed++
+		if cr.Status.OnDeleteFailed >= limit {
+			unsetSynced()
+		}
+	}
+
+	if err != nil {
+		if settingError := utils.SetRequestResourceStatus(*resource, resource.SetError(err), resource.SetLastReconcileTime(), resource.SetRequestDetails(), recordFailure); settingError != nil {
+			return errors.Wrap(settingError, utils.ErrFailedToSetStatus)
+		}
+		return c.giveUpOnDelete(cr, limit, err)
+	}
+
+	if utils.IsHTTPError(resource.HttpResponse.StatusCode) {
+		if settingError := utils.SetRequestResourceStatus(*resource, resource.SetStatusCode(), resource.SetLastReconcileTime(), resource.SetHeaders(), resource.SetBody(), resource.SetRequestDetails(), resource.SetError(nil), recordFailure); settingError != nil {
+			return errors.Wrap(settingError, utils.ErrFailedToSetStatus)
+		}
+
+		return c.giveUpOnDelete(cr, limit, utils.StatusCodeError(mapping.Method, resource.HttpResponse.StatusCode))
+	}
+
+	return utils.SetRequestResourceStatus(*resource, resource.SetStatusCode(), resource.SetLastReconcileTime(), resource.SetHeaders(), resource.SetBody(), resource.SetRequestDetails(), unsetSynced)
+}
+
+// giveUpOnDelete returns the error of a failed onDelete request, or nil once the request has failed as many
+// times as the rollback retries limit allows, so deletion finishes instead of retrying forever.
+func (c *external) giveUpOnDelete(cr *v1alpha2.DisposableRequest, limit int32, err error) error {
+	if cr.Status.OnDeleteFailed < limit {
+		return err
+	}
+
+	c.logger.Info(fmt.Sprintf(errOnDeleteRetriesExhausted, limit, err.Error()))
+	return nil
+}
+
+// generateOnDeleteObject creates the object the onDelete templates are evaluated against. It holds the
+// forProvider fields at the root and the response captured in status under the response key.
+func generateOnDeleteObject(cr *v1alpha2.DisposableRequest) map[string]interface{} {
+	baseMap, _ := json_util.StructToMap(cr.Spec.ForProvider)
+	if baseMap == nil {
+		baseMap = map[string]interface{}{}
+	}
+
+	statusMap, _ := json_util.StructToMap(map[string]interface{}{
+		"response": cr.Status.Response,
+	})
+
+	maps.Copy(baseMap, statusMap)
+	json_util.ConvertJSONStringsToMaps(&baseMap)
+
+	return baseMap
+}
+
+// generateOnDeleteBody applies the onDelete body template and injects the referenced secrets.
+func generateOnDeleteBody(ctx context.Context, localKube client.Client, mappingBody string, jqObject map[string]interface{}) (httpClient.Data, error) {
+	if mappingBody == "" {
+		return httpClient.Data{Encrypted: "", Decrypted: ""}, nil
+	}
+
+	body, err := requestprocessing.ApplyJQOnStr(requestprocessing.ConvertStringToJQQuery(mappingBody), jqObject)
+	if err != nil {
+		return httpClient.Data{}, err
+	}
+
+	sensitiveBody, err := datapatcher.PatchSecretsIntoBody(datapatcher.WithJQObject(ctx, jqObject), localKube, body)
+	if err != nil {
+		return httpClient.Data{}, err
+	}
+
+	return httpClient.Data{Encrypted: body, Decrypted: sensitiveBody}, nil
+}
+
+// generateOnDeleteHeaders applies the onDelete header templates and injects the referenced secrets.
+func generateOnDeleteHeaders(ctx context.Context, localKube client.Client, headers map[string][]string, jqObject map[string]interface{}) (httpClient.Data, error) {
+	generatedHeaders, err := requestprocessing.ApplyJQOnMapStrings(headers, jqObject)
+	if err != nil {
+		return httpClient.Data{}, err
+	}
+
+	sensitiveHeaders, err := datapatcher.PatchSecretsIntoHeaders(datapatcher.WithJQObject(ctx, jqObject), localKube, generatedHeaders)
+	if err != nil {
+		return httpClient.Data{}, err
+	}
+
+	return httpClient.Data{Encrypted: generatedHeaders, Decrypted: sensitiveHeaders}, nil
+}
@@ -21,7 +21,9 @@ import (
 
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	"github.com/crossplane-contrib/provider-http/internal/controller/config"
 	disposablerequest "github.com/crossplane-contrib/provider-http/internal/controller/disposablerequest"
 	request "github.com/crossplane-contrib/provider-http/internal/controller/request"
@@ -30,6 +32,10 @@ import (
 // Setup creates all http controllers with the supplied logger and adds them to
 // the supplied manager.
 func Setup(mgr ctrl.Manager, o controller.Options, timeout time.Duration) error {
+	if err := httpClient.RegisterCacheMetrics(metrics.Registry, httpClient.CacheBearerTokenFiles, httpClient.BearerTokenFileCacheStats); err != nil {
+		return err
+	}
+
 	for _, setup := range []func(ctrl.Manager, controller.Options, time.Duration) error{
 		config.Setup,
 		disposablerequest.Setup,
@@ -0,0 +1,95 @@
+package request
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+)
+
+const (
+	// minAdaptivePollInterval bounds the intervals derived from responses, so that a response can't make the
+	// resource requeue in a tight loop.
+	minAdaptivePollInterval = time.Second
+
+	errAdaptivePollInterval = "Warning, couldn't derive the poll interval from the GET response, error: %s"
+	errUnknownPollInterval  = "%s is neither a duration, a number of seconds nor one of the states"
+)
+
+// setAdaptivePollInterval records in the status the poll interval derived from the GET response. Without a
+// successful response, or when the interval can't be derived, the default interval of the configuration applies.
+func (c *external) setAdaptivePollInterval(cr *v1alpha2.Request, details httpClient.HttpDetails, responseErr error) {
+	config := cr.Spec.ForProvider.AdaptivePolling
+	if config == nil {
+		cr.Status.PollInterval = nil
+		return
+	}
+
+	cr.Status.PollInterval = config.Default
+	if responseErr != nil {
+		return
+	}
+
+	interval, err := adaptivePollInterval(cr, config, details.HttpResponse)
+	if err != nil {
+		c.logger.Info(fmt.Sprintf(errAdaptivePollInterval, err.Error()))
+		return
+	}
+
+	cr.Status.PollInterval = &metav1.Duration{Duration: interval}
+}
+
+// adaptivePollInterval evaluates the interval of the configuration against the response, and resolves its result
+// as a state, a duration or a number of seconds, in that order.
+func adaptivePollInterval(cr *v1alpha2.Request, config *v1alpha2.AdaptivePolling, response httpClient.HttpResponse) (time.Duration, error) {
+	var responseFormat string
+	if mapping, ok := getMappingByMethod(&cr.Spec.ForProvider, http.MethodGet); ok {
+		responseFormat = string(mapping.ResponseFormat)
+	}
+
+	responseMap, err := json_util.ResponseToMap(response, responseFormat)
+	if err != nil {
+		return 0, err
+	}
+
+	value, err := jq.ParseScalar(config.Interval, responseMap)
+	if err != nil {
+		return 0, err
+	}
+
+	var interval time.Duration
+	if state, ok := config.States[value]; ok {
+		interval = state.Duration
+	} else if duration, err := time.ParseDuration(value); err == nil {
+		interval = duration
+	} else if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		interval = time.Duration(seconds * float64(time.Second))
+	} else {
+		return 0, errors.Errorf(errUnknownPollInterval, value)
+	}
+
+	if interval < minAdaptivePollInterval {
+		return minAdaptivePollInterval, nil
+	}
+	return interval, nil
+}
+
+// pollIntervalFromResponse requeues a resource after the poll interval derived from its last GET response, in
+// place of the poll interval of the provider.
+func pollIntervalFromResponse(mg resource.Managed, pollInterval time.Duration) time.Duration {
+	cr, ok := mg.(*v1alpha2.Request)
+	if !ok || cr.Spec.ForProvider.AdaptivePolling == nil || cr.Status.PollInterval == nil {
+		return pollInterval
+	}
+
+	return cr.Status.PollInterval.Duration
+}
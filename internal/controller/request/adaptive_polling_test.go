@@ -0,0 +1,99 @@
+package request
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func withAdaptivePolling(interval string) httpRequestModifier {
+	return func(r *v1alpha2.Request) {
+		r.Spec.ForProvider.AdaptivePolling = &v1alpha2.AdaptivePolling{
+			Interval: interval,
+			States: map[string]metav1.Duration{
+				"Provisioning": {Duration: 5 * time.Second},
+				"Ready":        {Duration: 10 * time.Minute},
+			},
+			Default: &metav1.Duration{Duration: time.Minute},
+		}
+	}
+}
+
+func Test_setAdaptivePollInterval(t *testing.T) {
+	type args struct {
+		mg          *v1alpha2.Request
+		body        string
+		responseErr error
+	}
+	type want struct {
+		pollInterval time.Duration
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"State": {
+			args: args{
+				mg:   httpRequest(withAdaptivePolling(".body.status")),
+				body: `{"status": "Provisioning"}`,
+			},
+			want: want{pollInterval: 5 * time.Second},
+		},
+		"Duration": {
+			args: args{
+				mg:   httpRequest(withAdaptivePolling(".body.retryAfter")),
+				body: `{"retryAfter": "2m30s"}`,
+			},
+			want: want{pollInterval: 150 * time.Second},
+		},
+		"Seconds": {
+			args: args{
+				mg:   httpRequest(withAdaptivePolling(".body.retryAfter")),
+				body: `{"retryAfter": 30}`,
+			},
+			want: want{pollInterval: 30 * time.Second},
+		},
+		"BelowMinimum": {
+			args: args{
+				mg:   httpRequest(withAdaptivePolling(".body.retryAfter")),
+				body: `{"retryAfter": 0}`,
+			},
+			want: want{pollInterval: minAdaptivePollInterval},
+		},
+		"UnknownStateFallsBackToDefault": {
+			args: args{
+				mg:   httpRequest(withAdaptivePolling(".body.status")),
+				body: `{"status": "Deleting"}`,
+			},
+			want: want{pollInterval: time.Minute},
+		},
+		"FailedRequestFallsBackToDefault": {
+			args: args{
+				mg:          httpRequest(withAdaptivePolling(".body.status")),
+				responseErr: errBoom,
+			},
+			want: want{pollInterval: time.Minute},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			e := &external{logger: logging.NewNopLogger()}
+			details := httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusOK, Body: tc.args.body}}
+
+			e.setAdaptivePollInterval(tc.args.mg, details, tc.args.responseErr)
+			got := pollIntervalFromResponse(tc.args.mg, 10*time.Hour)
+			if diff := cmp.Diff(tc.want.pollInterval, got); diff != "" {
+				t.Errorf("pollIntervalFromResponse(...): -want poll interval, +got poll interval: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package request
+
+import (
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+)
+
+const (
+	errCanaryCohort    = "cannot determine the canary cohort of the request"
+	infoInCanaryCohort = "request belongs to the canary cohort of its ProviderConfig"
+)
+
+// typeComparison returns the type comparison mode of the Request, falling back to the one of its canary
+// rollout when the Request doesn't set one.
+func typeComparison(cr *v1alpha2.Request, canary *apisv1alpha1.CanaryRollout) v1alpha2.TypeComparisonMode {
+	if cr.Spec.ForProvider.TypeComparison != "" || canary == nil {
+		return cr.Spec.ForProvider.TypeComparison
+	}
+
+	return v1alpha2.TypeComparisonMode(canary.TypeComparison)
+}
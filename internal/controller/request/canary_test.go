@@ -0,0 +1,49 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+)
+
+func Test_typeComparison(t *testing.T) {
+	canary := &apisv1alpha1.CanaryRollout{Enabled: true, TypeComparison: string(v1alpha2.TypeComparisonLenient)}
+
+	type args struct {
+		typeComparison v1alpha2.TypeComparisonMode
+		canary         *apisv1alpha1.CanaryRollout
+	}
+	type want struct {
+		result v1alpha2.TypeComparisonMode
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"OutsideCohort": {
+			args: args{},
+			want: want{result: ""},
+		},
+		"CanaryOverride": {
+			args: args{canary: canary},
+			want: want{result: v1alpha2.TypeComparisonLenient},
+		},
+		"RequestTakesPrecedence": {
+			args: args{typeComparison: v1alpha2.TypeComparisonStrict, canary: canary},
+			want: want{result: v1alpha2.TypeComparisonStrict},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha2.Request{Spec: v1alpha2.RequestSpec{ForProvider: v1alpha2.RequestParameters{TypeComparison: tc.args.typeComparison}}}
+			if diff := cmp.Diff(tc.want.result, typeComparison(cr, tc.args.canary)); diff != "" {
+				t.Errorf("typeComparison(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package request
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	// defaultStreamingThresholdBytes is the size of the response body above which items are decoded one at a time.
+	defaultStreamingThresholdBytes = 1 << 20
+)
+
+// compareItemsAndDesiredState checks whether one of the items of the collection returned by the GET mapping
+// contains the desired state. Bodies larger than the streaming threshold of the mapping are decoded item by
+// item; smaller ones are decoded at once. Both compare the same way.
+func (c *external) compareItemsAndDesiredState(details httpClient.HttpDetails, err error, desiredState string, mapping *v1alpha2.Mapping, typeComparison v1alpha2.TypeComparisonMode, unorderedArrays []v1alpha2.UnorderedArray) (ObserveRequestDetails, error) {
+	observeRequestDetails := NewObserve(details, err, false)
+
+	path, pathErr := json.ParseFieldPath(mapping.ItemsPath)
+	if pathErr != nil {
+		return FailedObserve(), pathErr
+	}
+
+	if !json.IsJSONString(desiredState) {
+		return FailedObserve(), errors.Errorf(errNotValidJSON, "PUT mapping result", desiredState)
+	}
+	desiredStateMap := json.JsonStringToMap(desiredState)
+
+	// The type mismatches of strict comparison are not reported, since they only tell that an item doesn't match.
+	contains, containsErr := containsFunc(typeComparison, unorderedArrays)
+	if containsErr != nil {
+		return FailedObserve(), containsErr
+	}
+
+	body := details.HttpResponse.Body
+	var found bool
+	if int64(len(body)) > streamingThresholdBytes(mapping) {
+		found, containsErr = json.StreamContainsItem(strings.NewReader(body), path, desiredStateMap, contains)
+	} else {
+		found, containsErr = json.ContainsItem(body, path, desiredStateMap, contains)
+	}
+	if containsErr != nil {
+		return FailedObserve(), containsErr
+	}
+
+	observeRequestDetails.Synced = found && utils.IsHTTPSuccess(details.HttpResponse.StatusCode)
+	return observeRequestDetails, nil
+}
+
+func streamingThresholdBytes(mapping *v1alpha2.Mapping) int64 {
+	if mapping.StreamingThresholdBytes != nil {
+		return *mapping.StreamingThresholdBytes
+	}
+	return defaultStreamingThresholdBytes
+}
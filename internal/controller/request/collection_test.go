@@ -0,0 +1,81 @@
+package request
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_compareItemsAndDesiredState(t *testing.T) {
+	streamAlways := int64(0)
+	collection := `{"items": [{"name": "a", "replicas": "1"}, {"name": "b", "replicas": 2}]}`
+
+	type args struct {
+		body           string
+		desiredState   string
+		threshold      *int64
+		typeComparison v1alpha2.TypeComparisonMode
+	}
+	type want struct {
+		synced bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Matching": {
+			args: args{
+				body:         collection,
+				desiredState: `{"name": "b", "replicas": 2}`,
+			},
+			want: want{synced: true},
+		},
+		"MatchingStreamed": {
+			args: args{
+				body:         collection,
+				desiredState: `{"name": "b", "replicas": 2}`,
+				threshold:    &streamAlways,
+			},
+			want: want{synced: true},
+		},
+		"NotMatchingStreamed": {
+			args: args{
+				body:         collection,
+				desiredState: `{"name": "a", "replicas": 1}`,
+				threshold:    &streamAlways,
+			},
+			want: want{synced: false},
+		},
+		"MatchingLenientlyStreamed": {
+			args: args{
+				body:           collection,
+				desiredState:   `{"name": "a", "replicas": 1}`,
+				threshold:      &streamAlways,
+				typeComparison: v1alpha2.TypeComparisonLenient,
+			},
+			want: want{synced: true},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			e := &external{logger: logging.NewNopLogger()}
+			mapping := &v1alpha2.Mapping{Method: http.MethodGet, ItemsPath: ".items", StreamingThresholdBytes: tc.args.threshold}
+			details := httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusOK, Body: tc.args.body}}
+
+			got, err := e.compareItemsAndDesiredState(details, nil, tc.args.desiredState, mapping, tc.args.typeComparison, nil)
+			if err != nil {
+				t.Fatalf("compareItemsAndDesiredState(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.synced, got.Synced); diff != "" {
+				t.Errorf("compareItemsAndDesiredState(...): -want synced, +got synced: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package request
+
+import (
+	"fmt"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const errConnectionDetail = "Warning, couldn't extract connection detail %s from the response, error: %s"
+
+// connectionDetails returns the connection details of the Request, extracted from its latest successful response,
+// which is the cached one when the last request failed. Details that can't be extracted are omitted.
+func (c *external) connectionDetails(cr *v1alpha2.Request) managed.ConnectionDetails {
+	configs := cr.Spec.ForProvider.ConnectionDetails
+	if len(configs) == 0 {
+		return nil
+	}
+
+	response := cr.Status.Response
+	if !utils.IsHTTPSuccess(response.StatusCode) {
+		response = cr.Status.Cache.Response
+	}
+	if !utils.IsHTTPSuccess(response.StatusCode) {
+		return nil
+	}
+	response.Body = utils.DecompressBody(response.Body)
+
+	var responseFormat string
+	if mapping, ok := getMappingByMethod(&cr.Spec.ForProvider, utils.SentActionMethod(cr.Spec.ForProvider, cr.Status.RequestDetails.Method, cr.Status.RequestDetails.Action)); ok {
+		responseFormat = string(mapping.ResponseFormat)
+	}
+
+	responseMap, err := json_util.ResponseToMap(response, responseFormat)
+	if err != nil {
+		c.logger.Info(fmt.Sprintf(errConnectionDetail, "values", err.Error()))
+		return nil
+	}
+
+	details := make(managed.ConnectionDetails, len(configs))
+	for _, config := range configs {
+		value, err := jq.ParseJSON(config.ResponsePath, responseMap)
+		if err != nil {
+			c.logger.Info(fmt.Sprintf(errConnectionDetail, config.Key, err.Error()))
+			continue
+		}
+		details[config.Key] = []byte(value)
+	}
+
+	return details
+}
@@ -0,0 +1,115 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func withConnectionDetails(details ...v1alpha2.ConnectionDetail) httpRequestModifier {
+	return func(r *v1alpha2.Request) {
+		r.Spec.ForProvider.ConnectionDetails = details
+	}
+}
+
+func Test_connectionDetails(t *testing.T) {
+	credentials := []v1alpha2.ConnectionDetail{
+		{Key: "username", ResponsePath: ".body.username"},
+		{Key: "port", ResponsePath: ".body.port"},
+		{Key: "endpoint", ResponsePath: ".headers.Location[0]"},
+	}
+	successful := v1alpha2.Response{
+		StatusCode: http.StatusOK,
+		Body:       `{"username":"john_doe","port":5432}`,
+		Headers:    map[string][]string{"Location": {"https://db.example.com"}},
+	}
+
+	cases := map[string]struct {
+		cr   *v1alpha2.Request
+		want managed.ConnectionDetails
+	}{
+		"NotConfigured": {
+			cr: httpRequest(func(r *v1alpha2.Request) {
+				r.Status.Response = successful
+			}),
+			want: nil,
+		},
+		"ExtractedFromLatestResponse": {
+			cr: httpRequest(withConnectionDetails(credentials...), func(r *v1alpha2.Request) {
+				r.Status.Response = successful
+			}),
+			want: managed.ConnectionDetails{
+				"username": []byte("john_doe"),
+				"port":     []byte("5432"),
+				"endpoint": []byte("https://db.example.com"),
+			},
+		},
+		"ExtractedFromCacheWhenLatestResponseFailed": {
+			cr: httpRequest(withConnectionDetails(credentials[0]), func(r *v1alpha2.Request) {
+				r.Status.Response = v1alpha2.Response{StatusCode: http.StatusServiceUnavailable, Body: `{"error":"unavailable"}`}
+				r.Status.Cache.Response = successful
+			}),
+			want: managed.ConnectionDetails{
+				"username": []byte("john_doe"),
+			},
+		},
+		"NoSuccessfulResponse": {
+			cr: httpRequest(withConnectionDetails(credentials[0]), func(r *v1alpha2.Request) {
+				r.Status.Response = v1alpha2.Response{StatusCode: http.StatusServiceUnavailable, Body: `{"error":"unavailable"}`}
+			}),
+			want: nil,
+		},
+		"InvalidPathOmitted": {
+			cr: httpRequest(withConnectionDetails(credentials[0], v1alpha2.ConnectionDetail{Key: "broken", ResponsePath: ".body.username | error"}), func(r *v1alpha2.Request) {
+				r.Status.Response = successful
+			}),
+			want: managed.ConnectionDetails{
+				"username": []byte("john_doe"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			e := &external{logger: logging.NewNopLogger()}
+			if diff := cmp.Diff(tc.want, e.connectionDetails(tc.cr)); diff != "" {
+				t.Errorf("connectionDetails(...): -want details, +got details: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_httpExternal_Create_ConnectionDetails(t *testing.T) {
+	e := &external{
+		localKube: &test.MockClient{
+			MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+			MockCreate:       test.NewMockCreateFn(nil),
+			MockGet:          test.NewMockGetFn(nil),
+		},
+		logger: logging.NewNopLogger(),
+		http: &MockHttpClient{
+			MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+				return httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusCreated, Body: `{"id":"123","token":"s3cr3t"}`},
+				}, nil
+			},
+		},
+	}
+
+	got, err := e.Create(context.Background(), httpRequest(withConnectionDetails(v1alpha2.ConnectionDetail{Key: "token", ResponsePath: ".body.token"})))
+	if err != nil {
+		t.Fatalf("e.Create(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(managed.ConnectionDetails{"token": []byte("s3cr3t")}, got.ConnectionDetails); diff != "" {
+		t.Errorf("e.Create(...): -want connection details, +got connection details: %s", diff)
+	}
+}
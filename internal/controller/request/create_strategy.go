@@ -0,0 +1,46 @@
+package request
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/responseconverter"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errConflictFollowUpGet = "Warning, couldn't observe the existing object after a conflict, keeping the conflict response: %s"
+)
+
+// observeConflict follows a conflict response to a POST request with a GET request, so the status reflects the
+// existing object. The GET request is generated from the conflict response. If there is no GET mapping, or the
+// GET request can't be generated or doesn't succeed, the conflict response is kept.
+func (c *external) observeConflict(ctx context.Context, cr *v1alpha2.Request, conflict httpClient.HttpDetails) httpClient.HttpDetails {
+	mapping, ok := getMappingByMethod(&cr.Spec.ForProvider, http.MethodGet)
+	if !ok {
+		return conflict
+	}
+
+	response := responseconverter.HttpResponseToV1alpha1Response(conflict.HttpResponse)
+	requestDetails, err, ok := requestgen.GenerateRequestDetails(ctx, c.localKube, *mapping, cr.Spec.ForProvider, response)
+	if err != nil || !ok || !requestgen.IsRequestValid(requestDetails) {
+		c.logger.Debug(fmt.Sprintf(errConflictFollowUpGet, fmt.Sprint(err)))
+		return conflict
+	}
+
+	details, err := c.http.SendRequest(mappingContext(ctx, mapping), mapping.Method, requestDetails.Url, requestDetails.Body, requestDetails.Headers, skipTLSVerify(cr, mapping))
+	if err != nil {
+		c.logger.Debug(fmt.Sprintf(errConflictFollowUpGet, err.Error()))
+		return conflict
+	}
+	if !utils.IsHTTPSuccess(details.HttpResponse.StatusCode) {
+		c.logger.Debug(fmt.Sprintf(errConflictFollowUpGet, fmt.Sprintf("status code %d", details.HttpResponse.StatusCode)))
+		return conflict
+	}
+
+	return details
+}
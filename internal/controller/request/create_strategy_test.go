@@ -0,0 +1,94 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_observeConflict(t *testing.T) {
+	conflict := httpClient.HttpDetails{
+		HttpRequest:  httpClient.HttpRequest{Method: http.MethodPost, URL: "https://api.example.com/users"},
+		HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusConflict, Body: `{"id":"123"}`},
+	}
+	existing := httpClient.HttpDetails{
+		HttpRequest:  httpClient.HttpRequest{Method: http.MethodGet, URL: "https://api.example.com/users/123"},
+		HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusOK, Body: `{"id":"123","username":"john_doe"}`},
+	}
+
+	type args struct {
+		http httpClient.Client
+		mg   *v1alpha2.Request
+	}
+	type want struct {
+		details httpClient.HttpDetails
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"FollowUpGetSucceeded": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						if method != http.MethodGet || url != "https://api.example.com/users/123" {
+							t.Fatalf("unexpected %s request to %s", method, url)
+						}
+						return existing, nil
+					},
+				},
+				mg: httpRequest(),
+			},
+			want: want{
+				details: existing,
+			},
+		},
+		"FollowUpGetFailed": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusInternalServerError}}, nil
+					},
+				},
+				mg: httpRequest(),
+			},
+			want: want{
+				details: conflict,
+			},
+		},
+		"NoGetMapping": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						t.Fatalf("unexpected %s request to %s", method, url)
+						return httpClient.HttpDetails{}, nil
+					},
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{testPostMapping}
+				}),
+			},
+			want: want{
+				details: conflict,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				logger: logging.NewNopLogger(),
+				http:   tc.args.http,
+			}
+			got := e.observeConflict(context.Background(), tc.args.mg, conflict)
+			if diff := cmp.Diff(tc.want.details, got); diff != "" {
+				t.Fatalf("e.observeConflict(...): -want details, +got details: %s", diff)
+			}
+		})
+	}
+}
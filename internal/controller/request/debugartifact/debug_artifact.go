@@ -0,0 +1,190 @@
+package debugartifact
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	kubehandler "github.com/crossplane-contrib/provider-http/internal/kube-handler"
+)
+
+const (
+	// AnnotationKey is the annotation the artifact is written to when targeting the Request itself.
+	AnnotationKey = "http.crossplane.io/debug-artifact"
+
+	defaultMaxSizeBytes = 16384
+	redactedValue       = "[REDACTED]"
+	truncatedSuffix     = "...(truncated)"
+
+	errConfigMapRefMissing = "configMapRef is required when the debug artifact target is ConfigMap"
+	errMarshalArtifact     = "failed to marshal debug artifact"
+	errPatchAnnotation     = "failed to patch debug artifact annotation"
+)
+
+// sensitiveHeaders are redacted from the artifact. Secret placeholders are never resolved in the
+// exported request, but these headers may carry credentials written in plain text or set by the server.
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// Artifact is the sanitized record of the most recent HTTP call of a Request.
+type Artifact struct {
+	Timestamp  string                  `json:"timestamp"`
+	DurationMs int64                   `json:"durationMs"`
+	Request    httpClient.HttpRequest  `json:"request"`
+	Response   httpClient.HttpResponse `json:"response"`
+	Error      string                  `json:"error,omitempty"`
+	Truncated  bool                    `json:"truncated,omitempty"`
+}
+
+// Export writes the debug artifact of the given HTTP call to the target configured on the Request.
+// It is a no-op when the Request doesn't configure a debug artifact.
+func Export(ctx context.Context, localKube client.Client, cr *v1alpha2.Request, details httpClient.HttpDetails, sendErr error) error {
+	config := cr.Spec.ForProvider.DebugArtifact
+	if config == nil {
+		return nil
+	}
+
+	data, err := Render(details, sendErr, maxSizeBytes(config), time.Now())
+	if err != nil {
+		return err
+	}
+
+	switch config.Target {
+	case v1alpha2.DebugArtifactTargetConfigMap:
+		ref := config.ConfigMapRef
+		if ref == nil {
+			return errors.New(errConfigMapRefMissing)
+		}
+		return kubehandler.SetConfigMapKey(ctx, localKube, ref.Name, ref.Namespace, ref.Key, data)
+	default:
+		return patchAnnotation(ctx, localKube, cr, data)
+	}
+}
+
+// Render returns the JSON document of the sanitized artifact, truncating the bodies so it fits in maxSize bytes.
+func Render(details httpClient.HttpDetails, sendErr error, maxSize int, now time.Time) (string, error) {
+	artifact := Artifact{
+		Timestamp:  now.UTC().Format(time.RFC3339),
+		DurationMs: details.Duration.Milliseconds(),
+		Request:    details.HttpRequest,
+		Response:   details.HttpResponse,
+	}
+	if sendErr != nil {
+		artifact.Error = sendErr.Error()
+	}
+
+	artifact.Request.Headers = redactHeaders(artifact.Request.Headers)
+	artifact.Response.Headers = redactHeaders(artifact.Response.Headers)
+
+	data, err := json.Marshal(artifact)
+	if err != nil {
+		return "", errors.Wrap(err, errMarshalArtifact)
+	}
+
+	for len(data) > maxSize && (artifact.Response.Body != "" || artifact.Request.Body != "") {
+		excess := len(data) - maxSize + len(truncatedSuffix)
+		artifact.Truncated = true
+		artifact.Response.Body, excess = truncate(artifact.Response.Body, excess)
+		artifact.Request.Body, _ = truncate(artifact.Request.Body, excess)
+
+		if data, err = json.Marshal(artifact); err != nil {
+			return "", errors.Wrap(err, errMarshalArtifact)
+		}
+	}
+
+	// Bodies alone didn't make it fit, drop the headers as well.
+	if len(data) > maxSize {
+		artifact.Truncated = true
+		artifact.Request.Headers = nil
+		artifact.Response.Headers = nil
+
+		if data, err = json.Marshal(artifact); err != nil {
+			return "", errors.Wrap(err, errMarshalArtifact)
+		}
+	}
+
+	return string(data), nil
+}
+
+// truncate shortens s by up to excess bytes, marking it as truncated. The cut is moved back to the start of a
+// rune so a multi-byte character isn't split. It returns the bytes still in excess.
+func truncate(s string, excess int) (string, int) {
+	if excess <= 0 || s == "" {
+		return s, excess
+	}
+
+	keep := len(s) - excess
+	if keep <= len(truncatedSuffix) {
+		return "", excess - len(s)
+	}
+
+	cut := keep - len(truncatedSuffix)
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+
+	return s[:cut] + truncatedSuffix, 0
+}
+
+// redactHeaders returns a copy of headers with the values of sensitive headers redacted.
+func redactHeaders(headers map[string][]string) map[string][]string {
+	if headers == nil {
+		return nil
+	}
+
+	redacted := make(map[string][]string, len(headers))
+	for key, values := range headers {
+		redacted[key] = values
+		for _, sensitive := range sensitiveHeaders {
+			if http.CanonicalHeaderKey(key) == sensitive {
+				redacted[key] = []string{redactedValue}
+			}
+		}
+	}
+
+	return redacted
+}
+
+// patchAnnotation sets the debug artifact annotation with a merge patch. The patch is applied to a copy, so the
+// pending changes of the in-memory Request are kept, and the annotation and new resource version are then
+// carried over to it, so that its next update doesn't conflict with the patch.
+func patchAnnotation(ctx context.Context, localKube client.Client, cr *v1alpha2.Request, data string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{AnnotationKey: data},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, errMarshalArtifact)
+	}
+
+	patched := cr.DeepCopy()
+	if err := localKube.Patch(ctx, patched, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		return errors.Wrap(err, errPatchAnnotation)
+	}
+
+	meta.AddAnnotations(cr, map[string]string{AnnotationKey: data})
+	cr.SetResourceVersion(patched.GetResourceVersion())
+
+	return nil
+}
+
+func maxSizeBytes(config *v1alpha2.DebugArtifact) int {
+	if config.MaxSizeBytes != nil {
+		return int(*config.MaxSizeBytes)
+	}
+	return defaultMaxSizeBytes
+}
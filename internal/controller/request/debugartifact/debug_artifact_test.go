@@ -0,0 +1,235 @@
+package debugartifact
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_Render(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	errBoom := errors.New("boom")
+
+	type args struct {
+		details httpClient.HttpDetails
+		sendErr error
+		maxSize int
+	}
+	type want struct {
+		artifact Artifact
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"RedactsSensitiveHeaders": {
+			args: args{
+				details: httpClient.HttpDetails{
+					HttpRequest: httpClient.HttpRequest{
+						Method: "GET",
+						URL:    "http://example.com/users",
+						Headers: map[string][]string{
+							"authorization": {"Bearer token"},
+							"Accept":        {"application/json"},
+						},
+					},
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 200,
+						Body:       `{"id":1}`,
+						Headers: map[string][]string{
+							"Set-Cookie": {"session=abc"},
+						},
+					},
+					Duration: 250 * time.Millisecond,
+				},
+				maxSize: defaultMaxSizeBytes,
+			},
+			want: want{
+				artifact: Artifact{
+					Timestamp:  "2024-01-02T03:04:05Z",
+					DurationMs: 250,
+					Request: httpClient.HttpRequest{
+						Method: "GET",
+						URL:    "http://example.com/users",
+						Headers: map[string][]string{
+							"authorization": {redactedValue},
+							"Accept":        {"application/json"},
+						},
+					},
+					Response: httpClient.HttpResponse{
+						StatusCode: 200,
+						Body:       `{"id":1}`,
+						Headers: map[string][]string{
+							"Set-Cookie": {redactedValue},
+						},
+					},
+				},
+			},
+		},
+		"RecordsError": {
+			args: args{
+				details: httpClient.HttpDetails{
+					HttpRequest: httpClient.HttpRequest{
+						Method: "POST",
+						URL:    "http://example.com/users",
+					},
+				},
+				sendErr: errBoom,
+				maxSize: defaultMaxSizeBytes,
+			},
+			want: want{
+				artifact: Artifact{
+					Timestamp: "2024-01-02T03:04:05Z",
+					Request: httpClient.HttpRequest{
+						Method: "POST",
+						URL:    "http://example.com/users",
+					},
+					Error: errBoom.Error(),
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			data, err := Render(tc.args.details, tc.args.sendErr, tc.args.maxSize, now)
+			if err != nil {
+				t.Fatalf("Render(...): unexpected error: %s", err)
+			}
+
+			got := Artifact{}
+			if err := json.Unmarshal([]byte(data), &got); err != nil {
+				t.Fatalf("Render(...): invalid JSON: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.artifact, got); diff != "" {
+				t.Fatalf("Render(...): -want artifact, +got artifact: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_RenderTruncates(t *testing.T) {
+	details := httpClient.HttpDetails{
+		HttpRequest: httpClient.HttpRequest{
+			Method: "PUT",
+			URL:    "http://example.com/users/1",
+			Body:   strings.Repeat("a", 4096),
+		},
+		HttpResponse: httpClient.HttpResponse{
+			StatusCode: 200,
+			Body:       strings.Repeat("b", 4096),
+		},
+	}
+
+	data, err := Render(details, nil, 2048, time.Now())
+	if err != nil {
+		t.Fatalf("Render(...): unexpected error: %s", err)
+	}
+	if len(data) > 2048 {
+		t.Fatalf("Render(...): artifact is %d bytes, want at most 2048", len(data))
+	}
+
+	got := Artifact{}
+	if err := json.Unmarshal([]byte(data), &got); err != nil {
+		t.Fatalf("Render(...): invalid JSON: %s", err)
+	}
+	if !got.Truncated {
+		t.Fatalf("Render(...): want truncated artifact")
+	}
+	if got.Response.Body != "" && !strings.HasSuffix(got.Response.Body, truncatedSuffix) {
+		t.Fatalf("Render(...): response body %q is not marked as truncated", got.Response.Body)
+	}
+}
+
+func Test_truncate(t *testing.T) {
+	type want struct {
+		s      string
+		excess int
+	}
+
+	cases := map[string]struct {
+		s      string
+		excess int
+		want   want
+	}{
+		"NoExcess": {
+			s:      "abc",
+			excess: 0,
+			want:   want{s: "abc", excess: 0},
+		},
+		"Cut": {
+			s:      strings.Repeat("a", 30),
+			excess: 5,
+			want:   want{s: strings.Repeat("a", 11) + truncatedSuffix, excess: 0},
+		},
+		"CutBacksUpToRuneStart": {
+			s:      strings.Repeat("€", 10),
+			excess: 5,
+			want:   want{s: strings.Repeat("€", 3) + truncatedSuffix, excess: 0},
+		},
+		"DroppedWhenSuffixDoesNotFit": {
+			s:      "abcdef",
+			excess: 2,
+			want:   want{s: "", excess: -4},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			s, excess := truncate(tc.s, tc.excess)
+			if diff := cmp.Diff(tc.want, want{s: s, excess: excess}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("truncate(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_Export_AnnotationKeepsStatusUpdatable(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := v1alpha2.SchemeBuilder.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	stored := &v1alpha2.Request{ObjectMeta: metav1.ObjectMeta{Name: "user"}}
+	stored.Spec.ForProvider.DebugArtifact = &v1alpha2.DebugArtifact{Target: v1alpha2.DebugArtifactTargetAnnotation}
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(stored).WithStatusSubresource(stored).Build()
+
+	cr := &v1alpha2.Request{}
+	if err := kube.Get(context.Background(), client.ObjectKeyFromObject(stored), cr); err != nil {
+		t.Fatal(err)
+	}
+	cr.Status.Response.StatusCode = 200
+
+	details := httpClient.HttpDetails{HttpRequest: httpClient.HttpRequest{Method: "GET", URL: "https://api.example.com/users/1"}}
+	if err := Export(context.Background(), kube, cr, details, nil); err != nil {
+		t.Fatalf("Export(...): %s", err)
+	}
+
+	// The status update following the export, in the same reconcile, must not conflict with the patch.
+	if err := kube.Status().Update(context.Background(), cr); err != nil {
+		t.Fatalf("Status().Update(...): want no conflict after the export, got: %s", err)
+	}
+
+	got := &v1alpha2.Request{}
+	if err := kube.Get(context.Background(), client.ObjectKeyFromObject(stored), got); err != nil {
+		t.Fatal(err)
+	}
+	if got.GetAnnotations()[AnnotationKey] == "" {
+		t.Errorf("Export(...): want the artifact annotation stored")
+	}
+	if diff := cmp.Diff(200, got.Status.Response.StatusCode); diff != "" {
+		t.Errorf("Status().Update(...): -want status code, +got status code: %s", diff)
+	}
+}
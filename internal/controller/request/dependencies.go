@@ -0,0 +1,63 @@
+package request
+
+import (
+	"context"
+	"fmt"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+const (
+	// ReasonWaitingForDependency indicates nothing is sent until the Requests the resource depends on are ready.
+	ReasonWaitingForDependency xpv1.ConditionReason = "WaitingForDependency"
+
+	msgDependencyMissing  = "waiting for Request %s, which doesn't exist"
+	msgDependencyNotReady = "waiting for Request %s to become ready"
+
+	errGetDependency = "cannot get the Request %s it depends on"
+)
+
+// pendingDependency returns why the Request waits for the first of its dependencies that isn't ready, or an
+// empty string once all of them are ready. A Request being deleted waits for none, so its removal isn't held
+// back by a dependency that was deleted first.
+func (c *external) pendingDependency(ctx context.Context, cr *v1alpha2.Request) (string, error) {
+	if meta.WasDeleted(cr) {
+		return "", nil
+	}
+
+	for _, name := range cr.Spec.ForProvider.DependsOn {
+		dependency := &v1alpha2.Request{}
+		err := c.localKube.Get(ctx, types.NamespacedName{Name: name}, dependency)
+		if kerrors.IsNotFound(err) {
+			return fmt.Sprintf(msgDependencyMissing, name), nil
+		}
+		if err != nil {
+			return "", errors.Wrapf(err, errGetDependency, name)
+		}
+
+		if dependency.GetCondition(xpv1.TypeReady).Status != corev1.ConditionTrue {
+			return fmt.Sprintf(msgDependencyNotReady, name), nil
+		}
+	}
+
+	return "", nil
+}
+
+// waitingForDependency returns a condition indicating the resource waits for one of its dependencies.
+func waitingForDependency(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonWaitingForDependency,
+		Message:            message,
+	}
+}
@@ -0,0 +1,130 @@
+package request
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+// mockDependencies returns a Get function finding the Requests of the given names, ready or not, and failing
+// with errBoom for the broken one.
+func mockDependencies(ready map[string]bool) test.MockGetFn {
+	return func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+		if key.Name == "broken" {
+			return errBoom
+		}
+
+		isReady, ok := ready[key.Name]
+		if !ok {
+			return kerrors.NewNotFound(schema.GroupResource{Group: v1alpha2.Group, Resource: "requests"}, key.Name)
+		}
+
+		dependency := obj.(*v1alpha2.Request)
+		if isReady {
+			dependency.SetConditions(xpv1.Available())
+		} else {
+			dependency.SetConditions(xpv1.Creating())
+		}
+		return nil
+	}
+}
+
+func withDependsOn(names ...string) httpRequestModifier {
+	return func(r *v1alpha2.Request) {
+		r.Spec.ForProvider.DependsOn = names
+	}
+}
+
+func Test_pendingDependency(t *testing.T) {
+	type want struct {
+		pending string
+		err     error
+	}
+	cases := map[string]struct {
+		mg   *v1alpha2.Request
+		want want
+	}{
+		"NoDependencies": {
+			mg:   httpRequest(),
+			want: want{},
+		},
+		"AllReady": {
+			mg:   httpRequest(withDependsOn("network", "database")),
+			want: want{},
+		},
+		"NotReady": {
+			mg:   httpRequest(withDependsOn("network", "cache")),
+			want: want{pending: fmt.Sprintf(msgDependencyNotReady, "cache")},
+		},
+		"Missing": {
+			mg:   httpRequest(withDependsOn("queue")),
+			want: want{pending: fmt.Sprintf(msgDependencyMissing, "queue")},
+		},
+		"GetFailed": {
+			mg:   httpRequest(withDependsOn("broken")),
+			want: want{err: errors.Wrapf(errBoom, errGetDependency, "broken")},
+		},
+		"Deleted": {
+			mg: httpRequest(withDependsOn("queue"), func(r *v1alpha2.Request) {
+				now := v1.Now()
+				r.SetDeletionTimestamp(&now)
+			}),
+			want: want{},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				localKube: &test.MockClient{MockGet: mockDependencies(map[string]bool{"network": true, "database": true, "cache": false})},
+				logger:    logging.NewNopLogger(),
+			}
+			got, gotErr := e.pendingDependency(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("pendingDependency(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.pending, got); diff != "" {
+				t.Errorf("pendingDependency(...): -want pending, +got pending: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_Observe_WaitsForDependency(t *testing.T) {
+	cr := httpRequest(withDependsOn("cache"))
+	e := &external{
+		localKube: &test.MockClient{MockGet: mockDependencies(map[string]bool{"cache": false})},
+		logger:    logging.NewNopLogger(),
+		http: &MockHttpClient{
+			MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+				t.Errorf("unexpected %s request while waiting for a dependency", method)
+				return httpClient.HttpDetails{}, nil
+			},
+		},
+	}
+
+	got, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("Observe(...): unexpected error: %s", err)
+	}
+	if !got.ResourceExists || !got.ResourceUpToDate {
+		t.Errorf("Observe(...): want the resource reported as existing and up to date, got %+v", got)
+	}
+	if diff := cmp.Diff(ReasonWaitingForDependency, cr.GetCondition(xpv1.TypeReady).Reason); diff != "" {
+		t.Errorf("Observe(...): -want reason, +got reason: %s", diff)
+	}
+}
@@ -0,0 +1,57 @@
+package request
+
+import (
+	"context"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+const (
+	errForgetLastWrite   = "cannot record the removal of the object"
+	infoNoDriftDetection = "drift detection is disabled, observing from the last successful write"
+)
+
+// driftDetectionDisabled reports whether the Request is observed from its status rather than with a GET request.
+func driftDetectionDisabled(cr *v1alpha2.Request) bool {
+	return cr.Spec.ForProvider.DriftDetection == v1alpha2.DriftDetectionNone
+}
+
+// observeFromLastWrite observes a Request whose drift detection is disabled without sending any request. The
+// object exists once a POST request succeeded and until a DELETE request removes it, and is up to date as long
+// as the spec didn't change since the last successful write and the referenced secrets weren't rotated.
+func (c *external) observeFromLastWrite(ctx context.Context, cr *v1alpha2.Request) managed.ExternalObservation {
+	c.logger.Debug(infoNoDriftDetection)
+
+	applied := cr.Status.LastAppliedGeneration
+	if applied == 0 {
+		return managed.ExternalObservation{ResourceExists: false}
+	}
+
+	upToDate := applied == cr.GetGeneration()
+	if upToDate && c.secretsRotated(ctx, cr) {
+		c.logger.Debug(infoSecretsRotated)
+		upToDate = false
+	}
+
+	if upToDate {
+		cr.SetLastAction(string(v1alpha2.RequestActionNoop))
+	}
+
+	cr.Status.SetConditions(xpv1.Available())
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  upToDate,
+		ConnectionDetails: c.connectionDetails(cr),
+	}
+}
+
+// forgetLastWrite records that the object was removed, so that a Request whose drift detection is disabled is
+// no longer observed as existing.
+func (c *external) forgetLastWrite(ctx context.Context, cr *v1alpha2.Request) error {
+	cr.Status.LastAppliedGeneration = 0
+	return errors.Wrap(c.localKube.Status().Update(ctx, cr), errForgetLastWrite)
+}
@@ -0,0 +1,91 @@
+package request
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func withoutDriftDetection(r *v1alpha2.Request) {
+	r.Spec.ForProvider.DriftDetection = v1alpha2.DriftDetectionNone
+	r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{testPostMapping, testPutMapping}
+	r.SetGeneration(2)
+}
+
+func Test_observeFromLastWrite(t *testing.T) {
+	type args struct {
+		mg *v1alpha2.Request
+	}
+	type want struct {
+		obs        managed.ExternalObservation
+		lastAction v1alpha2.RequestAction
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NotWrittenYet": {
+			args: args{
+				mg: httpRequest(withoutDriftDetection),
+			},
+			want: want{
+				obs: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDateSinceLastWrite": {
+			args: args{
+				mg: httpRequest(withoutDriftDetection, func(r *v1alpha2.Request) {
+					r.Status.LastAppliedGeneration = 2
+				}),
+			},
+			want: want{
+				obs:        managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				lastAction: v1alpha2.RequestActionNoop,
+			},
+		},
+		"SpecChangedSinceLastWrite": {
+			args: args{
+				mg: httpRequest(withoutDriftDetection, func(r *v1alpha2.Request) {
+					r.Status.LastAppliedGeneration = 1
+				}),
+			},
+			want: want{
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				localKube: &test.MockClient{},
+				logger:    logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						t.Errorf("unexpected %s request to %s", method, url)
+						return httpClient.HttpDetails{}, errors.New("unexpected request")
+					},
+				},
+			}
+			got, err := e.Observe(context.Background(), tc.args.mg)
+			if err != nil {
+				t.Fatalf("e.Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.obs, got); diff != "" {
+				t.Errorf("e.Observe(...): -want result, +got result: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.lastAction, tc.args.mg.Status.LastAction); diff != "" {
+				t.Errorf("e.Observe(...): -want Status.LastAction, +got Status.LastAction: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,431 @@
+package request
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errForEachKey          = "forEach key of the element at index %d: %s"
+	errForEachDuplicateKey = "forEach key %s identifies more than one element"
+	errForEachRequests     = "requests of forEach elements failed: %s"
+	errItemRequestInvalid  = "the %s request can't be generated yet, its templates read fields the response doesn't have"
+	errItemResponse        = "HTTP %s request returned status code %d"
+	errUpdateItemsStatus   = "cannot update the status of the forEach elements"
+	errRecordItem          = "cannot record the forEach element"
+	errRecordedItem        = "cannot read the recorded forEach element"
+)
+
+// forEachItem is an element of forEach along with the key identifying its external resource.
+type forEachItem struct {
+	key   string
+	value interface{}
+}
+
+// forEachItems returns the elements of forEach of the Request with their keys, which must be unique.
+func forEachItems(ctx context.Context, cr *v1alpha2.Request) ([]forEachItem, error) {
+	values, err := requestgen.ForEachItems(ctx, cr.Spec.ForProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]forEachItem, 0, len(values))
+	keys := make(map[string]bool, len(values))
+	for i, value := range values {
+		key := strconv.Itoa(i)
+		if filter := cr.Spec.ForProvider.ForEach.Key; filter != "" {
+			if key, err = jq.ParseScalar(filter, value); err != nil {
+				return nil, errors.Errorf(errForEachKey, i, err.Error())
+			}
+		}
+
+		if keys[key] {
+			return nil, errors.Errorf(errForEachDuplicateKey, key)
+		}
+		keys[key] = true
+
+		items = append(items, forEachItem{key: key, value: value})
+	}
+
+	return items, nil
+}
+
+// itemStatus returns the recorded state of the external resource identified by the key, or nil if there is none.
+func itemStatus(cr *v1alpha2.Request, key string) *v1alpha2.ItemStatus {
+	for i := range cr.Status.Items {
+		if cr.Status.Items[i].Key == key {
+			return &cr.Status.Items[i]
+		}
+	}
+
+	return nil
+}
+
+// recordItem returns the recorded state of the external resource identified by the key, recording an empty one
+// first if there is none.
+func recordItem(cr *v1alpha2.Request, key string) *v1alpha2.ItemStatus {
+	if status := itemStatus(cr, key); status != nil {
+		return status
+	}
+
+	cr.Status.Items = append(cr.Status.Items, v1alpha2.ItemStatus{Key: key})
+	return &cr.Status.Items[len(cr.Status.Items)-1]
+}
+
+// removedItem returns the element recorded along with the external resource identified by the key, for the DELETE
+// request of an element that may no longer be in forEach. The element is null if none was recorded.
+func removedItem(cr *v1alpha2.Request, key string) (forEachItem, error) {
+	item := forEachItem{key: key}
+	if status := itemStatus(cr, key); status != nil && status.Item != "" {
+		if err := json.Unmarshal([]byte(status.Item), &item.value); err != nil {
+			return forEachItem{}, errors.Wrap(err, errRecordedItem)
+		}
+	}
+
+	return item, nil
+}
+
+// forgetItem removes the recorded state of the external resource identified by the key.
+func forgetItem(cr *v1alpha2.Request, key string) {
+	for i := range cr.Status.Items {
+		if cr.Status.Items[i].Key == key {
+			cr.Status.Items = append(cr.Status.Items[:i], cr.Status.Items[i+1:]...)
+			return
+		}
+	}
+}
+
+// isItemCreated reports whether the recorded state tells that the external resource was created.
+func isItemCreated(status *v1alpha2.ItemStatus) bool {
+	return status != nil && utils.IsHTTPSuccess(status.Response.StatusCode)
+}
+
+// orphanedItems returns the keys of the external resources recorded for elements that were removed from forEach.
+func orphanedItems(cr *v1alpha2.Request, items []forEachItem) []string {
+	current := make(map[string]bool, len(items))
+	for _, item := range items {
+		current[item.key] = true
+	}
+
+	var orphaned []string
+	for _, status := range cr.Status.Items {
+		if !current[status.Key] {
+			orphaned = append(orphaned, status.Key)
+		}
+	}
+
+	return orphaned
+}
+
+// observeForEach observes the external resource of every element of forEach. The Request exists once every
+// element has an external resource, and it is up to date when they all match their desired state and none is
+// left for an element removed from forEach. An external resource found missing is forgotten, so it is created
+// again.
+func (c *external) observeForEach(ctx context.Context, cr *v1alpha2.Request) (managed.ExternalObservation, error) {
+	if meta.WasDeleted(cr) {
+		if len(cr.Status.Items) == 0 {
+			c.logger.Debug(infoRemovalConfirmed)
+		}
+		return managed.ExternalObservation{ResourceExists: len(cr.Status.Items) > 0}, nil
+	}
+
+	items, err := forEachItems(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errFailedToCheckIfUpToDate)
+	}
+
+	ctx, err = c.withPreRequest(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errFailedToCheckIfUpToDate)
+	}
+
+	exists, upToDate := true, len(orphanedItems(cr, items)) == 0
+	for _, item := range items {
+		status := itemStatus(cr, item.key)
+		if !isItemCreated(status) {
+			exists = false
+			continue
+		}
+
+		found, synced, err := c.observeItem(ctx, cr, item, status.Response)
+		if httpClient.IsHostSaturated(err) || httpClient.IsTemporaryDNSFailure(err) {
+			return managed.ExternalObservation{}, err
+		}
+		if err != nil {
+			status.Synced, status.Error = false, err.Error()
+			upToDate = false
+			continue
+		}
+
+		if !found {
+			forgetItem(cr, item.key)
+			exists = false
+			continue
+		}
+
+		status.Synced, status.Error = synced, ""
+		upToDate = upToDate && synced
+	}
+
+	if exists {
+		cr.Status.SetConditions(xpv1.Available())
+	}
+
+	if err := c.updateItemsStatus(ctx, cr); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   exists,
+		ResourceUpToDate: upToDate,
+	}, nil
+}
+
+// observeItem sends the GET request of an element, and reports whether its external resource exists and contains
+// the desired state rendered by the PUT mapping for the element. Without a GET mapping, the external resource is
+// assumed to exist and be up to date, and without a PUT mapping it is up to date whenever it can be read.
+func (c *external) observeItem(ctx context.Context, cr *v1alpha2.Request, item forEachItem, response v1alpha2.Response) (bool, bool, error) {
+	mapping, ok := getMappingByMethod(&cr.Spec.ForProvider, http.MethodGet)
+	if !ok {
+		return true, true, nil
+	}
+
+	ctx = requestgen.WithItem(ctx, item.value)
+	details, err := c.sendItemRequest(ctx, cr, mapping, response)
+	if err != nil {
+		return false, false, err
+	}
+
+	outcome, err := utils.ClassifyResponse(cr.Spec.ForProvider, http.MethodGet, details.HttpResponse)
+	if err != nil {
+		return false, false, err
+	}
+
+	switch outcome {
+	case v1alpha2.ResponseOutcomeNotFound:
+		return false, false, nil
+	case v1alpha2.ResponseOutcomeSuccess:
+	default:
+		// An external resource that can't be read has drifted.
+		return true, false, nil
+	}
+
+	putMapping, ok := getMappingByMethod(&cr.Spec.ForProvider, http.MethodPut)
+	if !ok {
+		return true, true, nil
+	}
+
+	desiredState, err := c.itemRequestDetails(requestgen.WithUnredactedBody(ctx), cr, putMapping, response)
+	if err != nil {
+		return false, false, err
+	}
+
+	observed, err := c.compareResponseAndDesiredState(details, nil, desiredState.Body.Encrypted.(string), typeComparison(cr, c.canary), cr.Spec.ForProvider.UnorderedArrays)
+	if err != nil {
+		return false, false, err
+	}
+
+	return true, observed.Synced, nil
+}
+
+// deployForEach sends the requests of the given method for the elements of forEach that need them: POST for
+// the elements without an external resource, PUT for the drifted ones along with DELETE for the ones removed from
+// forEach, and DELETE for every external resource when the Request is deleted. Every element is handled even when
+// one fails, their failures are reported together and recorded in status.items.
+func (c *external) deployForEach(ctx context.Context, cr *v1alpha2.Request, method string) error {
+	ctx, cancel := utils.DrainOnShutdown(ctx)
+	defer cancel()
+
+	ctx, err := c.withPreRequest(ctx, cr)
+	if err != nil {
+		return err
+	}
+
+	var failures []string
+	deploy := func(item forEachItem, method string) error {
+		err := c.deployItem(ctx, cr, item, method)
+		if err != nil && !httpClient.IsHostSaturated(err) && !httpClient.IsTemporaryDNSFailure(err) {
+			recordItem(cr, item.key).Error = err.Error()
+			failures = append(failures, fmt.Sprintf("%s: %s", item.key, err.Error()))
+			return nil
+		}
+		return err
+	}
+
+	var items []forEachItem
+	if method != http.MethodDelete {
+		if items, err = forEachItems(ctx, cr); err != nil {
+			return err
+		}
+	}
+
+	var pending []forEachItem
+	switch method {
+	case http.MethodPost:
+		for _, item := range items {
+			if !isItemCreated(itemStatus(cr, item.key)) {
+				pending = append(pending, item)
+			}
+		}
+	case http.MethodPut:
+		for _, item := range items {
+			if status := itemStatus(cr, item.key); isItemCreated(status) && !status.Synced {
+				pending = append(pending, item)
+			}
+		}
+	}
+
+	for _, item := range pending {
+		if err := deploy(item, method); err != nil {
+			return err
+		}
+	}
+
+	var removed []string
+	switch method {
+	case http.MethodPut:
+		removed = orphanedItems(cr, items)
+	case http.MethodDelete:
+		for _, status := range cr.Status.Items {
+			removed = append(removed, status.Key)
+		}
+	}
+
+	for _, key := range removed {
+		item, err := removedItem(cr, key)
+		if err != nil {
+			return err
+		}
+		if err := deploy(item, http.MethodDelete); err != nil {
+			return err
+		}
+	}
+
+	if err := c.updateItemsStatus(ctx, cr); err != nil {
+		return err
+	}
+
+	if len(failures) > 0 {
+		return errors.Errorf(errForEachRequests, strings.Join(failures, "; "))
+	}
+
+	return nil
+}
+
+// deployItem sends the request of the given method for an element and records its outcome. The external resource
+// of a deleted element, including one that was already gone, is forgotten.
+func (c *external) deployItem(ctx context.Context, cr *v1alpha2.Request, item forEachItem, method string) error {
+	mapping, ok := getMappingByMethod(&cr.Spec.ForProvider, method)
+	if !ok {
+		c.logger.Info(fmt.Sprintf(errMappingNotFound, method))
+		if method == http.MethodDelete {
+			forgetItem(cr, item.key)
+		}
+		return nil
+	}
+
+	var response v1alpha2.Response
+	if status := itemStatus(cr, item.key); status != nil {
+		response = status.Response
+	}
+
+	ctx = requestgen.WithItem(ctx, item.value)
+	details, err := c.sendItemRequest(ctx, cr, mapping, response)
+	if err != nil {
+		return err
+	}
+
+	outcome, err := utils.ClassifyResponse(cr.Spec.ForProvider, method, details.HttpResponse)
+	if err != nil {
+		return err
+	}
+
+	if method == http.MethodDelete && (outcome == v1alpha2.ResponseOutcomeSuccess || outcome == v1alpha2.ResponseOutcomeNotFound) {
+		forgetItem(cr, item.key)
+		return nil
+	}
+
+	if outcome != v1alpha2.ResponseOutcomeSuccess {
+		return errors.Errorf(errItemResponse, method, details.HttpResponse.StatusCode)
+	}
+
+	value, err := json.Marshal(item.value)
+	if err != nil {
+		return errors.Wrap(err, errRecordItem)
+	}
+
+	status := recordItem(cr, item.key)
+	status.Item = string(value)
+	if method == http.MethodPost {
+		status.Response = v1alpha2.Response{
+			StatusCode: details.HttpResponse.StatusCode,
+			Body:       details.HttpResponse.Body,
+			Headers:    details.HttpResponse.Headers,
+		}
+	}
+	status.Synced, status.Error = true, ""
+
+	return nil
+}
+
+// sendItemRequest generates the request of the mapping for the element carried by the context, against the given
+// response of its external resource, and sends it.
+func (c *external) sendItemRequest(ctx context.Context, cr *v1alpha2.Request, mapping *v1alpha2.Mapping, response v1alpha2.Response) (httpClient.HttpDetails, error) {
+	requestDetails, err := c.itemRequestDetails(ctx, cr, mapping, response)
+	if err != nil {
+		return httpClient.HttpDetails{}, err
+	}
+
+	if err := encryptRequestBody(ctx, c.localKube, mapping, &requestDetails); err != nil {
+		return httpClient.HttpDetails{}, err
+	}
+
+	return c.http.SendRequest(mappingContext(ctx, mapping), mapping.Method, requestDetails.Url, requestDetails.Body, requestDetails.Headers, skipTLSVerify(cr, mapping))
+}
+
+// itemRequestDetails generates the request of the mapping for the element carried by the context, against the
+// given response of its external resource.
+func (c *external) itemRequestDetails(ctx context.Context, cr *v1alpha2.Request, mapping *v1alpha2.Mapping, response v1alpha2.Response) (requestgen.RequestDetails, error) {
+	requestDetails, err, _ := requestgen.GenerateRequestDetails(ctx, c.localKube, *mapping, cr.Spec.ForProvider, response)
+	if err != nil {
+		return requestgen.RequestDetails{}, err
+	}
+
+	if !requestgen.IsRequestValid(requestDetails) {
+		return requestgen.RequestDetails{}, errors.Errorf(errItemRequestInvalid, mapping.Method)
+	}
+
+	return requestDetails, nil
+}
+
+// updateItemsStatus stores the recorded state of the external resources of the elements on the latest version
+// of the Request.
+func (c *external) updateItemsStatus(ctx context.Context, cr *v1alpha2.Request) error {
+	items := cr.Status.Items
+	conditions := cr.Status.Conditions
+
+	if err := c.localKube.Get(ctx, types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, cr); err != nil {
+		return errors.Wrap(err, errGetLatestVersion)
+	}
+
+	cr.Status.Items = items
+	cr.Status.Conditions = conditions
+
+	return errors.Wrap(c.localKube.Status().Update(ctx, cr), errUpdateItemsStatus)
+}
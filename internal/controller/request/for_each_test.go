@@ -0,0 +1,271 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+var (
+	janeCreated = v1alpha2.ItemStatus{Key: "jane", Response: v1alpha2.Response{StatusCode: http.StatusCreated, Body: `{"id":"1"}`}, Item: `{"name":"jane"}`, Synced: true}
+	joeCreated  = v1alpha2.ItemStatus{Key: "joe", Response: v1alpha2.Response{StatusCode: http.StatusCreated, Body: `{"id":"2"}`}, Item: `{"name":"joe"}`, Synced: true}
+	bobCreated  = v1alpha2.ItemStatus{Key: "bob", Response: v1alpha2.Response{StatusCode: http.StatusCreated, Body: `{"id":"3"}`}, Item: `{"name":"bob"}`, Synced: true}
+)
+
+func withForEach(items ...v1alpha2.ItemStatus) httpRequestModifier {
+	return func(r *v1alpha2.Request) {
+		r.Spec.ForProvider.Payload = v1alpha2.Payload{
+			BaseUrl: "https://api.example.com/users",
+			Body:    `{"users":[{"name":"jane"},{"name":"joe"}]}`,
+		}
+		r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{
+			{Method: http.MethodPost, URL: ".payload.baseUrl", Body: "{ name: .item.name }"},
+			{Method: http.MethodGet, URL: `(.payload.baseUrl + "/" + .response.body.id)`},
+			{Method: http.MethodPut, URL: `(.payload.baseUrl + "/" + .response.body.id)`, Body: "{ name: .item.name, active: true }"},
+			{Method: http.MethodDelete, URL: `(.payload.baseUrl + "/" + .response.body.id)`},
+		}
+		r.Spec.ForProvider.ForEach = &v1alpha2.ForEach{Items: ".payload.body.users", Key: ".name"}
+		r.Status.Items = items
+	}
+}
+
+type sentRequest struct {
+	method string
+	url    string
+	body   string
+}
+
+func forEachExternal(responses map[string]httpClient.HttpResponse, sent *[]sentRequest) *external {
+	return &external{
+		localKube: &test.MockClient{
+			MockGet:          test.NewMockGetFn(nil),
+			MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+		},
+		logger: logging.NewNopLogger(),
+		http: &MockHttpClient{
+			MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+				*sent = append(*sent, sentRequest{method: method, url: url, body: body.Decrypted.(string)})
+				response, ok := responses[method+" "+url]
+				if !ok {
+					return httpClient.HttpDetails{}, errBoom
+				}
+				return httpClient.HttpDetails{HttpResponse: response}, nil
+			},
+		},
+	}
+}
+
+func Test_observeForEach(t *testing.T) {
+	type want struct {
+		observation managed.ExternalObservation
+		items       []v1alpha2.ItemStatus
+		err         error
+	}
+
+	cases := map[string]struct {
+		cr        *v1alpha2.Request
+		responses map[string]httpClient.HttpResponse
+		want      want
+	}{
+		"UpToDateWhenEveryElementMatches": {
+			cr: httpRequest(withForEach(janeCreated, joeCreated)),
+			responses: map[string]httpClient.HttpResponse{
+				"GET https://api.example.com/users/1": {StatusCode: http.StatusOK, Body: `{"id":"1","name":"jane","active":true}`},
+				"GET https://api.example.com/users/2": {StatusCode: http.StatusOK, Body: `{"id":"2","name":"joe","active":true}`},
+			},
+			want: want{
+				observation: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				items:       []v1alpha2.ItemStatus{janeCreated, joeCreated},
+			},
+		},
+		"MissingElementNotCreated": {
+			cr: httpRequest(withForEach(janeCreated)),
+			responses: map[string]httpClient.HttpResponse{
+				"GET https://api.example.com/users/1": {StatusCode: http.StatusOK, Body: `{"id":"1","name":"jane","active":true}`},
+			},
+			want: want{
+				observation: managed.ExternalObservation{ResourceExists: false, ResourceUpToDate: true},
+				items:       []v1alpha2.ItemStatus{janeCreated},
+			},
+		},
+		"DriftedElementRecorded": {
+			cr: httpRequest(withForEach(janeCreated, joeCreated)),
+			responses: map[string]httpClient.HttpResponse{
+				"GET https://api.example.com/users/1": {StatusCode: http.StatusOK, Body: `{"id":"1","name":"jane","active":true}`},
+				"GET https://api.example.com/users/2": {StatusCode: http.StatusOK, Body: `{"id":"2","name":"joe","active":false}`},
+			},
+			want: want{
+				observation: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				items: []v1alpha2.ItemStatus{janeCreated, {
+					Key:      "joe",
+					Response: joeCreated.Response,
+					Item:     joeCreated.Item,
+				}},
+			},
+		},
+		"RemovedElementNotUpToDate": {
+			cr: httpRequest(withForEach(janeCreated, joeCreated, bobCreated)),
+			responses: map[string]httpClient.HttpResponse{
+				"GET https://api.example.com/users/1": {StatusCode: http.StatusOK, Body: `{"id":"1","name":"jane","active":true}`},
+				"GET https://api.example.com/users/2": {StatusCode: http.StatusOK, Body: `{"id":"2","name":"joe","active":true}`},
+			},
+			want: want{
+				observation: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				items:       []v1alpha2.ItemStatus{janeCreated, joeCreated, bobCreated},
+			},
+		},
+		"GoneElementForgotten": {
+			cr: httpRequest(withForEach(janeCreated, joeCreated)),
+			responses: map[string]httpClient.HttpResponse{
+				"GET https://api.example.com/users/1": {StatusCode: http.StatusOK, Body: `{"id":"1","name":"jane","active":true}`},
+				"GET https://api.example.com/users/2": {StatusCode: http.StatusNotFound},
+			},
+			want: want{
+				observation: managed.ExternalObservation{ResourceExists: false, ResourceUpToDate: true},
+				items:       []v1alpha2.ItemStatus{janeCreated},
+			},
+		},
+		"DuplicateKeys": {
+			cr: httpRequest(withForEach(), func(r *v1alpha2.Request) {
+				r.Spec.ForProvider.Payload.Body = `{"users":[{"name":"jane"},{"name":"jane"}]}`
+			}),
+			want: want{
+				err: errors.Wrap(errors.Errorf(errForEachDuplicateKey, "jane"), errFailedToCheckIfUpToDate),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var sent []sentRequest
+			got, gotErr := forEachExternal(tc.responses, &sent).observeForEach(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("observeForEach(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.observation, got); diff != "" {
+				t.Errorf("observeForEach(...): -want observation, +got observation: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.items, tc.cr.Status.Items, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("observeForEach(...): -want status.items, +got status.items: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_deployForEach(t *testing.T) {
+	joeDrifted := joeCreated
+	joeDrifted.Synced = false
+
+	type want struct {
+		sent  []sentRequest
+		items []v1alpha2.ItemStatus
+		err   error
+	}
+
+	cases := map[string]struct {
+		cr        *v1alpha2.Request
+		method    string
+		responses map[string]httpClient.HttpResponse
+		want      want
+	}{
+		"CreateMissingElements": {
+			cr:     httpRequest(withForEach(janeCreated)),
+			method: http.MethodPost,
+			responses: map[string]httpClient.HttpResponse{
+				"POST https://api.example.com/users": {StatusCode: http.StatusCreated, Body: `{"id":"2"}`},
+			},
+			want: want{
+				sent:  []sentRequest{{method: http.MethodPost, url: "https://api.example.com/users", body: `{"name":"joe"}`}},
+				items: []v1alpha2.ItemStatus{janeCreated, joeCreated},
+			},
+		},
+		"UpdateDriftedAndDeleteRemovedElements": {
+			cr:     httpRequest(withForEach(janeCreated, joeDrifted, bobCreated)),
+			method: http.MethodPut,
+			responses: map[string]httpClient.HttpResponse{
+				"PUT https://api.example.com/users/2":    {StatusCode: http.StatusOK, Body: `{"id":"2","name":"joe","active":true}`},
+				"DELETE https://api.example.com/users/3": {StatusCode: http.StatusNoContent},
+			},
+			want: want{
+				sent: []sentRequest{
+					{method: http.MethodPut, url: "https://api.example.com/users/2", body: `{"active":true,"name":"joe"}`},
+					{method: http.MethodDelete, url: "https://api.example.com/users/3", body: ""},
+				},
+				items: []v1alpha2.ItemStatus{janeCreated, joeCreated},
+			},
+		},
+		"DeleteRemovedElementReadsRecordedItem": {
+			cr: httpRequest(withForEach(janeCreated, joeCreated, bobCreated), func(r *v1alpha2.Request) {
+				r.Spec.ForProvider.Mappings[3].URL = `(.payload.baseUrl + "/" + .item.name)`
+			}),
+			method: http.MethodPut,
+			responses: map[string]httpClient.HttpResponse{
+				"DELETE https://api.example.com/users/bob": {StatusCode: http.StatusNoContent},
+			},
+			want: want{
+				sent:  []sentRequest{{method: http.MethodDelete, url: "https://api.example.com/users/bob", body: ""}},
+				items: []v1alpha2.ItemStatus{janeCreated, joeCreated},
+			},
+		},
+		"DeleteEveryElement": {
+			cr:     httpRequest(withForEach(janeCreated, joeCreated)),
+			method: http.MethodDelete,
+			responses: map[string]httpClient.HttpResponse{
+				"DELETE https://api.example.com/users/1": {StatusCode: http.StatusNoContent},
+				"DELETE https://api.example.com/users/2": {StatusCode: http.StatusNotFound},
+			},
+			want: want{
+				sent: []sentRequest{
+					{method: http.MethodDelete, url: "https://api.example.com/users/1", body: ""},
+					{method: http.MethodDelete, url: "https://api.example.com/users/2", body: ""},
+				},
+			},
+		},
+		"FailuresRecordedPerElement": {
+			cr:     httpRequest(withForEach()),
+			method: http.MethodPost,
+			responses: map[string]httpClient.HttpResponse{
+				"POST https://api.example.com/users": {StatusCode: http.StatusConflict},
+			},
+			want: want{
+				sent: []sentRequest{
+					{method: http.MethodPost, url: "https://api.example.com/users", body: `{"name":"jane"}`},
+					{method: http.MethodPost, url: "https://api.example.com/users", body: `{"name":"joe"}`},
+				},
+				items: []v1alpha2.ItemStatus{
+					{Key: "jane", Error: errors.Errorf(errItemResponse, http.MethodPost, http.StatusConflict).Error()},
+					{Key: "joe", Error: errors.Errorf(errItemResponse, http.MethodPost, http.StatusConflict).Error()},
+				},
+				err: errors.Errorf(errForEachRequests, "jane: "+errors.Errorf(errItemResponse, http.MethodPost, http.StatusConflict).Error()+"; joe: "+errors.Errorf(errItemResponse, http.MethodPost, http.StatusConflict).Error()),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var sent []sentRequest
+			gotErr := forEachExternal(tc.responses, &sent).deployForEach(context.Background(), tc.cr, tc.method)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("deployForEach(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.sent, sent, cmp.AllowUnexported(sentRequest{})); diff != "" {
+				t.Errorf("deployForEach(...): -want sent requests, +got sent requests: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.items, tc.cr.Status.Items, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("deployForEach(...): -want status.items, +got status.items: %s", diff)
+			}
+		})
+	}
+}
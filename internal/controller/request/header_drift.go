@@ -0,0 +1,41 @@
+package request
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+)
+
+const (
+	infoHeadersDrifted = "response headers don't hold their desired values: %s"
+)
+
+// headersUpToDate reports whether the response headers the GET mapping checks for drift hold their desired
+// values. The values are generated against the response stored in the status, like the desired state of the body.
+func (c *external) headersUpToDate(ctx context.Context, cr *v1alpha2.Request, mapping *v1alpha2.Mapping, details httpClient.HttpDetails) (bool, error) {
+	desired, err := requestgen.DesiredHeaders(ctx, c.localKube, *mapping, cr.Spec.ForProvider, cr.Status.Response)
+	if err != nil {
+		return false, err
+	}
+
+	var drifted []string
+	for name, value := range desired {
+		if strings.Join(http.Header(details.HttpResponse.Headers).Values(name), ",") != value {
+			drifted = append(drifted, name)
+		}
+	}
+
+	if len(drifted) > 0 {
+		sort.Strings(drifted)
+		c.logger.Debug(fmt.Sprintf(infoHeadersDrifted, strings.Join(drifted, ", ")))
+		return false, nil
+	}
+
+	return true, nil
+}
@@ -0,0 +1,140 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+// withHeaderDriftChecks sets the header drift checks of the GET mapping, checking only them when headerOnly is set.
+func withHeaderDriftChecks(checks map[string]string, headerOnly bool) httpRequestModifier {
+	return func(r *v1alpha2.Request) {
+		getMapping := testGetMapping
+		getMapping.HeaderDriftChecks = checks
+		getMapping.HeaderDriftOnly = headerOnly
+		r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{testPostMapping, getMapping, testPutMapping, testDeleteMapping}
+		r.Status.RequestDetails = v1alpha2.Mapping{Method: http.MethodPost}
+		r.Status.Response.Body = `{"id":"123","username":"john_doe"}`
+		r.Status.Response.StatusCode = 201
+	}
+}
+
+func Test_isUpToDate_HeaderDrift(t *testing.T) {
+	type args struct {
+		mg       *v1alpha2.Request
+		response httpClient.HttpResponse
+	}
+	type want struct {
+		synced bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"HeadersHoldDesiredValues": {
+			args: args{
+				mg: httpRequest(withHeaderDriftChecks(map[string]string{"X-Owner": ".payload.body.username"}, false)),
+				response: httpClient.HttpResponse{
+					StatusCode: 200,
+					Body:       `{"username":"john_doe_new_username"}`,
+					Headers:    map[string][]string{"X-Owner": {"john_doe"}},
+				},
+			},
+			want: want{synced: true},
+		},
+		"HeaderOnlyDrift": {
+			args: args{
+				mg: httpRequest(withHeaderDriftChecks(map[string]string{"X-Config-Version": `"7"`}, false)),
+				response: httpClient.HttpResponse{
+					StatusCode: 200,
+					Body:       `{"username":"john_doe_new_username"}`,
+					Headers:    map[string][]string{"X-Config-Version": {"6"}},
+				},
+			},
+			want: want{synced: false},
+		},
+		"MissingHeaderDrifted": {
+			args: args{
+				mg: httpRequest(withHeaderDriftChecks(map[string]string{"X-Config-Version": `"7"`}, false)),
+				response: httpClient.HttpResponse{
+					StatusCode: 200,
+					Body:       `{"username":"john_doe_new_username"}`,
+				},
+			},
+			want: want{synced: false},
+		},
+		"HeaderMatchedCaseInsensitively": {
+			args: args{
+				mg: httpRequest(withHeaderDriftChecks(map[string]string{"x-config-version": `"7"`}, false)),
+				response: httpClient.HttpResponse{
+					StatusCode: 200,
+					Body:       `{"username":"john_doe_new_username"}`,
+					Headers:    map[string][]string{"X-Config-Version": {"7"}},
+				},
+			},
+			want: want{synced: true},
+		},
+		"BodyDriftStillDetected": {
+			args: args{
+				mg: httpRequest(withHeaderDriftChecks(map[string]string{"X-Config-Version": `"7"`}, false)),
+				response: httpClient.HttpResponse{
+					StatusCode: 200,
+					Body:       `{"username":"john_doe"}`,
+					Headers:    map[string][]string{"X-Config-Version": {"7"}},
+				},
+			},
+			want: want{synced: false},
+		},
+		"HeaderDriftOnlyIgnoresBody": {
+			args: args{
+				mg: httpRequest(withHeaderDriftChecks(map[string]string{"X-Config-Version": `"7"`}, true)),
+				response: httpClient.HttpResponse{
+					StatusCode: 200,
+					Body:       `{"username":"john_doe"}`,
+					Headers:    map[string][]string{"X-Config-Version": {"7"}},
+				},
+			},
+			want: want{synced: true},
+		},
+		"HeaderDriftOnlyDrifted": {
+			args: args{
+				mg: httpRequest(withHeaderDriftChecks(map[string]string{"X-Config-Version": `"7"`}, true)),
+				response: httpClient.HttpResponse{
+					StatusCode: 200,
+					Body:       `{"username":"john_doe_new_username"}`,
+					Headers:    map[string][]string{"X-Config-Version": {"6"}},
+				},
+			},
+			want: want{synced: false},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				localKube: &test.MockClient{MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil)},
+				logger:    logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{HttpResponse: tc.args.response}, nil
+					},
+				},
+			}
+			got, gotErr := e.isUpToDate(context.Background(), tc.args.mg)
+			if gotErr != nil {
+				t.Fatalf("isUpToDate(...): unexpected error: %s", gotErr)
+			}
+			if diff := cmp.Diff(tc.want.synced, got.Synced); diff != "" {
+				t.Errorf("isUpToDate(...): -want synced, +got synced: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package request
+
+import (
+	"fmt"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+const (
+	// ReasonWaitingForInitialDelay indicates the first request is held back until the initial delay elapses.
+	ReasonWaitingForInitialDelay xpv1.ConditionReason = "WaitingForInitialDelay"
+
+	msgWaitingForInitialDelay = "waiting %s for the initial delay to elapse before sending the first request"
+)
+
+// initialDelayRemaining returns how long is left, measured from the creation of the resource, before the first
+// request may be sent. It returns 0 once the delay elapsed, when no delay is set, or when the resource is deleted.
+func initialDelayRemaining(cr *v1alpha2.Request, now time.Time) time.Duration {
+	delay := cr.Spec.ForProvider.InitialDelay
+	if delay == nil || meta.WasDeleted(cr) {
+		return 0
+	}
+
+	remaining := cr.GetCreationTimestamp().Add(delay.Duration).Sub(now)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// waitingForInitialDelay returns a condition indicating the resource waits for its initial delay to elapse.
+func waitingForInitialDelay(remaining time.Duration) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonWaitingForInitialDelay,
+		Message:            fmt.Sprintf(msgWaitingForInitialDelay, remaining.Round(time.Second)),
+	}
+}
+
+// initialDelayPollInterval requeues a resource waiting for its initial delay as soon as the delay elapses,
+// rather than after a full poll interval.
+func initialDelayPollInterval(mg resource.Managed, pollInterval time.Duration) time.Duration {
+	cr, ok := mg.(*v1alpha2.Request)
+	if !ok {
+		return pollInterval
+	}
+
+	if remaining := initialDelayRemaining(cr, time.Now()); remaining > 0 && remaining < pollInterval {
+		return remaining
+	}
+	return pollInterval
+}
@@ -0,0 +1,80 @@
+package request
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+func Test_initialDelayRemaining(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	withDelay := func(delay time.Duration) httpRequestModifier {
+		return func(r *v1alpha2.Request) {
+			r.SetCreationTimestamp(v1.Time{Time: created})
+			r.Spec.ForProvider.InitialDelay = &v1.Duration{Duration: delay}
+		}
+	}
+
+	type args struct {
+		cr  *v1alpha2.Request
+		now time.Time
+	}
+	type want struct {
+		remaining time.Duration
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoDelay": {
+			args: args{
+				cr:  httpRequest(),
+				now: created,
+			},
+			want: want{
+				remaining: 0,
+			},
+		},
+		"Waiting": {
+			args: args{
+				cr:  httpRequest(withDelay(time.Minute)),
+				now: created.Add(20 * time.Second),
+			},
+			want: want{
+				remaining: 40 * time.Second,
+			},
+		},
+		"Elapsed": {
+			args: args{
+				cr:  httpRequest(withDelay(time.Minute)),
+				now: created.Add(2 * time.Minute),
+			},
+			want: want{
+				remaining: 0,
+			},
+		},
+		"Deleted": {
+			args: args{
+				cr: httpRequest(withDelay(time.Minute), func(r *v1alpha2.Request) {
+					r.SetDeletionTimestamp(&v1.Time{Time: created})
+				}),
+				now: created,
+			},
+			want: want{
+				remaining: 0,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := initialDelayRemaining(tc.args.cr, tc.args.now)
+			if diff := cmp.Diff(tc.want.remaining, got); diff != "" {
+				t.Fatalf("initialDelayRemaining(...): -want remaining, +got remaining: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package request
+
+import (
+	"net/http"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+// isNotModified reports whether the GET response is a 304 Not Modified answer to conditional headers, meaning the
+// object is up to date. A response classification rule matching the response takes precedence.
+func isNotModified(cr *v1alpha2.Request, details httpClient.HttpDetails, responseErr error) (bool, error) {
+	if responseErr != nil || details.HttpResponse.StatusCode != http.StatusNotModified {
+		return false, nil
+	}
+
+	outcome, err := utils.ClassifyResponse(cr.Spec.ForProvider, http.MethodGet, details.HttpResponse)
+	if err != nil {
+		return false, err
+	}
+
+	return outcome == "", nil
+}
+
+// notModifiedResponse returns the response stored in the status of the Request, which a 304 response confirms is
+// still current, with its headers updated by the ones of the 304 response, such as a new ETag.
+func notModifiedResponse(cr *v1alpha2.Request, notModified httpClient.HttpResponse) httpClient.HttpResponse {
+	headers := make(map[string][]string, len(cr.Status.Response.Headers)+len(notModified.Headers))
+	for key, values := range cr.Status.Response.Headers {
+		headers[key] = append([]string(nil), values...)
+	}
+	for key, values := range notModified.Headers {
+		headers[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+	}
+
+	return httpClient.HttpResponse{
+		StatusCode: cr.Status.Response.StatusCode,
+		Headers:    headers,
+		Body:       utils.DecompressBody(cr.Status.Response.Body),
+	}
+}
@@ -0,0 +1,42 @@
+package request
+
+import (
+	"net/http"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/killswitch"
+	"github.com/crossplane-contrib/provider-http/internal/notifications"
+)
+
+const errNotificationsDisabled = "Warning, notifications are disabled for this reconcile, error: %s"
+
+// notificationEvents are the events notified when the request sent with a method succeeds or fails.
+var notificationEvents = map[string]struct {
+	succeeded, failed apisv1alpha1.NotificationEvent
+}{
+	http.MethodPost:   {apisv1alpha1.NotificationEventCreateSucceeded, apisv1alpha1.NotificationEventCreateFailed},
+	http.MethodPut:    {apisv1alpha1.NotificationEventUpdateSucceeded, apisv1alpha1.NotificationEventUpdateFailed},
+	http.MethodDelete: {apisv1alpha1.NotificationEventDeleteSucceeded, apisv1alpha1.NotificationEventDeleteFailed},
+}
+
+// notify notifies the webhook of the ProviderConfig of the outcome of the request sent for the action of the
+// given method. Nothing is notified when the action has no mapping or its request was never sent, including
+// when the kill switch held it back.
+func (c *external) notify(cr *v1alpha2.Request, method string, err error) {
+	events, ok := notificationEvents[method]
+	if !ok || httpClient.IsHostSaturated(err) || httpClient.IsTemporaryDNSFailure(err) || killswitch.IsPaused(cr) {
+		return
+	}
+	if _, ok := getMappingByMethod(&cr.Spec.ForProvider, method); !ok {
+		return
+	}
+
+	event, outcome := events.succeeded, notifications.Outcome{Method: method, StatusCode: cr.Status.Response.StatusCode}
+	if err != nil {
+		event, outcome.Error = events.failed, err.Error()
+	}
+
+	c.notifier.Notify(event, v1alpha2.RequestGroupVersionKind, cr, outcome)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
 	"github.com/crossplane-contrib/provider-http/internal/json"
 	"github.com/crossplane-contrib/provider-http/internal/utils"
 	"github.com/pkg/errors"
@@ -17,12 +18,18 @@ import (
 const (
 	errObjectNotFound = "object wasn't found"
 	errNotValidJSON   = "%s is not a valid JSON string: %s"
+	errTypeMismatch   = "response field %s is a %s, but the desired state has a %s"
+
+	errConvertResponse       = "failed to convert response to map"
+	errExistsConditionFormat = "exists condition: JQ filter should return a boolean, but returned error: %s"
 )
 
 type ObserveRequestDetails struct {
 	Details       httpClient.HttpDetails
 	ResponseError error
 	Synced        bool
+	// DriftedSubResources are the names of the sub-resources that didn't match their desired state.
+	DriftedSubResources []string
 }
 
 // NewObserveRequestDetails is a constructor function that initializes
@@ -49,43 +56,194 @@ func (c *external) isUpToDate(ctx context.Context, cr *v1alpha2.Request) (Observ
 		return FailedObserve(), errors.New(errObjectNotFound)
 	}
 
-	requestDetails, err := c.requestDetails(ctx, cr, http.MethodGet)
+	ctx, err := c.withPreRequest(ctx, cr)
+	if err != nil {
+		return FailedObserve(), err
+	}
+
+	mapping, ok := getMappingByMethod(&cr.Spec.ForProvider, http.MethodGet)
+	if !ok {
+		return FailedObserve(), errors.Errorf(errMappingNotFound, http.MethodGet)
+	}
+
+	requestDetails, err := c.observeRequestDetails(ctx, cr, mapping)
+	if err != nil {
+		return FailedObserve(), err
+	}
+
+	details, responseErr := c.sendObserveRequest(ctx, cr, mapping, &requestDetails)
+	if httpClient.IsHostSaturated(responseErr) || httpClient.IsTemporaryDNSFailure(responseErr) {
+		return FailedObserve(), responseErr
+	}
+
+	c.exportDebugArtifact(ctx, cr, details, responseErr)
+
+	if err := decryptResponseBody(ctx, c.localKube, mapping, &details, responseErr); err != nil {
+		return FailedObserve(), err
+	}
+
+	notModified, err := isNotModified(cr, details, responseErr)
 	if err != nil {
 		return FailedObserve(), err
 	}
 
-	details, responseErr := c.http.SendRequest(ctx, http.MethodGet, requestDetails.Url, requestDetails.Body, requestDetails.Headers, cr.Spec.ForProvider.InsecureSkipTLSVerify)
-	if details.HttpResponse.StatusCode == http.StatusNotFound {
+	if notModified {
+		details.HttpResponse = notModifiedResponse(cr, details.HttpResponse)
+		return NewObserve(details, responseErr, true), nil
+	}
+
+	if mapping.Pagination != nil && responseErr == nil && utils.IsHTTPSuccess(details.HttpResponse.StatusCode) {
+		if details, err = c.aggregatePages(ctx, cr, mapping, requestDetails, details); err != nil {
+			return FailedObserve(), err
+		}
+	}
+
+	if err := transformResponse(cr, mapping, &details, responseErr); err != nil {
+		return FailedObserve(), err
+	}
+
+	exists, decided, err := existsByCondition(cr, details, responseErr)
+	if err != nil {
+		return FailedObserve(), err
+	}
+
+	if decided && !exists {
 		return FailedObserve(), errors.New(errObjectNotFound)
 	}
 
-	c.patchResponseToSecret(ctx, cr, &details.HttpResponse)
+	outcome, err := utils.ClassifyResponse(cr.Spec.ForProvider, http.MethodGet, details.HttpResponse)
+	if err != nil {
+		return FailedObserve(), err
+	}
+
+	if !decided && outcome == v1alpha2.ResponseOutcomeNotFound {
+		return FailedObserve(), errors.New(errObjectNotFound)
+	}
+
+	if responseErr == nil && details.HttpResponse.Body == "" && utils.IsHTTPSuccess(details.HttpResponse.StatusCode) {
+		switch emptyBodyMeans(cr) {
+		case v1alpha2.EmptyBodyMeansNotFound:
+			if !decided {
+				return FailedObserve(), errors.New(errObjectNotFound)
+			}
+		case v1alpha2.EmptyBodyMeansExists:
+			return NewObserve(details, responseErr, true), nil
+		}
+	}
+
+	c.patchResponseToSecret(ctx, cr, http.MethodGet, &details.HttpResponse)
+	headersUpToDate, err := c.headersUpToDate(ctx, cr, mapping, details)
+	if err != nil {
+		return FailedObserve(), err
+	}
+
+	if mapping.HeaderDriftOnly {
+		return c.observeSubResources(ctx, cr, mapping, NewObserve(details, responseErr, headersUpToDate && utils.IsHTTPSuccess(details.HttpResponse.StatusCode)))
+	}
+
 	desiredState, err := c.desiredState(ctx, cr)
 	if err != nil {
 		if isErrorMappingNotFound(err) {
 			// Since there is no PUT mapping, we skip the check for its presence in the GET response.
-			return NewObserve(details, responseErr, true), nil
+			return NewObserve(details, responseErr, headersUpToDate), nil
 		}
 
 		// For any other error, we return a failed observation.
 		return FailedObserve(), err
 	}
 
-	return c.compareResponseAndDesiredState(details, responseErr, desiredState)
+	var observed ObserveRequestDetails
+	if cr.Spec.ForProvider.UpToDateCondition != "" {
+		observed, err = c.compareWithUpToDateCondition(cr, mapping, details, responseErr, desiredState)
+	} else if mapping.ItemsPath != "" {
+		observed, err = c.compareItemsAndDesiredState(details, responseErr, desiredState, mapping, typeComparison(cr, c.canary), cr.Spec.ForProvider.UnorderedArrays)
+	} else {
+		observed, err = c.compareResponseAndDesiredState(details, responseErr, desiredState, typeComparison(cr, c.canary), cr.Spec.ForProvider.UnorderedArrays)
+	}
+	if err != nil {
+		return observed, err
+	}
+
+	observed.Synced = observed.Synced && headersUpToDate
+	return c.observeSubResources(ctx, cr, mapping, observed)
 }
 
 func (c *external) isObjectValidForObservation(cr *v1alpha2.Request) bool {
-	return cr.Status.Response.Body != "" &&
-		!(cr.Status.RequestDetails.Method == http.MethodPost && utils.IsHTTPError(cr.Status.Response.StatusCode))
+	method, statusCode := utils.SentActionMethod(cr.Spec.ForProvider, cr.Status.RequestDetails.Method, cr.Status.RequestDetails.Action), cr.Status.Response.StatusCode
+	if cr.Status.Response.Body == "" {
+		// An empty body only identifies an existing object when the API is known to answer with no content, or
+		// when the body of a successful response was kept out of the status because its mapping is sensitive.
+		mapping, ok := getMappingByMethod(&cr.Spec.ForProvider, method)
+		return (emptyBodyMeans(cr) == v1alpha2.EmptyBodyMeansExists || (ok && mapping.Sensitive)) && method != "" && utils.IsHTTPSuccess(statusCode)
+	}
+
+	if method != http.MethodPost || utils.IsCreateConflict(cr.Spec.ForProvider, method, statusCode) {
+		return true
+	}
+
+	// A POST response classified as an error means the object wasn't created.
+	outcome, err := utils.ClassifyResponse(cr.Spec.ForProvider, method, httpClient.HttpResponse{
+		StatusCode: statusCode,
+		Headers:    cr.Status.Response.Headers,
+		Body:       utils.DecompressBody(cr.Status.Response.Body),
+	})
+	return err == nil && (outcome == v1alpha2.ResponseOutcomeSuccess || outcome == "")
+}
+
+// existsByCondition evaluates the exists condition of the Request against the GET response. It reports whether
+// the condition decided the existence of the object, which it doesn't when none is set or the request failed.
+func existsByCondition(cr *v1alpha2.Request, details httpClient.HttpDetails, responseErr error) (exists bool, decided bool, err error) {
+	condition := cr.Spec.ForProvider.ExistsCondition
+	if condition == "" || responseErr != nil {
+		return false, false, nil
+	}
+
+	var responseFormat string
+	if mapping, ok := getMappingByMethod(&cr.Spec.ForProvider, http.MethodGet); ok {
+		responseFormat = string(mapping.ResponseFormat)
+	}
+
+	responseMap, err := json.ResponseToMap(details.HttpResponse, responseFormat)
+	if err != nil {
+		return false, false, errors.Wrap(err, errConvertResponse)
+	}
+
+	exists, err = jq.ParseBool(condition, responseMap)
+	if err != nil {
+		return false, false, errors.Errorf(errExistsConditionFormat, err.Error())
+	}
+
+	return exists, true, nil
 }
 
-func (c *external) compareResponseAndDesiredState(details httpClient.HttpDetails, err error, desiredState string) (ObserveRequestDetails, error) {
+// emptyBodyMeans returns how the GET mapping interprets a successful response with an empty body.
+func emptyBodyMeans(cr *v1alpha2.Request) v1alpha2.EmptyBodyInterpretation {
+	mapping, ok := getMappingByMethod(&cr.Spec.ForProvider, http.MethodGet)
+	if !ok {
+		return ""
+	}
+	return mapping.EmptyBodyMeans
+}
+
+func (c *external) compareResponseAndDesiredState(details httpClient.HttpDetails, err error, desiredState string, typeComparison v1alpha2.TypeComparisonMode, unorderedArrays []v1alpha2.UnorderedArray) (ObserveRequestDetails, error) {
 	observeRequestDetails := NewObserve(details, err, false)
 
 	if json.IsJSONString(details.HttpResponse.Body) && json.IsJSONString(desiredState) {
 		responseBodyMap := json.JsonStringToMap(details.HttpResponse.Body)
 		desiredStateMap := json.JsonStringToMap(desiredState)
-		observeRequestDetails.Synced = json.Contains(responseBodyMap, desiredStateMap) && utils.IsHTTPSuccess(details.HttpResponse.StatusCode)
+
+		contains, containsErr := containsFunc(typeComparison, unorderedArrays)
+		if containsErr != nil {
+			return FailedObserve(), containsErr
+		}
+
+		if typeComparison == v1alpha2.TypeComparisonStrict {
+			if path, desiredType, responseType, found := json.FindTypeMismatch(responseBodyMap, desiredStateMap); found {
+				return FailedObserve(), errors.Errorf(errTypeMismatch, path, responseType, desiredType)
+			}
+		}
+
+		observeRequestDetails.Synced = contains(responseBodyMap, desiredStateMap) && utils.IsHTTPSuccess(details.HttpResponse.StatusCode)
 		return observeRequestDetails, nil
 	}
 
@@ -101,8 +259,10 @@ func (c *external) compareResponseAndDesiredState(details httpClient.HttpDetails
 	return observeRequestDetails, nil
 }
 
+// desiredState returns the body of the PUT mapping the GET response is compared with, left unredacted so that
+// the redacted values don't show up as drift.
 func (c *external) desiredState(ctx context.Context, cr *v1alpha2.Request) (string, error) {
-	requestDetails, err := c.requestDetails(ctx, cr, http.MethodPut)
+	requestDetails, err := c.requestDetails(requestgen.WithUnredactedBody(ctx), cr, http.MethodPut)
 	if err != nil {
 		return "", err
 	}
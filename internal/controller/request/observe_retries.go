@@ -0,0 +1,91 @@
+package request
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+)
+
+const (
+	defaultObserveRetryDelay = time.Second
+
+	errRerenderObserveRequest = "couldn't render the GET request again before retrying it, retrying the previous one: %s"
+)
+
+// sendObserveRequest sends the GET request observing the object, retrying it within the reconcile as configured
+// by the observe retries of the Request while it fails transiently. Each retry renders the request again, so that
+// time dependent values such as timestamps and nonces are fresh, and requestDetails holds the last one sent.
+func (c *external) sendObserveRequest(ctx context.Context, cr *v1alpha2.Request, mapping *v1alpha2.Mapping, requestDetails *requestgen.RequestDetails) (httpClient.HttpDetails, error) {
+	retries := cr.Spec.ForProvider.ObserveRetries
+	sendCtx := mappingContext(ctx, mapping)
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			c.rerenderRequestDetails(ctx, cr, mapping, requestDetails)
+		}
+
+		details, err := c.http.SendRequest(sendCtx, mapping.Method, requestDetails.Url, requestDetails.Body, requestDetails.Headers, skipTLSVerify(cr, mapping))
+		if retries == nil || attempt >= int(retries.Limit) || !isTransientObserveFailure(details, err) {
+			return details, err
+		}
+
+		c.logger.Debug(fmt.Sprintf("GET request observing the object failed transiently, retrying (%d/%d)", attempt+1, retries.Limit))
+
+		select {
+		case <-sendCtx.Done():
+			return details, err
+		case <-time.After(observeRetryDelay(retries)):
+		}
+	}
+}
+
+// isTransientObserveFailure reports whether a GET response is worth retrying: the request failed to get a
+// response, other than because the host is saturated or doesn't exist or the headers are too large, or got a
+// 5xx one.
+func isTransientObserveFailure(details httpClient.HttpDetails, err error) bool {
+	if err != nil {
+		return !httpClient.IsHostSaturated(err) && !httpClient.IsHostNotFound(err) && !httpClient.IsHeadersTooLarge(err)
+	}
+
+	return details.HttpResponse.StatusCode >= http.StatusInternalServerError
+}
+
+// observeRequestDetails renders the GET request observing the object, encrypting its body if the mapping asks
+// for it.
+func (c *external) observeRequestDetails(ctx context.Context, cr *v1alpha2.Request, mapping *v1alpha2.Mapping) (requestgen.RequestDetails, error) {
+	requestDetails, err := generateValidRequestDetails(ctx, c.localKube, cr, mapping)
+	if err != nil {
+		return requestgen.RequestDetails{}, err
+	}
+
+	if err := encryptRequestBody(ctx, c.localKube, mapping, &requestDetails); err != nil {
+		return requestgen.RequestDetails{}, err
+	}
+
+	return requestDetails, nil
+}
+
+// rerenderRequestDetails renders the GET request again before it is retried. The request that was rendered
+// before is retried as is if it can't be rendered anymore.
+func (c *external) rerenderRequestDetails(ctx context.Context, cr *v1alpha2.Request, mapping *v1alpha2.Mapping, requestDetails *requestgen.RequestDetails) {
+	rendered, err := c.observeRequestDetails(ctx, cr, mapping)
+	if err != nil {
+		c.logger.Debug(fmt.Sprintf(errRerenderObserveRequest, err.Error()))
+		return
+	}
+
+	*requestDetails = rendered
+}
+
+func observeRetryDelay(retries *v1alpha2.ObserveRetries) time.Duration {
+	if retries.Delay == nil {
+		return defaultObserveRetryDelay
+	}
+
+	return retries.Delay.Duration
+}
@@ -0,0 +1,223 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_isUpToDate_ObserveRetries(t *testing.T) {
+	errConnection := errors.New("connection refused")
+	errHostNotFound := errors.Wrap(httpClient.ErrHostNotFound, "lookup api.example.com: no such host")
+	synced := httpClient.HttpResponse{Body: `{"username":"john_doe_new_username"}`, StatusCode: http.StatusOK}
+	unavailable := httpClient.HttpResponse{Body: `{"error":"unavailable"}`, StatusCode: http.StatusServiceUnavailable}
+	withRetries := func(limit int32) httpRequestModifier {
+		return func(r *v1alpha2.Request) {
+			r.Spec.ForProvider.ObserveRetries = &v1alpha2.ObserveRetries{Limit: limit, Delay: &v1.Duration{Duration: time.Millisecond}}
+			r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+		}
+	}
+
+	type attempt struct {
+		response httpClient.HttpResponse
+		err      error
+	}
+	type args struct {
+		attempts []attempt
+		mg       *v1alpha2.Request
+	}
+	type want struct {
+		sends  int
+		result ObserveRequestDetails
+		err    error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"RetryThenSuccessOn5xx": {
+			args: args{
+				attempts: []attempt{{response: unavailable}, {response: synced}},
+				mg:       httpRequest(withRetries(2)),
+			},
+			want: want{
+				sends:  2,
+				result: NewObserve(httpClient.HttpDetails{HttpResponse: synced}, nil, true),
+			},
+		},
+		"RetryThenSuccessOnConnectionError": {
+			args: args{
+				attempts: []attempt{{err: errConnection}, {err: errConnection}, {response: synced}},
+				mg:       httpRequest(withRetries(2)),
+			},
+			want: want{
+				sends:  3,
+				result: NewObserve(httpClient.HttpDetails{HttpResponse: synced}, nil, true),
+			},
+		},
+		"RetryExhaustion": {
+			args: args{
+				attempts: []attempt{{response: unavailable}, {response: unavailable}, {response: unavailable}},
+				mg:       httpRequest(withRetries(2)),
+			},
+			want: want{
+				sends:  3,
+				result: NewObserve(httpClient.HttpDetails{HttpResponse: unavailable}, nil, false),
+			},
+		},
+		"ClientErrorNotRetried": {
+			args: args{
+				attempts: []attempt{{response: httpClient.HttpResponse{Body: `{}`, StatusCode: http.StatusBadRequest}}, {response: synced}},
+				mg:       httpRequest(withRetries(2)),
+			},
+			want: want{
+				sends:  1,
+				result: NewObserve(httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{Body: `{}`, StatusCode: http.StatusBadRequest}}, nil, false),
+			},
+		},
+		"HostNotFoundNotRetried": {
+			args: args{
+				attempts: []attempt{{err: errHostNotFound}, {response: synced}},
+				mg:       httpRequest(withRetries(2)),
+			},
+			want: want{
+				sends:  1,
+				result: FailedObserve(),
+				err:    errors.Errorf(errNotValidJSON, "response body", ""),
+			},
+		},
+		"NotRetriedWithoutObserveRetries": {
+			args: args{
+				attempts: []attempt{{response: unavailable}, {response: synced}},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+				}),
+			},
+			want: want{
+				sends:  1,
+				result: NewObserve(httpClient.HttpDetails{HttpResponse: unavailable}, nil, false),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			sends := 0
+			e := &external{
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						attempt := tc.args.attempts[sends]
+						sends++
+						return httpClient.HttpDetails{HttpResponse: attempt.response}, attempt.err
+					},
+				},
+			}
+			got, gotErr := e.isUpToDate(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("isUpToDate(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.sends, sends); diff != "" {
+				t.Errorf("isUpToDate(...): -want sends, +got sends: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got, test.EquateErrors()); diff != "" {
+				t.Errorf("isUpToDate(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_sendObserveRequest_RendersEachRetry(t *testing.T) {
+	var urls []string
+	e := &external{
+		localKube: &test.MockClient{MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil)},
+		logger:    logging.NewNopLogger(),
+		http: &MockHttpClient{
+			MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+				urls = append(urls, url)
+				return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusServiceUnavailable}}, nil
+			},
+		},
+	}
+
+	cr := httpRequest(func(r *v1alpha2.Request) {
+		r.Spec.ForProvider.ObserveRetries = &v1alpha2.ObserveRetries{Limit: 1, Delay: &v1.Duration{Duration: time.Millisecond}}
+		r.Status.Response.Body = `{"id":"123"}`
+	})
+	mapping := &v1alpha2.Mapping{Method: http.MethodGet, URL: `(.payload.baseUrl + "/" + .response.body.id + "?timestamp=" + (now | tostring))`}
+
+	requestDetails, err := e.observeRequestDetails(context.Background(), cr, mapping)
+	if err != nil {
+		t.Fatalf("observeRequestDetails(...): unexpected error: %s", err)
+	}
+	if _, err := e.sendObserveRequest(context.Background(), cr, mapping, &requestDetails); err != nil {
+		t.Fatalf("sendObserveRequest(...): unexpected error: %s", err)
+	}
+
+	if len(urls) != 2 {
+		t.Fatalf("sendObserveRequest(...): want 2 requests sent, got %d", len(urls))
+	}
+	if urls[0] == urls[1] {
+		t.Errorf("sendObserveRequest(...): want a fresh timestamp on retry, got the same URL twice: %s", urls[0])
+	}
+	if diff := cmp.Diff(urls[1], requestDetails.Url); diff != "" {
+		t.Errorf("sendObserveRequest(...): -want last sent URL, +got request details URL: %s", diff)
+	}
+}
+
+func Test_isUpToDate_ObserveRetriesBypassResponseCache(t *testing.T) {
+	var sends int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&sends, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error":"unavailable"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"username":"john_doe_new_username"}`))
+	}))
+	defer server.Close()
+
+	// The cache keeps responses far longer than the retry delay, so a cached 5xx would be returned to the retry.
+	h, err := httpClient.NewClient(logging.NewNopLogger(), time.Minute, httpClient.WithResponseCache(httpClient.NewResponseCache(), time.Minute))
+	if err != nil {
+		t.Fatalf("NewClient(...): unexpected error: %s", err)
+	}
+
+	e := &external{
+		localKube: &test.MockClient{MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil)},
+		logger:    logging.NewNopLogger(),
+		http:      h,
+	}
+	cr := httpRequest(func(r *v1alpha2.Request) {
+		r.Spec.ForProvider.Payload.BaseUrl = server.URL
+		r.Spec.ForProvider.ObserveRetries = &v1alpha2.ObserveRetries{Limit: 2, Delay: &v1.Duration{Duration: time.Millisecond}}
+		r.Status.Response.Body = `{"id":"123"}`
+	})
+
+	got, err := e.isUpToDate(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("isUpToDate(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(int32(2), atomic.LoadInt32(&sends)); diff != "" {
+		t.Errorf("isUpToDate(...): -want requests reaching the server, +got requests reaching the server: %s", diff)
+	}
+	if !got.Synced {
+		t.Errorf("isUpToDate(...): want the retried GET to observe the synced object, got status code %d", got.Details.HttpResponse.StatusCode)
+	}
+}
@@ -18,6 +18,14 @@ var (
 	errNotFound = errors.New(errObjectNotFound)
 )
 
+func withEmptyBodyMeans(interpretation v1alpha2.EmptyBodyInterpretation) httpRequestModifier {
+	return func(r *v1alpha2.Request) {
+		getMapping := testGetMapping
+		getMapping.EmptyBodyMeans = interpretation
+		r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{testPostMapping, getMapping, testPutMapping, testDeleteMapping}
+	}
+}
+
 func Test_isUpToDate(t *testing.T) {
 	type args struct {
 		http      httpClient.Client
@@ -110,6 +118,93 @@ func Test_isUpToDate(t *testing.T) {
 				err: errors.Errorf(errNotValidJSON, "response body", "not a JSON"),
 			},
 		},
+		"StrictTypeMismatch": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":5}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.TypeComparison = v1alpha2.TypeComparisonStrict
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+				}),
+			},
+			want: want{
+				err: errors.Errorf(errTypeMismatch, ".username", "number", "string"),
+			},
+		},
+		"NotModifiedUpToDate": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Headers:    map[string][]string{"Etag": {`"v2"`}},
+								StatusCode: http.StatusNotModified,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					r.Status.Response = v1alpha2.Response{
+						Body:       `{"username":"old_name"}`,
+						Headers:    map[string][]string{"Etag": {`"v1"`}, "Content-Type": {"application/json"}},
+						StatusCode: http.StatusOK,
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"old_name"}`,
+							Headers:    map[string][]string{"Etag": {`"v2"`}, "Content-Type": {"application/json"}},
+							StatusCode: http.StatusOK,
+						},
+					},
+					ResponseError: nil,
+					Synced:        true,
+				},
+			},
+		},
+		"NotModifiedClassifiedByRule": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								StatusCode: http.StatusNotModified,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.ResponseClassification = []v1alpha2.ResponseClassificationRule{
+						{StatusCodes: []int32{http.StatusNotModified}, Outcome: v1alpha2.ResponseOutcomeNotFound},
+					}
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+				}),
+			},
+			want: want{
+				err: errNotFound,
+			},
+		},
 		"SuccessNotSynced": {
 			args: args{
 				http: &MockHttpClient{
@@ -144,6 +239,278 @@ func Test_isUpToDate(t *testing.T) {
 				},
 			},
 		},
+		"ExistsConditionFalse": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"items":[]}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.ExistsCondition = ".body.items | length > 0"
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+				}),
+			},
+			want: want{
+				err: errNotFound,
+			},
+		},
+		"ExistsConditionTrue": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"john_doe_new_username","items":[1]}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.ExistsCondition = ".body.items | length > 0"
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"john_doe_new_username","items":[1]}`,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        true,
+				},
+			},
+		},
+		"ReorderedUnorderedArray": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"tags":["b","a"]}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{testPostMapping, testGetMapping, {
+						Method: "PUT",
+						Body:   `{ tags: ["a", "b"] }`,
+						URL:    testPutMapping.URL,
+					}}
+					r.Spec.ForProvider.UnorderedArrays = []v1alpha2.UnorderedArray{{Path: ".tags"}}
+					r.Status.Response.Body = `{"id":"123"}`
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"tags":["b","a"]}`,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        true,
+				},
+			},
+		},
+		"RedactedPathsComparedUnredacted": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"john_doe","password":"hunter2"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{testPostMapping, testGetMapping, {
+						Method:      "PUT",
+						Body:        `{ username: "john_doe", password: "hunter2" }`,
+						URL:         testPutMapping.URL,
+						RedactPaths: []string{".password"},
+					}}
+					r.Status.Response.Body = `{"id":"123"}`
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"john_doe","password":"hunter2"}`,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        true,
+				},
+			},
+		},
+		"TransformedResponseComparedToDesiredState": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"data":{"tags":["a"]},"meta":{"etag":"abc"}}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{testPostMapping, testGetMapping, {
+						Method: "PUT",
+						Body:   `{ tags: ["a"] }`,
+						URL:    testPutMapping.URL,
+					}}
+					r.Spec.ForProvider.ResponseTransform = ".body.data"
+					r.Status.Response.Body = `{"id":"123"}`
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"tags":["a"]}`,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        true,
+				},
+			},
+		},
+		"TransformDroppingDesiredField": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"id":"123","tags":["a"]}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{testPostMapping, testGetMapping, {
+						Method: "PUT",
+						Body:   `{ tags: ["a"] }`,
+						URL:    testPutMapping.URL,
+					}}
+					r.Spec.ForProvider.ResponseTransform = ".body | {id}"
+					r.Status.Response.Body = `{"id":"123"}`
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"id":"123"}`,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        false,
+				},
+			},
+		},
+		"ExistsConditionTrueOverridesNotFoundStatusCode": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"john_doe_new_username","items":[1]}`,
+								StatusCode: 404,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.ExistsCondition = ".body.items | length > 0"
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"john_doe_new_username","items":[1]}`,
+							StatusCode: 404,
+						},
+					},
+					ResponseError: nil,
+					Synced:        false,
+				},
+			},
+		},
+		"ExistsConditionNotBoolean": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"items":[]}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.ExistsCondition = ".body.items"
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+				}),
+			},
+			want: want{
+				err: errors.Errorf(errExistsConditionFormat, errors.Errorf("failed to parse string: %s", "[]").Error()),
+			},
+		},
 		"SuccessNoPUTMapping": {
 			args: args{
 				http: &MockHttpClient{
@@ -184,6 +551,92 @@ func Test_isUpToDate(t *testing.T) {
 				},
 			},
 		},
+		"EmptyBodyMeansExists": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(withEmptyBodyMeans(v1alpha2.EmptyBodyMeansExists), func(r *v1alpha2.Request) {
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+					r.Status.Response.StatusCode = 200
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							StatusCode: 200,
+						},
+					},
+					Synced: true,
+				},
+			},
+		},
+		"EmptyBodyMeansExistsAfterEmptyCreation": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(withEmptyBodyMeans(v1alpha2.EmptyBodyMeansExists), func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.Mappings[1].URL = ".payload.baseUrl"
+					r.Status.RequestDetails.Method = http.MethodPost
+					r.Status.Response.StatusCode = 201
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							StatusCode: 200,
+						},
+					},
+					Synced: true,
+				},
+			},
+		},
+		"EmptyBodyMeansNotFound": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(withEmptyBodyMeans(v1alpha2.EmptyBodyMeansNotFound), func(r *v1alpha2.Request) {
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+					r.Status.Response.StatusCode = 200
+				}),
+			},
+			want: want{
+				err: errNotFound,
+			},
+		},
 		"SuccessJSONBody": {
 			args: args{
 				http: &MockHttpClient{
@@ -239,3 +692,130 @@ func Test_isUpToDate(t *testing.T) {
 		})
 	}
 }
+
+func withQueryObserve() httpRequestModifier {
+	return func(r *v1alpha2.Request) {
+		queryMapping := v1alpha2.Mapping{
+			Method: http.MethodPost,
+			Action: v1alpha2.MappingActionObserve,
+			Body:   "{ query: { username: .payload.body.username } }",
+			URL:    "(.payload.baseUrl + \"/search\")",
+		}
+		r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{testPostMapping, queryMapping, testPutMapping, testDeleteMapping}
+	}
+}
+
+func Test_isUpToDate_QueryObserve(t *testing.T) {
+	type args struct {
+		http httpClient.Client
+		mg   *v1alpha2.Request
+	}
+	type want struct {
+		result ObserveRequestDetails
+		err    error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ObservedWithPostQuery": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						if method != http.MethodPost || url != "https://api.example.com/users/search" || body.Decrypted.(string) != `{"query":{"username":"john_doe"}}` {
+							t.Errorf("unexpected observe request: %s %s %s", method, url, body.Decrypted)
+						}
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"john_doe_new_username"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				mg: httpRequest(withQueryObserve(), func(r *v1alpha2.Request) {
+					r.Status.RequestDetails = v1alpha2.Mapping{Method: http.MethodPost, Action: v1alpha2.MappingActionCreate}
+					r.Status.Response.Body = `{"id":"123","username":"john_doe"}`
+					r.Status.Response.StatusCode = 201
+				}),
+			},
+			want: want{
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"john_doe_new_username"}`,
+							StatusCode: 200,
+						},
+					},
+					Synced: true,
+				},
+			},
+		},
+		"FailedCreationNotMistakenForQuery": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						t.Errorf("unexpected observe request: %s %s", method, url)
+						return httpClient.HttpDetails{}, nil
+					},
+				},
+				mg: httpRequest(withQueryObserve(), func(r *v1alpha2.Request) {
+					r.Status.RequestDetails = v1alpha2.Mapping{Method: http.MethodPost, Action: v1alpha2.MappingActionCreate}
+					r.Status.Response.Body = `{"error":"invalid username"}`
+					r.Status.Response.StatusCode = 400
+				}),
+			},
+			want: want{
+				err: errNotFound,
+			},
+		},
+		"FailedQueryObservedAgain": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"john_doe_new_username"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				mg: httpRequest(withQueryObserve(), func(r *v1alpha2.Request) {
+					r.Status.RequestDetails = v1alpha2.Mapping{Method: http.MethodPost, Action: v1alpha2.MappingActionObserve}
+					r.Status.Response.Body = `{"error":"unavailable"}`
+					r.Status.Response.StatusCode = 503
+				}),
+			},
+			want: want{
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"john_doe_new_username"}`,
+							StatusCode: 200,
+						},
+					},
+					Synced: true,
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				localKube: &test.MockClient{MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil)},
+				logger:    logging.NewNopLogger(),
+				http:      tc.args.http,
+			}
+			got, gotErr := e.isUpToDate(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("isUpToDate(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("isUpToDate(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
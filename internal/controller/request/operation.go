@@ -0,0 +1,223 @@
+package request
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	// ReasonOperationInProgress indicates a long running operation started by the last request is still running.
+	ReasonOperationInProgress xpv1.ConditionReason = "OperationInProgress"
+
+	defaultOperationTimeout      = 10 * time.Minute
+	defaultOperationPollInterval = 10 * time.Second
+
+	// operationDeadlineMargin is the part of the reconcile deadline kept free when polling an operation, so
+	// that the reconcile can still record the operation and requeue before it is cancelled.
+	operationDeadlineMargin = 5 * time.Second
+
+	msgOperationInProgress        = "operation %s started %s ago is still in progress"
+	errOperationTimedOut          = "operation %s didn't finish within %s"
+	errOperationFailed            = "operation %s failed"
+	errPollOperation              = "cannot poll operation %s"
+	errOperationCondition         = "operation %s: JQ filter should return a boolean, but returned error: %s"
+	errRecordOperationEnd         = "cannot record the end of the operation"
+	infoOperationDeadline         = "reconcile deadline is approaching, requeueing to keep polling operation %s"
+	infoOperationNotFinished      = "operation %s is still in progress"
+	infoOperationSucceeded        = "operation %s finished"
+	infoOperationDeleteInProgress = "the DELETE operation %s is still in progress, not sending another DELETE request"
+)
+
+// observeOperation polls the long running operation in progress, if any. It reports whether the operation is
+// still running, in which case the resource waits for it rather than being observed. A finished or timed out
+// operation is removed from the status; an operation that can't be polled before the reconcile deadline stays
+// in the status to be polled again on the next reconcile.
+func (c *external) observeOperation(ctx context.Context, cr *v1alpha2.Request) (bool, error) {
+	operation := cr.Status.Operation
+	if operation == nil {
+		return false, nil
+	}
+
+	config := cr.Spec.ForProvider.AsyncOperation
+	if config == nil {
+		return false, c.endOperation(ctx, cr, nil)
+	}
+
+	if timeout := operationTimeout(config); time.Since(operation.StartTime.Time) > timeout {
+		return false, c.endOperation(ctx, cr, errors.Errorf(errOperationTimedOut, operation.URL, timeout))
+	}
+
+	pollCtx, cancel, ok := operationContext(ctx)
+	if !ok {
+		c.logger.Debug(fmt.Sprintf(infoOperationDeadline, operation.URL))
+		return true, nil
+	}
+	defer cancel()
+
+	details, err := c.pollOperation(pollCtx, cr, operation)
+	if err != nil && pollCtx.Err() != nil {
+		c.logger.Debug(fmt.Sprintf(infoOperationDeadline, operation.URL))
+		return true, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, errPollOperation, operation.URL)
+	}
+
+	now := metav1.Now()
+	operation.LastPollTime = &now
+
+	done, err := operationCondition(config.DoneCondition, operation, details)
+	if err != nil {
+		return false, err
+	}
+
+	if !done {
+		c.logger.Debug(fmt.Sprintf(infoOperationNotFinished, operation.URL))
+		return true, nil
+	}
+
+	failed, err := operationCondition(config.FailedCondition, operation, details)
+	if err != nil {
+		return false, err
+	}
+
+	if failed {
+		return false, c.endOperation(ctx, cr, errors.Errorf(errOperationFailed, operation.URL))
+	}
+
+	c.logger.Debug(fmt.Sprintf(infoOperationSucceeded, operation.URL))
+	return false, c.endOperation(ctx, cr, nil)
+}
+
+// pollOperation sends a GET request to the URL of the operation, with the headers of the mapping that started it.
+func (c *external) pollOperation(ctx context.Context, cr *v1alpha2.Request, operation *v1alpha2.OperationStatus) (httpClient.HttpDetails, error) {
+	ctx, err := c.withPreRequest(ctx, cr)
+	if err != nil {
+		return httpClient.HttpDetails{}, err
+	}
+
+	url, err := json.Marshal(operation.URL)
+	if err != nil {
+		return httpClient.HttpDetails{}, err
+	}
+
+	mapping := v1alpha2.Mapping{Method: http.MethodGet, URL: string(url)}
+	if started, ok := getMappingByMethod(&cr.Spec.ForProvider, utils.ActionMethod(cr.Spec.ForProvider, operation.Method)); ok {
+		mapping.Headers = started.Headers
+		mapping.TLSServerName = started.TLSServerName
+	}
+
+	requestDetails, err, _ := requestgen.GenerateRequestDetails(ctx, c.localKube, mapping, cr.Spec.ForProvider, cr.Status.Response)
+	if err != nil {
+		return httpClient.HttpDetails{}, err
+	}
+
+	return c.http.SendRequest(mappingContext(ctx, &mapping), http.MethodGet, requestDetails.Url, requestDetails.Body, requestDetails.Headers, skipTLSVerify(cr, &mapping))
+}
+
+// endOperation removes the operation from the status, recording the error it ended with, if any. The status is
+// updated right away, since the observation that follows starts from the latest version of the resource.
+func (c *external) endOperation(ctx context.Context, cr *v1alpha2.Request, err error) error {
+	cr.Status.Operation = nil
+	if err != nil {
+		cr.Status.Error = err.Error()
+	}
+
+	return errors.Wrap(c.localKube.Status().Update(ctx, cr), errRecordOperationEnd)
+}
+
+// operationCondition evaluates a condition of the operation against the response of its URL. An empty condition
+// is never met.
+func operationCondition(condition string, operation *v1alpha2.OperationStatus, details httpClient.HttpDetails) (bool, error) {
+	if condition == "" {
+		return false, nil
+	}
+
+	responseMap, err := json_util.ResponseToMap(details.HttpResponse, "")
+	if err != nil {
+		return false, errors.Wrap(err, errConvertResponse)
+	}
+
+	met, err := jq.ParseBool(condition, responseMap)
+	if err != nil {
+		return false, errors.Errorf(errOperationCondition, operation.URL, err.Error())
+	}
+
+	return met, nil
+}
+
+// operationContext returns the context operations are polled with, which ends before the reconcile deadline by
+// a margin. It returns false if the deadline is too close to poll at all.
+func operationContext(ctx context.Context) (context.Context, context.CancelFunc, bool) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return ctx, func() {}, true
+	}
+
+	remaining := time.Until(deadline) - operationDeadlineMargin
+	if remaining <= 0 {
+		return nil, nil, false
+	}
+
+	pollCtx, cancel := context.WithTimeout(ctx, remaining)
+	return pollCtx, cancel, true
+}
+
+// deleteInProgress reports whether a DELETE request already started an operation that is still running, in
+// which case no other DELETE request is sent.
+func deleteInProgress(cr *v1alpha2.Request) bool {
+	return cr.Status.Operation != nil && utils.ActionMethod(cr.Spec.ForProvider, cr.Status.Operation.Method) == http.MethodDelete
+}
+
+// operationInProgress returns a condition indicating the resource waits for a long running operation.
+func operationInProgress(operation *v1alpha2.OperationStatus) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonOperationInProgress,
+		Message:            fmt.Sprintf(msgOperationInProgress, operation.URL, time.Since(operation.StartTime.Time).Round(time.Second)),
+	}
+}
+
+func operationTimeout(config *v1alpha2.AsyncOperation) time.Duration {
+	if config.Timeout != nil {
+		return config.Timeout.Duration
+	}
+	return defaultOperationTimeout
+}
+
+// operationPollInterval requeues a resource waiting for a long running operation after the poll interval of
+// the operation, rather than after a full poll interval.
+func operationPollInterval(mg resource.Managed, pollInterval time.Duration) time.Duration {
+	cr, ok := mg.(*v1alpha2.Request)
+	if !ok || cr.Status.Operation == nil || cr.Spec.ForProvider.AsyncOperation == nil {
+		return pollInterval
+	}
+
+	interval := defaultOperationPollInterval
+	if config := cr.Spec.ForProvider.AsyncOperation; config.PollInterval != nil {
+		interval = config.PollInterval.Duration
+	}
+
+	if interval < pollInterval {
+		return interval
+	}
+	return pollInterval
+}
@@ -0,0 +1,159 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+const testOperationURL = "https://api.example.com/operations/1"
+
+func withOperation(startedAgo time.Duration) httpRequestModifier {
+	return func(r *v1alpha2.Request) {
+		r.Spec.ForProvider.AsyncOperation = &v1alpha2.AsyncOperation{
+			URL:             ".headers.Location[0]",
+			DoneCondition:   `.body.status == "succeeded" or .body.status == "failed"`,
+			FailedCondition: `.body.status == "failed"`,
+		}
+		r.Status.Operation = &v1alpha2.OperationStatus{
+			URL:       testOperationURL,
+			Method:    http.MethodPost,
+			StartTime: metav1.NewTime(time.Now().Add(-startedAgo)),
+		}
+	}
+}
+
+func Test_observeOperation(t *testing.T) {
+	type args struct {
+		mg       *v1alpha2.Request
+		status   string
+		deadline time.Duration
+	}
+	type want struct {
+		inProgress bool
+		requests   int
+		operation  bool
+		statusErr  string
+		err        error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoOperation": {
+			args: args{
+				mg: httpRequest(),
+			},
+			want: want{},
+		},
+		"StillRunning": {
+			args: args{
+				mg:     httpRequest(withOperation(time.Minute)),
+				status: "running",
+			},
+			want: want{
+				inProgress: true,
+				requests:   1,
+				operation:  true,
+			},
+		},
+		"Succeeded": {
+			args: args{
+				mg:     httpRequest(withOperation(time.Minute)),
+				status: "succeeded",
+			},
+			want: want{
+				requests: 1,
+			},
+		},
+		"Failed": {
+			args: args{
+				mg:     httpRequest(withOperation(time.Minute)),
+				status: "failed",
+			},
+			want: want{
+				requests:  1,
+				statusErr: errors.Errorf(errOperationFailed, testOperationURL).Error(),
+			},
+		},
+		"TimedOut": {
+			args: args{
+				mg: httpRequest(withOperation(time.Hour)),
+			},
+			want: want{
+				statusErr: errors.Errorf(errOperationTimedOut, testOperationURL, defaultOperationTimeout).Error(),
+			},
+		},
+		"ReconcileDeadlineApproaching": {
+			args: args{
+				mg:       httpRequest(withOperation(time.Minute)),
+				deadline: time.Second,
+			},
+			want: want{
+				inProgress: true,
+				operation:  true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			requests := 0
+			e := &external{
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						requests++
+						if method != http.MethodGet || url != testOperationURL {
+							t.Errorf("unexpected %s request to %s", method, url)
+						}
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								StatusCode: http.StatusOK,
+								Body:       `{"status": "` + tc.args.status + `"}`,
+							},
+						}, nil
+					},
+				},
+			}
+
+			ctx := context.Background()
+			if tc.args.deadline != 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.args.deadline)
+				defer cancel()
+			}
+
+			got, gotErr := e.observeOperation(ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("observeOperation(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.inProgress, got); diff != "" {
+				t.Errorf("observeOperation(...): -want in progress, +got in progress: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.requests, requests); diff != "" {
+				t.Errorf("observeOperation(...): -want requests, +got requests: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.operation, tc.args.mg.Status.Operation != nil); diff != "" {
+				t.Errorf("observeOperation(...): -want operation, +got operation: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.statusErr, tc.args.mg.Status.Error); diff != "" {
+				t.Errorf("observeOperation(...): -want status error, +got status error: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package request
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	defaultMaxPages = 10
+
+	errFetchPage      = "cannot fetch page %d of the response from %s"
+	errPageStatusCode = "page %d of the response from %s returned status code %d"
+	errPageItems      = "cannot read the items of page %d of the response"
+	errNextPageURL    = "pagination: JQ filter should return the URL of the next page, but returned error: %s"
+	errAggregatePages = "cannot aggregate the pages of the response"
+	infoMaxPages      = "stopped following the pages of the response after %d pages"
+)
+
+// aggregatePages follows the next pages of a successful response to the given mapping, and returns the response
+// with the items of every page in place of the ones of the first page. Pagination stops at the first page with
+// no next URL, at a URL already fetched, or after the maximum number of pages.
+func (c *external) aggregatePages(ctx context.Context, cr *v1alpha2.Request, mapping *v1alpha2.Mapping, requestDetails requestgen.RequestDetails, details httpClient.HttpDetails) (httpClient.HttpDetails, error) {
+	pagination := mapping.Pagination
+	path, err := json_util.ParseFieldPath(pagination.ItemsPath)
+	if err != nil {
+		return details, err
+	}
+
+	first, items, err := pageItems(details.HttpResponse.Body, path)
+	if err != nil {
+		return details, errors.Wrapf(err, errPageItems, 1)
+	}
+
+	fetched := map[string]bool{details.HttpRequest.URL: true}
+	page := details
+	for pages := 1; ; pages++ {
+		next, err := nextPageURL(pagination.NextURL, page)
+		if err != nil {
+			return details, err
+		}
+		if next == "" || fetched[next] {
+			break
+		}
+		if pages == maxPages(pagination) {
+			c.logger.Debug(fmt.Sprintf(infoMaxPages, pages))
+			break
+		}
+		fetched[next] = true
+
+		page, err = c.http.SendRequest(mappingContext(ctx, mapping), mapping.Method, next, requestDetails.Body, requestDetails.Headers, skipTLSVerify(cr, mapping))
+		if err != nil {
+			return details, errors.Wrapf(err, errFetchPage, pages+1, next)
+		}
+		if !utils.IsHTTPSuccess(page.HttpResponse.StatusCode) {
+			return details, errors.Errorf(errPageStatusCode, pages+1, next, page.HttpResponse.StatusCode)
+		}
+		if err := decryptResponseBody(ctx, c.localKube, mapping, &page, nil); err != nil {
+			return details, err
+		}
+
+		_, pageItems, err := pageItems(page.HttpResponse.Body, path)
+		if err != nil {
+			return details, errors.Wrapf(err, errPageItems, pages+1)
+		}
+		items = append(items, pageItems...)
+	}
+
+	aggregated, err := json_util.WithItems(first, path, items)
+	if err != nil {
+		return details, errors.Wrap(err, errAggregatePages)
+	}
+
+	body, err := json.Marshal(aggregated)
+	if err != nil {
+		return details, errors.Wrap(err, errAggregatePages)
+	}
+
+	details.HttpResponse.Body = string(body)
+	return details, nil
+}
+
+// pageItems decodes the body of a page and returns it along with its items.
+func pageItems(body string, path json_util.FieldPath) (interface{}, []interface{}, error) {
+	var document interface{}
+	if err := json.Unmarshal([]byte(body), &document); err != nil {
+		return nil, nil, err
+	}
+
+	items, err := json_util.ItemsAt(document, path)
+	return document, items, err
+}
+
+// nextPageURL evaluates the next URL expression against a page, and resolves the URL it returns against the URL
+// of the page. It returns an empty string when the expression returns null, false or an empty string.
+func nextPageURL(expression string, page httpClient.HttpDetails) (string, error) {
+	responseMap, err := json_util.ResponseToMap(page.HttpResponse, "")
+	if err != nil {
+		return "", errors.Wrap(err, errConvertResponse)
+	}
+
+	next, err := jq.ParseString(fmt.Sprintf(`(%s) // ""`, expression), responseMap)
+	if err != nil {
+		return "", errors.Errorf(errNextPageURL, err.Error())
+	}
+	if next == "" {
+		return "", nil
+	}
+
+	base, err := neturl.Parse(page.HttpRequest.URL)
+	if err != nil {
+		return next, nil
+	}
+	ref, err := neturl.Parse(next)
+	if err != nil {
+		return next, nil
+	}
+	return base.ResolveReference(ref).String(), nil
+}
+
+// storedResponse returns the response to store in the status of the Request, with the aggregated items capped
+// to the maxStoredItems of the pagination of its GET mapping, and records whether any were left out. The response
+// is stored as is when no cap applies or its items can't be read.
+func storedResponse(cr *v1alpha2.Request, details httpClient.HttpDetails) httpClient.HttpDetails {
+	cr.Status.ResponseTruncated = false
+
+	mapping, ok := getMappingByMethod(&cr.Spec.ForProvider, http.MethodGet)
+	if !ok || mapping.Pagination == nil || mapping.Pagination.MaxStoredItems == nil {
+		return details
+	}
+	limit := int(*mapping.Pagination.MaxStoredItems)
+
+	path, err := json_util.ParseFieldPath(mapping.Pagination.ItemsPath)
+	if err != nil {
+		return details
+	}
+
+	document, items, err := pageItems(details.HttpResponse.Body, path)
+	if err != nil || len(items) <= limit {
+		return details
+	}
+
+	truncated, err := json_util.WithItems(document, path, items[:limit])
+	if err != nil {
+		return details
+	}
+
+	body, err := json.Marshal(truncated)
+	if err != nil {
+		return details
+	}
+
+	details.HttpResponse.Body = string(body)
+	cr.Status.ResponseTruncated = true
+	return details
+}
+
+func maxPages(pagination *v1alpha2.Pagination) int {
+	if pagination.MaxPages != nil {
+		return int(*pagination.MaxPages)
+	}
+	return defaultMaxPages
+}
@@ -0,0 +1,182 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func withPaginatedGet(r *v1alpha2.Request) {
+	r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{testPostMapping, {
+		Method: http.MethodGet,
+		URL:    ".payload.baseUrl",
+		Pagination: &v1alpha2.Pagination{
+			NextURL:   ".body.next",
+			ItemsPath: ".items",
+		},
+	}}
+	r.Spec.ForProvider.SecretInjectionConfigs = []v1alpha2.SecretInjectionConfig{{
+		SecretRef:    v1alpha2.SecretRef{Name: "tokens", Namespace: testNamespace},
+		SecretKey:    "tokens",
+		ResponsePath: `.body.items | map(.token) | join(",")`,
+	}}
+	r.Status.Response.Body = `{"id":"123"}`
+}
+
+func Test_aggregatePages(t *testing.T) {
+	pages := map[string]string{
+		"https://api.example.com/users":        `{"items": [{"token": "a"}, {"token": "b"}], "next": "/users?page=2"}`,
+		"https://api.example.com/users?page=2": `{"items": [{"token": "c"}], "next": null}`,
+	}
+
+	type args struct {
+		pages map[string]string
+	}
+	type want struct {
+		body    string
+		secret  string
+		fetched []string
+		err     error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"TwoPagesAggregatedIntoTheSecret": {
+			args: args{
+				pages: pages,
+			},
+			want: want{
+				body:    `{"items":[{"token":"a"},{"token":"b"},{"token":"c"}],"next":"/users?page=2"}`,
+				secret:  "a,b,c",
+				fetched: []string{"https://api.example.com/users", "https://api.example.com/users?page=2"},
+			},
+		},
+		"FailedPage": {
+			args: args{
+				pages: map[string]string{
+					"https://api.example.com/users": pages["https://api.example.com/users"],
+				},
+			},
+			want: want{
+				fetched: []string{"https://api.example.com/users", "https://api.example.com/users?page=2"},
+				err:     errors.Errorf(errPageStatusCode, 2, "https://api.example.com/users?page=2", http.StatusNotFound),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var fetched []string
+			var secret string
+			e := &external{
+				localKube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
+					MockUpdate: func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+						if s, ok := obj.(*corev1.Secret); ok {
+							secret = string(s.Data["tokens"])
+						}
+						return nil
+					},
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						fetched = append(fetched, url)
+						page, ok := tc.args.pages[url]
+						if !ok {
+							return httpClient.HttpDetails{
+								HttpRequest:  httpClient.HttpRequest{Method: method, URL: url},
+								HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusNotFound},
+							}, nil
+						}
+						return httpClient.HttpDetails{
+							HttpRequest:  httpClient.HttpRequest{Method: method, URL: url},
+							HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusOK, Body: page},
+						}, nil
+					},
+				},
+			}
+
+			got, gotErr := e.isUpToDate(context.Background(), httpRequest(withPaginatedGet))
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("isUpToDate(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.body, got.Details.HttpResponse.Body); diff != "" {
+				t.Errorf("isUpToDate(...): -want body, +got body: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.secret, secret); diff != "" {
+				t.Errorf("isUpToDate(...): -want secret, +got secret: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.fetched, fetched); diff != "" {
+				t.Errorf("isUpToDate(...): -want fetched pages, +got fetched pages: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_storedResponse(t *testing.T) {
+	aggregated := `{"items":[{"id":"a"},{"id":"b"},{"id":"c"}],"total":3}`
+	two, five := int32(2), int32(5)
+
+	type args struct {
+		maxStoredItems *int32
+		body           string
+	}
+	type want struct {
+		body      string
+		truncated bool
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldStoreEveryItemWithoutCap": {
+			args: args{body: aggregated},
+			want: want{body: aggregated},
+		},
+		"ShouldTruncateItemsAboveCap": {
+			args: args{maxStoredItems: &two, body: aggregated},
+			want: want{body: `{"items":[{"id":"a"},{"id":"b"}],"total":3}`, truncated: true},
+		},
+		"ShouldKeepItemsBelowCap": {
+			args: args{maxStoredItems: &five, body: aggregated},
+			want: want{body: aggregated},
+		},
+		"ShouldKeepBodyWithoutItems": {
+			args: args{maxStoredItems: &two, body: `{"error":"not found"}`},
+			want: want{body: `{"error":"not found"}`},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			cr := httpRequest(withPaginatedGet)
+			cr.Spec.ForProvider.Mappings[1].Pagination.MaxStoredItems = tc.args.maxStoredItems
+			// A truncation recorded by a previous observation is cleared.
+			cr.Status.ResponseTruncated = true
+
+			got := storedResponse(cr, httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusOK, Body: tc.args.body}})
+			if diff := cmp.Diff(tc.want.body, got.HttpResponse.Body); diff != "" {
+				t.Errorf("storedResponse(...): -want body, +got body: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.truncated, cr.Status.ResponseTruncated); diff != "" {
+				t.Errorf("storedResponse(...): -want responseTruncated, +got responseTruncated: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package request
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+	kubehandler "github.com/crossplane-contrib/provider-http/internal/kube-handler"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errEncryptionKey         = "cannot get the encryption key from secret %s/%s"
+	errEncryptionKeyNotFound = "key %s not found in secret %s/%s"
+	errEncryptionKeyFormat   = "the encryption key should be the base64 encoding of a 128, 192 or 256-bit key"
+	errEncryptionScheme      = "unsupported encryption scheme %s"
+	errEncryptBody           = "cannot encrypt the request body"
+	errDecryptBody           = "cannot decrypt the response body"
+	errCiphertextTooShort    = "ciphertext is shorter than its nonce"
+	errDecryptBodyWarning    = "Warning, couldn't decrypt the response, keeping it as is, error: %s"
+)
+
+// encryptRequestBody encrypts the body sent for the mapping with the scheme and key of its encryption. The body
+// recorded in the status is kept in plain text.
+func encryptRequestBody(ctx context.Context, localKube client.Client, mapping *v1alpha2.Mapping, requestDetails *requestgen.RequestDetails) error {
+	encryption := mapping.Encryption
+	body, _ := requestDetails.Body.Decrypted.(string)
+	if encryption == nil || body == "" {
+		return nil
+	}
+
+	aead, err := payloadCipher(ctx, localKube, encryption)
+	if err != nil {
+		return errors.Wrap(err, errEncryptBody)
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return errors.Wrap(err, errEncryptBody)
+	}
+
+	requestDetails.Body.Decrypted = base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, []byte(body), nil))
+	return nil
+}
+
+// decryptResponseBody decrypts the body of a successful response to the mapping, when its encryption asks for
+// it. Failed and empty responses are left as they are.
+func decryptResponseBody(ctx context.Context, localKube client.Client, mapping *v1alpha2.Mapping, details *httpClient.HttpDetails, responseErr error) error {
+	encryption := mapping.Encryption
+	if encryption == nil || !encryption.DecryptResponse || responseErr != nil || details.HttpResponse.Body == "" || !utils.IsHTTPSuccess(details.HttpResponse.StatusCode) {
+		return nil
+	}
+
+	aead, err := payloadCipher(ctx, localKube, encryption)
+	if err != nil {
+		return errors.Wrap(err, errDecryptBody)
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(details.HttpResponse.Body)
+	if err != nil {
+		return errors.Wrap(err, errDecryptBody)
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return errors.Wrap(errors.New(errCiphertextTooShort), errDecryptBody)
+	}
+
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return errors.Wrap(err, errDecryptBody)
+	}
+
+	details.HttpResponse.Body = string(plaintext)
+	return nil
+}
+
+// decryptSentResponse decrypts the response of a request that changed the object. The request was sent either
+// way, so a failure to decrypt its response is only logged and the response is stored as is.
+func (c *external) decryptSentResponse(ctx context.Context, mapping *v1alpha2.Mapping, details *httpClient.HttpDetails, responseErr error) {
+	if err := decryptResponseBody(ctx, c.localKube, mapping, details, responseErr); err != nil {
+		c.logger.Info(fmt.Sprintf(errDecryptBodyWarning, err.Error()))
+	}
+}
+
+// payloadCipher returns the cipher of the scheme of the encryption, keyed with the key held by its secret.
+func payloadCipher(ctx context.Context, localKube client.Client, encryption *v1alpha2.PayloadEncryption) (cipher.AEAD, error) {
+	if encryption.Scheme != v1alpha2.EncryptionSchemeAESGCM {
+		return nil, errors.Errorf(errEncryptionScheme, encryption.Scheme)
+	}
+
+	ref := encryption.SecretRef
+	secret, err := kubehandler.GetSecret(ctx, localKube, ref.Name, ref.Namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, errEncryptionKey, ref.Namespace, ref.Name)
+	}
+
+	encoded, ok := secret.Data[encryption.SecretKey]
+	if !ok {
+		return nil, errors.Errorf(errEncryptionKeyNotFound, encryption.SecretKey, ref.Namespace, ref.Name)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+	if err != nil {
+		return nil, errors.New(errEncryptionKeyFormat)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.New(errEncryptionKeyFormat)
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,173 @@
+package request
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+)
+
+var testEncryptionKey = []byte("0123456789abcdef0123456789abcdef")
+
+func testEncryptionKube(key string) client.Client {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			obj.(*corev1.Secret).Data = map[string][]byte{"key": []byte(key)}
+			return nil
+		},
+	}
+}
+
+func testEncryptedMapping(decryptResponse bool) *v1alpha2.Mapping {
+	return &v1alpha2.Mapping{
+		Method: http.MethodPost,
+		Encryption: &v1alpha2.PayloadEncryption{
+			Scheme:          v1alpha2.EncryptionSchemeAESGCM,
+			SecretRef:       v1alpha2.SecretRef{Name: "payload-key", Namespace: testNamespace},
+			SecretKey:       "key",
+			DecryptResponse: decryptResponse,
+		},
+	}
+}
+
+func testGCM(t *testing.T) cipher.AEAD {
+	block, err := aes.NewCipher(testEncryptionKey)
+	if err != nil {
+		t.Fatalf("NewCipher(...): unexpected error: %s", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatalf("NewGCM(...): unexpected error: %s", err)
+	}
+	return aead
+}
+
+func Test_payloadEncryption_AESGCM(t *testing.T) {
+	aead := testGCM(t)
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		sealed, err := base64.StdEncoding.DecodeString(string(body))
+		if err != nil || len(sealed) < aead.NonceSize() {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = string(plaintext)
+
+		nonce := make([]byte, aead.NonceSize())
+		_, _ = w.Write([]byte(base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, []byte(`{"id":"123"}`), nil))))
+	}))
+	defer server.Close()
+
+	kube := testEncryptionKube(base64.StdEncoding.EncodeToString(testEncryptionKey) + "\n")
+	mapping := testEncryptedMapping(true)
+	headers := map[string][]string{}
+	requestDetails := requestgen.RequestDetails{
+		Url:     server.URL,
+		Body:    httpClient.Data{Encrypted: `{"name":"john"}`, Decrypted: `{"name":"john"}`},
+		Headers: httpClient.Data{Encrypted: headers, Decrypted: headers},
+	}
+
+	if err := encryptRequestBody(context.Background(), kube, mapping, &requestDetails); err != nil {
+		t.Fatalf("encryptRequestBody(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(`{"name":"john"}`, requestDetails.Body.Encrypted); diff != "" {
+		t.Errorf("encryptRequestBody(...): -want recorded body, +got recorded body: %s", diff)
+	}
+
+	c, err := httpClient.NewClient(logging.NewNopLogger(), 5*time.Second)
+	if err != nil {
+		t.Fatalf("NewClient(...): unexpected error: %s", err)
+	}
+	details, err := c.SendRequest(context.Background(), http.MethodPost, requestDetails.Url, requestDetails.Body, requestDetails.Headers, false)
+	if err != nil {
+		t.Fatalf("SendRequest(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(`{"name":"john"}`, received); diff != "" {
+		t.Errorf("SendRequest(...): -want decrypted request body, +got decrypted request body: %s", diff)
+	}
+
+	if err := decryptResponseBody(context.Background(), kube, mapping, &details, nil); err != nil {
+		t.Fatalf("decryptResponseBody(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(`{"id":"123"}`, details.HttpResponse.Body); diff != "" {
+		t.Errorf("decryptResponseBody(...): -want response body, +got response body: %s", diff)
+	}
+}
+
+func Test_decryptResponseBody(t *testing.T) {
+	aead := testGCM(t)
+	nonce := make([]byte, aead.NonceSize())
+	sealed := base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, []byte(`{"id":"123"}`), nil))
+	key := base64.StdEncoding.EncodeToString(testEncryptionKey)
+
+	type args struct {
+		key      string
+		mapping  *v1alpha2.Mapping
+		response httpClient.HttpResponse
+	}
+	type want struct {
+		body string
+		err  bool
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldDecryptSuccessfulResponse": {
+			args: args{key: key, mapping: testEncryptedMapping(true), response: httpClient.HttpResponse{StatusCode: http.StatusOK, Body: sealed}},
+			want: want{body: `{"id":"123"}`},
+		},
+		"ShouldKeepResponseWhenDecryptionIsOff": {
+			args: args{key: key, mapping: testEncryptedMapping(false), response: httpClient.HttpResponse{StatusCode: http.StatusOK, Body: sealed}},
+			want: want{body: sealed},
+		},
+		"ShouldKeepFailedResponse": {
+			args: args{key: key, mapping: testEncryptedMapping(true), response: httpClient.HttpResponse{StatusCode: http.StatusBadRequest, Body: `{"error":"bad key"}`}},
+			want: want{body: `{"error":"bad key"}`},
+		},
+		"ShouldFailWithWrongKey": {
+			args: args{key: base64.StdEncoding.EncodeToString([]byte("fedcba9876543210fedcba9876543210")), mapping: testEncryptedMapping(true), response: httpClient.HttpResponse{StatusCode: http.StatusOK, Body: sealed}},
+			want: want{body: sealed, err: true},
+		},
+		"ShouldFailWithInvalidKeySize": {
+			args: args{key: base64.StdEncoding.EncodeToString([]byte("short")), mapping: testEncryptedMapping(true), response: httpClient.HttpResponse{StatusCode: http.StatusOK, Body: sealed}},
+			want: want{body: sealed, err: true},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			details := httpClient.HttpDetails{HttpResponse: tc.args.response}
+			err := decryptResponseBody(context.Background(), testEncryptionKube(tc.args.key), tc.args.mapping, &details, nil)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("decryptResponseBody(...): -want error, +got error: %s (error: %v)", diff, err)
+			}
+			if diff := cmp.Diff(tc.want.body, details.HttpResponse.Body); diff != "" {
+				t.Errorf("decryptResponseBody(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
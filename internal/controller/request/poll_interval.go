@@ -0,0 +1,20 @@
+package request
+
+import (
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+// resourcePollInterval requeues a resource after its own poll interval, in place of the poll interval of the
+// provider. A non-positive interval, which would never requeue the resource, is ignored.
+func resourcePollInterval(mg resource.Managed, pollInterval time.Duration) time.Duration {
+	cr, ok := mg.(*v1alpha2.Request)
+	if !ok || cr.Spec.ForProvider.PollInterval == nil || cr.Spec.ForProvider.PollInterval.Duration <= 0 {
+		return pollInterval
+	}
+
+	return cr.Spec.ForProvider.PollInterval.Duration
+}
@@ -0,0 +1,70 @@
+package request
+
+import (
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
+	requestv1alpha2 "github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+func Test_resourcePollInterval(t *testing.T) {
+	withPollInterval := func(d time.Duration) httpRequestModifier {
+		return func(r *requestv1alpha2.Request) {
+			r.Spec.ForProvider.PollInterval = &metav1.Duration{Duration: d}
+		}
+	}
+
+	cases := map[string]struct {
+		mg   resource.Managed
+		want time.Duration
+	}{
+		"ProviderPollIntervalByDefault": {
+			mg:   httpRequest(),
+			want: time.Minute,
+		},
+		"FasterResourcePollInterval": {
+			mg:   httpRequest(withPollInterval(5 * time.Second)),
+			want: 5 * time.Second,
+		},
+		"SlowerResourcePollInterval": {
+			mg:   httpRequest(withPollInterval(time.Hour)),
+			want: time.Hour,
+		},
+		"NonPositiveResourcePollIntervalIgnored": {
+			mg:   httpRequest(withPollInterval(0)),
+			want: time.Minute,
+		},
+		"OtherKindsUnchanged": {
+			mg:   &v1alpha2.DisposableRequest{},
+			want: time.Minute,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got := resourcePollInterval(tc.mg, time.Minute)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("resourcePollInterval(...): -want poll interval, +got poll interval: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_pollIntervalFromResponse_OverridesResourcePollInterval(t *testing.T) {
+	mg := httpRequest(func(r *requestv1alpha2.Request) {
+		r.Spec.ForProvider.PollInterval = &metav1.Duration{Duration: 5 * time.Second}
+		r.Spec.ForProvider.AdaptivePolling = &requestv1alpha2.AdaptivePolling{Interval: ".body.status"}
+		r.Status.PollInterval = &metav1.Duration{Duration: 10 * time.Minute}
+	})
+
+	got := pollIntervalFromResponse(mg, resourcePollInterval(mg, time.Minute))
+	if diff := cmp.Diff(10*time.Minute, got); diff != "" {
+		t.Errorf("pollIntervalFromResponse(...): -want poll interval, +got poll interval: %s", diff)
+	}
+}
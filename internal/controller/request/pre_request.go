@@ -0,0 +1,42 @@
+package request
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errPreRequest           = "pre-request failed"
+	errPreRequestStatusCode = "pre-request failed with status code %d"
+	errPreRequestDetails    = "cannot generate the pre-request"
+)
+
+// withPreRequest sends the pre-request of the Request, if any, and returns a context whose generated requests
+// expose its response under .preRequest.
+func (c *external) withPreRequest(ctx context.Context, cr *v1alpha2.Request) (context.Context, error) {
+	preRequest := cr.Spec.ForProvider.PreRequest
+	if preRequest == nil {
+		return ctx, nil
+	}
+
+	requestDetails, err, _ := requestgen.GenerateRequestDetails(ctx, c.localKube, *preRequest, cr.Spec.ForProvider, cr.Status.Response)
+	if err != nil {
+		return ctx, errors.Wrap(err, errPreRequestDetails)
+	}
+
+	details, err := c.http.SendRequest(mappingContext(ctx, preRequest), preRequest.Method, requestDetails.Url, requestDetails.Body, requestDetails.Headers, skipTLSVerify(cr, preRequest))
+	if err != nil {
+		return ctx, errors.Wrap(err, errPreRequest)
+	}
+
+	if !utils.IsHTTPSuccess(details.HttpResponse.StatusCode) {
+		return ctx, errors.Errorf(errPreRequestStatusCode, details.HttpResponse.StatusCode)
+	}
+
+	return requestgen.WithPreRequestResponse(ctx, details.HttpResponse), nil
+}
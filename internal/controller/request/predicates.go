@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package request
+
+import (
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/debugartifact"
+)
+
+const (
+	// AnnotationKeyResyncToken is the annotation used to force an immediate
+	// reconcile of a Request. Like any annotation change, a change to its value
+	// enqueues the resource through desiredStateChanged.
+	AnnotationKeyResyncToken = "http.crossplane.io/resync-token"
+)
+
+// desiredStateChanged is like resource.DesiredStateChanged, but also ignores
+// changes to the debug artifact annotation, which the controller writes itself.
+func desiredStateChanged() predicate.Predicate {
+	return predicate.Or(
+		annotationsChanged(
+			meta.AnnotationKeyExternalCreateFailed,
+			meta.AnnotationKeyExternalCreatePending,
+			debugartifact.AnnotationKey,
+		),
+		predicate.LabelChangedPredicate{},
+		predicate.GenerationChangedPredicate{},
+	)
+}
+
+// annotationsChanged returns a predicate that accepts update events in which
+// any annotation other than the ignored ones changed.
+func annotationsChanged(ignored ...string) predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return false
+			}
+
+			return !equalIgnoring(e.ObjectOld.GetAnnotations(), e.ObjectNew.GetAnnotations(), ignored)
+		},
+	}
+}
+
+func equalIgnoring(a, b map[string]string, ignored []string) bool {
+	filtered := func(m map[string]string) map[string]string {
+		out := make(map[string]string, len(m))
+		for k, v := range m {
+			out[k] = v
+		}
+		for _, k := range ignored {
+			delete(out, k)
+		}
+		return out
+	}
+
+	fa, fb := filtered(a), filtered(b)
+	if len(fa) != len(fb) {
+		return false
+	}
+	for k, v := range fa {
+		if bv, ok := fb[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/debugartifact"
+)
+
+func requestWithAnnotations(annotations map[string]string) *v1alpha2.Request {
+	return &v1alpha2.Request{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        testRequestName,
+			Annotations: annotations,
+		},
+	}
+}
+
+func Test_desiredStateChanged(t *testing.T) {
+	type args struct {
+		event event.UpdateEvent
+	}
+	type want struct {
+		result bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"AnnotationChanged": {
+			args: args{
+				event: event.UpdateEvent{
+					ObjectOld: requestWithAnnotations(map[string]string{"other": "a"}),
+					ObjectNew: requestWithAnnotations(map[string]string{"other": "b"}),
+				},
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"ResyncTokenChanged": {
+			args: args{
+				event: event.UpdateEvent{
+					ObjectOld: requestWithAnnotations(map[string]string{AnnotationKeyResyncToken: "1"}),
+					ObjectNew: requestWithAnnotations(map[string]string{AnnotationKeyResyncToken: "2"}),
+				},
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"OnlyDebugArtifactChanged": {
+			args: args{
+				event: event.UpdateEvent{
+					ObjectOld: requestWithAnnotations(map[string]string{"other": "a"}),
+					ObjectNew: requestWithAnnotations(map[string]string{"other": "a", debugartifact.AnnotationKey: "{}"}),
+				},
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"NothingChanged": {
+			args: args{
+				event: event.UpdateEvent{
+					ObjectOld: requestWithAnnotations(map[string]string{"other": "a"}),
+					ObjectNew: requestWithAnnotations(map[string]string{"other": "a"}),
+				},
+			},
+			want: want{
+				result: false,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := desiredStateChanged().Update(tc.args.event)
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("desiredStateChanged().Update(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
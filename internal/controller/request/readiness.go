@@ -0,0 +1,69 @@
+package request
+
+import (
+	"fmt"
+	"net/http"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	"github.com/crossplane-contrib/provider-http/internal/json"
+)
+
+const (
+	// ReasonReadyConditionUnmet indicates the GET response doesn't satisfy the ready condition of the resource.
+	ReasonReadyConditionUnmet xpv1.ConditionReason = "ReadyConditionUnmet"
+
+	msgReadyConditionUnmet  = "the GET response doesn't satisfy the ready condition %s"
+	errReadyConditionFormat = "ready condition: JQ filter should return a boolean, but returned error: %s"
+)
+
+// readyCondition returns the Ready condition of the Request according to its ready condition evaluated against
+// the GET response. The resource is available when it sets no ready condition.
+func readyCondition(cr *v1alpha2.Request, details httpClient.HttpDetails, responseErr error) (xpv1.Condition, error) {
+	condition := cr.Spec.ForProvider.ReadyCondition
+	if condition == "" {
+		return xpv1.Available(), nil
+	}
+
+	if responseErr != nil {
+		return readyConditionUnmet(condition), nil
+	}
+
+	var responseFormat string
+	if mapping, ok := getMappingByMethod(&cr.Spec.ForProvider, http.MethodGet); ok {
+		responseFormat = string(mapping.ResponseFormat)
+	}
+
+	responseMap, err := json.ResponseToMap(details.HttpResponse, responseFormat)
+	if err != nil {
+		return xpv1.Condition{}, errors.Wrap(err, errConvertResponse)
+	}
+
+	ready, err := jq.ParseBool(condition, responseMap)
+	if err != nil {
+		return xpv1.Condition{}, errors.Errorf(errReadyConditionFormat, err.Error())
+	}
+
+	if !ready {
+		return readyConditionUnmet(condition), nil
+	}
+
+	return xpv1.Available(), nil
+}
+
+// readyConditionUnmet returns a condition indicating the resource exists but isn't operationally ready yet.
+func readyConditionUnmet(condition string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonReadyConditionUnmet,
+		Message:            fmt.Sprintf(msgReadyConditionUnmet, condition),
+	}
+}
@@ -0,0 +1,88 @@
+package request
+
+import (
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_readyCondition(t *testing.T) {
+	withReadyCondition := func(condition string) httpRequestModifier {
+		return func(r *v1alpha2.Request) {
+			r.Spec.ForProvider.ReadyCondition = condition
+		}
+	}
+	response := func(body string) httpClient.HttpDetails {
+		return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: 200, Body: body}}
+	}
+
+	type args struct {
+		cr          *v1alpha2.Request
+		details     httpClient.HttpDetails
+		responseErr error
+	}
+	type want struct {
+		condition xpv1.Condition
+		err       error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"AvailableWithoutReadyCondition": {
+			args: args{
+				cr:      httpRequest(),
+				details: response(`{"state":"provisioning"}`),
+			},
+			want: want{condition: xpv1.Available()},
+		},
+		"Ready": {
+			args: args{
+				cr:      httpRequest(withReadyCondition(`.body.state == "running"`)),
+				details: response(`{"state":"running"}`),
+			},
+			want: want{condition: xpv1.Available()},
+		},
+		"NotReady": {
+			args: args{
+				cr:      httpRequest(withReadyCondition(`.body.state == "running"`)),
+				details: response(`{"state":"provisioning"}`),
+			},
+			want: want{condition: readyConditionUnmet(`.body.state == "running"`)},
+		},
+		"NotReadyWhenRequestFailed": {
+			args: args{
+				cr:          httpRequest(withReadyCondition(`.body.state == "running"`)),
+				responseErr: errBoom,
+			},
+			want: want{condition: readyConditionUnmet(`.body.state == "running"`)},
+		},
+		"ConditionNotBoolean": {
+			args: args{
+				cr:      httpRequest(withReadyCondition(`.body.state`)),
+				details: response(`{"state":"running"}`),
+			},
+			want: want{err: errors.Errorf(errReadyConditionFormat, `failed to parse string: running`)},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := readyCondition(tc.args.cr, tc.args.details, tc.args.responseErr)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("readyCondition(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.condition, got, cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("readyCondition(...): -want condition, +got condition: %s", diff)
+			}
+		})
+	}
+}
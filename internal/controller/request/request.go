@@ -23,12 +23,16 @@ import (
 	"time"
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
-	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
@@ -38,9 +42,14 @@ import (
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
 	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/debugartifact"
 	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
 	"github.com/crossplane-contrib/provider-http/internal/controller/request/statushandler"
 	datapatcher "github.com/crossplane-contrib/provider-http/internal/data-patcher"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/killswitch"
+	"github.com/crossplane-contrib/provider-http/internal/notifications"
+	"github.com/crossplane-contrib/provider-http/internal/requestlog"
 	"github.com/crossplane-contrib/provider-http/internal/utils"
 )
 
@@ -51,17 +60,29 @@ const (
 	errProviderNotRetrieved         = "provider could not be retrieved"
 	errFailedToSendHttpRequest      = "something went wrong"
 	errFailedToCheckIfUpToDate      = "failed to check if request is up to date"
+	errFailedToCheckIfReady         = "failed to check if request is ready"
 	errFailedToUpdateStatusFailures = "failed to reset status failures counter"
 	errFailedUpdateStatusConditions = "failed updating status conditions"
 	errMappingNotFound              = "%s mapping doesn't exist in request, skipping operation"
 	errPatchDataToSecret            = "Warning, couldn't patch data from request to secret %s:%s:%s, error: %s"
 	errGetLatestVersion             = "failed to get the latest version of the resource"
+	errExportDebugArtifact          = "Warning, couldn't export debug artifact, error: %s"
+	errIndexSecretRefs              = "cannot index Requests by referenced secrets"
+	infoNoOpUpdate                  = "desired body matches the last applied body, skipping PUT request"
+	infoRemovalConfirmed            = "object wasn't found while the resource is being deleted, removal confirmed"
+	infoWritesPaused                = "the kill switch is engaged, skipping %s request"
 )
 
 // Setup adds a controller that reconciles Request managed resources.
 func Setup(mgr ctrl.Manager, o controller.Options, timeout time.Duration) error {
 	name := managed.ControllerName(v1alpha2.RequestGroupKind)
 	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
+	responseCache := httpClient.NewResponseCache()
+	if err := httpClient.RegisterCacheMetrics(metrics.Registry, httpClient.CacheResponsesRequest, responseCache.Stats); err != nil {
+		return err
+	}
 
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha2.RequestGroupVersionKind),
@@ -70,19 +91,35 @@ func Setup(mgr ctrl.Manager, o controller.Options, timeout time.Duration) error
 			kube:            mgr.GetClient(),
 			usage:           resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
 			newHttpClientFn: httpClient.NewClient,
+			hostLimiter:     httpClient.NewHostLimiter(),
+			responseCache:   responseCache,
+			recorder:        recorder,
+			killSwitches:    killswitch.NewCache(),
 		}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(func(mg resource.Managed, pollInterval time.Duration) time.Duration {
+			return operationPollInterval(mg, initialDelayPollInterval(mg, pollIntervalFromResponse(mg, resourcePollInterval(mg, pollInterval))))
+		}),
 		managed.WithTimeout(timeout),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithRecorder(recorder),
 		managed.WithConnectionPublishers(cps...))
 
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha2.Request{}, secretRefsIndex, indexSecretRefs); err != nil {
+		return errors.Wrap(err, errIndexSecretRefs)
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
-		WithEventFilter(resource.DesiredStateChanged()).
-		For(&v1alpha2.Request{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		For(&v1alpha2.Request{}, builder.WithPredicates(desiredStateChanged())).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(requestsForSecret(mgr.GetClient()))).
+		Complete(ratelimiter.NewReconciler(name, &serializingReconciler{
+			kube:       mgr.GetClient(),
+			logger:     o.Logger.WithValues("controller", name),
+			locks:      newKeyLocks(),
+			reconciler: r,
+		}, o.GlobalRateLimiter))
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
@@ -91,7 +128,13 @@ type connector struct {
 	logger          logging.Logger
 	kube            client.Client
 	usage           resource.Tracker
-	newHttpClientFn func(log logging.Logger, timeout time.Duration) (httpClient.Client, error)
+	newHttpClientFn func(log logging.Logger, timeout time.Duration, opts ...httpClient.ClientOption) (httpClient.Client, error)
+	hostLimiter     *httpClient.HostLimiter
+	responseCache   *httpClient.ResponseCache
+	// recorder records the entries of the request logs writing to events.
+	recorder event.Recorder
+	// killSwitches caches the state of the kill switches of the ProviderConfigs across reconciles.
+	killSwitches *killswitch.Cache
 }
 
 // Connect typically produces an ExternalClient by:
@@ -111,30 +154,61 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errTrackPCUsage)
 	}
 
-	pc := &apisv1alpha1.ProviderConfig{}
-	n := types.NamespacedName{Name: cr.GetProviderConfigReference().Name}
-	if err := c.kube.Get(ctx, n, pc); err != nil {
+	pc, err := utils.ResolveProviderConfig(ctx, c.kube, cr.GetProviderConfigReference().Name)
+	if err != nil {
 		return nil, errors.Wrap(err, errProviderNotRetrieved)
 	}
 
-	h, err := c.newHttpClientFn(l, utils.WaitTimeout(cr.Spec.ForProvider.WaitTimeout))
+	opts := utils.ClientOptions(pc, c.hostLimiter, c.responseCache)
+	if cr.Spec.ForProvider.CABundle != "" {
+		opts = append(opts, httpClient.WithCABundle(cr.Spec.ForProvider.CABundle))
+	}
+
+	h, err := c.newHttpClientFn(l, utils.WaitTimeout(cr.Spec.ForProvider.WaitTimeout), opts...)
 	if err != nil {
 		return nil, errors.Wrap(err, errNewHttpClient)
 	}
+	h = requestlog.WrapClient(h, requestlog.New(pc.Spec.RequestLog, c.recorder), l, pc.Name, v1alpha2.RequestGroupVersionKind, cr)
+
+	var canary *apisv1alpha1.CanaryRollout
+	inCohort, err := utils.InCanaryCohort(pc.Spec.Canary, cr)
+	if err != nil {
+		return nil, errors.Wrap(err, errCanaryCohort)
+	}
+	if inCohort {
+		l.Debug(infoInCanaryCohort)
+		canary = pc.Spec.Canary
+	}
+
+	notifier, err := notifications.New(ctx, c.kube, l, pc.Spec.Notifications)
+	if err != nil {
+		l.Info(fmt.Sprintf(errNotificationsDisabled, err.Error()))
+	}
 
 	return &external{
-		localKube: c.kube,
-		logger:    l,
-		http:      h,
+		localKube:  c.kube,
+		logger:     l,
+		http:       h,
+		objectRefs: datapatcher.NewObjectRefCache(),
+		canary:     canary,
+		notifier:   notifier,
+		killSwitch: killswitch.New(c.kube, pc.Spec.KillSwitch, c.killSwitches),
 	}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	localKube client.Client
-	logger    logging.Logger
-	http      httpClient.Client
+	localKube  client.Client
+	logger     logging.Logger
+	http       httpClient.Client
+	objectRefs *datapatcher.ObjectRefCache
+	// canary holds the canary rollout of the Request's cohort, if it belongs to one.
+	canary *apisv1alpha1.CanaryRollout
+	// notifier notifies the webhook of the ProviderConfig of the outcome of requests, if it configures one.
+	notifier *notifications.Notifier
+	// killSwitch pauses the writes of the Request, if the ProviderConfig configures one.
+	killSwitch *killswitch.Switch
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -143,6 +217,54 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotRequest)
 	}
 
+	ctx = datapatcher.WithObjectRefCache(ctx, c.objectRefs)
+	ctx = requestgen.WithMetadata(ctx, cr)
+
+	if remaining := initialDelayRemaining(cr, time.Now()); remaining > 0 {
+		// Report the resource as existing and up to date, so nothing is sent until the delay elapses.
+		cr.Status.SetConditions(waitingForInitialDelay(remaining))
+		return managed.ExternalObservation{
+			ResourceExists:   true,
+			ResourceUpToDate: true,
+		}, nil
+	}
+
+	pending, err := c.pendingDependency(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	if pending != "" {
+		// Report the resource as existing and up to date, so nothing is sent until its dependencies are ready.
+		cr.Status.SetConditions(waitingForDependency(pending))
+		return managed.ExternalObservation{
+			ResourceExists:   true,
+			ResourceUpToDate: true,
+		}, nil
+	}
+
+	if cr.Spec.ForProvider.ForEach != nil {
+		return c.observeForEach(ctx, cr)
+	}
+
+	inProgress, err := c.observeOperation(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	if inProgress {
+		// Report the resource as existing and up to date, so nothing is sent until the operation ends.
+		cr.Status.SetConditions(operationInProgress(cr.Status.Operation))
+		return managed.ExternalObservation{
+			ResourceExists:   true,
+			ResourceUpToDate: true,
+		}, nil
+	}
+
+	if driftDetectionDisabled(cr) {
+		return c.observeFromLastWrite(ctx, cr), nil
+	}
+
 	observeRequestDetails, err := c.isUpToDate(ctx, cr)
 	if err != nil && err.Error() == errObjectNotFound {
 		return managed.ExternalObservation{
@@ -159,17 +281,36 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.Wrap(err, errGetLatestVersion)
 	}
 
-	statusHandler, err := statushandler.NewStatusHandler(ctx, cr, observeRequestDetails.Details, observeRequestDetails.ResponseError, c.localKube, c.logger)
+	c.setAdaptivePollInterval(cr, observeRequestDetails.Details, observeRequestDetails.ResponseError)
+	cr.Status.DriftedSubResources = observeRequestDetails.DriftedSubResources
+
+	observed := observeRequestDetails.Details
+	if mapping, ok := getMappingByMethod(&cr.Spec.ForProvider, http.MethodGet); ok {
+		observed = withMappingAction(observed, mapping)
+	}
+
+	statusHandler, err := statushandler.NewStatusHandler(ctx, cr, storedResponse(cr, observed), observeRequestDetails.ResponseError, c.localKube, c.logger)
 	if err != nil {
 		return managed.ExternalObservation{}, err
 	}
 
 	synced := observeRequestDetails.Synced
+	if synced && c.secretsRotated(ctx, cr) {
+		c.logger.Debug(infoSecretsRotated)
+		synced = false
+	}
+
 	if synced {
 		statusHandler.ResetFailures()
+		cr.SetLastAction(string(v1alpha2.RequestActionNoop))
+	}
+
+	ready, err := readyCondition(cr, observeRequestDetails.Details, observeRequestDetails.ResponseError)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errFailedToCheckIfReady)
 	}
 
-	cr.Status.SetConditions(xpv1.Available())
+	cr.Status.SetConditions(ready)
 	err = statusHandler.SetRequestStatus()
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, " failed updating status")
@@ -178,24 +319,82 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	return managed.ExternalObservation{
 		ResourceExists:    true,
 		ResourceUpToDate:  synced,
-		ConnectionDetails: nil,
+		ConnectionDetails: c.connectionDetails(cr),
 	}, nil
 }
 
 func (c *external) deployAction(ctx context.Context, cr *v1alpha2.Request, method string) error {
+	held, err := c.killSwitch.Hold(ctx, cr)
+	if err != nil {
+		return err
+	}
+	if held {
+		// Nothing is sent, the write is attempted again once the resource is next reconciled.
+		c.logger.Debug(fmt.Sprintf(infoWritesPaused, method))
+		return nil
+	}
+
+	if cr.Spec.ForProvider.ForEach != nil {
+		return c.deployForEach(ctx, cr, method)
+	}
+
+	ctx, cancel := utils.DrainOnShutdown(ctx)
+	defer cancel()
+
 	mapping, ok := getMappingByMethod(&cr.Spec.ForProvider, method)
 	if !ok {
 		c.logger.Info(fmt.Sprintf(errMappingNotFound, method))
 		return nil
 	}
 
+	ctx, err = c.withPreRequest(ctx, cr)
+	if err != nil {
+		return err
+	}
+
 	requestDetails, err := generateValidRequestDetails(ctx, c.localKube, cr, mapping)
+	if requestgen.IsBodySchemaError(err) {
+		return c.setErrorStatus(ctx, cr, err)
+	}
 	if err != nil {
 		return err
 	}
 
-	details, err := c.http.SendRequest(ctx, mapping.Method, requestDetails.Url, requestDetails.Body, requestDetails.Headers, cr.Spec.ForProvider.InsecureSkipTLSVerify)
-	c.patchResponseToSecret(ctx, cr, &details.HttpResponse)
+	if method == http.MethodPut && isNoOpUpdate(cr, requestDetails) {
+		c.logger.Debug(infoNoOpUpdate)
+		cr.SetLastAction(string(v1alpha2.RequestActionNoop))
+		return nil
+	}
+
+	if err := encryptRequestBody(ctx, c.localKube, mapping, &requestDetails); err != nil {
+		return err
+	}
+
+	details, err := c.http.SendRequest(mappingContext(ctx, mapping), mapping.Method, requestDetails.Url, requestDetails.Body, requestDetails.Headers, skipTLSVerify(cr, mapping))
+	details = withMappingAction(details, mapping)
+	if httpClient.IsHostSaturated(err) || httpClient.IsTemporaryDNSFailure(err) {
+		// The request was never sent, requeue without recording a failure.
+		return err
+	}
+
+	c.exportDebugArtifact(ctx, cr, details, err)
+	c.decryptSentResponse(ctx, mapping, &details, err)
+
+	if err == nil && utils.IsCreateConflict(cr.Spec.ForProvider, method, details.HttpResponse.StatusCode) {
+		details = c.observeConflict(ctx, cr, details)
+	}
+
+	c.transformSentResponse(cr, mapping, &details, err)
+
+	if method == http.MethodDelete && isRemovalConfirmed(cr, details, err) {
+		c.logger.Debug(infoRemovalConfirmed)
+		if driftDetectionDisabled(cr) {
+			return c.forgetLastWrite(ctx, cr)
+		}
+		return nil
+	}
+
+	c.patchResponseToSecret(ctx, cr, method, &details.HttpResponse)
 
 	statusHandler, err := statushandler.NewStatusHandler(ctx, cr, details, err, c.localKube, c.logger)
 	if err != nil {
@@ -211,7 +410,17 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNotRequest)
 	}
 
-	return managed.ExternalCreation{}, errors.Wrap(c.deployAction(ctx, cr, http.MethodPost), errFailedToSendHttpRequest)
+	ctx = datapatcher.WithObjectRefCache(ctx, c.objectRefs)
+	ctx = requestgen.WithMetadata(ctx, cr)
+
+	method := c.writeMethod(ctx, cr, http.MethodPost)
+	err := c.deployAction(ctx, cr, method)
+	c.notify(cr, method, err)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errFailedToSendHttpRequest)
+	}
+
+	return managed.ExternalCreation{ConnectionDetails: c.connectionDetails(cr)}, nil
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
@@ -220,7 +429,17 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotRequest)
 	}
 
-	return managed.ExternalUpdate{}, errors.Wrap(c.deployAction(ctx, cr, http.MethodPut), errFailedToSendHttpRequest)
+	ctx = datapatcher.WithObjectRefCache(ctx, c.objectRefs)
+	ctx = requestgen.WithMetadata(ctx, cr)
+
+	method := c.writeMethod(ctx, cr, http.MethodPut)
+	err := c.deployAction(ctx, cr, method)
+	c.notify(cr, method, err)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errFailedToSendHttpRequest)
+	}
+
+	return managed.ExternalUpdate{ConnectionDetails: c.connectionDetails(cr)}, nil
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
@@ -229,18 +448,96 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return errors.New(errNotRequest)
 	}
 
-	return errors.Wrap(c.deployAction(ctx, cr, http.MethodDelete), errFailedToSendHttpRequest)
+	if deleteInProgress(cr) {
+		c.logger.Debug(fmt.Sprintf(infoOperationDeleteInProgress, cr.Status.Operation.URL))
+		return nil
+	}
+
+	ctx = datapatcher.WithObjectRefCache(ctx, c.objectRefs)
+	ctx = requestgen.WithMetadata(ctx, cr)
+
+	err := c.deployAction(ctx, cr, http.MethodDelete)
+	c.notify(cr, http.MethodDelete, err)
+
+	return errors.Wrap(err, errFailedToSendHttpRequest)
 }
 
-func (c *external) patchResponseToSecret(ctx context.Context, cr *v1alpha2.Request, response *httpClient.HttpResponse) {
-	for _, ref := range cr.Spec.ForProvider.SecretInjectionConfigs {
-		err := datapatcher.PatchResponseToSecret(ctx, c.localKube, c.logger, response, ref.ResponsePath, ref.SecretKey, ref.SecretRef.Name, ref.SecretRef.Namespace)
+// setErrorStatus records an error that prevented the request from being sent in the resource's status.
+func (c *external) setErrorStatus(ctx context.Context, cr *v1alpha2.Request, err error) error {
+	statusHandler, handlerErr := statushandler.NewStatusHandler(ctx, cr, httpClient.HttpDetails{}, err, c.localKube, c.logger)
+	if handlerErr != nil {
+		return handlerErr
+	}
+
+	return statusHandler.SetRequestStatus()
+}
+
+func (c *external) exportDebugArtifact(ctx context.Context, cr *v1alpha2.Request, details httpClient.HttpDetails, sendErr error) {
+	if err := debugartifact.Export(ctx, c.localKube, cr, details, sendErr); err != nil {
+		c.logger.Info(fmt.Sprintf(errExportDebugArtifact, err.Error()))
+	}
+}
+
+func (c *external) patchResponseToSecret(ctx context.Context, cr *v1alpha2.Request, method string, response *httpClient.HttpResponse) {
+	var responseFormat v1alpha2.ResponseFormat
+	if mapping, ok := getMappingByMethod(&cr.Spec.ForProvider, method); ok {
+		responseFormat = mapping.ResponseFormat
+	}
+
+	refs := cr.Spec.ForProvider.SecretInjectionConfigs
+	injections := make([]datapatcher.SecretInjection, len(refs))
+	for i, ref := range refs {
+		injections[i] = datapatcher.SecretInjection{
+			ResponsePath:    ref.ResponsePath,
+			Condition:       ref.Condition,
+			SecretKey:       ref.SecretKey,
+			SecretName:      ref.SecretRef.Name,
+			SecretNamespace: ref.SecretRef.Namespace,
+			KeyTransform:    string(ref.KeyTransform),
+			ResponseFormat:  string(responseFormat),
+		}
+	}
+
+	ctx = datapatcher.WithInjectionPolicy(ctx, datapatcher.InjectionPolicy{
+		Owner:             datapatcher.ControllerReference(v1alpha2.RequestGroupVersionKind, cr),
+		OwnCreatedSecrets: cr.Spec.ForProvider.OwnInjectedSecrets,
+		FailOnConflict:    cr.Spec.ForProvider.SecretInjectionConflicts == v1alpha2.SecretInjectionConflictError,
+	})
+
+	for i, err := range datapatcher.PatchResponseToSecrets(ctx, c.localKube, c.logger, response, injections) {
 		if err != nil {
-			c.logger.Info(fmt.Sprintf(errPatchDataToSecret, ref.SecretRef.Name, ref.SecretRef.Namespace, ref.SecretKey, err.Error()))
+			c.logger.Info(fmt.Sprintf(errPatchDataToSecret, refs[i].SecretRef.Name, refs[i].SecretRef.Namespace, refs[i].SecretKey, err.Error()))
 		}
 	}
 }
 
+// isRemovalConfirmed reports whether the response shows the object is already gone while the resource is being
+// deleted, in which case a not found response, such as a 404, is the expected outcome rather than a failure.
+func isRemovalConfirmed(cr *v1alpha2.Request, details httpClient.HttpDetails, err error) bool {
+	if err != nil || !meta.WasDeleted(cr) {
+		return false
+	}
+
+	outcome, err := utils.ClassifyResponse(cr.Spec.ForProvider, details.HttpRequest.Method, details.HttpResponse)
+	return err == nil && outcome == v1alpha2.ResponseOutcomeNotFound
+}
+
+// isNoOpUpdate reports whether the Request skips no-op updates and the desired body is identical, after
+// canonicalization, to the body of the last successful update. Bodies with injected secrets are never
+// considered no-op, since the secret values may have changed.
+func isNoOpUpdate(cr *v1alpha2.Request, requestDetails requestgen.RequestDetails) bool {
+	if !cr.Spec.ForProvider.SkipNoOpUpdates || cr.Status.LastAppliedBody == "" || requestDetails.Body.Encrypted != requestDetails.Body.Decrypted {
+		return false
+	}
+
+	desiredBody, ok := requestDetails.Body.Encrypted.(string)
+	if !ok {
+		return false
+	}
+
+	return json_util.CanonicalizeJSONString(desiredBody) == cr.Status.LastAppliedBody
+}
+
 // generateValidRequestDetails generates valid request details based on the given Request resource and Mapping configuration.
 // It first attempts to generate request details using the HTTP response stored in the Request's status. If the generated
 // details are valid, the function returns them. If not, it falls back to using the cached response in the Request's status
@@ -2,13 +2,19 @@ package request
 
 import (
 	"context"
+	"net/http"
+	"strconv"
 	"testing"
+	"time"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/killswitch"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
@@ -140,6 +146,44 @@ func Test_httpExternal_Create(t *testing.T) {
 				err: errors.Wrap(errBoom, errFailedToSendHttpRequest),
 			},
 		},
+		"BodySchemaViolation": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{}, errBoom
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					postMapping := testPostMapping
+					postMapping.BodySchema = &v1alpha2.BodySchema{Inline: `{"type": "object", "required": ["id"]}`}
+					r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{postMapping}
+				}),
+			},
+			want: want{
+				err: errors.Wrap(errors.New("request body failed schema validation: body.id in body is required"), errFailedToSendHttpRequest),
+			},
+		},
+		"HostSaturated": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{}, httpClient.ErrHostSaturated
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(errBoom),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				mg: httpRequest(),
+			},
+			want: want{
+				err: errors.Wrap(httpClient.ErrHostSaturated, errFailedToSendHttpRequest),
+			},
+		},
 		"Success": {
 			args: args{
 				http: &MockHttpClient{
@@ -183,7 +227,8 @@ func Test_httpExternal_Update(t *testing.T) {
 		mg        resource.Managed
 	}
 	type want struct {
-		err error
+		err        error
+		lastAction v1alpha2.RequestAction
 	}
 
 	cases := map[string]struct {
@@ -215,6 +260,68 @@ func Test_httpExternal_Update(t *testing.T) {
 				err: errors.Wrap(errBoom, errFailedToSendHttpRequest),
 			},
 		},
+		"NoOpUpdate": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{}, errBoom
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.SkipNoOpUpdates = true
+					r.Status.Response.Body = `{"id":"123"}`
+					r.Status.LastAppliedBody = `{"username":"john_doe_new_username"}`
+				}),
+			},
+			want: want{
+				err:        nil,
+				lastAction: v1alpha2.RequestActionNoop,
+			},
+		},
+		"NoOpUpdateNotSkipped": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{}, errBoom
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					r.Status.Response.Body = `{"id":"123"}`
+					r.Status.LastAppliedBody = `{"username":"john_doe_new_username"}`
+				}),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errFailedToSendHttpRequest),
+			},
+		},
+		"ChangedBodyUpdate": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{}, errBoom
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					r.Status.Response.Body = `{"id":"123"}`
+					r.Status.LastAppliedBody = `{"username":"john_doe"}`
+				}),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errFailedToSendHttpRequest),
+			},
+		},
 		"Success": {
 			args: args{
 				http: &MockHttpClient{
@@ -247,6 +354,11 @@ func Test_httpExternal_Update(t *testing.T) {
 			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
 				t.Fatalf("e.Update(...): -want error, +got error: %s", diff)
 			}
+			if cr, ok := tc.args.mg.(*v1alpha2.Request); ok {
+				if diff := cmp.Diff(tc.want.lastAction, cr.Status.LastAction); diff != "" {
+					t.Errorf("e.Update(...): -want Status.LastAction, +got Status.LastAction: %s", diff)
+				}
+			}
 		})
 	}
 }
@@ -308,6 +420,74 @@ func Test_httpExternal_Delete(t *testing.T) {
 				err: nil,
 			},
 		},
+		"NotFoundWhileDeletingConfirmsRemoval": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpRequest:  httpClient.HttpRequest{Method: http.MethodDelete},
+							HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusNotFound},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(errBoom),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					r.SetDeletionTimestamp(&v1.Time{Time: time.Now()})
+				}),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"CustomMethodDesignatedForDelete": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						if method != "PURGE" {
+							return httpClient.HttpDetails{}, errors.Errorf("unexpected method %s", method)
+						}
+						return httpClient.HttpDetails{}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{testPostMapping, testDeleteMapping, {
+						Method: "PURGE",
+						URL:    testDeleteMapping.URL,
+						Action: v1alpha2.MappingActionDelete,
+					}}
+				}),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"NotFoundNotDeleting": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpRequest:  httpClient.HttpRequest{Method: http.MethodDelete},
+							HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusNotFound},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				mg: httpRequest(),
+			},
+			want: want{
+				err: errors.Wrap(errors.Errorf(utils.ErrStatusCode, http.MethodDelete, strconv.Itoa(http.StatusNotFound)), errFailedToSendHttpRequest),
+			},
+		},
 	}
 	for name, tc := range cases {
 		tc := tc // Create local copies of loop variables
@@ -325,3 +505,71 @@ func Test_httpExternal_Delete(t *testing.T) {
 		})
 	}
 }
+
+func Test_deployAction_RendersEachAttempt(t *testing.T) {
+	var bodies []string
+	e := &external{
+		localKube: &test.MockClient{
+			MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+			MockGet:          test.NewMockGetFn(nil),
+		},
+		logger: logging.NewNopLogger(),
+		http: &MockHttpClient{
+			MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+				bodies = append(bodies, body.Decrypted.(string))
+				return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusUnauthorized, Body: `{"error":"expired timestamp"}`}}, nil
+			},
+		},
+	}
+
+	cr := httpRequest(func(r *v1alpha2.Request) {
+		postMapping := testPostMapping
+		postMapping.Body = "{ username: .payload.body.username, timestamp: now }"
+		r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{postMapping}
+	})
+
+	// The failed request is retried by the next reconcile, which must render a fresh timestamp.
+	for attempt := 0; attempt < 2; attempt++ {
+		if err := e.deployAction(context.Background(), cr, http.MethodPost); err == nil {
+			t.Fatalf("deployAction(...): want error for the failed request, got none")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if len(bodies) != 2 {
+		t.Fatalf("deployAction(...): want 2 requests sent, got %d", len(bodies))
+	}
+	if bodies[0] == bodies[1] {
+		t.Errorf("deployAction(...): want a fresh timestamp on retry, got the same body twice: %s", bodies[0])
+	}
+}
+
+func Test_deployAction_KillSwitchEngaged(t *testing.T) {
+	sent := 0
+	e := &external{
+		localKube: &test.MockClient{
+			MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+			MockGet:          test.NewMockGetFn(nil),
+		},
+		logger: logging.NewNopLogger(),
+		http: &MockHttpClient{
+			MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+				sent++
+				return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusOK}}, nil
+			},
+		},
+		killSwitch: killswitch.New(nil, &apisv1alpha1.KillSwitch{Engaged: true}, nil),
+	}
+
+	cr := httpRequest()
+	if err := e.deployAction(context.Background(), cr, http.MethodPost); err != nil {
+		t.Fatalf("deployAction(...): unexpected error: %v", err)
+	}
+
+	if sent != 0 {
+		t.Errorf("deployAction(...): want no request sent while the kill switch is engaged, got %d", sent)
+	}
+	if !killswitch.IsPaused(cr) {
+		t.Errorf("deployAction(...): want the Paused condition set while the kill switch is engaged")
+	}
+}
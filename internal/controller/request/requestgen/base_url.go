@@ -0,0 +1,53 @@
+package requestgen
+
+import (
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errInvalidBaseURL    = "base URL %s is not an absolute URL"
+	errInvalidMappingURL = "cannot parse the URL %s of the mapping"
+)
+
+// joinBaseURL joins the URL generated for a mapping with the base URL when it isn't absolute. The path of the
+// mapping is appended to the path of the base URL with a single slash between them, and the query parameters of
+// both are kept. Absolute URLs, and any URL when no base URL is set, are returned unchanged.
+func joinBaseURL(baseURL, mappingURL string) (string, error) {
+	if baseURL == "" {
+		return mappingURL, nil
+	}
+
+	ref, err := url.Parse(mappingURL)
+	if err != nil {
+		return "", errors.Wrapf(err, errInvalidMappingURL, mappingURL)
+	}
+	if ref.IsAbs() {
+		return mappingURL, nil
+	}
+
+	base, err := url.Parse(baseURL)
+	if err != nil || !base.IsAbs() {
+		return "", errors.Errorf(errInvalidBaseURL, baseURL)
+	}
+
+	joined := base
+	if ref.Path != "" {
+		joined = base.JoinPath(ref.EscapedPath())
+	}
+
+	switch {
+	case ref.RawQuery == "":
+	case joined.RawQuery == "":
+		joined.RawQuery = ref.RawQuery
+	default:
+		joined.RawQuery += "&" + ref.RawQuery
+	}
+
+	if ref.Fragment != "" {
+		joined.Fragment = ref.Fragment
+	}
+
+	return joined.String(), nil
+}
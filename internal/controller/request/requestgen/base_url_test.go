@@ -0,0 +1,101 @@
+package requestgen
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func Test_joinBaseURL(t *testing.T) {
+	type args struct {
+		baseURL    string
+		mappingURL string
+	}
+	type want struct {
+		url string
+		err error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldKeepURLWithoutBaseURL": {
+			args: args{
+				mappingURL: "https://api.example.com/users",
+			},
+			want: want{url: "https://api.example.com/users"},
+		},
+		"ShouldAppendPathToBaseURL": {
+			args: args{
+				baseURL:    "https://api.example.com/v1",
+				mappingURL: "users/123",
+			},
+			want: want{url: "https://api.example.com/v1/users/123"},
+		},
+		"ShouldJoinWithSingleSlash": {
+			args: args{
+				baseURL:    "https://api.example.com/v1/",
+				mappingURL: "/users/123",
+			},
+			want: want{url: "https://api.example.com/v1/users/123"},
+		},
+		"ShouldKeepTrailingSlashOfPath": {
+			args: args{
+				baseURL:    "https://api.example.com",
+				mappingURL: "/users/",
+			},
+			want: want{url: "https://api.example.com/users/"},
+		},
+		"ShouldKeepEscapedPathSegments": {
+			args: args{
+				baseURL:    "https://api.example.com/v1",
+				mappingURL: "/files/a%2Fb",
+			},
+			want: want{url: "https://api.example.com/v1/files/a%2Fb"},
+		},
+		"ShouldMergeQueryParameters": {
+			args: args{
+				baseURL:    "https://api.example.com/v1?api-version=2",
+				mappingURL: "/users?name=john",
+			},
+			want: want{url: "https://api.example.com/v1/users?api-version=2&name=john"},
+		},
+		"ShouldKeepBaseURLForQueryOnlyMapping": {
+			args: args{
+				baseURL:    "https://api.example.com/v1/",
+				mappingURL: "?name=john",
+			},
+			want: want{url: "https://api.example.com/v1/?name=john"},
+		},
+		"ShouldKeepAbsoluteMappingURL": {
+			args: args{
+				baseURL:    "https://api.example.com/v1",
+				mappingURL: "https://other.example.com/users",
+			},
+			want: want{url: "https://other.example.com/users"},
+		},
+		"ShouldFailOnRelativeBaseURL": {
+			args: args{
+				baseURL:    "api.example.com/v1",
+				mappingURL: "/users",
+			},
+			want: want{err: errors.Errorf(errInvalidBaseURL, "api.example.com/v1")},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := joinBaseURL(tc.args.baseURL, tc.args.mappingURL)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("joinBaseURL(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.url, got); diff != "" {
+				t.Errorf("joinBaseURL(...): -want url, +got url: %s", diff)
+			}
+		})
+	}
+}
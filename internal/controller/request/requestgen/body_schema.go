@@ -0,0 +1,119 @@
+package requestgen
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	kubehandler "github.com/crossplane-contrib/provider-http/internal/kube-handler"
+)
+
+const (
+	bodySchemaRoot = "body"
+
+	errBodySchemaNotDefined = "body schema must define either inline or configMapRef"
+	errBodySchemaKeyMissing = "key %s not found in configmap %s/%s"
+	errParseBodySchema      = "failed to parse body schema"
+	errBodyNotJSON          = "request body is not valid JSON"
+	errBodySchemaValidation = "request body failed schema validation: %s"
+	errBodySchemaInvalid    = "invalid body schema: %v"
+)
+
+// BodySchemaError is returned when a generated request body does not satisfy its mapping's JSON Schema.
+type BodySchemaError struct {
+	message string
+}
+
+func (e *BodySchemaError) Error() string {
+	return e.message
+}
+
+// IsBodySchemaError reports whether the cause of err is a body schema validation failure.
+func IsBodySchemaError(err error) bool {
+	_, ok := errors.Cause(err).(*BodySchemaError)
+	return ok
+}
+
+// validateBody validates the generated body against the mapping's JSON Schema, if one is defined.
+func validateBody(ctx context.Context, localKube client.Client, bodySchema *v1alpha2.BodySchema, body string) error {
+	if bodySchema == nil {
+		return nil
+	}
+
+	rawSchema, err := loadBodySchema(ctx, localKube, bodySchema)
+	if err != nil {
+		return err
+	}
+
+	schema := &spec.Schema{}
+	if err := json.Unmarshal([]byte(rawSchema), schema); err != nil {
+		return errors.Wrap(err, errParseBodySchema)
+	}
+
+	var data interface{}
+	if body != "" {
+		if err := json.Unmarshal([]byte(body), &data); err != nil {
+			return &BodySchemaError{message: errors.Wrap(err, errBodyNotJSON).Error()}
+		}
+	}
+
+	violations, err := validateAgainstSchema(schema, data)
+	if err != nil {
+		return err
+	}
+
+	if len(violations) > 0 {
+		return &BodySchemaError{message: fmt.Sprintf(errBodySchemaValidation, strings.Join(violations, "; "))}
+	}
+
+	return nil
+}
+
+// validateAgainstSchema returns the schema violations of data, each prefixed with the offending path.
+func validateAgainstSchema(schema *spec.Schema, data interface{}) (violations []string, err error) {
+	// The validator panics on unsupported schemas, such as ones using $ref.
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf(errBodySchemaInvalid, r)
+		}
+	}()
+
+	result := validate.NewSchemaValidator(schema, nil, bodySchemaRoot, strfmt.Default).Validate(data)
+	for _, validationErr := range result.Errors {
+		violations = append(violations, validationErr.Error())
+	}
+
+	return violations, nil
+}
+
+// loadBodySchema returns the raw JSON Schema document, reading it from a ConfigMap when referenced.
+func loadBodySchema(ctx context.Context, localKube client.Client, bodySchema *v1alpha2.BodySchema) (string, error) {
+	if bodySchema.Inline != "" {
+		return bodySchema.Inline, nil
+	}
+
+	ref := bodySchema.ConfigMapRef
+	if ref == nil {
+		return "", errors.New(errBodySchemaNotDefined)
+	}
+
+	configMap, err := kubehandler.GetConfigMap(ctx, localKube, ref.Name, ref.Namespace)
+	if err != nil {
+		return "", err
+	}
+
+	rawSchema, ok := configMap.Data[ref.Key]
+	if !ok {
+		return "", errors.Errorf(errBodySchemaKeyMissing, ref.Key, ref.Namespace, ref.Name)
+	}
+
+	return rawSchema, nil
+}
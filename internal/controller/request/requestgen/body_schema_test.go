@@ -0,0 +1,134 @@
+package requestgen
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+const testBodySchema = `{
+	"type": "object",
+	"required": ["username"],
+	"properties": {
+		"username": {"type": "string"},
+		"age": {"type": "integer"}
+	}
+}`
+
+func Test_validateBody(t *testing.T) {
+	type args struct {
+		localKube  client.Client
+		bodySchema *v1alpha2.BodySchema
+		body       string
+	}
+	type want struct {
+		err           error
+		isSchemaError bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoSchema": {
+			args: args{
+				body: `{"anything": true}`,
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"ValidInlineSchema": {
+			args: args{
+				bodySchema: &v1alpha2.BodySchema{Inline: testBodySchema},
+				body:       `{"username": "john_doe", "age": 30}`,
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"MissingRequiredField": {
+			args: args{
+				bodySchema: &v1alpha2.BodySchema{Inline: testBodySchema},
+				body:       `{"age": 30}`,
+			},
+			want: want{
+				err:           &BodySchemaError{message: "request body failed schema validation: body.username in body is required"},
+				isSchemaError: true,
+			},
+		},
+		"WrongFieldType": {
+			args: args{
+				bodySchema: &v1alpha2.BodySchema{Inline: testBodySchema},
+				body:       `{"username": "john_doe", "age": "thirty"}`,
+			},
+			want: want{
+				err:           &BodySchemaError{message: "request body failed schema validation: body.age in body must be of type integer: \"string\""},
+				isSchemaError: true,
+			},
+		},
+		"ConfigMapSchema": {
+			args: args{
+				localKube: &test.MockClient{
+					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+						cm, ok := obj.(*corev1.ConfigMap)
+						if !ok {
+							return errors.New("object is not a ConfigMap")
+						}
+						cm.Data = map[string]string{"schema.json": testBodySchema}
+						return nil
+					},
+				},
+				bodySchema: &v1alpha2.BodySchema{
+					ConfigMapRef: &v1alpha2.ConfigMapKeyRef{Name: "schemas", Namespace: "default", Key: "schema.json"},
+				},
+				body: `{"age": 30}`,
+			},
+			want: want{
+				err:           &BodySchemaError{message: "request body failed schema validation: body.username in body is required"},
+				isSchemaError: true,
+			},
+		},
+		"ConfigMapKeyMissing": {
+			args: args{
+				localKube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
+				},
+				bodySchema: &v1alpha2.BodySchema{
+					ConfigMapRef: &v1alpha2.ConfigMapKeyRef{Name: "schemas", Namespace: "default", Key: "schema.json"},
+				},
+				body: `{"age": 30}`,
+			},
+			want: want{
+				err: errors.Errorf(errBodySchemaKeyMissing, "schema.json", "default", "schemas"),
+			},
+		},
+		"SchemaNotDefined": {
+			args: args{
+				bodySchema: &v1alpha2.BodySchema{},
+				body:       `{"age": 30}`,
+			},
+			want: want{
+				err: errors.New(errBodySchemaNotDefined),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := validateBody(context.Background(), tc.args.localKube, tc.args.bodySchema, tc.args.body)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("validateBody(...): -want error, +got error: %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.isSchemaError, IsBodySchemaError(gotErr)); diff != "" {
+				t.Fatalf("IsBodySchemaError(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
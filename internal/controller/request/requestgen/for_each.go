@@ -0,0 +1,62 @@
+package requestgen
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+)
+
+const (
+	// itemRoot is the key under which the element of forEach a request is generated for is exposed to the templates.
+	itemRoot = "item"
+
+	errForEachItems       = "cannot evaluate the forEach items"
+	errForEachItemsFormat = "forEach items: JQ filter should return an array, but returned: %s"
+)
+
+type itemKey struct{}
+
+// itemValue wraps the element carried by a context, telling a null element apart from none.
+type itemValue struct {
+	value interface{}
+}
+
+// WithItem returns a context whose generated requests expose the given element of forEach to their templates
+// under .item.
+func WithItem(ctx context.Context, item interface{}) context.Context {
+	return context.WithValue(ctx, itemKey{}, itemValue{value: item})
+}
+
+// addItem exposes the element of forEach carried by the context, if any, under .item.
+func addItem(ctx context.Context, jqObject map[string]interface{}) {
+	if item, ok := ctx.Value(itemKey{}).(itemValue); ok {
+		jqObject[itemRoot] = item.value
+	}
+}
+
+// ForEachItems returns the elements selected by the forEach items filter of the given parameters, evaluated
+// against the same object as the mappings, without any response.
+func ForEachItems(ctx context.Context, forProvider v1alpha2.RequestParameters) ([]interface{}, error) {
+	jqObject := generateRequestObject(forProvider, v1alpha2.Response{})
+	addMetadata(ctx, jqObject)
+
+	result, err := jq.ParseInterface(forProvider.ForEach.Items, jqObject)
+	if err != nil {
+		return nil, errors.Wrap(err, errForEachItems)
+	}
+
+	if result == nil {
+		return nil, nil
+	}
+
+	items, ok := result.([]interface{})
+	if !ok {
+		return nil, errors.Errorf(errForEachItemsFormat, fmt.Sprint(result))
+	}
+
+	return items, nil
+}
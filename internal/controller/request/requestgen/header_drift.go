@@ -0,0 +1,48 @@
+package requestgen
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+const (
+	errDesiredHeaders = "cannot generate the desired values of the header drift checks"
+)
+
+// DesiredHeaders returns the desired values of the response headers the mapping checks for drift, generated like
+// its headers against the same object, with their secrets resolved. Values generated for several results are
+// joined by commas, as they would be in a single header.
+func DesiredHeaders(ctx context.Context, localKube client.Client, mapping v1alpha2.Mapping, forProvider v1alpha2.RequestParameters, response v1alpha2.Response) (map[string]string, error) {
+	if len(mapping.HeaderDriftChecks) == 0 {
+		return nil, nil
+	}
+
+	jqObject := generateRequestObject(forProvider, response)
+	addMetadata(ctx, jqObject)
+	addItem(ctx, jqObject)
+	if preRequest, ok := preRequestResponse(ctx); ok {
+		jqObject[preRequestRoot] = preRequest
+	}
+
+	checks := make(map[string][]string, len(mapping.HeaderDriftChecks))
+	for name, value := range mapping.HeaderDriftChecks {
+		checks[name] = []string{value}
+	}
+
+	headers, err := generateHeaders(ctx, localKube, rendererFor(mapping), checks, jqObject)
+	if err != nil {
+		return nil, errors.Wrap(err, errDesiredHeaders)
+	}
+
+	desired := make(map[string]string, len(checks))
+	for name, values := range headers.Decrypted.(map[string][]string) {
+		desired[name] = strings.Join(values, ",")
+	}
+
+	return desired, nil
+}
@@ -0,0 +1,45 @@
+package requestgen
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// metadataRoot is the key under which the metadata of the Request is exposed to the templates.
+const metadataRoot = "metadata"
+
+type metadataKey struct{}
+
+// WithMetadata returns a context whose generated requests expose the name, namespace, labels and annotations of
+// the given object to their templates under .metadata, e.g. .metadata.labels["env"].
+func WithMetadata(ctx context.Context, obj metav1.Object) context.Context {
+	return context.WithValue(ctx, metadataKey{}, map[string]interface{}{
+		"name":        obj.GetName(),
+		"namespace":   obj.GetNamespace(),
+		"labels":      stringMap(obj.GetLabels()),
+		"annotations": stringMap(obj.GetAnnotations()),
+	})
+}
+
+// addMetadata exposes the metadata carried by the context, if any, under .metadata. The roots of the request
+// object take precedence, so a spec or response root named metadata keeps its content.
+func addMetadata(ctx context.Context, jqObject map[string]interface{}) {
+	metadata, ok := ctx.Value(metadataKey{}).(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	if _, exists := jqObject[metadataRoot]; !exists {
+		jqObject[metadataRoot] = metadata
+	}
+}
+
+// stringMap returns the given map in the form jq expects, keeping an unset map empty rather than null.
+func stringMap(m map[string]string) map[string]interface{} {
+	converted := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		converted[key] = value
+	}
+	return converted
+}
@@ -0,0 +1,116 @@
+package requestgen
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_GenerateRequestDetailsWithMetadata(t *testing.T) {
+	testRequest := &v1alpha2.Request{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "user",
+			Namespace:   "default",
+			Labels:      map[string]string{"env": "prod"},
+			Annotations: map[string]string{"example.com/cluster": "eu-1"},
+		},
+	}
+
+	type args struct {
+		methodMapping v1alpha2.Mapping
+		forProvider   v1alpha2.RequestParameters
+		metadata      metav1.Object
+	}
+	type want struct {
+		body string
+		err  error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldExposeLabelsAndAnnotations": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method: "POST",
+					Body:   `{ env: .metadata.labels["env"], cluster: .metadata.annotations["example.com/cluster"], name: .metadata.name, namespace: .metadata.namespace }`,
+					URL:    ".payload.baseUrl",
+				},
+				forProvider: testForProvider,
+				metadata:    testRequest,
+			},
+			want: want{
+				body: `{"cluster":"eu-1","env":"prod","name":"user","namespace":"default"}`,
+			},
+		},
+		"ShouldKeepExistingRoots": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method: "POST",
+					Body:   `{ username: .payload.body.username, env: .metadata.labels["env"] }`,
+					URL:    ".payload.baseUrl",
+				},
+				forProvider: testForProvider,
+				metadata:    testRequest,
+			},
+			want: want{
+				body: `{"env":"prod","username":"john_doe"}`,
+			},
+		},
+		"ShouldNotOverrideRootNamedMetadata": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method: "POST",
+					Body:   `{ username: .metadata.payload.body.username }`,
+					URL:    ".metadata.payload.baseUrl",
+				},
+				forProvider: v1alpha2.RequestParameters{
+					Payload:  testForProvider.Payload,
+					Mappings: testForProvider.Mappings,
+					JQObject: &v1alpha2.JQObjectConfig{SpecRoot: "metadata"},
+				},
+				metadata: testRequest,
+			},
+			want: want{
+				body: `{"username":"john_doe"}`,
+			},
+		},
+		"ShouldExposeNothingWithoutMetadata": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method: "POST",
+					Body:   `{ env: .metadata.labels["env"] }`,
+					URL:    ".payload.baseUrl",
+				},
+				forProvider: testForProvider,
+			},
+			want: want{
+				body: `{"env":null}`,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.args.metadata != nil {
+				ctx = WithMetadata(ctx, tc.args.metadata)
+			}
+
+			got, gotErr, _ := GenerateRequestDetails(ctx, nil, tc.args.methodMapping, tc.args.forProvider, v1alpha2.Response{})
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("GenerateRequestDetails(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(httpClient.Data{Encrypted: tc.want.body, Decrypted: tc.want.body}, got.Body); diff != "" {
+				t.Errorf("GenerateRequestDetails(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package requestgen
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/responseconverter"
+)
+
+func Test_generateRequestObject_MultiValueHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Set-Cookie", "session=abc; Path=/; HttpOnly")
+		w.Header().Add("Set-Cookie", "theme=dark; Expires=Wed, 21 Oct 2026 07:28:00 GMT")
+		w.Header().Set("Cache-Control", "no-cache, no-store")
+		w.Header().Set("X-Json", `{"id": "123"}`)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c, err := httpClient.NewClient(logging.NewNopLogger(), 5*time.Second)
+	if err != nil {
+		t.Fatalf("NewClient(...): unexpected error: %s", err)
+	}
+	headers := map[string][]string{}
+	details, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, httpClient.Data{Encrypted: "", Decrypted: ""}, httpClient.Data{Encrypted: headers, Decrypted: headers}, false)
+	if err != nil {
+		t.Fatalf("SendRequest(...): unexpected error: %s", err)
+	}
+
+	response := responseconverter.HttpResponseToV1alpha1Response(details.HttpResponse)
+	jqObject := generateRequestObject(v1alpha2.RequestParameters{JQObject: &v1alpha2.JQObjectConfig{}}, response)
+	responseHeaders, _ := jqObject["response"].(map[string]interface{})["headers"].(map[string]interface{})
+
+	cases := map[string]struct {
+		header string
+		want   interface{}
+	}{
+		"SetCookieValuesKeptApartInOrder": {
+			header: "Set-Cookie",
+			want:   []interface{}{"session=abc; Path=/; HttpOnly", "theme=dark; Expires=Wed, 21 Oct 2026 07:28:00 GMT"},
+		},
+		"CommaJoinedValueKeptAsOneValue": {
+			header: "Cache-Control",
+			want:   []interface{}{"no-cache, no-store"},
+		},
+		"JSONLookingValueKeptAsString": {
+			header: "X-Json",
+			want:   []interface{}{`{"id": "123"}`},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, responseHeaders[tc.header]); diff != "" {
+				t.Errorf("generateRequestObject(...): -want header values, +got header values: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package requestgen
+
+import (
+	"text/template/parse"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+)
+
+const (
+	errNullPathParam = "URL path parameter resolved to null: %s"
+
+	// urlValueFunc is the Go template function rendering the values a URL template prints.
+	urlValueFunc = "urlValue"
+)
+
+// rejectNullURLValue returns an explicit error if rendering the URL template failed because a null value was
+// substituted into it, rather than producing a URL that silently targets the wrong resource.
+func rejectNullURLValue(urlTemplate string, err error) error {
+	if jq.IsNullURLValue(err) {
+		return errors.Errorf(errNullPathParam, urlTemplate)
+	}
+
+	return err
+}
+
+// formatURLValues pipes the value of every action printing into a Go template URL to urlValueFunc, so that the
+// values are rendered the way they are by the jq engine.
+func formatURLValues(tree *parse.Tree) {
+	formatURLValuesOf(tree.Root)
+}
+
+func formatURLValuesOf(node parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			formatURLValuesOf(child)
+		}
+	case *parse.ActionNode:
+		// Actions declaring variables print nothing.
+		if len(n.Pipe.Decl) == 0 {
+			n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{
+				NodeType: parse.NodeCommand,
+				Pos:      n.Pos,
+				Args:     []parse.Node{parse.NewIdentifier(urlValueFunc).SetPos(n.Pos)},
+			})
+		}
+	case *parse.IfNode:
+		formatURLValuesOf(n.List)
+		formatURLValuesOf(n.ElseList)
+	case *parse.RangeNode:
+		formatURLValuesOf(n.List)
+		formatURLValuesOf(n.ElseList)
+	case *parse.WithNode:
+		formatURLValuesOf(n.List)
+		formatURLValuesOf(n.ElseList)
+	}
+}
@@ -0,0 +1,249 @@
+package requestgen
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func Test_generateURL(t *testing.T) {
+	type args struct {
+		render      renderer
+		urlJQFilter string
+		jqObject    map[string]interface{}
+	}
+	type want struct {
+		url string
+		err error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"IntegerID": {
+			args: args{
+				urlJQFilter: `(.payload.baseUrl + "/" + (.response.body.id|tostring))`,
+				jqObject: map[string]interface{}{
+					"payload":  map[string]interface{}{"baseUrl": "https://api.example.com/users"},
+					"response": map[string]interface{}{"body": map[string]interface{}{"id": float64(123)}},
+				},
+			},
+			want: want{
+				url: "https://api.example.com/users/123",
+			},
+		},
+		"StringValueUnchanged": {
+			args: args{
+				urlJQFilter: `(.payload.baseUrl + "/" + .payload.body.apiVersion + "/users?page=1.0")`,
+				jqObject: map[string]interface{}{
+					"payload": map[string]interface{}{
+						"baseUrl": "https://api.example.com",
+						"body":    map[string]interface{}{"apiVersion": "2.0"},
+					},
+				},
+			},
+			want: want{
+				url: "https://api.example.com/2.0/users?page=1.0",
+			},
+		},
+		"LargeIntegerIDInPlainNotation": {
+			args: args{
+				urlJQFilter: `"\(.payload.baseUrl)/\(.response.body.id)"`,
+				jqObject: map[string]interface{}{
+					"payload":  map[string]interface{}{"baseUrl": "https://api.example.com/users"},
+					"response": map[string]interface{}{"body": map[string]interface{}{"id": float64(1e21)}},
+				},
+			},
+			want: want{
+				url: "https://api.example.com/users/1000000000000000000000",
+			},
+		},
+		"PathEncodedIntegerID": {
+			args: args{
+				urlJQFilter: `(.payload.baseUrl + "/" + (.response.body.id | pathEncode))`,
+				jqObject: map[string]interface{}{
+					"payload":  map[string]interface{}{"baseUrl": "https://api.example.com/users"},
+					"response": map[string]interface{}{"body": map[string]interface{}{"id": float64(1000000)}},
+				},
+			},
+			want: want{
+				url: "https://api.example.com/users/1000000",
+			},
+		},
+		"GoTemplateIntegerID": {
+			args: args{
+				render:      goTemplateRenderer{},
+				urlJQFilter: `{{ .payload.baseUrl }}/{{ .response.body.id }}`,
+				jqObject: map[string]interface{}{
+					"payload":  map[string]interface{}{"baseUrl": "https://api.example.com/users"},
+					"response": map[string]interface{}{"body": map[string]interface{}{"id": float64(1000000)}},
+				},
+			},
+			want: want{
+				url: "https://api.example.com/users/1000000",
+			},
+		},
+		"LiteralVersionSegmentUnchanged": {
+			args: args{
+				urlJQFilter: `("https://api.example.com/api/1.0/users/" + (.response.body.id|tostring))`,
+				jqObject: map[string]interface{}{
+					"response": map[string]interface{}{"body": map[string]interface{}{"id": float64(123)}},
+				},
+			},
+			want: want{
+				url: "https://api.example.com/api/1.0/users/123",
+			},
+		},
+		"VersionSegmentOfBaseURLUnchanged": {
+			args: args{
+				urlJQFilter: `(.payload.baseUrl + "/users/" + .response.body.id)`,
+				jqObject: map[string]interface{}{
+					"payload":  map[string]interface{}{"baseUrl": "https://api.example.com/api/1.0"},
+					"response": map[string]interface{}{"body": map[string]interface{}{"id": "7"}},
+				},
+			},
+			want: want{
+				url: "https://api.example.com/api/1.0/users/7",
+			},
+		},
+		"LiteralNullSegmentKept": {
+			args: args{
+				urlJQFilter: `("https://api.example.com/devices/null/" + (.response.body.id|tostring))`,
+				jqObject: map[string]interface{}{
+					"response": map[string]interface{}{"body": map[string]interface{}{"id": float64(3)}},
+				},
+			},
+			want: want{
+				url: "https://api.example.com/devices/null/3",
+			},
+		},
+		"NullIDNextToLiteralNullSegment": {
+			args: args{
+				urlJQFilter: `("https://api.example.com/devices/null/" + (.response.body.id|tostring))`,
+				jqObject: map[string]interface{}{
+					"response": map[string]interface{}{"body": map[string]interface{}{}},
+				},
+			},
+			want: want{
+				err: errors.Errorf(errNullPathParam, `("https://api.example.com/devices/null/" + (.response.body.id|tostring))`),
+			},
+		},
+		"NullIDInterpolated": {
+			args: args{
+				urlJQFilter: `"\(.payload.baseUrl)/\(.response.body.id)"`,
+				jqObject: map[string]interface{}{
+					"payload":  map[string]interface{}{"baseUrl": "https://api.example.com/users"},
+					"response": map[string]interface{}{"body": map[string]interface{}{"id": nil}},
+				},
+			},
+			want: want{
+				err: errors.Errorf(errNullPathParam, `"\(.payload.baseUrl)/\(.response.body.id)"`),
+			},
+		},
+		"GoTemplateNullID": {
+			args: args{
+				render:      goTemplateRenderer{},
+				urlJQFilter: `{{ .payload.baseUrl }}/{{ .response.body.id }}`,
+				jqObject: map[string]interface{}{
+					"payload":  map[string]interface{}{"baseUrl": "https://api.example.com/users"},
+					"response": map[string]interface{}{"body": map[string]interface{}{"id": nil}},
+				},
+			},
+			want: want{
+				err: errors.Errorf(errNullPathParam, `{{ .payload.baseUrl }}/{{ .response.body.id }}`),
+			},
+		},
+		"FractionalNumberUnchanged": {
+			args: args{
+				urlJQFilter: `(.payload.baseUrl + "/v" + (.response.body.version|tostring))`,
+				jqObject: map[string]interface{}{
+					"payload":  map[string]interface{}{"baseUrl": "https://api.example.com/docs"},
+					"response": map[string]interface{}{"body": map[string]interface{}{"version": float64(2.5)}},
+				},
+			},
+			want: want{
+				url: "https://api.example.com/docs/v2.5",
+			},
+		},
+		"PathEncodedIDWithSlash": {
+			args: args{
+				urlJQFilter: `(.payload.baseUrl + "/" + (.response.body.id | pathEncode) + "/roles")`,
+				jqObject: map[string]interface{}{
+					"payload":  map[string]interface{}{"baseUrl": "https://api.example.com/users"},
+					"response": map[string]interface{}{"body": map[string]interface{}{"id": "team/john"}},
+				},
+			},
+			want: want{
+				url: "https://api.example.com/users/team%2Fjohn/roles",
+			},
+		},
+		"PathEncodedIDWithSpace": {
+			args: args{
+				urlJQFilter: `(.payload.baseUrl + "/" + (.response.body.id | pathEncode))`,
+				jqObject: map[string]interface{}{
+					"payload":  map[string]interface{}{"baseUrl": "https://api.example.com/users"},
+					"response": map[string]interface{}{"body": map[string]interface{}{"id": "john doe"}},
+				},
+			},
+			want: want{
+				url: "https://api.example.com/users/john%20doe",
+			},
+		},
+		"PathEncodedUnicodeID": {
+			args: args{
+				urlJQFilter: `(.payload.baseUrl + "/" + (.response.body.id | pathEncode))`,
+				jqObject: map[string]interface{}{
+					"payload":  map[string]interface{}{"baseUrl": "https://api.example.com/users"},
+					"response": map[string]interface{}{"body": map[string]interface{}{"id": "zoë"}},
+				},
+			},
+			want: want{
+				url: "https://api.example.com/users/zo%C3%AB",
+			},
+		},
+		"GoTemplatePathEncodedID": {
+			args: args{
+				render:      goTemplateRenderer{},
+				urlJQFilter: `{{ .payload.baseUrl }}/{{ pathEncode .response.body.id }}`,
+				jqObject: map[string]interface{}{
+					"payload":  map[string]interface{}{"baseUrl": "https://api.example.com/users"},
+					"response": map[string]interface{}{"body": map[string]interface{}{"id": "team/john doe"}},
+				},
+			},
+			want: want{
+				url: "https://api.example.com/users/team%2Fjohn%20doe",
+			},
+		},
+		"NullID": {
+			args: args{
+				urlJQFilter: `(.payload.baseUrl + "/" + (.response.body.id|tostring))`,
+				jqObject: map[string]interface{}{
+					"payload":  map[string]interface{}{"baseUrl": "https://api.example.com/users"},
+					"response": map[string]interface{}{"body": map[string]interface{}{}},
+				},
+			},
+			want: want{
+				err: errors.Errorf(errNullPathParam, `(.payload.baseUrl + "/" + (.response.body.id|tostring))`),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			render := tc.args.render
+			if render == nil {
+				render = jqRenderer{}
+			}
+
+			got, gotErr := generateURL(render, tc.args.urlJQFilter, tc.args.jqObject, "", nil)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("generateURL(...): -want error, +got error: %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.url, got); diff != "" {
+				t.Fatalf("generateURL(...): -want url, +got url: %s", diff)
+			}
+		})
+	}
+}
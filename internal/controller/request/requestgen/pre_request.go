@@ -0,0 +1,78 @@
+package requestgen
+
+import (
+	"context"
+
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+)
+
+const (
+	// preRequestRoot is the key under which the response of the pre-request is exposed to the templates.
+	preRequestRoot = "preRequest"
+
+	// redactedValue replaces the string values of the pre-request response in what is stored in the status.
+	redactedValue = "[REDACTED]"
+)
+
+type preRequestKey struct{}
+
+// WithPreRequestResponse returns a context whose generated requests expose the given pre-request response
+// to their templates under .preRequest.
+func WithPreRequestResponse(ctx context.Context, response httpClient.HttpResponse) context.Context {
+	responseMap, _ := json_util.StructToMap(response)
+	json_util.ConvertJSONStringsToMaps(&responseMap)
+
+	return context.WithValue(ctx, preRequestKey{}, responseMap)
+}
+
+// preRequestResponse returns the pre-request response carried by the context, if any.
+func preRequestResponse(ctx context.Context) (map[string]interface{}, bool) {
+	response, ok := ctx.Value(preRequestKey{}).(map[string]interface{})
+	return response, ok
+}
+
+// redactPreRequestValues replaces the values derived from the pre-request response in the body and headers
+// shown in the status and logs. The templates are evaluated again with every string of the response redacted,
+// so that transformed values, such as "Bearer " + .preRequest.body.token, are redacted too. A body that can't
+// be generated from the redacted response is redacted as a whole.
+func redactPreRequestValues(render renderer, details *RequestDetails, jqObject, preRequest map[string]interface{}, body string, headers map[string][]string) {
+	jqObject[preRequestRoot] = redact(preRequest)
+	defer func() { jqObject[preRequestRoot] = preRequest }()
+
+	if encrypted, _ := details.Body.Encrypted.(string); encrypted != "" {
+		redactedBody, err := render.renderBody(body, jqObject)
+		if err != nil {
+			redactedBody = redactedValue
+		}
+		details.Body.Encrypted = redactedBody
+	}
+
+	if redactedHeaders, err := render.renderHeaders(headers, jqObject); err == nil {
+		details.Headers.Encrypted = redactedHeaders
+	} else {
+		details.Headers.Encrypted = map[string][]string{}
+	}
+}
+
+// redact returns a copy of the value with every string replaced by the redacted placeholder.
+func redact(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return redactedValue
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for key, inner := range v {
+			redacted[key] = redact(inner)
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, inner := range v {
+			redacted[i] = redact(inner)
+		}
+		return redacted
+	default:
+		return v
+	}
+}
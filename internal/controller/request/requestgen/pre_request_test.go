@@ -0,0 +1,176 @@
+package requestgen
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_GenerateRequestDetailsWithPreRequest(t *testing.T) {
+	preRequestResponse := httpClient.HttpResponse{
+		StatusCode: 200,
+		Body:       `{"token":"s3cr3t","expiresIn":3600}`,
+	}
+
+	type args struct {
+		methodMapping v1alpha2.Mapping
+		preRequest    *httpClient.HttpResponse
+		unredacted    bool
+	}
+	type want struct {
+		requestDetails RequestDetails
+		err            error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldRedactTokenInHeaders": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method: "POST",
+					URL:    ".payload.baseUrl",
+					Body:   "{ username: .payload.body.username }",
+					Headers: map[string][]string{
+						"Authorization": {`("Bearer " + .preRequest.body.token)`},
+					},
+				},
+				preRequest: &preRequestResponse,
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url: "https://api.example.com/users",
+					Body: httpClient.Data{
+						Encrypted: `{"username":"john_doe"}`,
+						Decrypted: `{"username":"john_doe"}`,
+					},
+					Headers: httpClient.Data{
+						Encrypted: map[string][]string{"Authorization": {"Bearer [REDACTED]"}},
+						Decrypted: map[string][]string{"Authorization": {"Bearer s3cr3t"}},
+					},
+				},
+			},
+		},
+		"ShouldRedactTokenInBody": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method: "POST",
+					URL:    ".payload.baseUrl",
+					Body:   "{ username: .payload.body.username, token: .preRequest.body.token, ttl: .preRequest.body.expiresIn }",
+				},
+				preRequest: &preRequestResponse,
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url: "https://api.example.com/users",
+					Body: httpClient.Data{
+						Encrypted: `{"token":"[REDACTED]","ttl":3600,"username":"john_doe"}`,
+						Decrypted: `{"token":"s3cr3t","ttl":3600,"username":"john_doe"}`,
+					},
+					Headers: httpClient.Data{
+						Encrypted: map[string][]string{},
+						Decrypted: map[string][]string{},
+					},
+				},
+			},
+		},
+		"ShouldKeepBodyUnredactedForDesiredState": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method: "PUT",
+					URL:    ".payload.baseUrl",
+					Body:   "{ username: .payload.body.username, token: .preRequest.body.token }",
+					Headers: map[string][]string{
+						"Authorization": {`("Bearer " + .preRequest.body.token)`},
+					},
+				},
+				preRequest: &preRequestResponse,
+				unredacted: true,
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url: "https://api.example.com/users",
+					Body: httpClient.Data{
+						Encrypted: `{"token":"s3cr3t","username":"john_doe"}`,
+						Decrypted: `{"token":"s3cr3t","username":"john_doe"}`,
+					},
+					Headers: httpClient.Data{
+						Encrypted: map[string][]string{"Authorization": {"Bearer [REDACTED]"}},
+						Decrypted: map[string][]string{"Authorization": {"Bearer s3cr3t"}},
+					},
+				},
+			},
+		},
+		"ShouldDeriveStatusValuesFromRedactedResponse": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method: "POST",
+					URL:    ".payload.baseUrl",
+					Body:   "{ ttl: (.preRequest.body.token | length) }",
+				},
+				preRequest: &preRequestResponse,
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url: "https://api.example.com/users",
+					Body: httpClient.Data{
+						Encrypted: `{"ttl":10}`,
+						Decrypted: `{"ttl":6}`,
+					},
+					Headers: httpClient.Data{
+						Encrypted: map[string][]string{},
+						Decrypted: map[string][]string{},
+					},
+				},
+			},
+		},
+		"ShouldNotExposePreRequestWithoutResponse": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method: "POST",
+					URL:    ".payload.baseUrl",
+					Body:   "{ token: .preRequest.body.token }",
+				},
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url: "https://api.example.com/users",
+					Body: httpClient.Data{
+						Encrypted: `{"token":null}`,
+						Decrypted: `{"token":null}`,
+					},
+					Headers: httpClient.Data{
+						Encrypted: map[string][]string{},
+						Decrypted: map[string][]string{},
+					},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.args.preRequest != nil {
+				ctx = WithPreRequestResponse(ctx, *tc.args.preRequest)
+			}
+			if tc.args.unredacted {
+				ctx = WithUnredactedBody(ctx)
+			}
+
+			got, gotErr, _ := GenerateRequestDetails(ctx, nil, tc.args.methodMapping, testForProvider, v1alpha2.Response{})
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("GenerateRequestDetails(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.requestDetails, got); diff != "" {
+				t.Errorf("GenerateRequestDetails(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
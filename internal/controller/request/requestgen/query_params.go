@@ -0,0 +1,103 @@
+package requestgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errQueryParamsNotObject = "body must be a JSON object to be sent as query params"
+	errQueryParamsURL       = "cannot parse the URL to add the query params to"
+)
+
+// appendQueryParams flattens the JSON object body into bracketed query params, for example
+// {"filter":{"status":"active"}} into filter[status]=active, and adds them to the query of the URL,
+// ahead of its fragment.
+// Object keys are sorted and array elements are indexed so the generated URL is deterministic.
+// Keys and values are escaped; the brackets are left as-is. Null values are omitted.
+func appendQueryParams(rawURL string, body string) (string, error) {
+	if body == "" {
+		return rawURL, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewBufferString(body))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return "", errors.Wrap(err, errQueryParamsNotObject)
+	}
+
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return "", errors.New(errQueryParamsNotObject)
+	}
+
+	params := []string{}
+	for _, key := range sortedKeys(object) {
+		params = flattenQueryParam(params, url.QueryEscape(key), object[key])
+	}
+
+	if len(params) == 0 {
+		return rawURL, nil
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", errors.Wrap(err, errQueryParamsURL)
+	}
+
+	query := strings.Join(params, "&")
+	if parsed.RawQuery != "" {
+		query = parsed.RawQuery + "&" + query
+	}
+	parsed.RawQuery = query
+
+	return parsed.String(), nil
+}
+
+// flattenQueryParam appends the query params of value, under the already escaped prefix, to params.
+func flattenQueryParam(params []string, prefix string, value interface{}) []string {
+	switch v := value.(type) {
+	case nil:
+		return params
+	case map[string]interface{}:
+		for _, key := range sortedKeys(v) {
+			params = flattenQueryParam(params, prefix+"["+url.QueryEscape(key)+"]", v[key])
+		}
+		return params
+	case []interface{}:
+		for i, element := range v {
+			params = flattenQueryParam(params, prefix+"["+strconv.Itoa(i)+"]", element)
+		}
+		return params
+	case string:
+		return append(params, prefix+"="+url.QueryEscape(v))
+	default:
+		// json.Number and bool render as their JSON representation.
+		return append(params, prefix+"="+url.QueryEscape(toString(v)))
+	}
+}
+
+func toString(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,117 @@
+package requestgen
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func Test_appendQueryParams(t *testing.T) {
+	type args struct {
+		url  string
+		body string
+	}
+	type want struct {
+		url string
+		err error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NestedObject": {
+			args: args{
+				url:  "https://api.example.com/users",
+				body: `{"filter":{"type":"x","status":"active"}}`,
+			},
+			want: want{
+				url: "https://api.example.com/users?filter[status]=active&filter[type]=x",
+			},
+		},
+		"ArraysIndexed": {
+			args: args{
+				url:  "https://api.example.com/users",
+				body: `{"ids":[1,2],"tags":[{"name":"a"}]}`,
+			},
+			want: want{
+				url: "https://api.example.com/users?ids[0]=1&ids[1]=2&tags[0][name]=a",
+			},
+		},
+		"ReservedCharactersEncoded": {
+			args: args{
+				url:  "https://api.example.com/users",
+				body: `{"q":"a&b=c d","a key":true}`,
+			},
+			want: want{
+				url: "https://api.example.com/users?a+key=true&q=a%26b%3Dc+d",
+			},
+		},
+		"ExistingQueryKept": {
+			args: args{
+				url:  "https://api.example.com/users?page=2",
+				body: `{"limit":10,"cursor":null}`,
+			},
+			want: want{
+				url: "https://api.example.com/users?page=2&limit=10",
+			},
+		},
+		"FragmentKeptLast": {
+			args: args{
+				url:  "https://api.example.com/users#section",
+				body: `{"limit":10}`,
+			},
+			want: want{
+				url: "https://api.example.com/users?limit=10#section",
+			},
+		},
+		"ExistingQueryKeptWithFragment": {
+			args: args{
+				url:  "https://api.example.com/users?page=2#section",
+				body: `{"filter":{"status":"active"}}`,
+			},
+			want: want{
+				url: "https://api.example.com/users?page=2&filter[status]=active#section",
+			},
+		},
+		"InvalidURL": {
+			args: args{
+				url:  "https://api.example.com/%zz",
+				body: `{"limit":10}`,
+			},
+			want: want{
+				err: errors.Wrap(&url.Error{Op: "parse", URL: "https://api.example.com/%zz", Err: url.EscapeError("%zz")}, errQueryParamsURL),
+			},
+		},
+		"EmptyBody": {
+			args: args{
+				url: "https://api.example.com/users",
+			},
+			want: want{
+				url: "https://api.example.com/users",
+			},
+		},
+		"NotAnObject": {
+			args: args{
+				url:  "https://api.example.com/users",
+				body: `[1,2]`,
+			},
+			want: want{
+				err: errors.New(errQueryParamsNotObject),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := appendQueryParams(tc.args.url, tc.args.body)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("appendQueryParams(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.url, got); diff != "" {
+				t.Fatalf("appendQueryParams(...): -want url, +got url: %s", diff)
+			}
+		})
+	}
+}
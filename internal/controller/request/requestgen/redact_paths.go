@@ -0,0 +1,52 @@
+package requestgen
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+)
+
+const errRedactPath = "cannot redact the request body"
+
+type unredactedBodyKey struct{}
+
+// WithUnredactedBody returns a context whose generated requests keep the body shown in the status unredacted, with
+// neither the values derived from the pre-request response nor the ones at the redacted paths replaced, so that it
+// can be compared with the GET response to detect drift. Such requests must not be recorded in the status or logs.
+func WithUnredactedBody(ctx context.Context) context.Context {
+	return context.WithValue(ctx, unredactedBodyKey{}, true)
+}
+
+// keepsBodyUnredacted reports whether the requests generated with the context keep their body unredacted.
+func keepsBodyUnredacted(ctx context.Context) bool {
+	unredacted, _ := ctx.Value(unredactedBodyKey{}).(bool)
+	return unredacted
+}
+
+// redactBodyPaths replaces the values found at the given paths of the body shown in the status and logs. A body
+// that isn't JSON can't be searched for the paths, so it is redacted as a whole.
+func redactBodyPaths(details *RequestDetails, paths []string) error {
+	body, _ := details.Body.Encrypted.(string)
+	if len(paths) == 0 || body == "" {
+		return nil
+	}
+
+	fieldPaths := make([]json_util.FieldPath, 0, len(paths))
+	for _, path := range paths {
+		fieldPath, err := json_util.ParseFieldPath(path)
+		if err != nil {
+			return errors.Wrap(err, errRedactPath)
+		}
+		fieldPaths = append(fieldPaths, fieldPath)
+	}
+
+	redacted, err := json_util.RedactPaths(body, fieldPaths)
+	if err != nil {
+		redacted = json_util.RedactedValue
+	}
+	details.Body.Encrypted = redacted
+
+	return nil
+}
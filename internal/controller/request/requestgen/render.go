@@ -0,0 +1,38 @@
+package requestgen
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+// RenderedMapping is the request generated by a mapping, or the error that prevented generating it.
+type RenderedMapping struct {
+	Method  string
+	Details RequestDetails
+	Err     error
+
+	// Incomplete tells that the request was generated but isn't valid, usually because its templates read a
+	// field the response doesn't have yet. The controller doesn't send such requests.
+	Incomplete bool
+}
+
+// RenderMappings generates the request of every mapping of the given parameters against the given response, the
+// way the controller does, and reports the error of each mapping that can't be generated instead of stopping at
+// the first one. It lets templates be checked against sample data without a running provider.
+func RenderMappings(ctx context.Context, localKube client.Client, forProvider v1alpha2.RequestParameters, response v1alpha2.Response) []RenderedMapping {
+	rendered := make([]RenderedMapping, 0, len(forProvider.Mappings))
+	for _, mapping := range forProvider.Mappings {
+		details, err, _ := GenerateRequestDetails(ctx, localKube, mapping, forProvider, response)
+		rendered = append(rendered, RenderedMapping{
+			Method:     mapping.Method,
+			Details:    details,
+			Err:        err,
+			Incomplete: err == nil && !IsRequestValid(details),
+		})
+	}
+
+	return rendered
+}
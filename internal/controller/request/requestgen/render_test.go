@@ -0,0 +1,66 @@
+package requestgen
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+func Test_RenderMappings(t *testing.T) {
+	forProvider := v1alpha2.RequestParameters{
+		Payload: v1alpha2.Payload{
+			BaseUrl: "https://api.example.com/users",
+			Body:    `{"username": "john_doe"}`,
+		},
+		Mappings: []v1alpha2.Mapping{
+			{Method: "POST", URL: ".payload.baseUrl", Body: "{ username: .payload.body.username }"},
+			{Method: "GET", URL: `.payload.baseUrl + "/" + .response.body.id`},
+			{Method: "PUT", URL: ".payload.baseUrl +"},
+			{Method: "DELETE", URL: ".payload.baseUrl", Body: "{ id: .response.body.missing }"},
+		},
+	}
+
+	type want struct {
+		url        string
+		body       string
+		failed     bool
+		incomplete bool
+	}
+	wants := []want{
+		{url: "https://api.example.com/users", body: `{"username":"john_doe"}`},
+		{url: "https://api.example.com/users/123"},
+		{failed: true},
+		{incomplete: true},
+	}
+
+	rendered := RenderMappings(context.Background(), &test.MockClient{}, forProvider, v1alpha2.Response{StatusCode: 200, Body: `{"id":"123"}`})
+	if diff := cmp.Diff(len(forProvider.Mappings), len(rendered)); diff != "" {
+		t.Fatalf("RenderMappings(...): -want mappings, +got mappings: %s", diff)
+	}
+
+	for i, got := range rendered {
+		method := forProvider.Mappings[i].Method
+		if diff := cmp.Diff(method, got.Method); diff != "" {
+			t.Errorf("RenderMappings(...)[%d]: -want method, +got method: %s", i, diff)
+		}
+		if diff := cmp.Diff(wants[i].failed, got.Err != nil); diff != "" {
+			t.Errorf("RenderMappings(...) %s: -want failed, +got failed: %s (error: %v)", method, diff, got.Err)
+		}
+		if diff := cmp.Diff(wants[i].incomplete, got.Incomplete); diff != "" {
+			t.Errorf("RenderMappings(...) %s: -want incomplete, +got incomplete: %s", method, diff)
+		}
+		if got.Err != nil || got.Incomplete {
+			continue
+		}
+		if diff := cmp.Diff(wants[i].url, got.Details.Url); diff != "" {
+			t.Errorf("RenderMappings(...) %s: -want url, +got url: %s", method, diff)
+		}
+		if diff := cmp.Diff(wants[i].body, got.Details.Body.Encrypted); diff != "" {
+			t.Errorf("RenderMappings(...) %s: -want body, +got body: %s", method, diff)
+		}
+	}
+}
@@ -2,7 +2,9 @@ package requestgen
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -10,7 +12,6 @@ import (
 
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
-	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestprocessing"
 	datapatcher "github.com/crossplane-contrib/provider-http/internal/data-patcher"
 	json_util "github.com/crossplane-contrib/provider-http/internal/json"
 	"github.com/crossplane-contrib/provider-http/internal/utils"
@@ -18,6 +19,16 @@ import (
 	"golang.org/x/exp/maps"
 )
 
+const (
+	defaultSpecRoot     = "spec"
+	defaultResponseRoot = "response"
+
+	errInvalidDefaults = "defaults should be a JSON object"
+
+	contentTypeHeader = "Content-Type"
+	jsonContentType   = "application/json"
+)
+
 type RequestDetails struct {
 	Url     string
 	Body    httpClient.Data
@@ -27,34 +38,140 @@ type RequestDetails struct {
 // GenerateRequestDetails generates request details.
 func GenerateRequestDetails(ctx context.Context, localKube client.Client, methodMapping v1alpha2.Mapping, forProvider v1alpha2.RequestParameters, response v1alpha2.Response) (RequestDetails, error, bool) {
 	jqObject := generateRequestObject(forProvider, response)
-	url, err := generateURL(methodMapping.URL, jqObject)
+	addMetadata(ctx, jqObject)
+	addItem(ctx, jqObject)
+	render := rendererFor(methodMapping)
+	if !utils.IsMethodValid(methodMapping.Method) {
+		return RequestDetails{}, errors.Errorf(utils.ErrInvalidMethod, methodMapping.Method), false
+	}
+
+	if forProvider.Defaults != "" && !json_util.IsJSONString(forProvider.Defaults) {
+		return RequestDetails{}, errors.New(errInvalidDefaults), false
+	}
+
+	preRequest, hasPreRequest := preRequestResponse(ctx)
+	if hasPreRequest {
+		jqObject[preRequestRoot] = preRequest
+	}
+
+	url, err := generateURL(render, methodMapping.URL, jqObject, forProvider.BaseURL, forProvider.URLNormalization)
+	if err != nil {
+		return RequestDetails{}, err, false
+	}
+
+	bodyData, err := generateBody(ctx, localKube, render, methodMapping.Body, methodMapping.TrimBody, jqObject)
 	if err != nil {
 		return RequestDetails{}, err, false
 	}
 
+	if err := validateBody(ctx, localKube, methodMapping.BodySchema, bodyData.Encrypted.(string)); err != nil {
+		return RequestDetails{}, err, false
+	}
+
+	if methodMapping.QueryParamsFromBody {
+		if url, err = appendQueryParams(url, bodyData.Encrypted.(string)); err != nil {
+			return RequestDetails{}, err, false
+		}
+		bodyData = httpClient.Data{Encrypted: "", Decrypted: ""}
+	}
+
+	if !sendsBody(methodMapping) {
+		bodyData = httpClient.Data{Encrypted: "", Decrypted: ""}
+	}
+
 	if !utils.IsUrlValid(url) {
 		return RequestDetails{}, errors.Errorf(utils.ErrInvalidURL, url), false
 	}
 
-	bodyData, err := generateBody(ctx, localKube, methodMapping.Body, jqObject)
+	headers := coalesceHeaders(methodMapping.Headers, forProvider.Headers)
+	headersData, err := generateHeaders(ctx, localKube, render, headers, jqObject)
 	if err != nil {
 		return RequestDetails{}, err, false
 	}
 
-	headersData, err := generateHeaders(ctx, localKube, coalesceHeaders(methodMapping.Headers, forProvider.Headers), jqObject)
-	if err != nil {
+	details := RequestDetails{Body: bodyData, Url: url, Headers: headersData}
+	if hasPreRequest {
+		redactPreRequestValues(render, &details, jqObject, preRequest, methodMapping.Body, headers)
+	}
+
+	if err := redactBodyPaths(&details, methodMapping.RedactPaths); err != nil {
 		return RequestDetails{}, err, false
 	}
 
-	return RequestDetails{Body: bodyData, Url: url, Headers: headersData}, nil, true
+	if keepsBodyUnredacted(ctx) {
+		details.Body = bodyData
+	}
+
+	if bodyData.Encrypted == "" && methodMapping.EmptyBodyValue != "" && sendsBody(methodMapping) && !methodMapping.QueryParamsFromBody {
+		applyEmptyBodyValue(&details, methodMapping.EmptyBodyValue)
+	}
+
+	return details, nil, true
+}
+
+// sendsBody reports whether the mapping's body is sent with the request. Unless set explicitly,
+// GET requests are sent without a body.
+func sendsBody(mapping v1alpha2.Mapping) bool {
+	if mapping.SendBody != nil {
+		return *mapping.SendBody
+	}
+
+	return mapping.Method != http.MethodGet
+}
+
+// applyEmptyBodyValue sets the body of the request to the given value, along with a JSON Content-Type when
+// the value is valid JSON and the headers don't set one.
+func applyEmptyBodyValue(details *RequestDetails, value string) {
+	details.Body = httpClient.Data{Encrypted: value, Decrypted: value}
+	if !json.Valid([]byte(value)) {
+		return
+	}
+
+	details.Headers = httpClient.Data{
+		Encrypted: withHeaderDefault(details.Headers.Encrypted.(map[string][]string), contentTypeHeader, jsonContentType),
+		Decrypted: withHeaderDefault(details.Headers.Decrypted.(map[string][]string), contentTypeHeader, jsonContentType),
+	}
+}
+
+// withHeaderDefault returns a copy of the headers with the given header set, unless it is already present
+// under any casing.
+func withHeaderDefault(headers map[string][]string, name, value string) map[string][]string {
+	for key := range headers {
+		if http.CanonicalHeaderKey(key) == name {
+			return headers
+		}
+	}
+
+	withDefault := maps.Clone(headers)
+	if withDefault == nil {
+		withDefault = map[string][]string{}
+	}
+	withDefault[name] = []string{value}
+	return withDefault
 }
 
 // generateRequestObject creates a JSON-compatible map from the specified Request's ForProvider and Response fields.
 // It merges the two maps, converts JSON strings to nested maps, and returns the resulting map.
+// When a JQObject configuration is set, the ForProvider fields are placed under their own root key
+// instead of being merged at the top level, unless legacy root fields are requested. The defaults of the
+// Request are merged beneath its fields, and a response body stored compressed is exposed decompressed.
 func generateRequestObject(forProvider v1alpha2.RequestParameters, response v1alpha2.Response) map[string]interface{} {
-	baseMap, _ := json_util.StructToMap(forProvider)
+	specMap, _ := json_util.StructToMap(forProvider)
+	specMap = withDefaults(forProvider.Defaults, specMap)
+	config := forProvider.JQObject
+
+	baseMap := map[string]interface{}{}
+	if config == nil || config.LegacyRootFields {
+		maps.Copy(baseMap, specMap)
+	}
+
+	if config != nil {
+		baseMap[specRoot(config)] = specMap
+	}
+
+	response.Body = utils.DecompressBody(response.Body)
 	statusMap, _ := json_util.StructToMap(map[string]interface{}{
-		"response": response,
+		responseRoot(config): response,
 	})
 
 	maps.Copy(baseMap, statusMap)
@@ -63,6 +180,38 @@ func generateRequestObject(forProvider v1alpha2.RequestParameters, response v1al
 	return baseMap
 }
 
+// withDefaults returns the ForProvider fields deep merged over the given JSON object of defaults, with the JSON
+// strings of both, such as the body of the payload, converted to maps first so they are merged too. The fields
+// are returned as is when there are no defaults or they aren't a valid JSON object, which GenerateRequestDetails
+// reports.
+func withDefaults(defaults string, specMap map[string]interface{}) map[string]interface{} {
+	if defaults == "" || !json_util.IsJSONString(defaults) {
+		return specMap
+	}
+
+	defaultsMap := json_util.JsonStringToMap(defaults)
+	json_util.ConvertJSONStringsToMaps(&defaultsMap)
+	json_util.ConvertJSONStringsToMaps(&specMap)
+
+	return json_util.DeepMerge(defaultsMap, specMap)
+}
+
+// specRoot returns the key under which the ForProvider fields are exposed.
+func specRoot(config *v1alpha2.JQObjectConfig) string {
+	if config == nil || config.SpecRoot == "" {
+		return defaultSpecRoot
+	}
+	return config.SpecRoot
+}
+
+// responseRoot returns the key under which the response is exposed.
+func responseRoot(config *v1alpha2.JQObjectConfig) string {
+	if config == nil || config.ResponseRoot == "" {
+		return defaultResponseRoot
+	}
+	return config.ResponseRoot
+}
+
 func IsRequestValid(requestDetails RequestDetails) bool {
 	return (!strings.Contains(fmt.Sprint(requestDetails), "null")) && (requestDetails.Url != "")
 }
@@ -75,18 +224,24 @@ func coalesceHeaders(mappingHeaders, defaultHeaders map[string][]string) map[str
 	return defaultHeaders
 }
 
-// generateURL applies a JQ filter to generate a URL.
-func generateURL(urlJQFilter string, jqObject map[string]interface{}) (string, error) {
-	getURL, err := requestprocessing.ApplyJQOnStr(urlJQFilter, jqObject)
+// generateURL renders the URL template, failing if a null value is substituted into it, joins it with the base URL
+// when it is a path and then normalizes it according to the given configuration.
+func generateURL(render renderer, urlTemplate string, jqObject map[string]interface{}, baseURL string, normalization *v1alpha2.URLNormalization) (string, error) {
+	getURL, err := render.renderURL(urlTemplate, jqObject)
+	if err != nil {
+		return "", rejectNullURLValue(urlTemplate, err)
+	}
+
+	getURL, err = joinBaseURL(baseURL, getURL)
 	if err != nil {
 		return "", err
 	}
 
-	return getURL, nil
+	return normalizeURL(getURL, normalization), nil
 }
 
-// generateBody applies a mapping body to generate the request body.
-func generateBody(ctx context.Context, localKube client.Client, mappingBody string, jqObject map[string]interface{}) (httpClient.Data, error) {
+// generateBody renders a mapping body to generate the request body.
+func generateBody(ctx context.Context, localKube client.Client, render renderer, mappingBody string, trimBody bool, jqObject map[string]interface{}) (httpClient.Data, error) {
 	if mappingBody == "" {
 		return httpClient.Data{
 			Encrypted: "",
@@ -94,13 +249,15 @@ func generateBody(ctx context.Context, localKube client.Client, mappingBody stri
 		}, nil
 	}
 
-	jqQuery := requestprocessing.ConvertStringToJQQuery(mappingBody)
-	body, err := requestprocessing.ApplyJQOnStr(jqQuery, jqObject)
+	body, err := render.renderBody(mappingBody, jqObject)
 	if err != nil {
 		return httpClient.Data{}, err
 	}
+	if trimBody {
+		body = strings.TrimSpace(body)
+	}
 
-	sensitiveBody, err := datapatcher.PatchSecretsIntoBody(ctx, localKube, body)
+	sensitiveBody, err := datapatcher.PatchSecretsIntoBody(datapatcher.WithJQObject(ctx, jqObject), localKube, body)
 	if err != nil {
 		return httpClient.Data{}, err
 	}
@@ -111,14 +268,14 @@ func generateBody(ctx context.Context, localKube client.Client, mappingBody stri
 	}, nil
 }
 
-// generateHeaders applies JQ queries to generate headers.
-func generateHeaders(ctx context.Context, localKube client.Client, headers map[string][]string, jqObject map[string]interface{}) (httpClient.Data, error) {
-	generatedHeaders, err := requestprocessing.ApplyJQOnMapStrings(headers, jqObject)
+// generateHeaders renders the header templates to generate headers.
+func generateHeaders(ctx context.Context, localKube client.Client, render renderer, headers map[string][]string, jqObject map[string]interface{}) (httpClient.Data, error) {
+	generatedHeaders, err := render.renderHeaders(headers, jqObject)
 	if err != nil {
 		return httpClient.Data{}, err
 	}
 
-	sensitiveHeaders, err := datapatcher.PatchSecretsIntoHeaders(ctx, localKube, generatedHeaders)
+	sensitiveHeaders, err := datapatcher.PatchSecretsIntoHeaders(datapatcher.WithJQObject(ctx, jqObject), localKube, generatedHeaders)
 	if err != nil {
 		return httpClient.Data{}, err
 	}
@@ -2,15 +2,19 @@ package requestgen
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 )
 
 var testHeaders = map[string][]string{
@@ -66,6 +70,8 @@ var (
 )
 
 func Test_GenerateRequestDetails(t *testing.T) {
+	sendBody, omitBody := true, false
+
 	type args struct {
 		methodMapping v1alpha2.Mapping
 		forProvider   v1alpha2.RequestParameters
@@ -186,6 +192,265 @@ func Test_GenerateRequestDetails(t *testing.T) {
 				ok:  true,
 			},
 		},
+		"GetOmitsBodyByDefault": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method: "GET",
+					Body:   "{ username: .payload.body.username }",
+					URL:    "(.payload.baseUrl + \"/\" + .response.body.id)",
+				},
+				forProvider: testForProvider,
+				response: v1alpha2.Response{
+					StatusCode: 200,
+					Body:       `{"id":"123","username":"john_doe"}`,
+				},
+				logger: logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url: "https://api.example.com/users/123",
+					Headers: httpClient.Data{
+						Decrypted: map[string][]string{},
+						Encrypted: map[string][]string{},
+					},
+					Body: httpClient.Data{
+						Decrypted: "",
+						Encrypted: "",
+					},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"GetSendsBodyWhenEnabled": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method:   "GET",
+					Body:     "{ username: .payload.body.username }",
+					URL:      "(.payload.baseUrl + \"/\" + .response.body.id)",
+					SendBody: &sendBody,
+				},
+				forProvider: testForProvider,
+				response: v1alpha2.Response{
+					StatusCode: 200,
+					Body:       `{"id":"123","username":"john_doe"}`,
+				},
+				logger: logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url: "https://api.example.com/users/123",
+					Headers: httpClient.Data{
+						Decrypted: map[string][]string{},
+						Encrypted: map[string][]string{},
+					},
+					Body: httpClient.Data{
+						Decrypted: `{"username":"john_doe"}`,
+						Encrypted: `{"username":"john_doe"}`,
+					},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"PutOmitsBodyWhenDisabled": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method:   "PUT",
+					Body:     "{ username: .payload.body.username }",
+					URL:      "(.payload.baseUrl + \"/\" + .response.body.id)",
+					SendBody: &omitBody,
+				},
+				forProvider: testForProvider,
+				response: v1alpha2.Response{
+					StatusCode: 200,
+					Body:       `{"id":"123","username":"john_doe"}`,
+				},
+				logger: logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url: "https://api.example.com/users/123",
+					Headers: httpClient.Data{
+						Decrypted: map[string][]string{},
+						Encrypted: map[string][]string{},
+					},
+					Body: httpClient.Data{
+						Decrypted: "",
+						Encrypted: "",
+					},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"PostSendsEmptyBodyValueWithJSONContentType": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method:         "POST",
+					URL:            ".payload.baseUrl",
+					EmptyBodyValue: "{}",
+				},
+				forProvider: testForProvider,
+				logger:      logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url: "https://api.example.com/users",
+					Headers: httpClient.Data{
+						Decrypted: map[string][]string{"Content-Type": {"application/json"}},
+						Encrypted: map[string][]string{"Content-Type": {"application/json"}},
+					},
+					Body: httpClient.Data{
+						Decrypted: "{}",
+						Encrypted: "{}",
+					},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"EmptyBodyValueKeepsContentTypeOfHeaders": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method:         "POST",
+					URL:            ".payload.baseUrl",
+					Headers:        map[string][]string{"content-type": {"application/merge-patch+json"}},
+					EmptyBodyValue: "{}",
+				},
+				forProvider: testForProvider,
+				logger:      logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url: "https://api.example.com/users",
+					Headers: httpClient.Data{
+						Decrypted: map[string][]string{"content-type": {"application/merge-patch+json"}},
+						Encrypted: map[string][]string{"content-type": {"application/merge-patch+json"}},
+					},
+					Body: httpClient.Data{
+						Decrypted: "{}",
+						Encrypted: "{}",
+					},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"EmptyBodyValueIgnoredWhenBodyIsGenerated": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method:         "POST",
+					Body:           "{ username: .payload.body.username }",
+					URL:            ".payload.baseUrl",
+					EmptyBodyValue: "{}",
+				},
+				forProvider: testForProvider,
+				logger:      logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url: "https://api.example.com/users",
+					Headers: httpClient.Data{
+						Decrypted: map[string][]string{},
+						Encrypted: map[string][]string{},
+					},
+					Body: httpClient.Data{
+						Decrypted: `{"username":"john_doe"}`,
+						Encrypted: `{"username":"john_doe"}`,
+					},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"EmptyBodyValueIgnoredWhenBodyIsNotSent": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method:         "POST",
+					URL:            ".payload.baseUrl",
+					SendBody:       &omitBody,
+					EmptyBodyValue: "{}",
+				},
+				forProvider: testForProvider,
+				logger:      logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url: "https://api.example.com/users",
+					Headers: httpClient.Data{
+						Decrypted: map[string][]string{},
+						Encrypted: map[string][]string{},
+					},
+					Body: httpClient.Data{
+						Decrypted: "",
+						Encrypted: "",
+					},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"GoTemplateKeepsSecretPlaceholders": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method:         "PUT",
+					TemplateEngine: v1alpha2.TemplateEngineGoTemplate,
+					URL:            "{{ .payload.baseUrl }}/{{ .response.body.id }}",
+					Body:           `{"username": "{{ .payload.body.username }}", "token": "{{api-creds:default:token}}"}`,
+					Headers: map[string][]string{
+						"Authorization": {"Bearer {{ api-creds:default:token }}"},
+						"X-Tags":        {"{{ toJson .payload.body.tags }}"},
+					},
+				},
+				forProvider: v1alpha2.RequestParameters{
+					Payload: v1alpha2.Payload{
+						BaseUrl: "https://api.example.com/users",
+						Body:    `{"username": "john_doe", "tags": ["a", "b"]}`,
+					},
+				},
+				response: v1alpha2.Response{
+					StatusCode: 200,
+					Body:       `{"id":"123"}`,
+				},
+				localKube: &test.MockClient{
+					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+						*obj.(*corev1.Secret) = corev1.Secret{Data: map[string][]byte{"token": []byte("s3cr3t")}}
+						return nil
+					},
+				},
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url: "https://api.example.com/users/123",
+					Body: httpClient.Data{
+						Encrypted: `{"username": "john_doe", "token": "{{api-creds:default:token}}"}`,
+						Decrypted: `{"username": "john_doe", "token": "s3cr3t"}`,
+					},
+					Headers: httpClient.Data{
+						Encrypted: map[string][]string{"Authorization": {"Bearer {{ api-creds:default:token }}"}, "X-Tags": {`["a","b"]`}},
+						Decrypted: map[string][]string{"Authorization": {"Bearer s3cr3t"}, "X-Tags": {`["a","b"]`}},
+					},
+				},
+				ok: true,
+			},
+		},
+		"GoTemplateFailsOnMissingKey": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method:         "GET",
+					TemplateEngine: v1alpha2.TemplateEngineGoTemplate,
+					URL:            "{{ .payload.baseUrl }}/{{ .response.body.id }}",
+				},
+				forProvider: v1alpha2.RequestParameters{
+					Payload: v1alpha2.Payload{BaseUrl: "https://api.example.com/users"},
+				},
+				response: v1alpha2.Response{},
+			},
+			want: want{
+				err: errors.Wrapf(errors.New(`template: mapping:1:35: executing "mapping" at <.response.body.id>: map has no entry for key "body"`), errRenderTemplate, "{{ .payload.baseUrl }}/{{ .response.body.id }}"),
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -362,6 +627,8 @@ func Test_coalesceHeaders(t *testing.T) {
 }
 
 func Test_generateRequestObject(t *testing.T) {
+	compressAlways := int64(0)
+
 	type args struct {
 		forProvider v1alpha2.RequestParameters
 		response    v1alpha2.Response
@@ -427,6 +694,176 @@ func Test_generateRequestObject(t *testing.T) {
 				},
 			},
 		},
+		"NamespacedRoots": {
+			args: args{
+				forProvider: v1alpha2.RequestParameters{
+					Payload: v1alpha2.Payload{
+						Body: `{"response": "spec-value"}`,
+					},
+					JQObject: &v1alpha2.JQObjectConfig{},
+				},
+				response: v1alpha2.Response{
+					StatusCode: 200,
+					Body:       `{"id": "123"}`,
+				},
+			},
+			want: want{
+				result: map[string]any{
+					"spec": map[string]any{
+						"mappings": nil,
+						"payload": map[string]any{
+							"body": map[string]any{"response": "spec-value"},
+						},
+						"jqObject": map[string]any{},
+					},
+					"response": map[string]any{
+						"body":       map[string]any{"id": "123"},
+						"statusCode": float64(200),
+					},
+				},
+			},
+		},
+		"CompressedResponseBody": {
+			args: args{
+				forProvider: v1alpha2.RequestParameters{
+					JQObject: &v1alpha2.JQObjectConfig{},
+				},
+				response: v1alpha2.Response{
+					StatusCode: 200,
+					Body:       utils.CompressBody(`{"id": "123", "padding": "`+strings.Repeat("a", 512)+`"}`, &compressAlways),
+				},
+			},
+			want: want{
+				result: map[string]any{
+					"spec": map[string]any{
+						"mappings": nil,
+						"payload":  map[string]any{},
+						"jqObject": map[string]any{},
+					},
+					"response": map[string]any{
+						"body":       map[string]any{"id": "123", "padding": strings.Repeat("a", 512)},
+						"statusCode": float64(200),
+					},
+				},
+			},
+		},
+		"CustomRootsWithLegacyFields": {
+			args: args{
+				forProvider: v1alpha2.RequestParameters{
+					Payload: v1alpha2.Payload{
+						BaseUrl: "https://api.example.com/users",
+					},
+					JQObject: &v1alpha2.JQObjectConfig{
+						SpecRoot:         "params",
+						ResponseRoot:     "observed",
+						LegacyRootFields: true,
+					},
+				},
+				response: v1alpha2.Response{
+					StatusCode: 200,
+				},
+			},
+			want: want{
+				result: map[string]any{
+					"mappings": nil,
+					"payload": map[string]any{
+						"baseUrl": "https://api.example.com/users",
+					},
+					"jqObject": map[string]any{
+						"specRoot":         "params",
+						"responseRoot":     "observed",
+						"legacyRootFields": true,
+					},
+					"params": map[string]any{
+						"mappings": nil,
+						"payload": map[string]any{
+							"baseUrl": "https://api.example.com/users",
+						},
+						"jqObject": map[string]any{
+							"specRoot":         "params",
+							"responseRoot":     "observed",
+							"legacyRootFields": true,
+						},
+					},
+					"observed": map[string]any{
+						"statusCode": float64(200),
+					},
+				},
+			},
+		},
+		"DefaultsMergedBeneathFields": {
+			args: args{
+				forProvider: v1alpha2.RequestParameters{
+					Payload: v1alpha2.Payload{
+						Body: `{"name": "john", "limits": {"memory": "2Gi"}, "tags": ["web"]}`,
+					},
+					Defaults: `{"payload": {"baseUrl": "https://api.example.com/users", "body": {"region": "eu-west-1", "limits": {"cpu": "1", "memory": "1Gi"}, "tags": ["default"]}}}`,
+					JQObject: &v1alpha2.JQObjectConfig{},
+				},
+				response: v1alpha2.Response{
+					StatusCode: 200,
+				},
+			},
+			want: want{
+				result: map[string]any{
+					"spec": map[string]any{
+						"mappings": nil,
+						"payload": map[string]any{
+							"baseUrl": "https://api.example.com/users",
+							"body": map[string]any{
+								"name":   "john",
+								"region": "eu-west-1",
+								"limits": map[string]any{"cpu": "1", "memory": "2Gi"},
+								"tags":   []any{"web"},
+							},
+						},
+						"defaults": map[string]any{
+							"payload": map[string]any{
+								"baseUrl": "https://api.example.com/users",
+								"body": map[string]any{
+									"region": "eu-west-1",
+									"limits": map[string]any{"cpu": "1", "memory": "1Gi"},
+									"tags":   []any{"default"},
+								},
+							},
+						},
+						"jqObject": map[string]any{},
+					},
+					"response": map[string]any{
+						"statusCode": float64(200),
+					},
+				},
+			},
+		},
+		"InvalidDefaultsIgnored": {
+			args: args{
+				forProvider: v1alpha2.RequestParameters{
+					Payload: v1alpha2.Payload{
+						BaseUrl: "https://api.example.com/users",
+					},
+					Defaults: `["not", "an", "object"]`,
+					JQObject: &v1alpha2.JQObjectConfig{},
+				},
+				response: v1alpha2.Response{
+					StatusCode: 200,
+				},
+			},
+			want: want{
+				result: map[string]any{
+					"spec": map[string]any{
+						"mappings": nil,
+						"payload": map[string]any{
+							"baseUrl": "https://api.example.com/users",
+						},
+						"defaults": `["not", "an", "object"]`,
+						"jqObject": map[string]any{},
+					},
+					"response": map[string]any{
+						"statusCode": float64(200),
+					},
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -438,3 +875,178 @@ func Test_generateRequestObject(t *testing.T) {
 		})
 	}
 }
+
+func Test_generateBody_TrimBody(t *testing.T) {
+	jqObject := map[string]interface{}{"payload": map[string]interface{}{"id": "123"}}
+	bodyTemplate := "\n  {\"id\": \"{{ .payload.id }}\"}\n"
+
+	cases := map[string]struct {
+		trimBody bool
+		want     string
+	}{
+		"ShouldSendBodyAsRenderedByDefault": {
+			trimBody: false,
+			want:     "\n  {\"id\": \"123\"}\n",
+		},
+		"ShouldTrimSurroundingWhitespace": {
+			trimBody: true,
+			want:     `{"id": "123"}`,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got, err := generateBody(context.Background(), nil, goTemplateRenderer{}, bodyTemplate, tc.trimBody, jqObject)
+			if err != nil {
+				t.Fatalf("generateBody(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Encrypted); diff != "" {
+				t.Errorf("generateBody(...): -want body, +got body: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want, got.Decrypted); diff != "" {
+				t.Errorf("generateBody(...): -want sent body, +got sent body: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_GenerateRequestDetails_Defaults(t *testing.T) {
+	mapping := v1alpha2.Mapping{
+		Method: "POST",
+		Body:   "{ name: .payload.body.name, region: .payload.body.region }",
+		URL:    ".payload.baseUrl",
+	}
+
+	type want struct {
+		body string
+		err  error
+	}
+	cases := map[string]struct {
+		defaults string
+		want     want
+	}{
+		"DefaultsFillMissingFields": {
+			defaults: `{"payload": {"body": {"region": "eu-west-1"}}}`,
+			want:     want{body: `{"name":"john_doe","region":"eu-west-1"}`},
+		},
+		"FieldsOverrideDefaults": {
+			defaults: `{"payload": {"body": {"name": "default", "region": "eu-west-1"}}}`,
+			want:     want{body: `{"name":"john_doe","region":"eu-west-1"}`},
+		},
+		"InvalidDefaults": {
+			defaults: `{"payload": `,
+			want:     want{err: errors.New(errInvalidDefaults)},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+		t.Run(name, func(t *testing.T) {
+			forProvider := v1alpha2.RequestParameters{
+				Payload: v1alpha2.Payload{
+					BaseUrl: "https://api.example.com/users",
+					Body:    `{"name": "john_doe"}`,
+				},
+				Defaults: tc.defaults,
+			}
+
+			got, gotErr, _ := GenerateRequestDetails(context.Background(), nil, mapping, forProvider, v1alpha2.Response{})
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("GenerateRequestDetails(...): -want error, +got error: %s", diff)
+			}
+			if gotErr != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.body, got.Body.Encrypted); diff != "" {
+				t.Errorf("GenerateRequestDetails(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_GenerateRequestDetails_RedactPaths(t *testing.T) {
+	forProvider := v1alpha2.RequestParameters{
+		Payload: v1alpha2.Payload{
+			BaseUrl: "https://api.example.com/users",
+			Body:    `{"name": "john_doe", "password": "hunter2", "contacts": [{"phone": "555-0100", "ssn": "078-05-1120"}]}`,
+		},
+	}
+
+	type want struct {
+		shown string
+		sent  string
+		err   error
+	}
+	cases := map[string]struct {
+		body        string
+		redactPaths []string
+		unredacted  bool
+		want        want
+	}{
+		"NestedPathsRedacted": {
+			body:        "{ name: .payload.body.name, password: .payload.body.password, contacts: .payload.body.contacts }",
+			redactPaths: []string{".password", ".contacts.ssn"},
+			want: want{
+				shown: `{"contacts":[{"phone":"555-0100","ssn":"****"}],"name":"john_doe","password":"****"}`,
+				sent:  `{"contacts":[{"phone":"555-0100","ssn":"078-05-1120"}],"name":"john_doe","password":"hunter2"}`,
+			},
+		},
+		"UnredactedBodyForDesiredState": {
+			body:        "{ name: .payload.body.name, password: .payload.body.password }",
+			redactPaths: []string{".password"},
+			unredacted:  true,
+			want: want{
+				shown: `{"name":"john_doe","password":"hunter2"}`,
+				sent:  `{"name":"john_doe","password":"hunter2"}`,
+			},
+		},
+		"MissingPathIgnored": {
+			body:        "{ name: .payload.body.name }",
+			redactPaths: []string{".password"},
+			want: want{
+				shown: `{"name":"john_doe"}`,
+				sent:  `{"name":"john_doe"}`,
+			},
+		},
+		"BodyNotJSONRedactedAsAWhole": {
+			body:        `"password=" + .payload.body.password`,
+			redactPaths: []string{".password"},
+			want: want{
+				shown: "****",
+				sent:  "password=hunter2",
+			},
+		},
+		"InvalidPath": {
+			body:        "{ name: .payload.body.name }",
+			redactPaths: []string{"password"},
+			want: want{
+				err: errors.Wrap(errors.Errorf("invalid path %s, it must be . or a path of object fields such as .items or .data.results", "password"), errRedactPath),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+		t.Run(name, func(t *testing.T) {
+			mapping := v1alpha2.Mapping{Method: "POST", Body: tc.body, URL: ".payload.baseUrl", RedactPaths: tc.redactPaths}
+
+			ctx := context.Background()
+			if tc.unredacted {
+				ctx = WithUnredactedBody(ctx)
+			}
+
+			got, gotErr, _ := GenerateRequestDetails(ctx, nil, mapping, forProvider, v1alpha2.Response{})
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("GenerateRequestDetails(...): -want error, +got error: %s", diff)
+			}
+			if gotErr != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.shown, got.Body.Encrypted); diff != "" {
+				t.Errorf("GenerateRequestDetails(...): -want shown body, +got shown body: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.sent, got.Body.Decrypted); diff != "" {
+				t.Errorf("GenerateRequestDetails(...): -want sent body, +got sent body: %s", diff)
+			}
+		})
+	}
+}
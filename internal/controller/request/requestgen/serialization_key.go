@@ -0,0 +1,27 @@
+package requestgen
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+)
+
+const (
+	errSerializationKey = "cannot evaluate the serialization key %s"
+)
+
+// SerializationKey returns the key the reconciles of the request are serialized by, evaluated against the same
+// object as the mappings, or an empty key when the request sets none.
+func SerializationKey(forProvider v1alpha2.RequestParameters, response v1alpha2.Response) (string, error) {
+	if forProvider.SerializationKey == "" {
+		return "", nil
+	}
+
+	key, err := jq.ParseScalar(forProvider.SerializationKey, generateRequestObject(forProvider, response))
+	if err != nil {
+		return "", errors.Wrapf(err, errSerializationKey, forProvider.SerializationKey)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,149 @@
+package requestgen
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestprocessing"
+	datapatcher "github.com/crossplane-contrib/provider-http/internal/data-patcher"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+)
+
+const (
+	errParseTemplate  = "cannot parse Go template %s"
+	errRenderTemplate = "cannot render Go template %s"
+)
+
+// A renderer renders the URL, body and headers of a mapping against the jq object of the request.
+type renderer interface {
+	renderURL(urlTemplate string, data map[string]interface{}) (string, error)
+	renderBody(bodyTemplate string, data map[string]interface{}) (string, error)
+	renderValue(valueTemplate string, data map[string]interface{}) (interface{}, error)
+	renderHeaders(headers map[string][]string, data map[string]interface{}) (map[string][]string, error)
+}
+
+// rendererFor returns the renderer of the template engine and body encoding of the mapping.
+func rendererFor(mapping v1alpha2.Mapping) renderer {
+	var r renderer = jqRenderer{multipleResults: mapping.MultipleResults}
+	if mapping.TemplateEngine == v1alpha2.TemplateEngineGoTemplate {
+		r = goTemplateRenderer{}
+	}
+
+	if mapping.BodyEncoding == v1alpha2.BodyEncodingYAMLToJSON {
+		return yamlBodyRenderer{renderer: r}
+	}
+
+	return r
+}
+
+// jqRenderer renders jq filters, the default template engine.
+type jqRenderer struct {
+	// multipleResults defines how a URL or body filter yielding several results is handled.
+	multipleResults v1alpha2.MultipleResults
+}
+
+func (r jqRenderer) renderURL(urlTemplate string, data map[string]interface{}) (string, error) {
+	return requestprocessing.ApplyJQOnURLWithResults(urlTemplate, data, r.multipleResults)
+}
+
+func (r jqRenderer) renderBody(bodyTemplate string, data map[string]interface{}) (string, error) {
+	return requestprocessing.ApplyJQOnStrWithResults(requestprocessing.ConvertStringToJQQuery(bodyTemplate), data, r.multipleResults)
+}
+
+// renderValue evaluates a single value of a structured body. Values that aren't valid jq filters are kept as
+// literal strings, like header values are.
+func (jqRenderer) renderValue(valueTemplate string, data map[string]interface{}) (interface{}, error) {
+	value, err := jq.ParseInterface(valueTemplate, data)
+	if err != nil {
+		return valueTemplate, nil
+	}
+
+	return value, nil
+}
+
+func (jqRenderer) renderHeaders(headers map[string][]string, data map[string]interface{}) (map[string][]string, error) {
+	return requestprocessing.ApplyJQOnMapStrings(headers, data)
+}
+
+// goTemplateRenderer renders Go text/templates. Secret and object reference placeholders are kept as is, even
+// though they share the delimiters of Go template actions.
+type goTemplateRenderer struct{}
+
+var goTemplateFuncs = template.FuncMap{
+	"toJson": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"pathEncode": func(v interface{}) (string, error) {
+		value, err := jq.FormatURLValue(v)
+		return url.PathEscape(value), err
+	},
+	urlValueFunc: jq.FormatURLValue,
+}
+
+func (goTemplateRenderer) renderURL(urlTemplate string, data map[string]interface{}) (string, error) {
+	return renderGoTemplate(urlTemplate, data, formatURLValues)
+}
+
+func (goTemplateRenderer) renderBody(bodyTemplate string, data map[string]interface{}) (string, error) {
+	return renderGoTemplate(bodyTemplate, data)
+}
+
+func (goTemplateRenderer) renderValue(valueTemplate string, data map[string]interface{}) (interface{}, error) {
+	return renderGoTemplate(valueTemplate, data)
+}
+
+// renderHeaders renders every header value, omitting the values that render to an empty string and the headers
+// left without any value, like the jq engine does for null results.
+func (goTemplateRenderer) renderHeaders(headers map[string][]string, data map[string]interface{}) (map[string][]string, error) {
+	result := make(map[string][]string, len(headers))
+	for key, templates := range headers {
+		values := make([]string, 0, len(templates))
+		for _, t := range templates {
+			value, err := renderGoTemplate(t, data)
+			if err != nil {
+				return nil, err
+			}
+			if value != "" {
+				values = append(values, value)
+			}
+		}
+
+		if len(values) > 0 {
+			result[key] = values
+		}
+	}
+
+	return result, nil
+}
+
+// renderGoTemplate renders a Go template against the data, failing on keys missing from it. Placeholders are
+// turned into actions printing them literally before the template is parsed, and the parsed template is then
+// passed through the given rewrites.
+func renderGoTemplate(text string, data map[string]interface{}, rewrites ...func(*parse.Tree)) (string, error) {
+	escaped := datapatcher.ReplacePlaceholders(text, func(placeholder string) string {
+		return "{{" + strconv.Quote(placeholder) + "}}"
+	})
+
+	t, err := template.New("mapping").Option("missingkey=error").Funcs(goTemplateFuncs).Parse(escaped)
+	if err != nil {
+		return "", errors.Wrapf(err, errParseTemplate, text)
+	}
+	for _, rewrite := range rewrites {
+		rewrite(t.Tree)
+	}
+
+	var rendered strings.Builder
+	if err := t.Execute(&rendered, data); err != nil {
+		return "", errors.Wrapf(err, errRenderTemplate, text)
+	}
+
+	return rendered.String(), nil
+}
@@ -0,0 +1,49 @@
+package requestgen
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+var repeatedSlashes = regexp.MustCompile(`/{2,}`)
+
+// normalizeURL applies the configured normalization steps to the path of rawURL.
+// URLs that cannot be parsed are returned unchanged, so they are reported by the URL validation.
+func normalizeURL(rawURL string, normalization *v1alpha2.URLNormalization) string {
+	if normalization == nil {
+		return rawURL
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	u.Path = normalizePath(u.Path, normalization)
+	if u.RawPath != "" {
+		u.RawPath = normalizePath(u.RawPath, normalization)
+	}
+
+	return u.String()
+}
+
+// normalizePath collapses repeated slashes and applies the trailing slash policy to path.
+func normalizePath(path string, normalization *v1alpha2.URLNormalization) string {
+	if normalization.CollapseSlashes {
+		path = repeatedSlashes.ReplaceAllString(path, "/")
+	}
+
+	switch normalization.TrailingSlash {
+	case v1alpha2.TrailingSlashEnforce:
+		if !strings.HasSuffix(path, "/") {
+			path += "/"
+		}
+	case v1alpha2.TrailingSlashStrip:
+		path = strings.TrimRight(path, "/")
+	}
+
+	return path
+}
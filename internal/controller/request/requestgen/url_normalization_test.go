@@ -0,0 +1,115 @@
+package requestgen
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+func Test_normalizeURL(t *testing.T) {
+	type args struct {
+		rawURL        string
+		normalization *v1alpha2.URLNormalization
+	}
+	type want struct {
+		url string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoNormalization": {
+			args: args{
+				rawURL: "https://api.example.com//users/",
+			},
+			want: want{
+				url: "https://api.example.com//users/",
+			},
+		},
+		"CollapseSlashes": {
+			args: args{
+				rawURL:        "https://api.example.com//users///123",
+				normalization: &v1alpha2.URLNormalization{CollapseSlashes: true},
+			},
+			want: want{
+				url: "https://api.example.com/users/123",
+			},
+		},
+		"CollapseSlashesPreservesQuery": {
+			args: args{
+				rawURL:        "https://api.example.com/users//search?redirect=https://other.example.com//x&q=a%2Fb",
+				normalization: &v1alpha2.URLNormalization{CollapseSlashes: true},
+			},
+			want: want{
+				url: "https://api.example.com/users/search?redirect=https://other.example.com//x&q=a%2Fb",
+			},
+		},
+		"CollapseSlashesPreservesEscapedSlash": {
+			args: args{
+				rawURL:        "https://api.example.com//files/a%2Fb",
+				normalization: &v1alpha2.URLNormalization{CollapseSlashes: true},
+			},
+			want: want{
+				url: "https://api.example.com/files/a%2Fb",
+			},
+		},
+		"EnforceTrailingSlash": {
+			args: args{
+				rawURL:        "https://api.example.com/users?page=2",
+				normalization: &v1alpha2.URLNormalization{TrailingSlash: v1alpha2.TrailingSlashEnforce},
+			},
+			want: want{
+				url: "https://api.example.com/users/?page=2",
+			},
+		},
+		"EnforceTrailingSlashAlreadyPresent": {
+			args: args{
+				rawURL:        "https://api.example.com/users/",
+				normalization: &v1alpha2.URLNormalization{TrailingSlash: v1alpha2.TrailingSlashEnforce},
+			},
+			want: want{
+				url: "https://api.example.com/users/",
+			},
+		},
+		"StripTrailingSlash": {
+			args: args{
+				rawURL:        "https://api.example.com/users//?page=2",
+				normalization: &v1alpha2.URLNormalization{TrailingSlash: v1alpha2.TrailingSlashStrip},
+			},
+			want: want{
+				url: "https://api.example.com/users?page=2",
+			},
+		},
+		"CollapseAndEnforce": {
+			args: args{
+				rawURL: "https://api.example.com//users//123",
+				normalization: &v1alpha2.URLNormalization{
+					CollapseSlashes: true,
+					TrailingSlash:   v1alpha2.TrailingSlashEnforce,
+				},
+			},
+			want: want{
+				url: "https://api.example.com/users/123/",
+			},
+		},
+		"UnparsableURL": {
+			args: args{
+				rawURL:        "https://api.example.com/%zz//users",
+				normalization: &v1alpha2.URLNormalization{CollapseSlashes: true},
+			},
+			want: want{
+				url: "https://api.example.com/%zz//users",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := normalizeURL(tc.args.rawURL, tc.args.normalization)
+			if diff := cmp.Diff(tc.want.url, got); diff != "" {
+				t.Fatalf("normalizeURL(...): -want url, +got url: %s", diff)
+			}
+		})
+	}
+}
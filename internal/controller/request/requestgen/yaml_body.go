@@ -0,0 +1,79 @@
+package requestgen
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	errParseYAMLBody  = "cannot parse YAML body"
+	errEncodeYAMLBody = "cannot encode YAML body as JSON"
+)
+
+// yamlBodyRenderer renders bodies authored as YAML documents and sends them as JSON. The document is parsed
+// before it is rendered, and each of its string values is rendered on its own by the underlying renderer, so
+// rendered values can't break the structure of the document. Keys, numbers and booleans are kept as they are.
+type yamlBodyRenderer struct {
+	renderer
+}
+
+func (r yamlBodyRenderer) renderBody(bodyTemplate string, data map[string]interface{}) (string, error) {
+	converted, err := yaml.ToJSON([]byte(bodyTemplate))
+	if err != nil {
+		return "", errors.Wrap(err, errParseYAMLBody)
+	}
+
+	// Numbers are decoded as json.Number so that large integers keep their precision.
+	decoder := json.NewDecoder(bytes.NewReader(converted))
+	decoder.UseNumber()
+
+	var body interface{}
+	if err := decoder.Decode(&body); err != nil {
+		return "", errors.Wrap(err, errParseYAMLBody)
+	}
+
+	rendered, err := r.renderValues(body, data)
+	if err != nil {
+		return "", err
+	}
+
+	encoded, err := json.Marshal(rendered)
+	if err != nil {
+		return "", errors.Wrap(err, errEncodeYAMLBody)
+	}
+
+	return string(encoded), nil
+}
+
+// renderValues renders the string values nested in the value.
+func (r yamlBodyRenderer) renderValues(value interface{}, data map[string]interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		rendered := make(map[string]interface{}, len(v))
+		for key, nested := range v {
+			renderedValue, err := r.renderValues(nested, data)
+			if err != nil {
+				return nil, err
+			}
+			rendered[key] = renderedValue
+		}
+		return rendered, nil
+	case []interface{}:
+		rendered := make([]interface{}, 0, len(v))
+		for _, nested := range v {
+			renderedValue, err := r.renderValues(nested, data)
+			if err != nil {
+				return nil, err
+			}
+			rendered = append(rendered, renderedValue)
+		}
+		return rendered, nil
+	case string:
+		return r.renderValue(v, data)
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,114 @@
+package requestgen
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+func Test_yamlBodyRenderer_renderBody(t *testing.T) {
+	data := map[string]interface{}{
+		"payload": map[string]interface{}{
+			"body": map[string]interface{}{
+				"username": "john_doe",
+				"roles":    []interface{}{"admin", "dev"},
+			},
+		},
+	}
+
+	type args struct {
+		engine v1alpha2.TemplateEngine
+		body   string
+	}
+	type want struct {
+		body string
+		err  error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NestedBodyWithJQValues": {
+			args: args{
+				body: `
+user:
+  name: .payload.body.username
+  roles: .payload.body.roles
+  profile:
+    active: true
+    age: 42
+    id: 12345678901234567890
+    ratio: 0.5
+    nickname: null
+tags:
+  - team a
+  - .payload.body.username
+`,
+			},
+			want: want{
+				body: `{"tags":["team a","john_doe"],"user":{"name":"john_doe","profile":{"active":true,"age":42,"id":12345678901234567890,"nickname":null,"ratio":0.5},"roles":["admin","dev"]}}`,
+			},
+		},
+		"JQStringLiteralsKeptAsStrings": {
+			args: args{
+				body: `
+version: '"2"'
+enabled: '"true"'
+`,
+			},
+			want: want{
+				body: `{"enabled":"true","version":"2"}`,
+			},
+		},
+		"PlaceholdersKept": {
+			args: args{
+				body: `
+auth:
+  password: "{{secret-name:default:password}}"
+`,
+			},
+			want: want{
+				body: `{"auth":{"password":"{{secret-name:default:password}}"}}`,
+			},
+		},
+		"NestedBodyWithGoTemplateValues": {
+			args: args{
+				engine: v1alpha2.TemplateEngineGoTemplate,
+				body: `
+user:
+  name: "{{ .payload.body.username }}: admin"
+  count: 3
+`,
+			},
+			want: want{
+				body: `{"user":{"count":3,"name":"john_doe: admin"}}`,
+			},
+		},
+		"InvalidYAML": {
+			args: args{
+				body: "user: [a, b",
+			},
+			want: want{
+				err: errors.Wrap(errors.New("yaml: line 1: did not find expected ',' or ']'"), errParseYAMLBody),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			render := rendererFor(v1alpha2.Mapping{TemplateEngine: tc.args.engine, BodyEncoding: v1alpha2.BodyEncodingYAMLToJSON})
+			got, gotErr := render.renderBody(tc.args.body, data)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("renderBody(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.body, got); diff != "" {
+				t.Errorf("renderBody(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
@@ -2,8 +2,12 @@ package requestprocessing
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
 	"github.com/crossplane-contrib/provider-http/internal/jq"
 )
 
@@ -11,23 +15,69 @@ func ConvertStringToJQQuery(input string) string {
 	return strings.Join(strings.Fields(input), " ")
 }
 
+const (
+	errMultipleResults = "jq query %s returned %d results, set multipleResults to First or Join to accept several"
+	errResultFormat    = "jq query %s should return an object or a string, but returned: %s"
+)
+
 // ApplyJQOnStr applies a jq query to a Request, returning the result as a string.
-// The function handles complex results by converting them to JSON format.
+// The function handles complex results by converting them to JSON format. A query returning several results fails.
 func ApplyJQOnStr(jqQuery string, baseMap map[string]interface{}) (string, error) {
-	if result, _ := jq.ParseMapInterface(jqQuery, baseMap); result != nil {
-		transformedData, err := json.Marshal(result)
-		if err != nil {
-			return "", err
-		}
-		return string(transformedData), nil
-	}
+	return ApplyJQOnStrWithResults(jqQuery, baseMap, v1alpha2.MultipleResultsError)
+}
+
+// ApplyJQOnStrWithResults applies a jq query like ApplyJQOnStr, handling a query returning several results as
+// multipleResults defines: failing, the default, keeping the first result, or joining the results with newlines.
+func ApplyJQOnStrWithResults(jqQuery string, baseMap map[string]interface{}, multipleResults v1alpha2.MultipleResults) (string, error) {
+	return applyJQOnStr(jq.ParseAll, jqQuery, baseMap, multipleResults)
+}
 
-	stringResult, err := jq.ParseString(jqQuery, baseMap)
+// ApplyJQOnURLWithResults applies the jq query of a URL like ApplyJQOnStrWithResults, rendering the values it
+// substitutes into the URL as jq.ParseAllURL does.
+func ApplyJQOnURLWithResults(jqQuery string, baseMap map[string]interface{}, multipleResults v1alpha2.MultipleResults) (string, error) {
+	return applyJQOnStr(jq.ParseAllURL, jqQuery, baseMap, multipleResults)
+}
+
+func applyJQOnStr(parseAll func(string, interface{}) ([]interface{}, error), jqQuery string, baseMap map[string]interface{}, multipleResults v1alpha2.MultipleResults) (string, error) {
+	results, err := parseAll(jqQuery, baseMap)
 	if err != nil {
 		return "", err
 	}
 
-	return stringResult, nil
+	if len(results) > 1 {
+		switch multipleResults {
+		case v1alpha2.MultipleResultsFirst:
+			results = results[:1]
+		case v1alpha2.MultipleResultsJoin:
+		default:
+			return "", errors.Errorf(errMultipleResults, jqQuery, len(results))
+		}
+	}
+
+	rendered := make([]string, len(results))
+	for i, result := range results {
+		if rendered[i], err = resultToStr(jqQuery, result); err != nil {
+			return "", err
+		}
+	}
+
+	return strings.Join(rendered, "\n"), nil
+}
+
+// resultToStr returns a string result as is, and an object result in its JSON form.
+func resultToStr(jqQuery string, result interface{}) (string, error) {
+	switch v := result.(type) {
+	case string:
+		return v, nil
+	case map[string]interface{}:
+		transformedData, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(transformedData), nil
+	default:
+		return "", errors.Errorf(errResultFormat, jqQuery, fmt.Sprint(result))
+	}
 }
 
 // ApplyJQOnMapStrings applies the provided JQ queries to a map of strings, using the given Request.
@@ -5,6 +5,9 @@ import (
 
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
 )
 
 var testHeaders = map[string][]string{
@@ -167,6 +170,70 @@ func Test_ApplyJQOnMapStrings(t *testing.T) {
 				err: nil,
 			},
 		},
+		"OptionalHeaderPresent": {
+			args: args{
+				keyToJQQueries: map[string][]string{
+					"Authorization": {`if .payload.body.username then "Bearer " + .payload.body.username else null end`},
+				},
+				jqObject: testJQObject,
+			},
+			want: want{
+				result: map[string][]string{
+					"Authorization": {"Bearer john_doe"},
+				},
+				err: nil,
+			},
+		},
+		"OptionalHeaderOmittedWhenNull": {
+			args: args{
+				keyToJQQueries: map[string][]string{
+					"fruits":        {"apple"},
+					"Authorization": {`if .payload.body.token then "Bearer " + .payload.body.token else null end`},
+				},
+				jqObject: testJQObject,
+			},
+			want: want{
+				result: map[string][]string{
+					"fruits": {"apple"},
+				},
+				err: nil,
+			},
+		},
+		"EmptyValuesOmitted": {
+			args: args{
+				keyToJQQueries: map[string][]string{
+					"fruits":      {"apple", `""`, ".payload.body.missing"},
+					"X-Tenant-Id": {".payload.body.tenant"},
+				},
+				jqObject: testJQObject,
+			},
+			want: want{
+				result: map[string][]string{
+					"fruits": {"apple"},
+				},
+				err: nil,
+			},
+		},
+		"MultiValueHeaderForwarded": {
+			args: args{
+				keyToJQQueries: map[string][]string{
+					"Cookie": {`.response.headers["Set-Cookie"]`},
+				},
+				jqObject: map[string]any{
+					"response": map[string]any{
+						"headers": map[string]any{
+							"Set-Cookie": []any{"session=abc; Path=/", "theme=dark, light"},
+						},
+					},
+				},
+			},
+			want: want{
+				result: map[string][]string{
+					"Cookie": {"session=abc; Path=/", "theme=dark, light"},
+				},
+				err: nil,
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -181,3 +248,76 @@ func Test_ApplyJQOnMapStrings(t *testing.T) {
 		})
 	}
 }
+
+func Test_ApplyJQOnStrWithResults(t *testing.T) {
+	type args struct {
+		jqQuery         string
+		multipleResults v1alpha2.MultipleResults
+	}
+	type want struct {
+		result string
+		err    error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ZeroResultsFail": {
+			args: args{
+				jqQuery:         `.payload.body | empty`,
+				multipleResults: v1alpha2.MultipleResultsJoin,
+			},
+			want: want{
+				err: errors.Errorf("query should return at least one value, failed on: %s", `.payload.body | empty`),
+			},
+		},
+		"SingleResultKeptWithDefault": {
+			args: args{
+				jqQuery: `.payload.body.username`,
+			},
+			want: want{
+				result: "john_doe",
+			},
+		},
+		"MultipleResultsFailByDefault": {
+			args: args{
+				jqQuery: `.payload.body.username, .payload.body.email`,
+			},
+			want: want{
+				err: errors.Errorf(errMultipleResults, `.payload.body.username, .payload.body.email`, 2),
+			},
+		},
+		"MultipleResultsKeepFirst": {
+			args: args{
+				jqQuery:         `.payload.body.username, .payload.body.email`,
+				multipleResults: v1alpha2.MultipleResultsFirst,
+			},
+			want: want{
+				result: "john_doe",
+			},
+		},
+		"MultipleResultsJoined": {
+			args: args{
+				jqQuery:         `.mappings[] | { method }`,
+				multipleResults: v1alpha2.MultipleResultsJoin,
+			},
+			want: want{
+				result: "{\"method\":\"POST\"}\n{\"method\":\"GET\"}\n{\"method\":\"PUT\"}\n{\"method\":\"DELETE\"}",
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ApplyJQOnStrWithResults(tc.args.jqQuery, testJQObject, tc.args.multipleResults)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("ApplyJQOnStrWithResults(...): -want error, +got error: %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("ApplyJQOnStrWithResults(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package request
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errTransformResponse        = "cannot transform the response with %s"
+	errTransformResponseWarning = "Warning, couldn't transform the response, keeping it as is, error: %s"
+)
+
+// transformResponse replaces the body of a successful response with the result of the response transform of
+// the Request. Failed and empty responses are left as they are.
+func transformResponse(cr *v1alpha2.Request, mapping *v1alpha2.Mapping, details *httpClient.HttpDetails, responseErr error) error {
+	transform := cr.Spec.ForProvider.ResponseTransform
+	if transform == "" || responseErr != nil || details.HttpResponse.Body == "" || !utils.IsHTTPSuccess(details.HttpResponse.StatusCode) {
+		return nil
+	}
+
+	responseMap, err := json_util.ResponseToMap(details.HttpResponse, string(mapping.ResponseFormat))
+	if err != nil {
+		return errors.Wrapf(err, errTransformResponse, transform)
+	}
+
+	body, err := jq.ParseJSON(transform, responseMap)
+	if err != nil {
+		return errors.Wrapf(err, errTransformResponse, transform)
+	}
+
+	details.HttpResponse.Body = body
+	return nil
+}
+
+// transformSentResponse transforms the response of a request that changed the object. The request was sent
+// either way, so a failure to transform its response is only logged and the response is stored as is.
+func (c *external) transformSentResponse(cr *v1alpha2.Request, mapping *v1alpha2.Mapping, details *httpClient.HttpDetails, responseErr error) {
+	if err := transformResponse(cr, mapping, details, responseErr); err != nil {
+		c.logger.Info(fmt.Sprintf(errTransformResponseWarning, err.Error()))
+	}
+}
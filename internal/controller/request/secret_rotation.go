@@ -0,0 +1,80 @@
+package request
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	datapatcher "github.com/crossplane-contrib/provider-http/internal/data-patcher"
+)
+
+const (
+	// secretRefsIndex indexes Requests by the namespace/name of the secrets their placeholders reference.
+	secretRefsIndex = "spec.forProvider.secretRefs"
+
+	errSecretsFingerprint = "couldn't compute the fingerprint of the referenced secrets: %s"
+	infoSecretsRotated    = "a referenced secret was rotated, sending the PUT request again"
+)
+
+// indexSecretRefs returns the namespace/name of every secret referenced by the Request's placeholders.
+func indexSecretRefs(obj client.Object) []string {
+	cr, ok := obj.(*v1alpha2.Request)
+	if !ok {
+		return nil
+	}
+
+	keys := []string{}
+	seen := map[string]bool{}
+	for _, ref := range datapatcher.FindSecretRefs(cr.Spec.ForProvider) {
+		key := types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}.String()
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// requestsForSecret returns a function mapping a secret to the Requests referencing it, so they are
+// reconciled as soon as the secret changes.
+func requestsForSecret(kube client.Client) func(ctx context.Context, obj client.Object) []reconcile.Request {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		list := &v1alpha2.RequestList{}
+		key := types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}.String()
+		if err := kube.List(ctx, list, client.MatchingFields{secretRefsIndex: key}); err != nil {
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(list.Items))
+		for _, item := range list.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: item.Name, Namespace: item.Namespace}})
+		}
+		return requests
+	}
+}
+
+// secretsRotated reports whether a secret referenced by the Request changed since the last successful POST or
+// PUT request. Requests without a recorded fingerprint or without a PUT mapping are never considered rotated.
+func (c *external) secretsRotated(ctx context.Context, cr *v1alpha2.Request) bool {
+	if cr.Status.SecretsFingerprint == "" {
+		return false
+	}
+
+	if _, ok := getMappingByMethod(&cr.Spec.ForProvider, http.MethodPut); !ok {
+		return false
+	}
+
+	fingerprint, err := datapatcher.SecretsFingerprint(ctx, c.localKube, datapatcher.FindSecretRefs(cr.Spec.ForProvider))
+	if err != nil {
+		c.logger.Debug(fmt.Sprintf(errSecretsFingerprint, err.Error()))
+		return false
+	}
+
+	return fingerprint != cr.Status.SecretsFingerprint
+}
@@ -0,0 +1,116 @@
+package request
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	datapatcher "github.com/crossplane-contrib/provider-http/internal/data-patcher"
+)
+
+func withSecretHeader(r *v1alpha2.Request) {
+	r.Spec.ForProvider.Headers = map[string][]string{
+		"Authorization": {"Bearer {{auth:default:token}}"},
+		"X-Api-Key":     {"{{auth:default:key}}"},
+	}
+}
+
+func secretClient(token string) client.Client {
+	return &test.MockClient{
+		MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+			if secret, ok := obj.(*corev1.Secret); ok {
+				secret.Data = map[string][]byte{"token": []byte(token), "key": []byte("key")}
+			}
+			return nil
+		},
+	}
+}
+
+func Test_indexSecretRefs(t *testing.T) {
+	got := indexSecretRefs(httpRequest(withSecretHeader))
+	if diff := cmp.Diff([]string{"default/auth"}, got); diff != "" {
+		t.Fatalf("indexSecretRefs(...): -want keys, +got keys: %s", diff)
+	}
+}
+
+func Test_secretsRotated(t *testing.T) {
+	cr := httpRequest(withSecretHeader)
+	fingerprint, err := datapatcher.SecretsFingerprint(context.Background(), secretClient("old"), datapatcher.FindSecretRefs(cr.Spec.ForProvider))
+	if err != nil {
+		t.Fatalf("SecretsFingerprint(...): unexpected error: %s", err)
+	}
+
+	type args struct {
+		localKube client.Client
+		mg        *v1alpha2.Request
+	}
+	type want struct {
+		result bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Unchanged": {
+			args: args{
+				localKube: secretClient("old"),
+				mg: httpRequest(withSecretHeader, func(r *v1alpha2.Request) {
+					r.Status.SecretsFingerprint = fingerprint
+				}),
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"Rotated": {
+			args: args{
+				localKube: secretClient("new"),
+				mg: httpRequest(withSecretHeader, func(r *v1alpha2.Request) {
+					r.Status.SecretsFingerprint = fingerprint
+				}),
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"NoRecordedFingerprint": {
+			args: args{
+				localKube: secretClient("new"),
+				mg:        httpRequest(withSecretHeader),
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"NoPutMapping": {
+			args: args{
+				localKube: secretClient("new"),
+				mg: httpRequest(withSecretHeader, func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{testPostMapping, testGetMapping}
+					r.Status.SecretsFingerprint = fingerprint
+				}),
+			},
+			want: want{
+				result: false,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				localKube: tc.args.localKube,
+				logger:    logging.NewNopLogger(),
+			}
+			got := e.secretsRotated(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("e.secretsRotated(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
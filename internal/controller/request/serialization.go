@@ -0,0 +1,95 @@
+package request
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+)
+
+const (
+	// serializationRetryInterval is how long a reconcile waits to be retried when another reconcile holds its
+	// serialization key.
+	serializationRetryInterval = time.Second
+
+	errSerializeReconcile = "cannot serialize the reconcile of the request"
+	infoSerializationBusy = "serialization key %q is held by another reconcile, requeueing"
+)
+
+// keyLocks is an in-memory keyed mutex. Locks are only held for the keys in use.
+type keyLocks struct {
+	mu     sync.Mutex
+	locked map[string]bool
+}
+
+// newKeyLocks returns keyLocks with no key locked.
+func newKeyLocks() *keyLocks {
+	return &keyLocks{
+		locked: map[string]bool{},
+	}
+}
+
+// TryLock locks the given key. It returns false without locking when the key is already locked.
+func (l *keyLocks) TryLock(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.locked[key] {
+		return false
+	}
+
+	l.locked[key] = true
+	return true
+}
+
+// Unlock unlocks the given key.
+func (l *keyLocks) Unlock(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.locked, key)
+}
+
+// serializingReconciler runs the reconciles of the Requests sharing a serialization key one at a time. A
+// reconcile whose key is held is requeued instead of blocking a worker, so that Requests with other keys keep
+// being reconciled.
+type serializingReconciler struct {
+	kube       client.Client
+	logger     logging.Logger
+	locks      *keyLocks
+	reconciler reconcile.Reconciler
+}
+
+// Reconcile reconciles the Request once no other reconcile holds its serialization key.
+func (r *serializingReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	cr := &v1alpha2.Request{}
+	if err := r.kube.Get(ctx, req.NamespacedName, cr); err != nil {
+		// Let the wrapped reconciler handle missing Requests.
+		return r.reconciler.Reconcile(ctx, req)
+	}
+
+	key, err := requestgen.SerializationKey(cr.Spec.ForProvider, cr.Status.Response)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, errSerializeReconcile)
+	}
+
+	if key == "" {
+		return r.reconciler.Reconcile(ctx, req)
+	}
+
+	if !r.locks.TryLock(key) {
+		r.logger.Debug(fmt.Sprintf(infoSerializationBusy, key), "request", req.Name)
+		return reconcile.Result{RequeueAfter: serializationRetryInterval}, nil
+	}
+	defer r.locks.Unlock(key)
+
+	return r.reconciler.Reconcile(ctx, req)
+}
@@ -0,0 +1,139 @@
+package request
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+func withSerializationKey(key string) httpRequestModifier {
+	return func(r *v1alpha2.Request) {
+		r.Spec.ForProvider.SerializationKey = key
+	}
+}
+
+func Test_serializingReconciler_Reconcile(t *testing.T) {
+	type args struct {
+		cr   *v1alpha2.Request
+		held []string
+	}
+	type want struct {
+		result     reconcile.Result
+		reconciled bool
+		err        bool
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldReconcileWithoutKey": {
+			args: args{
+				cr:   httpRequest(),
+				held: []string{""},
+			},
+			want: want{reconciled: true},
+		},
+		"ShouldReconcileWhenKeyIsFree": {
+			args: args{
+				cr: httpRequest(withSerializationKey(`"users-" + .payload.body.username`)),
+			},
+			want: want{reconciled: true},
+		},
+		"ShouldReconcileWhenAnotherKeyIsHeld": {
+			args: args{
+				cr:   httpRequest(withSerializationKey(`"users-" + .payload.body.username`)),
+				held: []string{"users-jane_doe"},
+			},
+			want: want{reconciled: true},
+		},
+		"ShouldRequeueWhenKeyIsHeld": {
+			args: args{
+				cr:   httpRequest(withSerializationKey(`"users-" + .payload.body.username`)),
+				held: []string{"users-john_doe"},
+			},
+			want: want{result: reconcile.Result{RequeueAfter: serializationRetryInterval}},
+		},
+		"ShouldFailOnInvalidKey": {
+			args: args{
+				cr: httpRequest(withSerializationKey(`.payload.body.missing`)),
+			},
+			want: want{err: true},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			locks := newKeyLocks()
+			for _, key := range tc.args.held {
+				locks.TryLock(key)
+			}
+
+			reconciled := false
+			r := &serializingReconciler{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						*obj.(*v1alpha2.Request) = *tc.args.cr
+						return nil
+					}),
+				},
+				logger: logging.NewNopLogger(),
+				locks:  locks,
+				reconciler: reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+					reconciled = true
+					return reconcile.Result{}, nil
+				}),
+			}
+
+			got, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: testRequestName, Namespace: testNamespace}})
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("Reconcile(...): -want error, +got error: %s (error: %v)", diff, err)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("Reconcile(...): -want result, +got result: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.reconciled, reconciled); diff != "" {
+				t.Errorf("Reconcile(...): -want reconciled, +got reconciled: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_serializingReconciler_ReleasesKey(t *testing.T) {
+	cr := httpRequest(withSerializationKey(`"users"`))
+	locks := newKeyLocks()
+
+	r := &serializingReconciler{
+		kube: &test.MockClient{
+			MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+				*obj.(*v1alpha2.Request) = *cr
+				return nil
+			}),
+		},
+		logger: logging.NewNopLogger(),
+		locks:  locks,
+		reconciler: reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+			if locks.TryLock("users") {
+				t.Errorf("Reconcile(...): want the key held while reconciling")
+			}
+			return reconcile.Result{}, errors.New("boom")
+		}),
+	}
+
+	if _, err := r.Reconcile(context.Background(), reconcile.Request{}); err == nil {
+		t.Fatalf("Reconcile(...): want the error of the wrapped reconciler")
+	}
+	if !locks.TryLock("users") {
+		t.Errorf("Reconcile(...): want the key released after reconciling")
+	}
+}
@@ -0,0 +1,65 @@
+package statushandler
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+)
+
+const (
+	errInjectAnnotations     = "failed to inject response data into the annotations"
+	errExtractAnnotationData = "Warning, couldn't extract the value of annotation %s, error: %s"
+)
+
+// injectAnnotations sets the annotations of the Request configured to receive values from the response. The
+// Request is only patched when a value changed, since every update of the annotations triggers a new reconcile.
+func (r *requestStatusHandler) injectAnnotations() error {
+	configs := r.forProvider.AnnotationInjectionConfigs
+	if len(configs) == 0 {
+		return nil
+	}
+
+	var responseFormat string
+	if mapping, ok := mappingOf(r.forProvider, r.resource.HttpRequest); ok {
+		responseFormat = string(mapping.ResponseFormat)
+	}
+
+	responseMap, err := json_util.ResponseToMap(r.resource.HttpResponse, responseFormat)
+	if err != nil {
+		return errors.Wrap(err, errConvertResToMap)
+	}
+
+	cr := r.resource.Resource
+	annotations := cr.GetAnnotations()
+	changed := map[string]string{}
+	for _, config := range configs {
+		value, err := jq.ParseScalar(config.ResponsePath, responseMap)
+		if err != nil {
+			r.logger.Info(fmt.Sprintf(errExtractAnnotationData, config.Annotation, err.Error()))
+			continue
+		}
+
+		if current, ok := annotations[config.Annotation]; !ok || current != value {
+			changed[config.Annotation] = value
+		}
+	}
+
+	if len(changed) == 0 {
+		return nil
+	}
+
+	patch := client.MergeFrom(cr.DeepCopyObject().(client.Object))
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	for key, value := range changed {
+		annotations[key] = value
+	}
+	cr.SetAnnotations(annotations)
+
+	return errors.Wrap(r.resource.LocalClient.Patch(r.resource.RequestContext, cr, patch), errInjectAnnotations)
+}
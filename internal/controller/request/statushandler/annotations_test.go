@@ -0,0 +1,118 @@
+package statushandler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+func Test_injectAnnotations(t *testing.T) {
+	configs := []v1alpha2.AnnotationInjectionConfig{
+		{Annotation: "example.com/id", ResponsePath: ".body.id"},
+		{Annotation: "example.com/ready", ResponsePath: ".body.ready"},
+		{Annotation: "example.com/missing", ResponsePath: ".body.missing"},
+	}
+
+	type args struct {
+		annotations map[string]string
+		patchErr    error
+	}
+	type want struct {
+		annotations map[string]string
+		patches     int
+		err         error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldPatchNewValues": {
+			args: args{
+				annotations: map[string]string{"other": "kept"},
+			},
+			want: want{
+				annotations: map[string]string{"other": "kept", "example.com/id": "123", "example.com/ready": "true"},
+				patches:     1,
+			},
+		},
+		"ShouldNotPatchUnchangedValues": {
+			args: args{
+				annotations: map[string]string{"example.com/id": "123", "example.com/ready": "true"},
+			},
+			want: want{
+				annotations: map[string]string{"example.com/id": "123", "example.com/ready": "true"},
+			},
+		},
+		"ShouldPatchChangedValues": {
+			args: args{
+				annotations: map[string]string{"example.com/id": "122", "example.com/ready": "true"},
+			},
+			want: want{
+				annotations: map[string]string{"example.com/id": "123", "example.com/ready": "true"},
+				patches:     1,
+			},
+		},
+		"ShouldFailWhenPatchFails": {
+			args: args{
+				patchErr: errBoom,
+			},
+			want: want{
+				annotations: map[string]string{"example.com/id": "123", "example.com/ready": "true"},
+				patches:     1,
+				err:         errors.Wrap(errBoom, errInjectAnnotations),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			patches := 0
+			localKube := &test.MockClient{
+				MockPatch: func(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					patches++
+					return tc.args.patchErr
+				},
+			}
+
+			cr := testCr.DeepCopy()
+			cr.Spec.ForProvider.AnnotationInjectionConfigs = configs
+			cr.SetAnnotations(tc.args.annotations)
+
+			r := &requestStatusHandler{
+				logger:      logging.NewNopLogger(),
+				forProvider: cr.Spec.ForProvider,
+				resource: &utils.RequestResource{
+					Resource:       cr,
+					RequestContext: context.Background(),
+					LocalClient:    localKube,
+					HttpRequest:    testRequest,
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 200,
+						Body:       `{"id":123,"ready":true}`,
+					},
+				},
+			}
+
+			gotErr := r.injectAnnotations()
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("injectAnnotations(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.annotations, cr.GetAnnotations()); diff != "" {
+				t.Errorf("injectAnnotations(...): -want annotations, +got annotations: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.patches, patches); diff != "" {
+				t.Errorf("injectAnnotations(...): -want patches, +got patches: %s", diff)
+			}
+		})
+	}
+}
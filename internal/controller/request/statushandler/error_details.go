@@ -0,0 +1,52 @@
+package statushandler
+
+import (
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+// errorDetails returns the setter of the error code and message extracted from the failure response. The code
+// is left empty and the message falls back to the raw body, unless it is sensitive, when they can't be extracted.
+// It returns nil when the Request doesn't configure how to extract them.
+func (r *requestStatusHandler) errorDetails() utils.SetRequestStatusFunc {
+	config := r.forProvider.ErrorDetails
+	if config == nil {
+		return nil
+	}
+
+	code, message := "", r.stored.HttpResponse.Body
+
+	var responseFormat string
+	if mapping, ok := mappingOf(r.forProvider, r.resource.HttpRequest); ok {
+		responseFormat = string(mapping.ResponseFormat)
+	}
+
+	responseMap, err := json_util.ResponseToMap(r.resource.HttpResponse, responseFormat)
+	if err != nil {
+		return r.resource.SetErrorDetails(code, message)
+	}
+
+	if config.Code != "" {
+		if extracted, err := jq.ParseScalar(config.Code, responseMap); err == nil {
+			code = extracted
+		}
+	}
+
+	if config.Message != "" {
+		if extracted, err := jq.ParseScalar(config.Message, responseMap); err == nil && extracted != "" {
+			message = extracted
+		}
+	}
+
+	return r.resource.SetErrorDetails(code, message)
+}
+
+// appendErrorDetails appends the setter of the error details of the failure response, if any.
+func (r *requestStatusHandler) appendErrorDetails(combinedSetters []utils.SetRequestStatusFunc) []utils.SetRequestStatusFunc {
+	if setter := r.errorDetails(); setter != nil {
+		return append(combinedSetters, setter)
+	}
+
+	return combinedSetters
+}
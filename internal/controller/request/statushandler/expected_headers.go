@@ -0,0 +1,91 @@
+package statushandler
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errExpectedHeaderFormat = "expected header %s: JQ filter should return a boolean, but returned error: %s"
+	errExpectedHeaders      = "HTTP %s request response failed header assertions: %s"
+)
+
+// checkExpectedHeaders evaluates the expected header assertions of the mapping used for the request.
+// It returns an error listing the headers whose assertion did not hold, or nil if all of them passed.
+func (r *requestStatusHandler) checkExpectedHeaders() error {
+	mapping, ok := mappingOf(r.forProvider, r.resource.HttpRequest)
+	if !ok || len(mapping.ExpectedHeaders) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(mapping.ExpectedHeaders))
+	for name := range mapping.ExpectedHeaders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var failed []string
+	for _, name := range names {
+		values := headerValues(r.resource.HttpResponse.Headers, name)
+
+		passed, err := jq.ParseBool(mapping.ExpectedHeaders[name], values)
+		if err != nil {
+			return errors.Errorf(errExpectedHeaderFormat, name, err.Error())
+		}
+
+		if !passed {
+			failed = append(failed, name)
+		}
+	}
+
+	if len(failed) > 0 {
+		return errors.Errorf(errExpectedHeaders, r.resource.HttpRequest.Method, strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+// headerValues returns all values received for the named header, matching names case-insensitively.
+// Values of headers repeated under different casings are concatenated in the order of their names. It returns an
+// empty array if the header is missing.
+func headerValues(headers map[string][]string, name string) []interface{} {
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		if strings.EqualFold(key, name) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	values := []interface{}{}
+	for _, key := range keys {
+		for _, value := range headers[key] {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+// mappingOf returns the mapping the given request was sent with. The action recorded with the request tells apart
+// the mappings sharing its method, while a request recorded without one gets the first mapping of its method.
+func mappingOf(forProvider v1alpha2.RequestParameters, request httpClient.HttpRequest) (v1alpha2.Mapping, bool) {
+	if request.Action != "" {
+		return utils.MappingForAction(forProvider, utils.SentActionMethod(forProvider, request.Method, v1alpha2.MappingAction(request.Action)))
+	}
+
+	for _, mapping := range forProvider.Mappings {
+		if mapping.Method == request.Method {
+			return mapping, true
+		}
+	}
+
+	return v1alpha2.Mapping{}, false
+}
@@ -0,0 +1,45 @@
+package statushandler
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_headerValues(t *testing.T) {
+	cases := map[string]struct {
+		headers map[string][]string
+		name    string
+		want    []interface{}
+	}{
+		"RepeatedValuesKeptInOrder": {
+			headers: map[string][]string{"Set-Cookie": {"session=abc; Path=/", "theme=dark"}},
+			name:    "set-cookie",
+			want:    []interface{}{"session=abc; Path=/", "theme=dark"},
+		},
+		"CommaJoinedValueKeptAsOneValue": {
+			headers: map[string][]string{"Cache-Control": {"no-cache, no-store"}},
+			name:    "Cache-Control",
+			want:    []interface{}{"no-cache, no-store"},
+		},
+		"CasingsConcatenatedInNameOrder": {
+			headers: map[string][]string{"x-id": {"c"}, "X-Id": {"a", "b"}},
+			name:    "X-ID",
+			want:    []interface{}{"a", "b", "c"},
+		},
+		"MissingHeader": {
+			headers: map[string][]string{"Content-Type": {"application/json"}},
+			name:    "Set-Cookie",
+			want:    []interface{}{},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, headerValues(tc.headers, tc.name)); diff != "" {
+				t.Errorf("headerValues(...): -want values, +got values: %s", diff)
+			}
+		})
+	}
+}
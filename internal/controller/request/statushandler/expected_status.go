@@ -0,0 +1,95 @@
+package statushandler
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errExpectedStatusFormat = "expected status: JQ filter should return a status code or an array of status codes, but returned: %s"
+)
+
+// classifyResponse returns the outcome of the response. A response no classification rule matches is checked
+// against the expected status of the mapping used for the request, when it computes one, instead of being
+// classified by its status code.
+func (r *requestStatusHandler) classifyResponse() (v1alpha2.ResponseOutcome, error) {
+	outcome, matched, err := utils.ClassifyResponseByRules(r.forProvider, r.resource.HttpRequest.Method, r.resource.HttpResponse)
+	if err != nil || matched {
+		return outcome, err
+	}
+
+	mapping, ok := mappingOf(r.forProvider, r.resource.HttpRequest)
+	if !ok || mapping.ExpectedStatus == "" {
+		return utils.ClassifyStatusCode(r.resource.HttpResponse.StatusCode), nil
+	}
+
+	codes, err := r.expectedStatusCodes(mapping.ExpectedStatus)
+	if err != nil {
+		return "", err
+	}
+
+	if slices.Contains(codes, r.resource.HttpResponse.StatusCode) {
+		return v1alpha2.ResponseOutcomeSuccess, nil
+	}
+
+	return v1alpha2.ResponseOutcomeTerminalError, nil
+}
+
+// expectedStatusCodes evaluates the expected status expression against the request and the response.
+func (r *requestStatusHandler) expectedStatusCodes(expectedStatus string) ([]int, error) {
+	requestMap, err := json_util.StructToMap(r.resource.HttpRequest)
+	if err != nil {
+		return nil, errors.Wrap(err, errConvertResToMap)
+	}
+
+	responseMap, err := json_util.StructToMap(r.resource.HttpResponse)
+	if err != nil {
+		return nil, errors.Wrap(err, errConvertResToMap)
+	}
+
+	json_util.ConvertJSONStringsToMaps(&requestMap)
+	json_util.ConvertJSONStringsToMaps(&responseMap)
+
+	result, err := jq.ParseInterface(expectedStatus, map[string]interface{}{"request": requestMap, "response": responseMap})
+	if err != nil {
+		return nil, errors.Errorf(errExpectedStatusFormat, err.Error())
+	}
+
+	values, ok := result.([]interface{})
+	if !ok {
+		values = []interface{}{result}
+	}
+
+	codes := make([]int, 0, len(values))
+	for _, value := range values {
+		code, ok := statusCode(value)
+		if !ok {
+			return nil, errors.Errorf(errExpectedStatusFormat, fmt.Sprint(result))
+		}
+		codes = append(codes, code)
+	}
+
+	return codes, nil
+}
+
+// statusCode returns the status code held by an integral jq number.
+func statusCode(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case float64:
+		return int(v), v == float64(int(v))
+	case *big.Int:
+		return int(v.Int64()), v.IsInt64()
+	default:
+		return 0, false
+	}
+}
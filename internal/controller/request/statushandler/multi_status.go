@@ -0,0 +1,74 @@
+package statushandler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+)
+
+const (
+	// maxMultiStatusFailures bounds the number of failed sub-operations recorded in the status.
+	maxMultiStatusFailures = 10
+
+	errMultiStatusFormat  = "multi-status failures: JQ filter should return an array, but returned error: %s"
+	errMultiStatusFailure = "HTTP %s request partially failed, %d of its sub-operations failed: %s"
+)
+
+// multiStatusFailures returns the failed sub-operations of a 207 Multi-Status response in their JSON form. It
+// returns nil for other responses, or when the Request doesn't configure how to check them.
+func (r *requestStatusHandler) multiStatusFailures() ([]string, error) {
+	config := r.forProvider.MultiStatus
+	if config == nil || r.resource.HttpResponse.StatusCode != http.StatusMultiStatus {
+		return nil, nil
+	}
+
+	var responseFormat string
+	if mapping, ok := mappingOf(r.forProvider, r.resource.HttpRequest); ok {
+		responseFormat = string(mapping.ResponseFormat)
+	}
+
+	responseMap, err := json_util.ResponseToMap(r.resource.HttpResponse, responseFormat)
+	if err != nil {
+		return nil, errors.Wrap(err, errConvertResToMap)
+	}
+
+	result, err := jq.ParseJSON(config.Failures, responseMap)
+	if err != nil {
+		return nil, errors.Errorf(errMultiStatusFormat, err.Error())
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal([]byte(result), &items); err != nil {
+		return nil, errors.Errorf(errMultiStatusFormat, result)
+	}
+
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	failures := make([]string, 0, len(items))
+	for _, item := range items {
+		failures = append(failures, string(item))
+	}
+
+	return failures, nil
+}
+
+// multiStatusError returns the error of a request whose sub-operations failed.
+func (r *requestStatusHandler) multiStatusError(failures []string) error {
+	return errors.Errorf(errMultiStatusFailure, r.resource.HttpRequest.Method, len(failures), strings.Join(recordedFailures(failures), ", "))
+}
+
+// recordedFailures returns the failures kept in the status.
+func recordedFailures(failures []string) []string {
+	if len(failures) > maxMultiStatusFailures {
+		return failures[:maxMultiStatusFailures]
+	}
+
+	return failures
+}
@@ -0,0 +1,48 @@
+package statushandler
+
+import (
+	"fmt"
+	"net/http"
+	neturl "net/url"
+
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errOperationURL = "Warning, couldn't extract the URL of the operation, no operation is tracked, error: %s"
+)
+
+// appendOperation records the long running operation started by a successful write request, if its response
+// has the URL of one. Relative URLs, such as the ones of Location headers, are resolved against the request URL.
+func (r *requestStatusHandler) appendOperation(combinedSetters *[]utils.SetRequestStatusFunc) {
+	config := r.forProvider.AsyncOperation
+	if config == nil || r.actionMethod() == http.MethodGet {
+		return
+	}
+
+	var responseFormat string
+	if mapping, ok := mappingOf(r.forProvider, r.resource.HttpRequest); ok {
+		responseFormat = string(mapping.ResponseFormat)
+	}
+
+	responseMap, err := json_util.ResponseToMap(r.resource.HttpResponse, responseFormat)
+	if err != nil {
+		r.logger.Info(fmt.Sprintf(errOperationURL, err.Error()))
+		return
+	}
+
+	url, err := jq.ParseString(config.URL, responseMap)
+	if err != nil || url == "" {
+		return
+	}
+
+	if base, err := neturl.Parse(r.resource.HttpRequest.URL); err == nil {
+		if ref, err := neturl.Parse(url); err == nil {
+			url = base.ResolveReference(ref).String()
+		}
+	}
+
+	*combinedSetters = append(*combinedSetters, r.resource.SetOperation(url))
+}
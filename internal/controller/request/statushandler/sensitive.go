@@ -0,0 +1,31 @@
+package statushandler
+
+import (
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+// storedResource returns the resource whose request and response are recorded in the status. For a sensitive
+// mapping, their bodies are omitted, and so are their headers when the mapping asks for it, while the resource
+// of the handler keeps them to check the response.
+func storedResource(resource *utils.RequestResource, forProvider v1alpha2.RequestParameters) *utils.RequestResource {
+	mapping, ok := mappingOf(forProvider, resource.HttpRequest)
+	if !ok || !mapping.Sensitive {
+		return resource
+	}
+
+	stored := *resource
+	stored.HttpRequest.Body = ""
+	stored.HttpResponse.Body = ""
+	if mapping.SensitiveHeaders {
+		stored.HttpRequest.Headers = nil
+		stored.HttpResponse.Headers = nil
+	}
+
+	return &stored
+}
+
+// isSensitive reports whether the request and response of the handler are kept out of the status.
+func (r *requestStatusHandler) isSensitive() bool {
+	return r.stored != r.resource
+}
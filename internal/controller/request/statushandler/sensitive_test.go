@@ -0,0 +1,95 @@
+package statushandler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_SetRequestStatus_Sensitive(t *testing.T) {
+	secretBody := `{"password":"s3cr3t"}`
+	secretHeaders := map[string][]string{"Authorization": {"Bearer s3cr3t"}}
+
+	sensitiveCr := func(sensitiveHeaders bool, retryableResponse string) *v1alpha2.Request {
+		cr := testCr.DeepCopy()
+		cr.Spec.ForProvider.RetryableResponse = retryableResponse
+		for i := range cr.Spec.ForProvider.Mappings {
+			if cr.Spec.ForProvider.Mappings[i].Method == testMethod {
+				cr.Spec.ForProvider.Mappings[i].Sensitive = true
+				cr.Spec.ForProvider.Mappings[i].SensitiveHeaders = sensitiveHeaders
+			}
+		}
+		return cr
+	}
+
+	type want struct {
+		err      error
+		response v1alpha2.Response
+		request  v1alpha2.Mapping
+	}
+	cases := map[string]struct {
+		cr   *v1alpha2.Request
+		want want
+	}{
+		"BodiesOmitted": {
+			cr: sensitiveCr(false, ""),
+			want: want{
+				response: v1alpha2.Response{StatusCode: 200, Headers: secretHeaders},
+				request:  v1alpha2.Mapping{Method: testMethod, URL: testRequest.URL, Headers: secretHeaders},
+			},
+		},
+		"HeadersOmitted": {
+			cr: sensitiveCr(true, ""),
+			want: want{
+				response: v1alpha2.Response{StatusCode: 200},
+				request:  v1alpha2.Mapping{Method: testMethod, URL: testRequest.URL},
+			},
+		},
+		"RetryableResponseKeptOutOfError": {
+			cr: sensitiveCr(false, `.body.password == "s3cr3t"`),
+			want: want{
+				err:     errors.Errorf(errRetryableResponse, testMethod, 200, ""),
+				request: v1alpha2.Mapping{Method: testMethod, URL: testRequest.URL, Headers: secretHeaders},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			localKube := &test.MockClient{
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				MockGet:          test.NewMockGetFn(nil),
+			}
+			details := httpClient.HttpDetails{
+				HttpResponse: httpClient.HttpResponse{StatusCode: 200, Body: secretBody, Headers: secretHeaders},
+				HttpRequest:  httpClient.HttpRequest{Method: testMethod, URL: testRequest.URL, Body: secretBody, Headers: secretHeaders},
+			}
+
+			r, _ := NewStatusHandler(context.Background(), tc.cr, details, nil, localKube, logging.NewNopLogger())
+			gotErr := r.SetRequestStatus()
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("SetRequestStatus(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.response, tc.cr.Status.Response); diff != "" {
+				t.Errorf("SetRequestStatus(...): -want response, +got response: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.request, tc.cr.Status.RequestDetails); diff != "" {
+				t.Errorf("SetRequestStatus(...): -want request details, +got request details: %s", diff)
+			}
+			if diff := cmp.Diff(v1alpha2.Response{}, tc.cr.Status.Cache.Response); diff != "" {
+				t.Errorf("SetRequestStatus(...): -want no cached response, +got cached response: %s", diff)
+			}
+			if tc.cr.Status.LastAppliedBody != "" {
+				t.Errorf("SetRequestStatus(...): want no last applied body, got %s", tc.cr.Status.LastAppliedBody)
+			}
+		})
+	}
+}
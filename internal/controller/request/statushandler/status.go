@@ -2,13 +2,17 @@ package statushandler
 
 import (
 	"context"
+	"fmt"
 	"net/http"
-	"strconv"
+	"unicode/utf8"
 
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
 	"github.com/crossplane-contrib/provider-http/internal/controller/request/responseconverter"
+	datapatcher "github.com/crossplane-contrib/provider-http/internal/data-patcher"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
 	"github.com/crossplane-contrib/provider-http/internal/utils"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/pkg/errors"
@@ -16,6 +20,24 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	errConvertResToMap    = "failed to convert response to map"
+	errRetryableFormat    = "JQ filter should return a boolean, but returned error: %s"
+	errResetFailuresCond  = "resetFailuresCondition: JQ filter should return a boolean, but returned error: %s"
+	errRetryableResponse  = "HTTP %s request returned a retryable response with status code %d: %s"
+	errSecretsFingerprint = "couldn't compute the fingerprint of the referenced secrets: %s"
+
+	// maxErrorBodyLength is the number of bytes of a retryable response body quoted in status.error.
+	maxErrorBodyLength = 256
+)
+
+// methodActions are the actions recorded for the successful requests of each method.
+var methodActions = map[string]v1alpha2.RequestAction{
+	http.MethodPost:   v1alpha2.RequestActionCreated,
+	http.MethodPut:    v1alpha2.RequestActionUpdated,
+	http.MethodDelete: v1alpha2.RequestActionDeleted,
+}
+
 // RequestStatusHandler is the interface to interact with status setting for v1alpha2.Request
 type RequestStatusHandler interface {
 	SetRequestStatus() error
@@ -25,9 +47,11 @@ type RequestStatusHandler interface {
 // requestStatusHandler sets the request status.
 // it checks wether to set cache, and failures count.
 type requestStatusHandler struct {
-	logger        logging.Logger
-	extraSetters  *[]utils.SetRequestStatusFunc
-	resource      *utils.RequestResource
+	logger       logging.Logger
+	extraSetters *[]utils.SetRequestStatusFunc
+	resource     *utils.RequestResource
+	// stored is the resource whose request and response are recorded in the status, see storedResource.
+	stored        *utils.RequestResource
 	responseError error
 	forProvider   v1alpha2.RequestParameters
 }
@@ -42,31 +66,70 @@ func (r *requestStatusHandler) SetRequestStatus() error {
 	}
 
 	basicSetters := []utils.SetRequestStatusFunc{
-		r.resource.SetStatusCode(),
-		r.resource.SetHeaders(),
-		r.resource.SetBody(),
-		r.resource.SetRequestDetails(),
+		r.stored.SetStatusCode(),
+		r.stored.SetHeaders(),
+		r.stored.SetBody(),
+		r.stored.SetRequestDetails(),
 	}
 
 	basicSetters = append(basicSetters, *r.extraSetters...)
 
-	if utils.IsHTTPError(r.resource.HttpResponse.StatusCode) {
-		return r.incrementFailuresAndReturn(basicSetters)
+	outcome, err := r.classifyResponse()
+	if err != nil {
+		return r.setErrorAndReturn(err)
 	}
 
-	if utils.IsHTTPSuccess(r.resource.HttpResponse.StatusCode) {
+	switch {
+	case utils.IsCreateConflict(r.forProvider, r.actionMethod(), r.resource.HttpResponse.StatusCode):
+		// The object already exists, so the conflict response is recorded as a successful creation.
+		r.appendExtraSetters(r.forProvider, &basicSetters)
+	case outcome == v1alpha2.ResponseOutcomeRetryableError:
+		return r.retryAndReturn()
+	case outcome == v1alpha2.ResponseOutcomeTerminalError || outcome == v1alpha2.ResponseOutcomeNotFound:
+		return r.incrementFailuresAndReturn(basicSetters)
+	case outcome == v1alpha2.ResponseOutcomeSuccess:
+		if err := r.checkExpectedHeaders(); err != nil {
+			return r.failAndReturn(basicSetters, err)
+		}
+
+		failures, err := r.multiStatusFailures()
+		if err != nil {
+			return r.setErrorAndReturn(err)
+		}
+
+		basicSetters = append(basicSetters, r.resource.SetMultiStatusFailures(recordedFailures(failures)))
+		if len(failures) > 0 {
+			return r.failAndReturn(basicSetters, r.multiStatusError(failures))
+		}
+
+		isRetryable, err := r.isRetryableResponse()
+		if err != nil {
+			return r.setErrorAndReturn(err)
+		}
+
+		if isRetryable {
+			return r.retryAndReturn()
+		}
+
 		r.appendExtraSetters(r.forProvider, &basicSetters)
 	}
 
+	basicSetters = append(basicSetters, r.resource.RecordLatency(true))
 	if settingError := utils.SetRequestResourceStatus(*r.resource, basicSetters...); settingError != nil {
 		return errors.Wrap(settingError, utils.ErrFailedToSetStatus)
 	}
 
-	return nil
+	return r.injectAnnotations()
 }
 
 func (r *requestStatusHandler) setErrorAndReturn(err error) error {
-	if settingError := utils.SetRequestResourceStatus(*r.resource, r.resource.SetError(err)); settingError != nil {
+	setters := []utils.SetRequestStatusFunc{r.resource.SetError(err), r.resource.RecordLatency(false)}
+	if r.forProvider.ErrorDetails != nil {
+		// The error didn't come from a failure response, so the details of a previous one no longer apply.
+		setters = append(setters, r.resource.SetErrorDetails("", ""))
+	}
+
+	if settingError := utils.SetRequestResourceStatus(*r.resource, setters...); settingError != nil {
 		return errors.Wrap(settingError, utils.ErrFailedToSetStatus)
 	}
 
@@ -74,21 +137,155 @@ func (r *requestStatusHandler) setErrorAndReturn(err error) error {
 }
 
 func (r *requestStatusHandler) incrementFailuresAndReturn(combinedSetters []utils.SetRequestStatusFunc) error {
-	combinedSetters = append(combinedSetters, r.resource.SetError(nil)) // should increment failures counter
+	combinedSetters = append(combinedSetters, r.resource.SetError(nil), r.resource.RecordLatency(false)) // should increment failures counter
+	combinedSetters = r.appendErrorDetails(combinedSetters)
+	combinedSetters, err := r.appendFailuresReset(combinedSetters)
+	if err != nil {
+		return r.setErrorAndReturn(err)
+	}
 
 	if settingError := utils.SetRequestResourceStatus(*r.resource, combinedSetters...); settingError != nil {
 		return errors.Wrap(settingError, utils.ErrFailedToSetStatus)
 	}
 
-	return errors.Errorf(utils.ErrStatusCode, r.resource.HttpRequest.Method, strconv.Itoa(r.resource.HttpResponse.StatusCode))
+	return utils.StatusCodeError(r.resource.HttpRequest.Method, r.resource.HttpResponse.StatusCode)
+}
+
+// failAndReturn stores the response and marks the request as failed with the given error.
+func (r *requestStatusHandler) failAndReturn(combinedSetters []utils.SetRequestStatusFunc, err error) error {
+	combinedSetters = append(combinedSetters, r.resource.SetError(err), r.resource.RecordLatency(false))
+	combinedSetters, resetErr := r.appendFailuresReset(combinedSetters)
+	if resetErr != nil {
+		return r.setErrorAndReturn(resetErr)
+	}
+
+	if settingError := utils.SetRequestResourceStatus(*r.resource, combinedSetters...); settingError != nil {
+		return errors.Wrap(settingError, utils.ErrFailedToSetStatus)
+	}
+
+	return err
+}
+
+// retryAndReturn marks the request as failed without storing the response, so the
+// next reconcile sends the request again as if it had never succeeded.
+func (r *requestStatusHandler) retryAndReturn() error {
+	err := errors.Errorf(errRetryableResponse, r.resource.HttpRequest.Method, r.resource.HttpResponse.StatusCode, truncateBody(r.stored.HttpResponse.Body))
+
+	setters := r.appendErrorDetails([]utils.SetRequestStatusFunc{r.stored.SetRequestDetails(), r.resource.SetError(err), r.resource.RecordLatency(false)})
+	setters, resetErr := r.appendFailuresReset(setters)
+	if resetErr != nil {
+		return r.setErrorAndReturn(resetErr)
+	}
+
+	if settingError := utils.SetRequestResourceStatus(*r.resource, setters...); settingError != nil {
+		return errors.Wrap(settingError, utils.ErrFailedToSetStatus)
+	}
+
+	return err
+}
+
+// truncateBody cuts the body to maxErrorBodyLength bytes, backing up to the start of a rune so a multi-byte
+// character isn't split, and marks the cut with an ellipsis.
+func truncateBody(body string) string {
+	if len(body) <= maxErrorBodyLength {
+		return body
+	}
+
+	cut := maxErrorBodyLength
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+
+	return body[:cut] + "..."
+}
+
+// isRetryableResponse evaluates the RetryableResponse jq filter against the HTTP response.
+// If no filter is defined, the response is never considered retryable.
+func (r *requestStatusHandler) isRetryableResponse() (bool, error) {
+	if r.forProvider.RetryableResponse == "" {
+		return false, nil
+	}
+
+	responseMap, err := r.responseMap()
+	if err != nil {
+		return false, err
+	}
+
+	isRetryable, err := jq.ParseBool(r.forProvider.RetryableResponse, responseMap)
+	if err != nil {
+		return false, errors.Errorf(errRetryableFormat, err.Error())
+	}
+
+	return isRetryable, nil
+}
+
+// appendFailuresReset appends the reset of the failures counter to the setters of a failed request when the
+// ResetFailuresCondition jq filter matches its response. The setters must already count the failure.
+func (r *requestStatusHandler) appendFailuresReset(setters []utils.SetRequestStatusFunc) ([]utils.SetRequestStatusFunc, error) {
+	if r.forProvider.ResetFailuresCondition == "" {
+		return setters, nil
+	}
+
+	responseMap, err := r.responseMap()
+	if err != nil {
+		return setters, err
+	}
+
+	reset, err := jq.ParseBool(r.forProvider.ResetFailuresCondition, responseMap)
+	if err != nil {
+		return setters, errors.Errorf(errResetFailuresCond, err.Error())
+	}
+
+	if reset {
+		setters = append(setters, r.resource.ResetFailureCount())
+	}
+
+	return setters, nil
+}
+
+// responseMap returns the HTTP response as the object the jq filters are evaluated against.
+func (r *requestStatusHandler) responseMap() (map[string]interface{}, error) {
+	responseMap, err := json_util.StructToMap(r.resource.HttpResponse)
+	if err != nil {
+		return nil, errors.Wrap(err, errConvertResToMap)
+	}
+
+	json_util.ConvertJSONStringsToMaps(&responseMap)
+	return responseMap, nil
+}
+
+// actionMethod returns the standard method of the action the request was sent for, since custom methods, and
+// standard methods designated for another action, are handled like the method of the action they run.
+func (r *requestStatusHandler) actionMethod() string {
+	return utils.SentActionMethod(r.forProvider, r.resource.HttpRequest.Method, v1alpha2.MappingAction(r.resource.HttpRequest.Action))
 }
 
 func (r *requestStatusHandler) appendExtraSetters(forProvider v1alpha2.RequestParameters, combinedSetters *[]utils.SetRequestStatusFunc) {
-	if r.resource.HttpRequest.Method != http.MethodGet {
+	method := r.actionMethod()
+	if method != http.MethodGet {
 		*combinedSetters = append(*combinedSetters, r.resource.ResetFailures())
 	}
 
-	if r.shouldSetCache(forProvider) {
+	if method == http.MethodPut && !r.isSensitive() {
+		*combinedSetters = append(*combinedSetters, r.resource.SetLastAppliedBody())
+	}
+
+	if method == http.MethodPost || method == http.MethodPut {
+		r.appendSecretsFingerprint(forProvider, combinedSetters)
+		*combinedSetters = append(*combinedSetters, r.resource.SetLastAppliedGeneration(r.resource.Resource.GetGeneration()))
+	}
+
+	if method == http.MethodDelete {
+		*combinedSetters = append(*combinedSetters, r.resource.SetLastAppliedGeneration(0))
+	}
+
+	if action, ok := methodActions[method]; ok {
+		*combinedSetters = append(*combinedSetters, r.resource.SetLastAction(string(action)))
+	}
+
+	r.appendOperation(combinedSetters)
+
+	if !r.isSensitive() && r.shouldSetCache(forProvider) {
 		*combinedSetters = append(*combinedSetters, r.resource.SetCache())
 	}
 }
@@ -108,6 +305,18 @@ func (r *requestStatusHandler) shouldSetCache(forProvider v1alpha2.RequestParame
 	return true
 }
 
+// appendSecretsFingerprint records the fingerprint of the secrets referenced by the request, so their rotation
+// can be detected. If a secret can't be read, the previous fingerprint is kept.
+func (r *requestStatusHandler) appendSecretsFingerprint(forProvider v1alpha2.RequestParameters, combinedSetters *[]utils.SetRequestStatusFunc) {
+	fingerprint, err := datapatcher.SecretsFingerprint(r.resource.RequestContext, r.resource.LocalClient, datapatcher.FindSecretRefs(forProvider))
+	if err != nil {
+		r.logger.Debug(fmt.Sprintf(errSecretsFingerprint, err.Error()))
+		return
+	}
+
+	*combinedSetters = append(*combinedSetters, r.resource.SetSecretsFingerprint(fingerprint))
+}
+
 func (r *requestStatusHandler) ResetFailures() {
 	if r.extraSetters == nil {
 		r.extraSetters = &[]utils.SetRequestStatusFunc{}
@@ -123,16 +332,22 @@ func NewStatusHandler(ctx context.Context, cr *v1alpha2.Request, requestDetails
 		return nil, errors.Wrap(err, "failed to get the latest version of the resource")
 	}
 
+	resource := &utils.RequestResource{
+		Resource: cr,
+		// A gzipped body, such as the one of an error response, is decompressed so that it is classified and
+		// stored readable.
+		HttpResponse:   httpClient.DecodeResponse(requestDetails.HttpResponse),
+		HttpRequest:    requestDetails.HttpRequest,
+		RequestContext: ctx,
+		LocalClient:    localKube,
+		Duration:       requestDetails.Duration,
+	}
+
 	requestStatusHandler := &requestStatusHandler{
-		logger:       logger,
-		extraSetters: &[]utils.SetRequestStatusFunc{},
-		resource: &utils.RequestResource{
-			Resource:       cr,
-			HttpResponse:   requestDetails.HttpResponse,
-			HttpRequest:    requestDetails.HttpRequest,
-			RequestContext: ctx,
-			LocalClient:    localKube,
-		},
+		logger:        logger,
+		extraSetters:  &[]utils.SetRequestStatusFunc{},
+		resource:      resource,
+		stored:        storedResource(resource, cr.Spec.ForProvider),
 		responseError: err,
 		forProvider:   cr.Spec.ForProvider,
 	}
@@ -1,14 +1,20 @@
 package statushandler
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"fmt"
+	"net/http"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/pkg/errors"
 
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
 	"github.com/crossplane-contrib/provider-http/internal/utils"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
@@ -76,6 +82,108 @@ var testCr = &v1alpha2.Request{
 	},
 }
 
+var testRetryableCr = &v1alpha2.Request{
+	Spec: v1alpha2.RequestSpec{
+		ForProvider: v1alpha2.RequestParameters{
+			Payload:           testForProvider.Payload,
+			Mappings:          testForProvider.Mappings,
+			RetryableResponse: `.body.error.code == "RATE_LIMITED"`,
+		},
+	},
+}
+
+var testExpectedHeadersCr = &v1alpha2.Request{
+	Spec: v1alpha2.RequestSpec{
+		ForProvider: v1alpha2.RequestParameters{
+			Payload: testForProvider.Payload,
+			Mappings: []v1alpha2.Mapping{
+				{
+					Method: testPostMapping.Method,
+					Body:   testPostMapping.Body,
+					URL:    testPostMapping.URL,
+					ExpectedHeaders: map[string]string{
+						"Content-Type":          `any(startswith("application/json"))`,
+						"X-RateLimit-Remaining": `length > 0 and (.[0] | tonumber > 0)`,
+					},
+				},
+			},
+		},
+	},
+}
+
+var testExpectedStatusCr = &v1alpha2.Request{
+	Spec: v1alpha2.RequestSpec{
+		ForProvider: v1alpha2.RequestParameters{
+			Payload: testForProvider.Payload,
+			Mappings: []v1alpha2.Mapping{
+				{
+					Method:         testPostMapping.Method,
+					Body:           testPostMapping.Body,
+					URL:            testPostMapping.URL,
+					ExpectedStatus: `if .request.body.upsert then [200, 201] else 201 end`,
+				},
+			},
+		},
+	},
+}
+
+func testUpsertRequest(upsert bool) httpClient.HttpRequest {
+	return httpClient.HttpRequest{
+		Method: testMethod,
+		Body:   fmt.Sprintf(`{"upsert":%t}`, upsert),
+		URL:    testPostMapping.URL,
+	}
+}
+
+var testMultiStatusCr = &v1alpha2.Request{
+	Spec: v1alpha2.RequestSpec{
+		ForProvider: v1alpha2.RequestParameters{
+			Payload:  testForProvider.Payload,
+			Mappings: testForProvider.Mappings,
+			MultiStatus: &v1alpha2.MultiStatus{
+				Failures: `[.body.results[] | select(.status >= 400)]`,
+			},
+		},
+	},
+}
+
+var testErrorDetailsCr = &v1alpha2.Request{
+	Spec: v1alpha2.RequestSpec{
+		ForProvider: v1alpha2.RequestParameters{
+			Payload:  testForProvider.Payload,
+			Mappings: testForProvider.Mappings,
+			ErrorDetails: &v1alpha2.ErrorDetails{
+				Code:    `.body.error.code`,
+				Message: `.body.error.message`,
+			},
+		},
+	},
+}
+
+var testCreateOrConflictCr = &v1alpha2.Request{
+	Spec: v1alpha2.RequestSpec{
+		ForProvider: v1alpha2.RequestParameters{
+			Payload:        testForProvider.Payload,
+			Mappings:       testForProvider.Mappings,
+			CreateStrategy: v1alpha2.CreateStrategyCreateOrConflict,
+		},
+	},
+}
+
+var testClassifiedCr = &v1alpha2.Request{
+	Spec: v1alpha2.RequestSpec{
+		ForProvider: v1alpha2.RequestParameters{
+			Payload:  testForProvider.Payload,
+			Mappings: testForProvider.Mappings,
+			ResponseClassification: []v1alpha2.ResponseClassificationRule{
+				{StatusCodes: []int32{422}, Methods: []string{"POST"}, Outcome: v1alpha2.ResponseOutcomeRetryableError},
+				{StatusCodes: []int32{400}, Condition: `.body.error.code == "ALREADY_EXISTS"`, Outcome: v1alpha2.ResponseOutcomeSuccess},
+				{StatusCodes: []int32{200}, Condition: `.body.status == "failed"`, Outcome: v1alpha2.ResponseOutcomeTerminalError},
+			},
+		},
+	},
+}
+
 var testRequest = httpClient.HttpRequest{
 	Method: testMethod,
 	Body:   "{ username: .payload.body.username, email: .payload.body.email }",
@@ -91,9 +199,13 @@ func Test_SetRequestStatus(t *testing.T) {
 		isSynced       bool
 	}
 	type want struct {
-		err           error
-		httpRequest   httpClient.HttpRequest
-		failuresIndex int32
+		err                 error
+		httpRequest         httpClient.HttpRequest
+		failuresIndex       int32
+		multiStatusFailures []string
+		lastAction          v1alpha2.RequestAction
+		errorCode           string
+		errorMessage        string
 	}
 	cases := map[string]struct {
 		args args
@@ -101,7 +213,7 @@ func Test_SetRequestStatus(t *testing.T) {
 	}{
 		"Success": {
 			args: args{
-				cr: testCr,
+				cr: testCr.DeepCopy(),
 				localKube: &test.MockClient{
 					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
 					MockGet:          test.NewMockGetFn(nil),
@@ -120,11 +232,58 @@ func Test_SetRequestStatus(t *testing.T) {
 				err:           nil,
 				httpRequest:   testRequest,
 				failuresIndex: 0,
+				lastAction:    v1alpha2.RequestActionCreated,
+			},
+		},
+		"UpdateRecordsUpdatedAction": {
+			args: args{
+				cr: testCr.DeepCopy(),
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 200,
+						Body:       `{"id":"123","username":"john_doe"}`,
+						Headers:    testHeaders,
+					},
+					HttpRequest: httpClient.HttpRequest{Method: "PUT", URL: testRequest.URL},
+				},
+			},
+			want: want{
+				err:           nil,
+				httpRequest:   httpClient.HttpRequest{Method: "PUT", URL: testRequest.URL},
+				failuresIndex: 0,
+				lastAction:    v1alpha2.RequestActionUpdated,
+			},
+		},
+		"DeleteRecordsDeletedAction": {
+			args: args{
+				cr: testCr.DeepCopy(),
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 200,
+						Body:       `{"id":"123","username":"john_doe"}`,
+						Headers:    testHeaders,
+					},
+					HttpRequest: httpClient.HttpRequest{Method: "DELETE", URL: testRequest.URL},
+				},
+			},
+			want: want{
+				err:           nil,
+				httpRequest:   httpClient.HttpRequest{Method: "DELETE", URL: testRequest.URL},
+				failuresIndex: 0,
+				lastAction:    v1alpha2.RequestActionDeleted,
 			},
 		},
 		"StatusCodeFailed": {
 			args: args{
-				cr: testCr,
+				cr: testCr.DeepCopy(),
 				localKube: &test.MockClient{
 					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
 					MockGet:          test.NewMockGetFn(nil),
@@ -145,9 +304,63 @@ func Test_SetRequestStatus(t *testing.T) {
 				failuresIndex: 1,
 			},
 		},
+		"ErrorDetailsExtracted": {
+			args: args{
+				cr: testErrorDetailsCr.DeepCopy(),
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 400,
+						Body:       `{"error":{"code":"QUOTA_EXCEEDED","message":"quota of 10 users exceeded"}}`,
+					},
+					HttpRequest: testRequest,
+				},
+			},
+			want: want{
+				err:           errors.Errorf(utils.ErrStatusCode, testMethod, strconv.Itoa(400)),
+				httpRequest:   testRequest,
+				failuresIndex: 1,
+				errorCode:     "QUOTA_EXCEEDED",
+				errorMessage:  "quota of 10 users exceeded",
+			},
+		},
+		"ErrorDetailsFallBackToBody": {
+			args: args{
+				cr: testErrorDetailsCr.DeepCopy(),
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 502,
+						Body:       `upstream unavailable`,
+					},
+					HttpRequest: testRequest,
+				},
+			},
+			want: want{
+				err:           errors.Errorf(utils.ErrStatusCode, testMethod, strconv.Itoa(502)),
+				httpRequest:   testRequest,
+				failuresIndex: 1,
+				errorMessage:  "upstream unavailable",
+			},
+		},
 		"RequestFailed": {
 			args: args{
-				cr: testCr,
+				cr: func() *v1alpha2.Request {
+					cr := testCr.DeepCopy()
+					cr.Status.Failed = 1
+					cr.Status.RequestDetails = v1alpha2.Mapping{
+						Method: testRequest.Method,
+						Body:   testRequest.Body,
+						URL:    testRequest.URL,
+					}
+					return cr
+				}(),
 				localKube: &test.MockClient{
 					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
 					MockGet:          test.NewMockGetFn(nil),
@@ -170,7 +383,7 @@ func Test_SetRequestStatus(t *testing.T) {
 		},
 		"ResetFailures": {
 			args: args{
-				cr: testCr,
+				cr: testCr.DeepCopy(),
 				localKube: &test.MockClient{
 					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
 					MockGet:          test.NewMockGetFn(nil),
@@ -189,6 +402,396 @@ func Test_SetRequestStatus(t *testing.T) {
 				err:           nil,
 				httpRequest:   testRequest,
 				failuresIndex: 0,
+				lastAction:    v1alpha2.RequestActionCreated,
+			},
+		},
+		"ConflictMeansExists": {
+			args: args{
+				cr: func() *v1alpha2.Request {
+					cr := testCreateOrConflictCr.DeepCopy()
+					cr.Status.Failed = 1
+					return cr
+				}(),
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 409,
+						Body:       `{"id":"123","username":"john_doe"}`,
+						Headers:    testHeaders,
+					},
+					HttpRequest: testRequest,
+				},
+			},
+			want: want{
+				err:           nil,
+				httpRequest:   testRequest,
+				failuresIndex: 0,
+				lastAction:    v1alpha2.RequestActionCreated,
+			},
+		},
+		"ConflictFailsWithObserveFirst": {
+			args: args{
+				cr: testCr.DeepCopy(),
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 409,
+						Body:       `{"id":"123","username":"john_doe"}`,
+						Headers:    testHeaders,
+					},
+					HttpRequest: testRequest,
+				},
+			},
+			want: want{
+				err:           errors.Errorf(utils.ErrStatusCode, testMethod, strconv.Itoa(409)),
+				httpRequest:   testRequest,
+				failuresIndex: 1,
+			},
+		},
+		"RetryableApplicationError": {
+			args: args{
+				cr: testRetryableCr.DeepCopy(),
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 200,
+						Body:       `{"error":{"code":"RATE_LIMITED"}}`,
+						Headers:    testHeaders,
+					},
+					HttpRequest: testRequest,
+				},
+			},
+			want: want{
+				err:           errors.Errorf(errRetryableResponse, testMethod, 200, `{"error":{"code":"RATE_LIMITED"}}`),
+				httpRequest:   testRequest,
+				failuresIndex: 1,
+			},
+		},
+		"TerminalApplicationError": {
+			args: args{
+				cr: testRetryableCr.DeepCopy(),
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 200,
+						Body:       `{"error":{"code":"INVALID_ARGUMENT"}}`,
+						Headers:    testHeaders,
+					},
+					HttpRequest: testRequest,
+				},
+			},
+			want: want{
+				err:           nil,
+				httpRequest:   testRequest,
+				failuresIndex: 0,
+				lastAction:    v1alpha2.RequestActionCreated,
+			},
+		},
+		"ExpectedHeadersMatch": {
+			args: args{
+				cr: testExpectedHeadersCr.DeepCopy(),
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 200,
+						Body:       `{"id":"123"}`,
+						Headers: map[string][]string{
+							"content-type":          {"text/plain", "application/json; charset=utf-8"},
+							"X-Ratelimit-Remaining": {"5"},
+						},
+					},
+					HttpRequest: testRequest,
+				},
+			},
+			want: want{
+				err:           nil,
+				httpRequest:   testRequest,
+				failuresIndex: 0,
+				lastAction:    v1alpha2.RequestActionCreated,
+			},
+		},
+		"ExpectedHeadersMismatch": {
+			args: args{
+				cr: testExpectedHeadersCr.DeepCopy(),
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 200,
+						Body:       `{"id":"123"}`,
+						Headers: map[string][]string{
+							"Content-Type":          {"application/json"},
+							"X-RateLimit-Remaining": {"0"},
+						},
+					},
+					HttpRequest: testRequest,
+				},
+			},
+			want: want{
+				err:           errors.Errorf(errExpectedHeaders, testMethod, "X-RateLimit-Remaining"),
+				httpRequest:   testRequest,
+				failuresIndex: 1,
+			},
+		},
+		"ExpectedHeadersMissing": {
+			args: args{
+				cr: testExpectedHeadersCr.DeepCopy(),
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 200,
+						Body:       `{"id":"123"}`,
+						Headers:    testHeaders,
+					},
+					HttpRequest: testRequest,
+				},
+			},
+			want: want{
+				err:           errors.Errorf(errExpectedHeaders, testMethod, "Content-Type, X-RateLimit-Remaining"),
+				httpRequest:   testRequest,
+				failuresIndex: 1,
+			},
+		},
+		"ExpectedStatusComputedForUpsert": {
+			args: args{
+				cr: testExpectedStatusCr.DeepCopy(),
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 200,
+						Body:       `{"id":"123"}`,
+					},
+					HttpRequest: testUpsertRequest(true),
+				},
+			},
+			want: want{
+				err:           nil,
+				httpRequest:   testUpsertRequest(true),
+				failuresIndex: 0,
+				lastAction:    v1alpha2.RequestActionCreated,
+			},
+		},
+		"ExpectedStatusComputedForCreateMismatch": {
+			args: args{
+				cr: testExpectedStatusCr.DeepCopy(),
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 200,
+						Body:       `{"id":"123"}`,
+					},
+					HttpRequest: testUpsertRequest(false),
+				},
+			},
+			want: want{
+				err:           errors.Errorf(utils.ErrStatusCode, testMethod, "200"),
+				httpRequest:   testUpsertRequest(false),
+				failuresIndex: 1,
+			},
+		},
+		"ExpectedStatusNotAStatusCode": {
+			args: args{
+				cr: func() *v1alpha2.Request {
+					cr := testExpectedStatusCr.DeepCopy()
+					cr.Spec.ForProvider.Mappings[0].ExpectedStatus = `"created"`
+					return cr
+				}(),
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 201,
+						Body:       `{"id":"123"}`,
+					},
+					HttpRequest: testUpsertRequest(false),
+				},
+			},
+			want: want{
+				err:           errors.Errorf(errExpectedStatusFormat, "created"),
+				failuresIndex: 1,
+			},
+		},
+		"MultiStatusMixedResults": {
+			args: args{
+				cr: testMultiStatusCr.DeepCopy(),
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 207,
+						Body:       `{"results":[{"id":"a","status":201},{"id":"b","status":409},{"id":"c","status":500}]}`,
+					},
+					HttpRequest: testRequest,
+				},
+			},
+			want: want{
+				err:                 errors.Errorf(errMultiStatusFailure, testMethod, 2, `{"id":"b","status":409}, {"id":"c","status":500}`),
+				httpRequest:         testRequest,
+				failuresIndex:       1,
+				multiStatusFailures: []string{`{"id":"b","status":409}`, `{"id":"c","status":500}`},
+			},
+		},
+		"MultiStatusAllSucceeded": {
+			args: args{
+				cr: testMultiStatusCr.DeepCopy(),
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 207,
+						Body:       `{"results":[{"id":"a","status":201},{"id":"b","status":200}]}`,
+					},
+					HttpRequest: testRequest,
+				},
+			},
+			want: want{
+				err:           nil,
+				httpRequest:   testRequest,
+				failuresIndex: 0,
+				lastAction:    v1alpha2.RequestActionCreated,
+			},
+		},
+		"MultiStatusWithoutConfigurationSucceeds": {
+			args: args{
+				cr: testCr.DeepCopy(),
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 207,
+						Body:       `{"results":[{"id":"a","status":201},{"id":"b","status":409}]}`,
+					},
+					HttpRequest: testRequest,
+				},
+			},
+			want: want{
+				err:           nil,
+				httpRequest:   testRequest,
+				failuresIndex: 0,
+				lastAction:    v1alpha2.RequestActionCreated,
+			},
+		},
+		"ClassifiedRetryableError": {
+			args: args{
+				cr: testClassifiedCr.DeepCopy(),
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 422,
+						Body:       `{"error":"locked"}`,
+						Headers:    testHeaders,
+					},
+					HttpRequest: testRequest,
+				},
+			},
+			want: want{
+				err:           errors.Errorf(errRetryableResponse, testMethod, 422, `{"error":"locked"}`),
+				httpRequest:   testRequest,
+				failuresIndex: 1,
+			},
+		},
+		"ClassifiedSuccess": {
+			args: args{
+				cr: testClassifiedCr.DeepCopy(),
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 400,
+						Body:       `{"error":{"code":"ALREADY_EXISTS"}}`,
+						Headers:    testHeaders,
+					},
+					HttpRequest: testRequest,
+				},
+			},
+			want: want{
+				err:           nil,
+				httpRequest:   testRequest,
+				failuresIndex: 0,
+				lastAction:    v1alpha2.RequestActionCreated,
+			},
+		},
+		"ClassifiedTerminalError": {
+			args: args{
+				cr: testClassifiedCr.DeepCopy(),
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 200,
+						Body:       `{"status":"failed"}`,
+						Headers:    testHeaders,
+					},
+					HttpRequest: testRequest,
+				},
+			},
+			want: want{
+				err:           errors.Errorf(utils.ErrStatusCode, testMethod, strconv.Itoa(200)),
+				httpRequest:   testRequest,
+				failuresIndex: 1,
+			},
+		},
+		"UnclassifiedError": {
+			args: args{
+				cr: testClassifiedCr.DeepCopy(),
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 400,
+						Body:       `{"error":{"code":"INVALID"}}`,
+						Headers:    testHeaders,
+					},
+					HttpRequest: testRequest,
+				},
+			},
+			want: want{
+				err:           errors.Errorf(utils.ErrStatusCode, testMethod, strconv.Itoa(400)),
+				httpRequest:   testRequest,
+				failuresIndex: 1,
 			},
 		},
 	}
@@ -209,6 +812,22 @@ func Test_SetRequestStatus(t *testing.T) {
 				t.Fatalf("SetRequestStatus(...): -want Status.Failed, +got Status.Failed: %s", diff)
 			}
 
+			if diff := cmp.Diff(tc.want.multiStatusFailures, tc.args.cr.Status.MultiStatusFailures); diff != "" {
+				t.Fatalf("SetRequestStatus(...): -want Status.MultiStatusFailures, +got Status.MultiStatusFailures: %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.errorCode, tc.args.cr.Status.ErrorCode); diff != "" {
+				t.Fatalf("SetRequestStatus(...): -want Status.ErrorCode, +got Status.ErrorCode: %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.errorMessage, tc.args.cr.Status.ErrorMessage); diff != "" {
+				t.Fatalf("SetRequestStatus(...): -want Status.ErrorMessage, +got Status.ErrorMessage: %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.lastAction, tc.args.cr.Status.LastAction); diff != "" {
+				t.Fatalf("SetRequestStatus(...): -want Status.LastAction, +got Status.LastAction: %s", diff)
+			}
+
 			if diff := cmp.Diff(tc.want.httpRequest.Body, tc.args.cr.Status.RequestDetails.Body); diff != "" {
 				t.Fatalf("SetRequestStatus(...): -want RequestDetails.Body, +got RequestDetails.Body: %s", diff)
 			}
@@ -248,3 +867,195 @@ func Test_SetRequestStatus(t *testing.T) {
 		})
 	}
 }
+
+func Test_SetRequestStatus_GzippedErrorResponse(t *testing.T) {
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	_, _ = zw.Write([]byte(`{"error":{"code":"UNAVAILABLE","message":"try again later"}}`))
+	_ = zw.Close()
+
+	cr := testCr.DeepCopy()
+	cr.Spec.ForProvider.ResponseClassification = []v1alpha2.ResponseClassificationRule{
+		{StatusCodes: []int32{500}, Condition: `.body.error.code == "UNAVAILABLE"`, Outcome: v1alpha2.ResponseOutcomeRetryableError},
+	}
+	cr.Spec.ForProvider.ErrorDetails = &v1alpha2.ErrorDetails{Code: ".body.error.code", Message: ".body.error.message"}
+
+	localKube := &test.MockClient{
+		MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+		MockGet:          test.NewMockGetFn(nil),
+	}
+	requestDetails := httpClient.HttpDetails{
+		HttpResponse: httpClient.HttpResponse{
+			StatusCode: 500,
+			Headers:    map[string][]string{"Content-Encoding": {"gzip"}},
+			Body:       compressed.String(),
+		},
+		HttpRequest: testRequest,
+	}
+
+	r, _ := NewStatusHandler(context.Background(), cr, requestDetails, nil, localKube, logging.NewNopLogger())
+	gotErr := r.SetRequestStatus()
+
+	wantErr := errors.Errorf(errRetryableResponse, testMethod, 500, `{"error":{"code":"UNAVAILABLE","message":"try again later"}}`)
+	if diff := cmp.Diff(wantErr, gotErr, test.EquateErrors()); diff != "" {
+		t.Fatalf("SetRequestStatus(...): -want error, +got error: %s", diff)
+	}
+
+	if diff := cmp.Diff(wantErr.Error(), cr.Status.Error); diff != "" {
+		t.Errorf("SetRequestStatus(...): -want Status.Error, +got Status.Error: %s", diff)
+	}
+
+	if diff := cmp.Diff("UNAVAILABLE", cr.Status.ErrorCode); diff != "" {
+		t.Errorf("SetRequestStatus(...): -want Status.ErrorCode, +got Status.ErrorCode: %s", diff)
+	}
+
+	if diff := cmp.Diff("try again later", cr.Status.ErrorMessage); diff != "" {
+		t.Errorf("SetRequestStatus(...): -want Status.ErrorMessage, +got Status.ErrorMessage: %s", diff)
+	}
+}
+
+func Test_SetRequestStatus_QueryObserveResponse(t *testing.T) {
+	cr := testCr.DeepCopy()
+	cr.SetGeneration(2)
+	cr.Spec.ForProvider.Mappings = []v1alpha2.Mapping{
+		{Method: http.MethodPost, URL: ".payload.baseUrl"},
+		{Method: http.MethodPost, URL: ".payload.baseUrl + \"/search\"", Action: v1alpha2.MappingActionObserve},
+	}
+
+	localKube := &test.MockClient{
+		MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+		MockGet:          test.NewMockGetFn(nil),
+	}
+	requestDetails := httpClient.HttpDetails{
+		HttpResponse: httpClient.HttpResponse{
+			StatusCode: 200,
+			Body:       `{"id":"123"}`,
+		},
+		HttpRequest: httpClient.HttpRequest{
+			Method: http.MethodPost,
+			URL:    "https://api.example.com/users/search",
+			Body:   `{"query":{"username":"john_doe"}}`,
+			Action: string(v1alpha2.MappingActionObserve),
+		},
+	}
+
+	r, _ := NewStatusHandler(context.Background(), cr, requestDetails, nil, localKube, logging.NewNopLogger())
+	if err := r.SetRequestStatus(); err != nil {
+		t.Fatalf("SetRequestStatus(...): unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(v1alpha2.MappingActionObserve, cr.Status.RequestDetails.Action); diff != "" {
+		t.Errorf("SetRequestStatus(...): -want RequestDetails.Action, +got RequestDetails.Action: %s", diff)
+	}
+
+	// The observation is no creation, so it is recorded neither as an action nor as the last applied generation.
+	if diff := cmp.Diff(v1alpha2.RequestAction(""), cr.Status.LastAction); diff != "" {
+		t.Errorf("SetRequestStatus(...): -want Status.LastAction, +got Status.LastAction: %s", diff)
+	}
+
+	if diff := cmp.Diff(int64(0), cr.Status.LastAppliedGeneration); diff != "" {
+		t.Errorf("SetRequestStatus(...): -want Status.LastAppliedGeneration, +got Status.LastAppliedGeneration: %s", diff)
+	}
+}
+
+func Test_SetRequestStatus_ResetFailuresCondition(t *testing.T) {
+	type args struct {
+		condition         string
+		retryableResponse string
+		statusCode        int
+		body              string
+	}
+	type want struct {
+		failed int32
+		err    error
+	}
+	_, errNotBoolean := jq.ParseBool(".", "fresh")
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"FailureCountedWithoutCondition": {
+			args: args{statusCode: 500, body: `{"error":"fresh"}`},
+			want: want{failed: 3, err: errors.Errorf(utils.ErrStatusCode, testMethod, "500")},
+		},
+		"FailureCountedWhenConditionFalse": {
+			args: args{condition: `.body.error == "fresh"`, statusCode: 500, body: `{"error":"stale"}`},
+			want: want{failed: 3, err: errors.Errorf(utils.ErrStatusCode, testMethod, "500")},
+		},
+		"FailureResetOnSignal": {
+			args: args{condition: `.body.error == "fresh"`, statusCode: 500, body: `{"error":"fresh"}`},
+			want: want{failed: 0, err: errors.Errorf(utils.ErrStatusCode, testMethod, "500")},
+		},
+		"RetryableResponseResetOnSignal": {
+			args: args{condition: `.body.error == "fresh"`, retryableResponse: `.body.error != null`, statusCode: 200, body: `{"error":"fresh"}`},
+			want: want{failed: 0, err: errors.Errorf(errRetryableResponse, testMethod, 200, `{"error":"fresh"}`)},
+		},
+		"InvalidCondition": {
+			args: args{condition: `.body.error`, statusCode: 500, body: `{"error":"fresh"}`},
+			want: want{failed: 3, err: errors.Errorf(errResetFailuresCond, errNotBoolean.Error())},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			cr := testCr.DeepCopy()
+			cr.Status.Failed = 2
+			cr.Spec.ForProvider.ResetFailuresCondition = tc.args.condition
+			cr.Spec.ForProvider.RetryableResponse = tc.args.retryableResponse
+
+			localKube := &test.MockClient{
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				MockGet:          test.NewMockGetFn(nil),
+			}
+			requestDetails := httpClient.HttpDetails{
+				HttpResponse: httpClient.HttpResponse{StatusCode: tc.args.statusCode, Body: tc.args.body},
+				HttpRequest:  httpClient.HttpRequest{Method: testMethod, URL: "https://api.example.com/users", Body: `{"username":"john_doe"}`},
+			}
+
+			r, _ := NewStatusHandler(context.Background(), cr, requestDetails, nil, localKube, logging.NewNopLogger())
+			gotErr := r.SetRequestStatus()
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("SetRequestStatus(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.failed, cr.Status.Failed); diff != "" {
+				t.Errorf("SetRequestStatus(...): -want Status.Failed, +got Status.Failed: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_truncateBody(t *testing.T) {
+	long := strings.Repeat("a", maxErrorBodyLength)
+
+	cases := map[string]struct {
+		body string
+		want string
+	}{
+		"ShortBodyUnchanged": {
+			body: `{"error":"locked"}`,
+			want: `{"error":"locked"}`,
+		},
+		"BodyAtLimitUnchanged": {
+			body: long,
+			want: long,
+		},
+		"LongBodyCut": {
+			body: long + "bbb",
+			want: long + "...",
+		},
+		"CutBacksUpToRuneStart": {
+			body: long[:maxErrorBodyLength-1] + "€",
+			want: long[:maxErrorBodyLength-1] + "...",
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, truncateBody(tc.body)); diff != "" {
+				t.Errorf("truncateBody(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package request
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errObserveSubResources  = "cannot observe sub-resources: %s"
+	infoSubResourcesDrifted = "sub-resources drifted from their desired state: %s"
+)
+
+// observeSubResources observes the sub-resources of a Request whose object matches its desired state. The
+// Request stays up to date only when every sub-resource matches its own desired state as well, and the ones that
+// don't are recorded. Every sub-resource is observed even when one fails, and their failures are reported together.
+func (c *external) observeSubResources(ctx context.Context, cr *v1alpha2.Request, mapping *v1alpha2.Mapping, observed ObserveRequestDetails) (ObserveRequestDetails, error) {
+	subResources := cr.Spec.ForProvider.SubResources
+	if len(subResources) == 0 || !observed.Synced {
+		return observed, nil
+	}
+
+	var failures []string
+	for _, subResource := range subResources {
+		synced, err := c.observeSubResource(ctx, cr, mapping, subResource)
+		if httpClient.IsHostSaturated(err) {
+			return FailedObserve(), err
+		}
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", subResource.Name, err.Error()))
+			continue
+		}
+		if !synced {
+			observed.DriftedSubResources = append(observed.DriftedSubResources, subResource.Name)
+		}
+	}
+
+	if len(failures) > 0 {
+		return FailedObserve(), errors.Errorf(errObserveSubResources, strings.Join(failures, "; "))
+	}
+
+	if len(observed.DriftedSubResources) > 0 {
+		c.logger.Debug(fmt.Sprintf(infoSubResourcesDrifted, strings.Join(observed.DriftedSubResources, ", ")))
+		observed.Synced = false
+	}
+
+	return observed, nil
+}
+
+// observeSubResource sends the GET request of a sub-resource, with the client settings of the GET mapping, and
+// reports whether it succeeded with a response containing the desired state of the sub-resource.
+func (c *external) observeSubResource(ctx context.Context, cr *v1alpha2.Request, mapping *v1alpha2.Mapping, subResource v1alpha2.SubResource) (bool, error) {
+	// The desired state is rendered, unredacted, as the body of the GET mapping, but never sent.
+	sendBody := true
+	subMapping := v1alpha2.Mapping{
+		Method:   http.MethodGet,
+		URL:      subResource.URL,
+		Headers:  subResource.Headers,
+		Body:     subResource.DesiredState,
+		SendBody: &sendBody,
+	}
+
+	requestDetails, err := generateValidRequestDetails(requestgen.WithUnredactedBody(ctx), c.localKube, cr, &subMapping)
+	if err != nil {
+		return false, err
+	}
+
+	details, err := c.http.SendRequest(mappingContext(ctx, mapping), http.MethodGet, requestDetails.Url, httpClient.Data{Encrypted: "", Decrypted: ""}, requestDetails.Headers, skipTLSVerify(cr, mapping))
+	if err != nil {
+		return false, err
+	}
+	if !utils.IsHTTPSuccess(details.HttpResponse.StatusCode) {
+		// A sub-resource that can't be read, such as a missing one, has drifted.
+		return false, nil
+	}
+
+	observed, err := c.compareResponseAndDesiredState(details, nil, requestDetails.Body.Encrypted.(string), typeComparison(cr, c.canary), cr.Spec.ForProvider.UnorderedArrays)
+	if err != nil {
+		return false, err
+	}
+
+	return observed.Synced, nil
+}
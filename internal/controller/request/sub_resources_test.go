@@ -0,0 +1,116 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func withSubResources(r *v1alpha2.Request) {
+	r.Spec.ForProvider.SubResources = []v1alpha2.SubResource{
+		{
+			Name:         "members",
+			URL:          `(.payload.baseUrl + "/" + .response.body.id + "/members")`,
+			DesiredState: `{ members: ["jane"] }`,
+		},
+		{
+			Name:         "settings",
+			URL:          `(.payload.baseUrl + "/" + .response.body.id + "/settings")`,
+			DesiredState: `{ theme: "dark" }`,
+		},
+	}
+	r.Status.Response.Body = `{"id":"123","username":"john_doe_new_username"}`
+	r.Status.Response.StatusCode = http.StatusOK
+}
+
+func Test_isUpToDate_SubResources(t *testing.T) {
+	object := httpClient.HttpResponse{StatusCode: http.StatusOK, Body: `{"id":"123","username":"john_doe_new_username"}`}
+
+	type want struct {
+		synced  bool
+		drifted []string
+		err     error
+	}
+
+	cases := map[string]struct {
+		responses map[string]httpClient.HttpResponse
+		want      want
+	}{
+		"UpToDateWhenEverySubResourceMatches": {
+			responses: map[string]httpClient.HttpResponse{
+				"https://api.example.com/users/123":          object,
+				"https://api.example.com/users/123/members":  {StatusCode: http.StatusOK, Body: `{"members":["jane"]}`},
+				"https://api.example.com/users/123/settings": {StatusCode: http.StatusOK, Body: `{"theme":"dark","language":"en"}`},
+			},
+			want: want{synced: true},
+		},
+		"DriftedSubResourceReported": {
+			responses: map[string]httpClient.HttpResponse{
+				"https://api.example.com/users/123":          object,
+				"https://api.example.com/users/123/members":  {StatusCode: http.StatusOK, Body: `{"members":["joe"]}`},
+				"https://api.example.com/users/123/settings": {StatusCode: http.StatusOK, Body: `{"theme":"dark"}`},
+			},
+			want: want{synced: false, drifted: []string{"members"}},
+		},
+		"MissingSubResourceDrifted": {
+			responses: map[string]httpClient.HttpResponse{
+				"https://api.example.com/users/123":          object,
+				"https://api.example.com/users/123/members":  {StatusCode: http.StatusOK, Body: `{"members":["jane"]}`},
+				"https://api.example.com/users/123/settings": {StatusCode: http.StatusNotFound},
+			},
+			want: want{synced: false, drifted: []string{"settings"}},
+		},
+		"SubResourcesSkippedWhenObjectDrifted": {
+			responses: map[string]httpClient.HttpResponse{
+				"https://api.example.com/users/123": {StatusCode: http.StatusOK, Body: `{"id":"123","username":"john_doe"}`},
+			},
+			want: want{synced: false},
+		},
+		"FailuresAggregated": {
+			responses: map[string]httpClient.HttpResponse{
+				"https://api.example.com/users/123": object,
+			},
+			want: want{
+				err: errors.Errorf(errObserveSubResources, "members: "+errBoom.Error()+"; settings: "+errBoom.Error()),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				localKube: &test.MockClient{MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil)},
+				logger:    logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						response, ok := tc.responses[url]
+						if !ok {
+							return httpClient.HttpDetails{}, errBoom
+						}
+						return httpClient.HttpDetails{HttpResponse: response}, nil
+					},
+				},
+			}
+
+			got, gotErr := e.isUpToDate(context.Background(), httpRequest(withSubResources))
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("isUpToDate(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.synced, got.Synced); diff != "" {
+				t.Errorf("isUpToDate(...): -want synced, +got synced: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.drifted, got.DriftedSubResources); diff != "" {
+				t.Errorf("isUpToDate(...): -want drifted sub-resources, +got drifted sub-resources: %s", diff)
+			}
+		})
+	}
+}
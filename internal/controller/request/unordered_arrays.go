@@ -0,0 +1,29 @@
+package request
+
+import (
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/json"
+)
+
+// containsFunc returns the function checking that a response contains the desired state, according to the type
+// comparison mode and the arrays designated as unordered.
+func containsFunc(typeComparison v1alpha2.TypeComparisonMode, arrays []v1alpha2.UnorderedArray) (func(container, containee map[string]interface{}) bool, error) {
+	lenient := typeComparison == v1alpha2.TypeComparisonLenient
+	if len(arrays) == 0 {
+		if lenient {
+			return json.ContainsLenient, nil
+		}
+		return json.Contains, nil
+	}
+
+	unordered := make([]json.UnorderedArray, len(arrays))
+	for i, array := range arrays {
+		path, err := json.ParseFieldPath(array.Path)
+		if err != nil {
+			return nil, err
+		}
+		unordered[i] = json.UnorderedArray{Path: path, Key: array.Key}
+	}
+
+	return json.ContainsUnordered(unordered, lenient), nil
+}
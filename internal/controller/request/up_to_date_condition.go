@@ -0,0 +1,41 @@
+package request
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	"github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errUpToDateConditionFormat = "up-to-date condition should return a boolean, but returned error: %s"
+)
+
+// compareWithUpToDateCondition decides whether the object is up to date with the up-to-date condition of the
+// Request, evaluated against the desired state as .desired and the body of the GET response as .observed. Both
+// are parsed when they are JSON, and the response with the format of the GET mapping.
+func (c *external) compareWithUpToDateCondition(cr *v1alpha2.Request, mapping *v1alpha2.Mapping, details httpClient.HttpDetails, err error, desiredState string) (ObserveRequestDetails, error) {
+	observeRequestDetails := NewObserve(details, err, false)
+
+	responseMap, parseErr := json.ResponseToMap(details.HttpResponse, string(mapping.ResponseFormat))
+	if parseErr != nil {
+		return FailedObserve(), errors.Wrap(parseErr, errConvertResponse)
+	}
+
+	states := map[string]interface{}{
+		"desired":  desiredState,
+		"observed": responseMap["body"],
+	}
+	json.ConvertJSONStringsToMaps(&states)
+
+	upToDate, parseErr := jq.ParseBool(cr.Spec.ForProvider.UpToDateCondition, states)
+	if parseErr != nil {
+		return FailedObserve(), errors.Errorf(errUpToDateConditionFormat, parseErr.Error())
+	}
+
+	observeRequestDetails.Synced = upToDate && utils.IsHTTPSuccess(details.HttpResponse.StatusCode)
+	return observeRequestDetails, nil
+}
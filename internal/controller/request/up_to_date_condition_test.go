@@ -0,0 +1,176 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/json"
+)
+
+func Test_compareWithUpToDateCondition(t *testing.T) {
+	desiredState := `{"username":"john_doe","tags":["a","b"]}`
+
+	type args struct {
+		condition      string
+		responseFormat v1alpha2.ResponseFormat
+		response       httpClient.HttpResponse
+	}
+	type want struct {
+		synced bool
+		err    error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"EqualObjects": {
+			args: args{
+				condition: ".observed == .desired",
+				response:  httpClient.HttpResponse{StatusCode: http.StatusOK, Body: `{"tags":["a","b"],"username":"john_doe"}`},
+			},
+			want: want{synced: true},
+		},
+		"ExtraFieldsFailEquality": {
+			args: args{
+				condition: ".observed == .desired",
+				response:  httpClient.HttpResponse{StatusCode: http.StatusOK, Body: `{"id":"123","tags":["a","b"],"username":"john_doe"}`},
+			},
+			want: want{synced: false},
+		},
+		"DesiredTagsSubsetOfObserved": {
+			args: args{
+				condition: "(.desired.tags - .observed.tags) == []",
+				response:  httpClient.HttpResponse{StatusCode: http.StatusOK, Body: `{"tags":["c","b","a"]}`},
+			},
+			want: want{synced: true},
+		},
+		"OrderInsensitiveArrays": {
+			args: args{
+				condition: "(.observed.tags | sort) == (.desired.tags | sort)",
+				response:  httpClient.HttpResponse{StatusCode: http.StatusOK, Body: `{"tags":["b","a"],"username":"jane"}`},
+			},
+			want: want{synced: true},
+		},
+		"CaseInsensitiveField": {
+			args: args{
+				condition: "(.observed.username | ascii_downcase) == .desired.username",
+				response:  httpClient.HttpResponse{StatusCode: http.StatusOK, Body: `{"username":"John_Doe"}`},
+			},
+			want: want{synced: true},
+		},
+		"DriftDetected": {
+			args: args{
+				condition: ".observed.username == .desired.username",
+				response:  httpClient.HttpResponse{StatusCode: http.StatusOK, Body: `{"username":"jane"}`},
+			},
+			want: want{synced: false},
+		},
+		"NonJSONResponseComparedAsString": {
+			args: args{
+				condition: `.observed == "ok"`,
+				response:  httpClient.HttpResponse{StatusCode: http.StatusOK, Body: `ok`},
+			},
+			want: want{synced: true},
+		},
+		"NDJSONResponse": {
+			args: args{
+				condition:      "(.observed | map(.username)) == [.desired.username]",
+				responseFormat: v1alpha2.ResponseFormat(json.FormatNDJSON),
+				response:       httpClient.HttpResponse{StatusCode: http.StatusOK, Body: "{\"username\":\"john_doe\"}\n"},
+			},
+			want: want{synced: true},
+		},
+		"FailedResponseNeverUpToDate": {
+			args: args{
+				condition: "true",
+				response:  httpClient.HttpResponse{StatusCode: http.StatusInternalServerError, Body: `{}`},
+			},
+			want: want{synced: false},
+		},
+		"NonBooleanCondition": {
+			args: args{
+				condition: ".observed.username",
+				response:  httpClient.HttpResponse{StatusCode: http.StatusOK, Body: `{"username":"john_doe"}`},
+			},
+			want: want{err: errors.Errorf(errUpToDateConditionFormat, errors.Errorf("failed to parse string: %s", "john_doe").Error())},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			cr := httpRequest(func(r *v1alpha2.Request) {
+				r.Spec.ForProvider.UpToDateCondition = tc.args.condition
+			})
+			mapping := &v1alpha2.Mapping{Method: http.MethodGet, ResponseFormat: tc.args.responseFormat}
+
+			e := &external{logger: logging.NewNopLogger()}
+			got, gotErr := e.compareWithUpToDateCondition(cr, mapping, httpClient.HttpDetails{HttpResponse: tc.args.response}, nil, desiredState)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("compareWithUpToDateCondition(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.synced, got.Synced); diff != "" {
+				t.Errorf("compareWithUpToDateCondition(...): -want synced, +got synced: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_isUpToDate_UpToDateCondition(t *testing.T) {
+	cases := map[string]struct {
+		condition string
+		body      string
+		want      bool
+	}{
+		"ContainsByDefault": {
+			body: `{"id":"123","username":"john_doe_new_username"}`,
+			want: true,
+		},
+		"ConditionOverridesContains": {
+			condition: ".observed == .desired",
+			body:      `{"id":"123","username":"john_doe_new_username"}`,
+			want:      false,
+		},
+		"ConditionMatchesWhereContainsWouldNot": {
+			condition: "(.observed.username | ascii_downcase) == .desired.username",
+			body:      `{"id":"123","username":"JOHN_DOE_NEW_USERNAME"}`,
+			want:      true,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				localKube: &test.MockClient{MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil)},
+				logger:    logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusOK, Body: tc.body}}, nil
+					},
+				},
+			}
+
+			cr := httpRequest(func(r *v1alpha2.Request) {
+				r.Spec.ForProvider.UpToDateCondition = tc.condition
+				r.Status.Response.Body = `{"id":"123","username":"john_doe"}`
+				r.Status.Response.StatusCode = http.StatusOK
+			})
+			got, err := e.isUpToDate(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("isUpToDate(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Synced); diff != "" {
+				t.Errorf("isUpToDate(...): -want synced, +got synced: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package request
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errUpsertExistence = "Warning, couldn't check whether the object exists before writing it, sending the %s request: %s"
+)
+
+// writeMethod returns the method of the write the given method stands for. In upsert mode, it is PUT when the
+// GET request finds the object and POST when it doesn't; otherwise, or when the GET request doesn't tell, the
+// given method is kept. The elements of forEach are written with the given method.
+func (c *external) writeMethod(ctx context.Context, cr *v1alpha2.Request, method string) string {
+	if !cr.Spec.ForProvider.Upsert || cr.Spec.ForProvider.ForEach != nil {
+		return method
+	}
+
+	exists, known, err := c.existsUpstream(ctx, cr)
+	if err != nil {
+		c.logger.Info(fmt.Sprintf(errUpsertExistence, method, err.Error()))
+		return method
+	}
+
+	switch {
+	case !known:
+		return method
+	case exists:
+		return http.MethodPut
+	default:
+		return http.MethodPost
+	}
+}
+
+// existsUpstream sends the GET request to find whether the object exists, as decided by the exists condition of
+// the Request or else by the classification of the response. It reports false as known when there is no GET
+// mapping or the response neither succeeded nor was classified as not found.
+func (c *external) existsUpstream(ctx context.Context, cr *v1alpha2.Request) (exists bool, known bool, err error) {
+	mapping, ok := getMappingByMethod(&cr.Spec.ForProvider, http.MethodGet)
+	if !ok {
+		return false, false, nil
+	}
+
+	ctx, err = c.withPreRequest(ctx, cr)
+	if err != nil {
+		return false, false, err
+	}
+
+	requestDetails, err := c.observeRequestDetails(ctx, cr, mapping)
+	if err != nil {
+		return false, false, err
+	}
+
+	details, err := c.sendObserveRequest(ctx, cr, mapping, &requestDetails)
+	if err != nil {
+		return false, false, err
+	}
+
+	if err := decryptResponseBody(ctx, c.localKube, mapping, &details, nil); err != nil {
+		return false, false, err
+	}
+
+	if exists, decided, err := existsByCondition(cr, details, nil); err != nil || decided {
+		return exists, err == nil, err
+	}
+
+	outcome, err := utils.ClassifyResponse(cr.Spec.ForProvider, http.MethodGet, details.HttpResponse)
+	if err != nil {
+		return false, false, err
+	}
+
+	switch outcome {
+	case v1alpha2.ResponseOutcomeSuccess:
+		return true, true, nil
+	case v1alpha2.ResponseOutcomeNotFound:
+		return false, true, nil
+	default:
+		return false, false, nil
+	}
+}
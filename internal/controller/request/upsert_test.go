@@ -0,0 +1,142 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func withUpsert() httpRequestModifier {
+	return func(r *v1alpha2.Request) {
+		url := "(.payload.baseUrl + \"/\" + .payload.body.username)"
+		r.Spec.ForProvider.Upsert = true
+		r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{
+			{Method: http.MethodPost, URL: ".payload.baseUrl", Body: ".payload.body"},
+			{Method: http.MethodGet, URL: url},
+			{Method: http.MethodPut, URL: url, Body: ".payload.body"},
+		}
+	}
+}
+
+func Test_httpExternal_Upsert(t *testing.T) {
+	type args struct {
+		getStatusCode int
+		getErr        error
+		update        bool
+		mg            *v1alpha2.Request
+	}
+	type want struct {
+		methods []string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"FirstCreate": {
+			args: args{
+				getStatusCode: http.StatusNotFound,
+				mg:            httpRequest(withUpsert()),
+			},
+			want: want{methods: []string{http.MethodGet, http.MethodPost}},
+		},
+		"CreateOfExistingObjectUpdatesIt": {
+			args: args{
+				getStatusCode: http.StatusOK,
+				mg:            httpRequest(withUpsert()),
+			},
+			want: want{methods: []string{http.MethodGet, http.MethodPut}},
+		},
+		"SubsequentUpdate": {
+			args: args{
+				getStatusCode: http.StatusOK,
+				update:        true,
+				mg:            httpRequest(withUpsert()),
+			},
+			want: want{methods: []string{http.MethodGet, http.MethodPut}},
+		},
+		"UpdateOfRemovedObjectCreatesIt": {
+			args: args{
+				getStatusCode: http.StatusNotFound,
+				update:        true,
+				mg:            httpRequest(withUpsert()),
+			},
+			want: want{methods: []string{http.MethodGet, http.MethodPost}},
+		},
+		"UnknownExistenceKeepsMethod": {
+			args: args{
+				getStatusCode: http.StatusServiceUnavailable,
+				update:        true,
+				mg:            httpRequest(withUpsert()),
+			},
+			want: want{methods: []string{http.MethodGet, http.MethodPut}},
+		},
+		"FailedGetKeepsMethod": {
+			args: args{
+				getErr: errBoom,
+				mg:     httpRequest(withUpsert()),
+			},
+			want: want{methods: []string{http.MethodGet, http.MethodPost}},
+		},
+		"ExistsConditionDecides": {
+			args: args{
+				getStatusCode: http.StatusOK,
+				mg: httpRequest(withUpsert(), func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.ExistsCondition = ".body.items | length > 0"
+				}),
+			},
+			want: want{methods: []string{http.MethodGet, http.MethodPost}},
+		},
+		"NotUpsertingSendsNoGet": {
+			args: args{
+				getStatusCode: http.StatusOK,
+				mg: httpRequest(withUpsert(), func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.Upsert = false
+				}),
+			},
+			want: want{methods: []string{http.MethodPost}},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var methods []string
+			e := &external{
+				localKube: &test.MockClient{
+					MockGet:          test.NewMockGetFn(nil),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						methods = append(methods, method)
+						if method != http.MethodGet {
+							return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusOK, Body: `{"username":"john_doe"}`}}, nil
+						}
+						return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: tc.args.getStatusCode, Body: `{"items":[]}`}}, tc.args.getErr
+					},
+				},
+			}
+
+			var err error
+			if tc.args.update {
+				_, err = e.Update(context.Background(), tc.args.mg)
+			} else {
+				_, err = e.Create(context.Background(), tc.args.mg)
+			}
+			if err != nil {
+				t.Fatalf("upsert: unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.methods, methods); diff != "" {
+				t.Errorf("upsert: -want methods, +got methods: %s", diff)
+			}
+		})
+	}
+}
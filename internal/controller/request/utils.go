@@ -1,14 +1,43 @@
 package request
 
 import (
+	"context"
+
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
 )
 
+// getMappingByMethod returns the mapping that runs the action of the given standard method, which may send
+// another method when it is designated for the action.
 func getMappingByMethod(requestParams *v1alpha2.RequestParameters, method string) (*v1alpha2.Mapping, bool) {
-	for _, mapping := range requestParams.Mappings {
-		if mapping.Method == method {
-			return &mapping, true
-		}
+	mapping, ok := utils.MappingForAction(*requestParams, method)
+	if !ok {
+		return nil, false
 	}
-	return nil, false
+	return &mapping, true
+}
+
+// mappingContext returns a context whose requests use the client settings the mapping overrides, its TLS server
+// name and bearer token file, instead of the ones of the ProviderConfig.
+func mappingContext(ctx context.Context, mapping *v1alpha2.Mapping) context.Context {
+	ctx = httpClient.ContextWithTLSServerName(ctx, mapping.TLSServerName)
+	return httpClient.ContextWithBearerTokenFile(ctx, mapping.BearerTokenFile)
+}
+
+// withMappingAction records in the details the action the mapping was sent for, so that its response is handled
+// like the ones of that action even when another mapping shares its method.
+func withMappingAction(details httpClient.HttpDetails, mapping *v1alpha2.Mapping) httpClient.HttpDetails {
+	details.HttpRequest.Action = string(utils.MappingActionOf(*mapping))
+	return details
+}
+
+// skipTLSVerify reports whether the requests of the mapping skip the TLS certificate checks. The setting of the
+// mapping takes precedence over the one of the Request.
+func skipTLSVerify(cr *v1alpha2.Request, mapping *v1alpha2.Mapping) bool {
+	if mapping.InsecureSkipTLSVerify != nil {
+		return *mapping.InsecureSkipTLSVerify
+	}
+
+	return cr.Spec.ForProvider.InsecureSkipTLSVerify
 }
@@ -99,3 +99,39 @@ func Test_getMappingByMethod(t *testing.T) {
 		})
 	}
 }
+
+func Test_skipTLSVerify(t *testing.T) {
+	skip, verify := true, false
+
+	cases := map[string]struct {
+		requestSkips bool
+		mappingSkips *bool
+		want         bool
+	}{
+		"RequestSettingWithoutOverride": {
+			requestSkips: true,
+			want:         true,
+		},
+		"MappingSkips": {
+			mappingSkips: &skip,
+			want:         true,
+		},
+		"MappingVerifiesDespiteRequest": {
+			requestSkips: true,
+			mappingSkips: &verify,
+			want:         false,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			cr := httpRequest(func(r *v1alpha2.Request) {
+				r.Spec.ForProvider.InsecureSkipTLSVerify = tc.requestSkips
+			})
+			if diff := cmp.Diff(tc.want, skipTLSVerify(cr, &v1alpha2.Mapping{InsecureSkipTLSVerify: tc.mappingSkips})); diff != "" {
+				t.Errorf("skipTLSVerify(...): -want skip, +got skip: %s", diff)
+			}
+		})
+	}
+}
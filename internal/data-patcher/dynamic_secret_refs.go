@@ -0,0 +1,88 @@
+package datapatcher
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	kubehandler "github.com/crossplane-contrib/provider-http/internal/kube-handler"
+)
+
+const (
+	errDynamicSecretRefWithoutObject = "dynamic secret reference %s can only be used where the request is generated with jq"
+	errResolveDynamicSecretRef       = "cannot resolve %s of dynamic secret reference %s"
+	errEmptyDynamicSecretRef         = "%s of dynamic secret reference %s resolved to an empty value"
+)
+
+const (
+	// dynamicSecretPattern matches {{jq:name:namespace:key}}. Each component starting with a dot is a jq filter
+	// evaluated against the jq object of the request, any other component is used as is.
+	dynamicSecretPattern = `\{\{\s*jq:([^:{}]+):([^:{}]+):([^:{}]+?)\s*\}\}`
+)
+
+var dynamicSecretRe = regexp.MustCompile(dynamicSecretPattern)
+
+type jqObjectKey struct{}
+
+// WithJQObject returns a context whose dynamic secret references are resolved against the given jq object.
+func WithJQObject(ctx context.Context, jqObject map[string]interface{}) context.Context {
+	return context.WithValue(ctx, jqObjectKey{}, jqObject)
+}
+
+// resolveDynamicSecretRefComponent returns the value of a component of a dynamic secret reference.
+func resolveDynamicSecretRefComponent(component, field, placeholder string, jqObject map[string]interface{}) (string, error) {
+	component = strings.TrimSpace(component)
+	if !strings.HasPrefix(component, ".") {
+		return component, nil
+	}
+
+	value, err := jq.ParseScalar(component, jqObject)
+	if err != nil {
+		return "", errors.Wrapf(err, errResolveDynamicSecretRef, field, placeholder)
+	}
+	if value == "" {
+		return "", errors.Errorf(errEmptyDynamicSecretRef, field, placeholder)
+	}
+
+	return value, nil
+}
+
+// patchDynamicSecretRefsToValue replaces the dynamic secret references in the provided value with the values of
+// the secret keys they resolve to.
+func patchDynamicSecretRefsToValue(ctx context.Context, localKube client.Client, valueToHandle string) (string, error) {
+	placeholders := removeDuplicates(dynamicSecretRe.FindAllString(valueToHandle, -1))
+	if len(placeholders) == 0 {
+		return valueToHandle, nil
+	}
+
+	jqObject, ok := ctx.Value(jqObjectKey{}).(map[string]interface{})
+	if !ok {
+		return "", errors.Errorf(errDynamicSecretRefWithoutObject, placeholders[0])
+	}
+
+	for _, placeholder := range placeholders {
+		matches := dynamicSecretRe.FindStringSubmatch(placeholder)
+
+		resolved := make([]string, 3)
+		for i, field := range []string{"name", "namespace", "key"} {
+			value, err := resolveDynamicSecretRefComponent(matches[i+1], field, placeholder, jqObject)
+			if err != nil {
+				return "", err
+			}
+			resolved[i] = value
+		}
+
+		secret, err := kubehandler.GetSecret(ctx, localKube, resolved[0], resolved[1])
+		if err != nil {
+			return "", err
+		}
+
+		valueToHandle = replacePlaceholderWithSecretValue(valueToHandle, placeholder, secret, resolved[2])
+	}
+
+	return valueToHandle, nil
+}
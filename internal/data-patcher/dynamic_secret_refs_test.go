@@ -0,0 +1,118 @@
+package datapatcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Test_patchDynamicSecretRefsToValue(t *testing.T) {
+	jqObject := map[string]interface{}{
+		"response": map[string]interface{}{
+			"body": map[string]interface{}{
+				"tenant": "acme",
+			},
+		},
+		"payload": map[string]interface{}{
+			"namespace": "tenants",
+			"empty":     "",
+		},
+	}
+
+	type args struct {
+		value    string
+		jqObject map[string]interface{}
+	}
+	type want struct {
+		result string
+		gets   []client.ObjectKey
+		err    error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldResolveSecretNamedAfterResponseValue": {
+			args: args{
+				value:    `{"token": "{{jq:.response.body.tenant:.payload.namespace:key}}"}`,
+				jqObject: jqObject,
+			},
+			want: want{
+				result: `{"token": "value"}`,
+				gets:   []client.ObjectKey{{Namespace: "tenants", Name: "acme"}},
+			},
+		},
+		"ShouldResolveExpressionsWithSpaces": {
+			args: args{
+				value:    `{{ jq:.response.body.tenant + "-credentials" : default : key }}`,
+				jqObject: jqObject,
+			},
+			want: want{
+				result: `value`,
+				gets:   []client.ObjectKey{{Namespace: "default", Name: "acme-credentials"}},
+			},
+		},
+		"ShouldLeaveStaticPlaceholdersUntouched": {
+			args: args{
+				value:    `{"token": "{{name:namespace:key}}"}`,
+				jqObject: jqObject,
+			},
+			want: want{
+				result: `{"token": "{{name:namespace:key}}"}`,
+			},
+		},
+		"ShouldFailWhenComponentResolvesToEmptyValue": {
+			args: args{
+				value:    `{{jq:.payload.empty:default:key}}`,
+				jqObject: jqObject,
+			},
+			want: want{
+				err: errors.Errorf(errEmptyDynamicSecretRef, "name", "{{jq:.payload.empty:default:key}}"),
+			},
+		},
+		"ShouldFailWithoutJQObject": {
+			args: args{
+				value: `{{jq:.response.body.tenant:default:key}}`,
+			},
+			want: want{
+				err: errors.Errorf(errDynamicSecretRefWithoutObject, "{{jq:.response.body.tenant:default:key}}"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var gets []client.ObjectKey
+			localKube := &test.MockClient{
+				MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+					gets = append(gets, key)
+					*obj.(*corev1.Secret) = *createSpecificSecret(key.Name, key.Namespace, "key", "value")
+					return nil
+				},
+			}
+
+			ctx := context.Background()
+			if tc.args.jqObject != nil {
+				ctx = WithJQObject(ctx, tc.args.jqObject)
+			}
+
+			got, gotErr := patchDynamicSecretRefsToValue(ctx, localKube, tc.args.value)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("patchDynamicSecretRefsToValue(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("patchDynamicSecretRefsToValue(...): -want result, +got result: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.gets, gets); diff != "" {
+				t.Errorf("patchDynamicSecretRefsToValue(...): -want gets, +got gets: %s", diff)
+			}
+		})
+	}
+}
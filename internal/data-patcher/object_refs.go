@@ -0,0 +1,138 @@
+package datapatcher
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+)
+
+const (
+	errInvalidObjectRefVersion = "invalid apiVersion %s in object reference %s"
+	errSecretObjectRef         = "secrets cannot be referenced as objects, use a {{name:namespace:key}} placeholder instead: %s"
+	errGetReferencedObject     = "cannot get %s %s referenced by %s"
+	errResolveObjectRefField   = "cannot resolve field %s of %s %s"
+)
+
+const (
+	// objectRefPattern matches {{ref:apiVersion:kind:namespace:name:fieldPath}}. The namespace is left empty for
+	// cluster scoped objects, and the field path is a jq filter evaluated against the object.
+	objectRefPattern = `\{\{\s*ref:([^:{}\s]+):([^:{}\s]+):([^:{}\s]*):([^:{}\s]+):([^{}\s]+)\s*\}\}`
+)
+
+var objectRefRe = regexp.MustCompile(objectRefPattern)
+
+// objectRef identifies a field of an arbitrary Kubernetes object referenced by a placeholder.
+type objectRef struct {
+	gvk       schema.GroupVersionKind
+	name      types.NamespacedName
+	fieldPath string
+}
+
+// parseObjectRef parses an object reference placeholder and returns its components.
+func parseObjectRef(placeholder string) (objectRef, error) {
+	matches := objectRefRe.FindStringSubmatch(placeholder)
+	gv, err := schema.ParseGroupVersion(matches[1])
+	if err != nil {
+		return objectRef{}, errors.Errorf(errInvalidObjectRefVersion, matches[1], placeholder)
+	}
+
+	gvk := gv.WithKind(matches[2])
+	if gvk.Group == "" && strings.EqualFold(gvk.Kind, "Secret") {
+		return objectRef{}, errors.Errorf(errSecretObjectRef, placeholder)
+	}
+
+	return objectRef{
+		gvk:       gvk,
+		name:      types.NamespacedName{Namespace: matches[3], Name: matches[4]},
+		fieldPath: matches[5],
+	}, nil
+}
+
+type objectKey struct {
+	gvk  schema.GroupVersionKind
+	name types.NamespacedName
+}
+
+// ObjectRefCache holds the objects fetched while resolving object references, so that an object referenced
+// several times during a reconcile is only fetched once.
+type ObjectRefCache struct {
+	mu      sync.Mutex
+	objects map[objectKey]map[string]interface{}
+}
+
+// NewObjectRefCache returns an empty ObjectRefCache.
+func NewObjectRefCache() *ObjectRefCache {
+	return &ObjectRefCache{objects: map[objectKey]map[string]interface{}{}}
+}
+
+type objectRefCacheKey struct{}
+
+// WithObjectRefCache returns a context whose object reference lookups go through the given cache.
+func WithObjectRefCache(ctx context.Context, cache *ObjectRefCache) context.Context {
+	return context.WithValue(ctx, objectRefCacheKey{}, cache)
+}
+
+// getObject returns the referenced object as a JSON compatible map, consulting the cache of the context if any.
+func getObject(ctx context.Context, localKube client.Client, ref objectRef, placeholder string) (map[string]interface{}, error) {
+	key := objectKey{gvk: ref.gvk, name: ref.name}
+	cache, _ := ctx.Value(objectRefCacheKey{}).(*ObjectRefCache)
+	if cache != nil {
+		cache.mu.Lock()
+		defer cache.mu.Unlock()
+
+		if obj, ok := cache.objects[key]; ok {
+			return obj, nil
+		}
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(ref.gvk)
+	if err := localKube.Get(ctx, ref.name, u); err != nil {
+		return nil, errors.Wrapf(err, errGetReferencedObject, ref.gvk.Kind, ref.name, placeholder)
+	}
+
+	obj, err := json_util.StructToMap(u.Object)
+	if err != nil {
+		return nil, errors.Wrap(err, errConvertData)
+	}
+
+	if cache != nil {
+		cache.objects[key] = obj
+	}
+
+	return obj, nil
+}
+
+// patchObjectRefsToValue replaces the object references in the provided value with the referenced fields.
+func patchObjectRefsToValue(ctx context.Context, localKube client.Client, valueToHandle string) (string, error) {
+	for _, placeholder := range removeDuplicates(objectRefRe.FindAllString(valueToHandle, -1)) {
+		ref, err := parseObjectRef(placeholder)
+		if err != nil {
+			return "", err
+		}
+
+		obj, err := getObject(ctx, localKube, ref, placeholder)
+		if err != nil {
+			return "", err
+		}
+
+		value, err := jq.ParseScalar(ref.fieldPath, obj)
+		if err != nil {
+			return "", errors.Wrapf(err, errResolveObjectRefField, ref.fieldPath, ref.gvk.Kind, ref.name)
+		}
+
+		valueToHandle = strings.ReplaceAll(valueToHandle, placeholder, value)
+	}
+
+	return valueToHandle, nil
+}
@@ -0,0 +1,120 @@
+package datapatcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var errBoom = errors.New("boom")
+
+func Test_patchObjectRefsToValue(t *testing.T) {
+	type args struct {
+		value  string
+		getErr error
+	}
+	type want struct {
+		result string
+		gets   int
+		err    error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldResolveConfigMapField": {
+			args: args{
+				value: `{"endpoint": "{{ref:v1:ConfigMap:default:settings:.data.endpoint}}"}`,
+			},
+			want: want{
+				result: `{"endpoint": "https://api.example.com"}`,
+				gets:   1,
+			},
+		},
+		"ShouldResolveNonStringFieldsOfGroupedKinds": {
+			args: args{
+				value: `{"replicas": {{ ref:apps/v1:Deployment:default:settings:.spec.replicas }}}`,
+			},
+			want: want{
+				result: `{"replicas": 3}`,
+				gets:   1,
+			},
+		},
+		"ShouldFetchAnObjectOnceWhenCached": {
+			args: args{
+				value: `{{ref:v1:ConfigMap:default:settings:.data.endpoint}}/{{ref:v1:ConfigMap:default:settings:.metadata.name}}`,
+			},
+			want: want{
+				result: `https://api.example.com/settings`,
+				gets:   1,
+			},
+		},
+		"ShouldLeaveSecretPlaceholdersUntouched": {
+			args: args{
+				value: `{"token": "{{name:namespace:key}}"}`,
+			},
+			want: want{
+				result: `{"token": "{{name:namespace:key}}"}`,
+			},
+		},
+		"ShouldRefuseSecrets": {
+			args: args{
+				value: `{{ref:v1:Secret:default:settings:.data.token}}`,
+			},
+			want: want{
+				err: errors.Errorf(errSecretObjectRef, "{{ref:v1:Secret:default:settings:.data.token}}"),
+			},
+		},
+		"ShouldFailWhenObjectCannotBeFetched": {
+			args: args{
+				value:  `{{ref:v1:ConfigMap:default:settings:.data.endpoint}}`,
+				getErr: errBoom,
+			},
+			want: want{
+				err:  errors.Wrapf(errBoom, errGetReferencedObject, "ConfigMap", types.NamespacedName{Namespace: "default", Name: "settings"}, "{{ref:v1:ConfigMap:default:settings:.data.endpoint}}"),
+				gets: 1,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			gets := 0
+			localKube := &test.MockClient{
+				MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+					gets++
+					if tc.args.getErr != nil {
+						return tc.args.getErr
+					}
+
+					u := obj.(*unstructured.Unstructured)
+					u.SetName(key.Name)
+					u.SetNamespace(key.Namespace)
+					_ = unstructured.SetNestedField(u.Object, "https://api.example.com", "data", "endpoint")
+					_ = unstructured.SetNestedField(u.Object, int64(3), "spec", "replicas")
+					return nil
+				},
+			}
+
+			ctx := WithObjectRefCache(context.Background(), NewObjectRefCache())
+			got, gotErr := patchObjectRefsToValue(ctx, localKube, tc.args.value)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("patchObjectRefsToValue(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("patchObjectRefsToValue(...): -want result, +got result: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.gets, gets); diff != "" {
+				t.Errorf("patchObjectRefsToValue(...): -want gets, +got gets: %s", diff)
+			}
+		})
+	}
+}
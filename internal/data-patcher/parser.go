@@ -21,8 +21,9 @@ import (
 )
 
 const (
-	errEmptyKey    = "Warning, value at field %s is empty, skipping secret update for: %s"
-	errConvertData = "failed to convert data to map"
+	errEmptyKey        = "Warning, value at field %s is empty, skipping secret update for: %s"
+	errConvertData     = "failed to convert data to map"
+	errConditionFormat = "injection condition should return a boolean, but returned error: %s"
 )
 
 const (
@@ -31,6 +32,15 @@ const (
 
 var re = regexp.MustCompile(secretPattern)
 
+// anyPlaceholderRe matches object references, dynamic secret references and secret placeholders.
+var anyPlaceholderRe = regexp.MustCompile(objectRefPattern + "|" + dynamicSecretPattern + "|" + secretPattern)
+
+// ReplacePlaceholders replaces every secret, dynamic secret and object reference placeholder of the value with
+// the result of replace, in a single pass so that replacements aren't matched again.
+func ReplacePlaceholders(value string, replace func(placeholder string) string) string {
+	return anyPlaceholderRe.ReplaceAllStringFunc(value, replace)
+}
+
 // findPlaceholders finds all placeholders in the provided string.
 func findPlaceholders(value string) []string {
 	return re.FindAllString(value, -1)
@@ -68,8 +78,19 @@ func replacePlaceholderWithSecretValue(originalString, old string, secret *corev
 	return strings.ReplaceAll(originalString, old, replacementString)
 }
 
-// patchSecretsToValue patches secrets referenced in the provided value.
+// patchSecretsToValue patches secrets and objects referenced in the provided value.
+// Object references are resolved first, so that the value of a referenced field may itself hold a secret placeholder.
 func patchSecretsToValue(ctx context.Context, localKube client.Client, valueToHandle string) (string, error) {
+	valueToHandle, err := patchObjectRefsToValue(ctx, localKube, valueToHandle)
+	if err != nil {
+		return "", err
+	}
+
+	valueToHandle, err = patchDynamicSecretRefsToValue(ctx, localKube, valueToHandle)
+	if err != nil {
+		return "", err
+	}
+
 	placeholders := removeDuplicates(findPlaceholders(valueToHandle))
 	for _, placeholder := range placeholders {
 
@@ -89,14 +110,33 @@ func patchSecretsToValue(ctx context.Context, localKube client.Client, valueToHa
 
 }
 
-// patchValueToSecret patches a value to a secret.
-func patchValueToSecret(ctx context.Context, kubeClient client.Client, logger logging.Logger, data *httpClient.HttpResponse, secret *corev1.Secret, secretKey string, requestFieldPath string) error {
-	dataMap, err := json_util.StructToMap(data)
+// isConditionMet evaluates a jq condition against the response data.
+// An empty condition is always met.
+func isConditionMet(data *httpClient.HttpResponse, condition, responseFormat string) (bool, error) {
+	if condition == "" {
+		return true, nil
+	}
+
+	dataMap, err := json_util.ResponseToMap(data, responseFormat)
 	if err != nil {
-		return errors.Wrap(err, errConvertData)
+		return false, errors.Wrap(err, errConvertData)
 	}
 
-	json_util.ConvertJSONStringsToMaps(&dataMap)
+	conditionMet, err := jq.ParseBool(condition, dataMap)
+	if err != nil {
+		return false, errors.Errorf(errConditionFormat, err.Error())
+	}
+
+	return conditionMet, nil
+}
+
+// extractValue evaluates the jq path against the response and returns the value to store in a secret.
+// Boolean results are rendered as strings. It returns an empty string when the path yields no value.
+func extractValue(logger logging.Logger, data *httpClient.HttpResponse, requestFieldPath, responseFormat string) (string, error) {
+	dataMap, err := json_util.ResponseToMap(data, responseFormat)
+	if err != nil {
+		return "", errors.Wrap(err, errConvertData)
+	}
 
 	valueToPatch, err := jq.ParseString(requestFieldPath, dataMap)
 	if err != nil {
@@ -110,24 +150,19 @@ func patchValueToSecret(ctx context.Context, kubeClient client.Client, logger lo
 
 	if valueToPatch == "" {
 		logger.Info(fmt.Sprintf(errEmptyKey, requestFieldPath, fmt.Sprint(data)))
-		return nil
-	}
-
-	if secret.Data == nil {
-		secret.Data = make(map[string][]byte)
 	}
 
-	secret.Data[secretKey] = []byte(valueToPatch)
+	return valueToPatch, nil
+}
 
-	// patch the {{name:namespace:key}} of secret instead of the sensitive value
-	placeholder := fmt.Sprintf("{{%s:%s:%s}}", secret.Name, secret.Namespace, secretKey)
-	data.Body = strings.ReplaceAll(data.Body, valueToPatch, placeholder)
+// replaceValueWithPlaceholder replaces the sensitive value in the response body and headers with the
+// {{name:namespace:key}} placeholder of the secret it was stored in.
+func replaceValueWithPlaceholder(data *httpClient.HttpResponse, value, secretName, secretNamespace, secretKey string) {
+	placeholder := fmt.Sprintf("{{%s:%s:%s}}", secretName, secretNamespace, secretKey)
+	data.Body = strings.ReplaceAll(data.Body, value, placeholder)
 	for _, headersList := range data.Headers {
 		for i, header := range headersList {
-			newHeader := strings.ReplaceAll(header, valueToPatch, placeholder)
-			headersList[i] = newHeader
+			headersList[i] = strings.ReplaceAll(header, value, placeholder)
 		}
 	}
-
-	return kubehandler.UpdateSecret(ctx, kubeClient, secret)
 }
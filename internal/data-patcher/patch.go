@@ -2,16 +2,24 @@ package datapatcher
 
 import (
 	"context"
+	"fmt"
+	"sync"
 
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	kubehandler "github.com/crossplane-contrib/provider-http/internal/kube-handler"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
+	// maxConcurrentSecretPatches bounds the number of secrets updated in parallel for a single response.
+	maxConcurrentSecretPatches = 4
+
 	errPatchToReferencedSecret = "cannot patch to referenced secret"
+	infoConditionNotMet        = "injection condition is not met, skipping secret update for: %s:%s:%s"
 )
 
 // PatchSecretsIntoBody patches secrets into the provided string body.
@@ -50,17 +58,168 @@ func copyHeaders(headers map[string][]string) map[string][]string {
 	return headersCopy
 }
 
+// SecretInjection describes a value extracted from a response and stored in a secret key.
+type SecretInjection struct {
+	// ResponsePath is the jq filter extracting the value from the response.
+	ResponsePath string
+	// Condition is an optional jq filter that must return true for the value to be stored.
+	Condition       string
+	SecretKey       string
+	SecretName      string
+	SecretNamespace string
+	// ResponseFormat is the format the response body is parsed with, see json.FormatNDJSON.
+	ResponseFormat string
+	// KeyTransform is the casing of the keys named after the fields of the object returned by ResponsePath,
+	// used when SecretKey is empty, see KeyTransformSnakeUpper.
+	KeyTransform string
+}
+
 // PatchResponseToSecret patches response data into a Kubernetes secret.
-func PatchResponseToSecret(ctx context.Context, localKube client.Client, logger logging.Logger, data *httpClient.HttpResponse, path, secretKey, secretName, secretNamespace string) error {
-	secret, err := kubehandler.GetOrCreateSecret(ctx, localKube, secretName, secretNamespace)
+// If a condition is given, the secret is only patched when it evaluates to true against the response.
+func PatchResponseToSecret(ctx context.Context, localKube client.Client, logger logging.Logger, data *httpClient.HttpResponse, path, condition, secretKey, secretName, secretNamespace string) error {
+	return PatchResponseToSecrets(ctx, localKube, logger, data, []SecretInjection{{
+		ResponsePath:    path,
+		Condition:       condition,
+		SecretKey:       secretKey,
+		SecretName:      secretName,
+		SecretNamespace: secretNamespace,
+	}})[0]
+}
+
+// PatchResponseToSecrets patches response data into Kubernetes secrets, returning the error of each injection
+// at its index. An injection without a secret key stores every field of the object its path returns in a key
+// named after the field. Every value is first extracted from the unmodified response, then distinct secrets are
+// updated concurrently, with the keys of a secret shared by several injections written in a single update, following
+// the InjectionPolicy of the context when several injections write the same key.
+// Finally, the extracted values are replaced with their placeholders in the response, in the order of the injections.
+func PatchResponseToSecrets(ctx context.Context, localKube client.Client, logger logging.Logger, data *httpClient.HttpResponse, injections []SecretInjection) []error {
+	errs := make([]error, len(injections))
+
+	// Each injection derived from the fields of an object reports its error at the index of the original one.
+	expanded := make([]SecretInjection, 0, len(injections))
+	origins := make([]int, 0, len(injections))
+	for i, injection := range injections {
+		if injection.SecretKey != "" {
+			expanded = append(expanded, injection)
+			origins = append(origins, i)
+			continue
+		}
+
+		fields, err := fieldInjections(data, injection)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		for _, field := range fields {
+			expanded = append(expanded, field)
+			origins = append(origins, i)
+		}
+	}
+
+	for j, err := range patchResponseToSecrets(ctx, localKube, logger, data, expanded) {
+		if i := origins[j]; errs[i] == nil {
+			errs[i] = err
+		}
+	}
+
+	return errs
+}
+
+// patchResponseToSecrets patches response data into Kubernetes secrets, for injections that all have a secret key.
+func patchResponseToSecrets(ctx context.Context, localKube client.Client, logger logging.Logger, data *httpClient.HttpResponse, injections []SecretInjection) []error {
+	errs := make([]error, len(injections))
+	values := make([]string, len(injections))
+
+	for i, injection := range injections {
+		conditionMet, err := isConditionMet(data, injection.Condition, injection.ResponseFormat)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+
+		if !conditionMet {
+			logger.Debug(fmt.Sprintf(infoConditionNotMet, injection.SecretName, injection.SecretNamespace, injection.SecretKey))
+			continue
+		}
+
+		if values[i], errs[i] = extractValue(logger, data, injection.ResponsePath, injection.ResponseFormat); errs[i] != nil {
+			errs[i] = errors.Wrap(errs[i], errPatchToReferencedSecret)
+		}
+	}
+
+	// Group the injections by secret, keeping the order in which secrets first appear.
+	policy := injectionPolicy(ctx)
+	secrets := []types.NamespacedName{}
+	indexesBySecret := map[types.NamespacedName][]int{}
+	for i, injection := range injections {
+		if values[i] == "" {
+			continue
+		}
+
+		secret := types.NamespacedName{Name: injection.SecretName, Namespace: injection.SecretNamespace}
+		if _, ok := indexesBySecret[secret]; !ok {
+			secrets = append(secrets, secret)
+		}
+		indexesBySecret[secret] = append(indexesBySecret[secret], i)
+	}
+
+	for _, secret := range secrets {
+		indexesBySecret[secret] = resolveConflicts(policy, logger, secret, injections, indexesBySecret[secret], errs)
+	}
+
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, maxConcurrentSecretPatches)
+	for _, secret := range secrets {
+		if len(indexesBySecret[secret]) == 0 {
+			continue
+		}
+
+		wg.Add(1)
+		workers <- struct{}{}
+
+		go func(secret types.NamespacedName, indexes []int) {
+			defer wg.Done()
+			defer func() { <-workers }()
+
+			// Each goroutine only writes the errors of its own injections.
+			err := patchValuesToSecret(ctx, localKube, policy, secret, injections, values, indexes)
+			for _, i := range indexes {
+				errs[i] = err
+			}
+		}(secret, indexesBySecret[secret])
+	}
+	wg.Wait()
+
+	for i, injection := range injections {
+		if values[i] != "" {
+			replaceValueWithPlaceholder(data, values[i], injection.SecretName, injection.SecretNamespace, injection.SecretKey)
+		}
+	}
+
+	return errs
+}
+
+// patchValuesToSecret writes the values of the given injections, which target distinct keys, to the secret in a
+// single update, unless it is controlled by another resource of the provider.
+func patchValuesToSecret(ctx context.Context, localKube client.Client, policy InjectionPolicy, name types.NamespacedName, injections []SecretInjection, values []string, indexes []int) error {
+	var owner *metav1.OwnerReference
+	if policy.OwnCreatedSecrets {
+		owner = policy.Owner
+	}
+
+	secret, err := kubehandler.GetOrCreateOwnedSecret(ctx, localKube, name.Name, name.Namespace, owner)
 	if err != nil {
 		return err
 	}
 
-	err = patchValueToSecret(ctx, localKube, logger, data, secret, secretKey, path)
-	if err != nil {
+	if err := checkController(policy, secret); err != nil {
 		return errors.Wrap(err, errPatchToReferencedSecret)
 	}
 
-	return nil
+	data := make(map[string][]byte, len(indexes))
+	for _, i := range indexes {
+		data[injections[i].SecretKey] = []byte(values[i])
+	}
+
+	return errors.Wrap(kubehandler.UpdateSecret(ctx, localKube, secret, data), errPatchToReferencedSecret)
 }
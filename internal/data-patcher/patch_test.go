@@ -2,8 +2,12 @@ package datapatcher
 
 import (
 	"context"
+	"sync"
 	"testing"
 
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
@@ -194,3 +198,212 @@ func TestPatchSecretsIntoHeaders(t *testing.T) {
 		})
 	}
 }
+
+func TestPatchResponseToSecret(t *testing.T) {
+	type args struct {
+		localKube client.Client
+		data      *httpClient.HttpResponse
+		path      string
+		condition string
+	}
+
+	type want struct {
+		secretData map[string][]byte
+		err        error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldPatchWithoutCondition": {
+			args: args{
+				data: &httpClient.HttpResponse{
+					Body: `{"status":"pending","token":"new-token"}`,
+				},
+				path: ".body.token",
+			},
+			want: want{
+				secretData: map[string][]byte{
+					"key":       []byte("new-token"),
+					"other-key": []byte("otherSecretValue"),
+				},
+			},
+		},
+		"ShouldPatchWhenConditionMet": {
+			args: args{
+				data: &httpClient.HttpResponse{
+					Body: `{"status":"active","token":"new-token"}`,
+				},
+				path:      ".body.token",
+				condition: `.body.status == "active"`,
+			},
+			want: want{
+				secretData: map[string][]byte{
+					"key":       []byte("new-token"),
+					"other-key": []byte("otherSecretValue"),
+				},
+			},
+		},
+		"ShouldSkipWhenConditionNotMet": {
+			args: args{
+				data: &httpClient.HttpResponse{
+					Body: `{"status":"pending","token":"new-token"}`,
+				},
+				path:      ".body.token",
+				condition: `.body.status == "active"`,
+			},
+			want: want{
+				secretData: nil,
+			},
+		},
+		"ShouldFailWhenConditionIsNotBoolean": {
+			args: args{
+				data: &httpClient.HttpResponse{
+					Body: `{"status":"pending","token":"new-token"}`,
+				},
+				path:      ".body.token",
+				condition: `.body.status`,
+			},
+			want: want{
+				secretData: nil,
+				err:        errors.Errorf(errConditionFormat, errors.Errorf("failed to parse string: %s", "pending").Error()),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var updated map[string][]byte
+			localKube := &test.MockClient{
+				MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+					secret, ok := obj.(*corev1.Secret)
+					if !ok {
+						return errors.New("object is not a Secret")
+					}
+
+					*secret = *createSpecificSecret("name", "namespace", "key", "old-token")
+					return nil
+				},
+				MockUpdate: func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+					updated = obj.(*corev1.Secret).Data
+					return nil
+				},
+			}
+
+			gotErr := PatchResponseToSecret(context.Background(), localKube, logging.NewNopLogger(), tc.args.data, tc.args.path, tc.args.condition, "key", "name", "namespace")
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("PatchResponseToSecret(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.secretData, updated); diff != "" {
+				t.Errorf("PatchResponseToSecret(...): -want secret data, +got secret data: %s", diff)
+			}
+		})
+	}
+}
+
+func TestPatchResponseToSecrets(t *testing.T) {
+	data := `{"id":"123","token":"t0k3n","user":{"name":"john","password":"s3cr3t"},"region":"eu","active":true}`
+
+	injections := []SecretInjection{
+		{ResponsePath: ".body.token", SecretKey: "token", SecretName: "auth", SecretNamespace: "default"},
+		{ResponsePath: ".body.user.password", SecretKey: "password", SecretName: "user", SecretNamespace: "default"},
+		{ResponsePath: ".body.user.name", SecretKey: "name", SecretName: "user", SecretNamespace: "default"},
+		{ResponsePath: ".body.region", SecretKey: "region", SecretName: "meta", SecretNamespace: "other"},
+		{ResponsePath: ".body.active", SecretKey: "active", SecretName: "meta", SecretNamespace: "other"},
+		{ResponsePath: ".body.id", SecretKey: "id", SecretName: "ids", SecretNamespace: "default"},
+		{ResponsePath: ".body.id", SecretKey: "id", SecretName: "ids-copy", SecretNamespace: "default"},
+		{ResponsePath: ".body.token", Condition: `.body.region == "us"`, SecretKey: "token", SecretName: "skipped", SecretNamespace: "default"},
+		{ResponsePath: ".body.missing", SecretKey: "missing", SecretName: "empty", SecretNamespace: "default"},
+		{ResponsePath: ".body.token", Condition: ".body.region", SecretKey: "token", SecretName: "invalid", SecretNamespace: "default"},
+	}
+
+	wantSecrets := map[string]map[string][]byte{
+		"default/auth":     {"token": []byte("t0k3n")},
+		"default/user":     {"password": []byte("s3cr3t"), "name": []byte("john")},
+		"other/meta":       {"region": []byte("eu"), "active": []byte("true")},
+		"default/ids":      {"id": []byte("123")},
+		"default/ids-copy": {"id": []byte("123")},
+	}
+	wantBody := `{"id":"{{ids:default:id}}","token":"{{auth:default:token}}","user":{"name":"{{user:default:name}}","password":"{{user:default:password}}"},"region":"{{meta:other:region}}","active":{{meta:other:active}}}`
+	wantErrs := []error{nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		errors.Errorf(errConditionFormat, errors.Errorf("failed to parse string: %s", "eu").Error())}
+
+	// Run several times so that a nondeterministic ordering of the concurrent updates would surface.
+	for run := 0; run < 10; run++ {
+		var mu sync.Mutex
+		updated := map[string]map[string][]byte{}
+		localKube := &test.MockClient{
+			MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+				secret, ok := obj.(*corev1.Secret)
+				if !ok {
+					return errors.New("object is not a Secret")
+				}
+
+				secret.Name = key.Name
+				secret.Namespace = key.Namespace
+				return nil
+			},
+			MockUpdate: func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+				mu.Lock()
+				defer mu.Unlock()
+
+				updated[obj.GetNamespace()+"/"+obj.GetName()] = obj.(*corev1.Secret).Data
+				return nil
+			},
+		}
+
+		response := &httpClient.HttpResponse{Body: data}
+		gotErrs := PatchResponseToSecrets(context.Background(), localKube, logging.NewNopLogger(), response, injections)
+		if diff := cmp.Diff(wantErrs, gotErrs, test.EquateErrors()); diff != "" {
+			t.Fatalf("PatchResponseToSecrets(...): -want errors, +got errors: %s", diff)
+		}
+		if diff := cmp.Diff(wantSecrets, updated); diff != "" {
+			t.Errorf("PatchResponseToSecrets(...): -want secrets, +got secrets: %s", diff)
+		}
+		if diff := cmp.Diff(wantBody, response.Body); diff != "" {
+			t.Errorf("PatchResponseToSecrets(...): -want body, +got body: %s", diff)
+		}
+	}
+}
+
+func TestPatchResponseToSecretsNDJSON(t *testing.T) {
+	data := "{\"id\":\"1\",\"token\":\"old\"}\n{\"id\":\"2\",\"token\":\"n3w\"}\n"
+	injections := []SecretInjection{
+		{ResponsePath: ".body | last | .token", SecretKey: "token", SecretName: "auth", SecretNamespace: "default", ResponseFormat: json_util.FormatNDJSON},
+		{ResponsePath: `.body | map(.id) | join(",")`, SecretKey: "ids", SecretName: "meta", SecretNamespace: "default", ResponseFormat: json_util.FormatNDJSON},
+	}
+
+	updated := map[string]map[string][]byte{}
+	var mu sync.Mutex
+	localKube := &test.MockClient{
+		MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+			obj.SetName(key.Name)
+			obj.SetNamespace(key.Namespace)
+			return nil
+		},
+		MockUpdate: func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			updated[obj.GetNamespace()+"/"+obj.GetName()] = obj.(*corev1.Secret).Data
+			return nil
+		},
+	}
+
+	response := &httpClient.HttpResponse{Body: data}
+	gotErrs := PatchResponseToSecrets(context.Background(), localKube, logging.NewNopLogger(), response, injections)
+	if diff := cmp.Diff([]error{nil, nil}, gotErrs, test.EquateErrors()); diff != "" {
+		t.Fatalf("PatchResponseToSecrets(...): -want errors, +got errors: %s", diff)
+	}
+
+	wantSecrets := map[string]map[string][]byte{
+		"default/auth": {"token": []byte("n3w")},
+		"default/meta": {"ids": []byte("1,2")},
+	}
+	if diff := cmp.Diff(wantSecrets, updated); diff != "" {
+		t.Errorf("PatchResponseToSecrets(...): -want secrets, +got secrets: %s", diff)
+	}
+}
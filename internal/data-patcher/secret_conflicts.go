@@ -0,0 +1,103 @@
+package datapatcher
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// providerGroup is the API group of the resources of the provider, which don't write to the secrets
+	// controlled by one another.
+	providerGroup = "http.crossplane.io"
+
+	errSecretKeyConflict  = "secret key %s of secret %s/%s is written by %d secret injections"
+	errSecretOwned        = "secret %s/%s is controlled by %s %s"
+	infoSecretKeyConflict = "Warning, secret key %s of secret %s/%s is written by %d secret injections, writing the value of the last one"
+)
+
+// InjectionPolicy decides how the secret injections of a resource share secrets, within the resource and with
+// the other resources of the provider.
+type InjectionPolicy struct {
+	// Owner is the resource the values are injected for. The secrets controlled by another resource of the
+	// provider aren't written.
+	Owner *metav1.OwnerReference
+	// OwnCreatedSecrets sets Owner as the controller of the secrets created for the injections.
+	OwnCreatedSecrets bool
+	// FailOnConflict fails the injections writing the same key of a secret, instead of writing the value of the
+	// last of them.
+	FailOnConflict bool
+}
+
+type injectionPolicyKey struct{}
+
+// WithInjectionPolicy returns a context whose secret injections follow the given policy.
+func WithInjectionPolicy(ctx context.Context, policy InjectionPolicy) context.Context {
+	return context.WithValue(ctx, injectionPolicyKey{}, policy)
+}
+
+// injectionPolicy returns the policy of the secret injections of the context, if any.
+func injectionPolicy(ctx context.Context) InjectionPolicy {
+	policy, _ := ctx.Value(injectionPolicyKey{}).(InjectionPolicy)
+	return policy
+}
+
+// ControllerReference returns the reference making the given resource the controller of the secrets it owns.
+// It doesn't block the deletion of the resource, which would require the permission to update its finalizers.
+func ControllerReference(gvk schema.GroupVersionKind, obj metav1.Object) *metav1.OwnerReference {
+	controller := true
+	return &metav1.OwnerReference{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Name:       obj.GetName(),
+		UID:        obj.GetUID(),
+		Controller: &controller,
+	}
+}
+
+// resolveConflicts returns the given injections of a secret without the ones whose key is written by a later
+// one, so that the value of the last of them is written. With FailOnConflict, every injection writing a shared key
+// is dropped instead, and its error set.
+func resolveConflicts(policy InjectionPolicy, logger logging.Logger, secret types.NamespacedName, injections []SecretInjection, indexes []int, errs []error) []int {
+	byKey := map[string][]int{}
+	for _, i := range indexes {
+		byKey[injections[i].SecretKey] = append(byKey[injections[i].SecretKey], i)
+	}
+
+	resolved := make([]int, 0, len(indexes))
+	for _, i := range indexes {
+		shared := byKey[injections[i].SecretKey]
+		switch {
+		case len(shared) == 1:
+			resolved = append(resolved, i)
+		case policy.FailOnConflict:
+			errs[i] = errors.Errorf(errSecretKeyConflict, injections[i].SecretKey, secret.Namespace, secret.Name, len(shared))
+		case i == shared[len(shared)-1]:
+			logger.Info(fmt.Sprintf(infoSecretKeyConflict, injections[i].SecretKey, secret.Namespace, secret.Name, len(shared)))
+			resolved = append(resolved, i)
+		}
+	}
+
+	return resolved
+}
+
+// checkController returns an error if the secret is controlled by another resource of the provider than the owner.
+func checkController(policy InjectionPolicy, secret *corev1.Secret) error {
+	controller := metav1.GetControllerOf(secret)
+	if controller == nil || policy.Owner == nil || controller.UID == policy.Owner.UID {
+		return nil
+	}
+
+	gv, err := schema.ParseGroupVersion(controller.APIVersion)
+	if err != nil || gv.Group != providerGroup {
+		return nil
+	}
+
+	return errors.Errorf(errSecretOwned, secret.Namespace, secret.Name, controller.Kind, controller.Name)
+}
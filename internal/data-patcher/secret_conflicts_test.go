@@ -0,0 +1,156 @@
+package datapatcher
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+var (
+	testOwner        = ControllerReference(schema.GroupVersionKind{Group: providerGroup, Version: "v1alpha2", Kind: "Request"}, &metav1.ObjectMeta{Name: "user", UID: "owner-uid"})
+	testOtherOwner   = ControllerReference(schema.GroupVersionKind{Group: providerGroup, Version: "v1alpha2", Kind: "Request"}, &metav1.ObjectMeta{Name: "other", UID: "other-uid"})
+	testForeignOwner = ControllerReference(schema.GroupVersionKind{Group: "external-secrets.io", Version: "v1beta1", Kind: "ExternalSecret"}, &metav1.ObjectMeta{Name: "creds", UID: "foreign-uid"})
+)
+
+func TestPatchResponseToSecrets_Conflicts(t *testing.T) {
+	data := `{"id":"123","token":"t0k3n","region":"eu"}`
+	colliding := []SecretInjection{
+		{ResponsePath: ".body.token", SecretKey: "token", SecretName: "auth", SecretNamespace: "default"},
+		{ResponsePath: ".body.id", SecretKey: "token", SecretName: "auth", SecretNamespace: "default"},
+		{ResponsePath: ".body.region", SecretKey: "region", SecretName: "auth", SecretNamespace: "default"},
+	}
+	single := []SecretInjection{
+		{ResponsePath: ".body.token", SecretKey: "token", SecretName: "auth", SecretNamespace: "default"},
+	}
+
+	type args struct {
+		policy     InjectionPolicy
+		injections []SecretInjection
+		existing   *metav1.OwnerReference
+		missing    bool
+	}
+	type want struct {
+		errs    []error
+		secrets map[string]map[string][]byte
+		owners  []metav1.OwnerReference
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"LastInjectionWins": {
+			args: args{injections: colliding},
+			want: want{
+				errs:    []error{nil, nil, nil},
+				secrets: map[string]map[string][]byte{"default/auth": {"token": []byte("123"), "region": []byte("eu")}},
+			},
+		},
+		"ConflictFails": {
+			args: args{policy: InjectionPolicy{FailOnConflict: true}, injections: colliding},
+			want: want{
+				errs: []error{
+					errors.Errorf(errSecretKeyConflict, "token", "default", "auth", 2),
+					errors.Errorf(errSecretKeyConflict, "token", "default", "auth", 2),
+					nil,
+				},
+				secrets: map[string]map[string][]byte{"default/auth": {"region": []byte("eu")}},
+			},
+		},
+		"ControlledByAnotherResource": {
+			args: args{policy: InjectionPolicy{Owner: testOwner}, injections: single, existing: testOtherOwner},
+			want: want{
+				errs:    []error{errors.Wrap(errors.Errorf(errSecretOwned, "default", "auth", "Request", "other"), errPatchToReferencedSecret)},
+				secrets: map[string]map[string][]byte{},
+			},
+		},
+		"ControlledByTheOwner": {
+			args: args{policy: InjectionPolicy{Owner: testOwner}, injections: single, existing: testOwner},
+			want: want{
+				errs:    []error{nil},
+				secrets: map[string]map[string][]byte{"default/auth": {"token": []byte("t0k3n")}},
+			},
+		},
+		"ControlledOutsideTheProvider": {
+			args: args{policy: InjectionPolicy{Owner: testOwner}, injections: single, existing: testForeignOwner},
+			want: want{
+				errs:    []error{nil},
+				secrets: map[string]map[string][]byte{"default/auth": {"token": []byte("t0k3n")}},
+			},
+		},
+		"CreatedSecretOwned": {
+			args: args{policy: InjectionPolicy{Owner: testOwner, OwnCreatedSecrets: true}, injections: single, missing: true},
+			want: want{
+				errs:    []error{nil},
+				secrets: map[string]map[string][]byte{"default/auth": {"token": []byte("t0k3n")}},
+				owners:  []metav1.OwnerReference{*testOwner},
+			},
+		},
+		"CreatedSecretNotOwned": {
+			args: args{policy: InjectionPolicy{Owner: testOwner}, injections: single, missing: true},
+			want: want{
+				errs:    []error{nil},
+				secrets: map[string]map[string][]byte{"default/auth": {"token": []byte("t0k3n")}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var mu sync.Mutex
+			updated := map[string]map[string][]byte{}
+			var owners []metav1.OwnerReference
+			localKube := &test.MockClient{
+				MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+					if tc.args.missing {
+						return kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, key.Name)
+					}
+
+					secret := obj.(*corev1.Secret)
+					secret.Name = key.Name
+					secret.Namespace = key.Namespace
+					if tc.args.existing != nil {
+						secret.OwnerReferences = []metav1.OwnerReference{*tc.args.existing}
+					}
+					return nil
+				},
+				MockCreate: func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+					owners = obj.GetOwnerReferences()
+					return nil
+				},
+				MockUpdate: func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+					mu.Lock()
+					defer mu.Unlock()
+
+					updated[obj.GetNamespace()+"/"+obj.GetName()] = obj.(*corev1.Secret).Data
+					return nil
+				},
+			}
+
+			ctx := WithInjectionPolicy(context.Background(), tc.args.policy)
+			response := &httpClient.HttpResponse{Body: data}
+			gotErrs := PatchResponseToSecrets(ctx, localKube, logging.NewNopLogger(), response, tc.args.injections)
+			if diff := cmp.Diff(tc.want.errs, gotErrs, test.EquateErrors()); diff != "" {
+				t.Fatalf("PatchResponseToSecrets(...): -want errors, +got errors: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.secrets, updated); diff != "" {
+				t.Errorf("PatchResponseToSecrets(...): -want secrets, +got secrets: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.owners, owners); diff != "" {
+				t.Errorf("PatchResponseToSecrets(...): -want owner references, +got owner references: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package datapatcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+)
+
+const (
+	// KeyTransformAsIs names the secret keys derived from the fields of a response exactly like the fields.
+	KeyTransformAsIs = "asIs"
+	// KeyTransformSnakeUpper names the secret keys like environment variables, e.g. apiKey becomes API_KEY.
+	KeyTransformSnakeUpper = "snakeUpper"
+	// KeyTransformKebab names the secret keys in kebab case, e.g. apiKey becomes api-key.
+	KeyTransformKebab = "kebab"
+
+	errFieldsNotObject = "response path %s should return an object to derive secret keys from its fields, but returned: %s"
+)
+
+// fieldInjections returns an injection for every field of the object the response path of the injection returns,
+// stored in a key named after the field with the key transform of the injection. Fields set to null are skipped,
+// and so is every field when the condition of the injection isn't met.
+func fieldInjections(data *httpClient.HttpResponse, injection SecretInjection) ([]SecretInjection, error) {
+	conditionMet, err := isConditionMet(data, injection.Condition, injection.ResponseFormat)
+	if err != nil || !conditionMet {
+		return nil, err
+	}
+
+	dataMap, err := json_util.ResponseToMap(data, injection.ResponseFormat)
+	if err != nil {
+		return nil, errors.Wrap(err, errConvertData)
+	}
+
+	result, err := jq.ParseInterface(injection.ResponsePath, dataMap)
+	if err != nil {
+		return nil, errors.Wrap(err, errPatchToReferencedSecret)
+	}
+
+	fields, ok := result.(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf(errFieldsNotObject, injection.ResponsePath, fmt.Sprint(result))
+	}
+
+	names := make([]string, 0, len(fields))
+	for name, value := range fields {
+		if value != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	injections := make([]SecretInjection, 0, len(names))
+	for _, name := range names {
+		quoted, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+
+		field := injection
+		field.ResponsePath = fmt.Sprintf("(%s)[%s] | tostring", injection.ResponsePath, quoted)
+		field.Condition = ""
+		field.SecretKey = TransformSecretKey(name, injection.KeyTransform)
+		injections = append(injections, field)
+	}
+
+	return injections, nil
+}
+
+// TransformSecretKey turns the name of a response field into the name of a secret key with the given
+// transform. Names are split into words at separators and case changes, so that apiKey, api_key and api-key
+// all become API_KEY in snake upper case. Unknown transforms keep the name as is.
+func TransformSecretKey(name, transform string) string {
+	switch transform {
+	case KeyTransformSnakeUpper:
+		return strings.ToUpper(strings.Join(keyWords(name), "_"))
+	case KeyTransformKebab:
+		return strings.ToLower(strings.Join(keyWords(name), "-"))
+	default:
+		return name
+	}
+}
+
+// keyWords splits a field name into its words. A word starts after a character that is neither a letter nor
+// a digit, at an upper case letter following a lower case letter or a digit, and at the last upper case letter
+// of an acronym followed by a lower case letter, e.g. HTTPServer is made of HTTP and Server.
+func keyWords(name string) []string {
+	var words []string
+	var word []rune
+
+	runes := []rune(name)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(word) > 0 {
+				words = append(words, string(word))
+				word = nil
+			}
+			continue
+		}
+
+		if unicode.IsUpper(r) && len(word) > 0 {
+			previous := runes[i-1]
+			acronymEnds := unicode.IsUpper(previous) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || acronymEnds {
+				words = append(words, string(word))
+				word = nil
+			}
+		}
+
+		word = append(word, r)
+	}
+
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+
+	return words
+}
@@ -0,0 +1,120 @@
+package datapatcher
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func TestTransformSecretKey(t *testing.T) {
+	type args struct {
+		name      string
+		transform string
+	}
+
+	cases := map[string]struct {
+		args args
+		want string
+	}{
+		"AsIsKeepsCamelCase": {
+			args: args{name: "apiKey", transform: KeyTransformAsIs},
+			want: "apiKey",
+		},
+		"EmptyTransformKeepsName": {
+			args: args{name: "api_key", transform: ""},
+			want: "api_key",
+		},
+		"SnakeUpperFromCamelCase": {
+			args: args{name: "apiKey", transform: KeyTransformSnakeUpper},
+			want: "API_KEY",
+		},
+		"SnakeUpperFromKebabCase": {
+			args: args{name: "api-key", transform: KeyTransformSnakeUpper},
+			want: "API_KEY",
+		},
+		"SnakeUpperSplitsAcronyms": {
+			args: args{name: "HTTPServerURL", transform: KeyTransformSnakeUpper},
+			want: "HTTP_SERVER_URL",
+		},
+		"SnakeUpperKeepsDigitsInWords": {
+			args: args{name: "oauth2Token", transform: KeyTransformSnakeUpper},
+			want: "OAUTH2_TOKEN",
+		},
+		"KebabFromCamelCase": {
+			args: args{name: "apiKey", transform: KeyTransformKebab},
+			want: "api-key",
+		},
+		"KebabFromSnakeUpperCase": {
+			args: args{name: "CLIENT_SECRET", transform: KeyTransformKebab},
+			want: "client-secret",
+		},
+		"KebabCollapsesSeparators": {
+			args: args{name: "user.  name", transform: KeyTransformKebab},
+			want: "user-name",
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got := TransformSecretKey(tc.args.name, tc.args.transform)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("TransformSecretKey(...): -want key, +got key: %s", diff)
+			}
+		})
+	}
+}
+
+func TestPatchResponseToSecretsFieldKeys(t *testing.T) {
+	data := `{"credentials":{"apiKey":"k3y","clientSecret":"s3cr3t","port":8080,"unset":null},"region":"eu"}`
+
+	injections := []SecretInjection{
+		{ResponsePath: ".body.credentials", KeyTransform: KeyTransformSnakeUpper, SecretName: "env", SecretNamespace: "default"},
+		{ResponsePath: ".body.credentials", KeyTransform: KeyTransformKebab, SecretName: "files", SecretNamespace: "default"},
+		{ResponsePath: ".body.region", SecretKey: "region", SecretName: "meta", SecretNamespace: "default"},
+		{ResponsePath: ".body.credentials", Condition: `.body.region == "us"`, SecretName: "skipped", SecretNamespace: "default"},
+		{ResponsePath: ".body.region", SecretName: "invalid", SecretNamespace: "default"},
+	}
+
+	var mu sync.Mutex
+	updated := map[string]map[string][]byte{}
+	localKube := &test.MockClient{
+		MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+			obj.SetName(key.Name)
+			obj.SetNamespace(key.Namespace)
+			return nil
+		},
+		MockUpdate: func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			updated[obj.GetNamespace()+"/"+obj.GetName()] = obj.(*corev1.Secret).Data
+			return nil
+		},
+	}
+
+	response := &httpClient.HttpResponse{Body: data}
+	gotErrs := PatchResponseToSecrets(context.Background(), localKube, logging.NewNopLogger(), response, injections)
+	wantErrs := []error{nil, nil, nil, nil, errors.Errorf(errFieldsNotObject, ".body.region", "eu")}
+	if diff := cmp.Diff(wantErrs, gotErrs, test.EquateErrors()); diff != "" {
+		t.Fatalf("PatchResponseToSecrets(...): -want errors, +got errors: %s", diff)
+	}
+
+	wantSecrets := map[string]map[string][]byte{
+		"default/env":   {"API_KEY": []byte("k3y"), "CLIENT_SECRET": []byte("s3cr3t"), "PORT": []byte("8080")},
+		"default/files": {"api-key": []byte("k3y"), "client-secret": []byte("s3cr3t"), "port": []byte("8080")},
+		"default/meta":  {"region": []byte("eu")},
+	}
+	if diff := cmp.Diff(wantSecrets, updated); diff != "" {
+		t.Errorf("PatchResponseToSecrets(...): -want secrets, +got secrets: %s", diff)
+	}
+}
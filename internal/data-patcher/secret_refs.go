@@ -0,0 +1,75 @@
+package datapatcher
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubehandler "github.com/crossplane-contrib/provider-http/internal/kube-handler"
+)
+
+// SecretKeyRef identifies a key of a secret referenced by a {{name:namespace:key}} placeholder.
+type SecretKeyRef struct {
+	Name      string
+	Namespace string
+	Key       string
+}
+
+// FindSecretRefs returns the secret keys referenced by placeholders anywhere in the JSON form of obj,
+// sorted and without duplicates.
+func FindSecretRefs(obj interface{}) []SecretKeyRef {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil
+	}
+
+	refs := []SecretKeyRef{}
+	seen := map[SecretKeyRef]bool{}
+	for _, placeholder := range findPlaceholders(string(data)) {
+		name, namespace, key, ok := parsePlaceholder(placeholder)
+		ref := SecretKeyRef{Name: name, Namespace: namespace, Key: key}
+		if ok && !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Namespace != refs[j].Namespace {
+			return refs[i].Namespace < refs[j].Namespace
+		}
+		if refs[i].Name != refs[j].Name {
+			return refs[i].Name < refs[j].Name
+		}
+		return refs[i].Key < refs[j].Key
+	})
+
+	return refs
+}
+
+// SecretsFingerprint returns a hash of the current values of the given secret keys, which changes whenever
+// one of them is rotated. It returns an empty string when there are no references.
+func SecretsFingerprint(ctx context.Context, localKube client.Client, refs []SecretKeyRef) (string, error) {
+	if len(refs) == 0 {
+		return "", nil
+	}
+
+	hash := sha256.New()
+	for _, ref := range refs {
+		secret, err := kubehandler.GetSecret(ctx, localKube, ref.Name, ref.Namespace)
+		if err != nil {
+			return "", err
+		}
+
+		for _, part := range []string{ref.Namespace, ref.Name, ref.Key, string(secret.Data[ref.Key])} {
+			hash.Write([]byte(part))
+			hash.Write([]byte{0})
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,84 @@
+package datapatcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Test_FindSecretRefs(t *testing.T) {
+	type args struct {
+		obj interface{}
+	}
+	type want struct {
+		result []SecretKeyRef
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"SortedWithoutDuplicates": {
+			args: args{
+				obj: map[string]interface{}{
+					"body":    `{"password": "{{ creds:ns-b:password }}", "token": "{{creds:ns-b:password}}"}`,
+					"headers": map[string][]string{"Authorization": {"Bearer {{auth:ns-a:token}}"}},
+				},
+			},
+			want: want{
+				result: []SecretKeyRef{
+					{Name: "auth", Namespace: "ns-a", Key: "token"},
+					{Name: "creds", Namespace: "ns-b", Key: "password"},
+				},
+			},
+		},
+		"NoPlaceholders": {
+			args: args{
+				obj: map[string]interface{}{"body": `{"username": "john_doe"}`},
+			},
+			want: want{
+				result: []SecretKeyRef{},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := FindSecretRefs(tc.args.obj)
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("FindSecretRefs(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SecretsFingerprint(t *testing.T) {
+	refs := []SecretKeyRef{{Name: "auth", Namespace: "default", Key: "token"}}
+	fingerprintOf := func(value string) string {
+		kube := &test.MockClient{
+			MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+				createSpecificSecret("auth", "default", "token", value).DeepCopyInto(obj.(*corev1.Secret))
+				return nil
+			},
+		}
+		fingerprint, err := SecretsFingerprint(context.Background(), kube, refs)
+		if err != nil {
+			t.Fatalf("SecretsFingerprint(...): unexpected error: %s", err)
+		}
+		return fingerprint
+	}
+
+	if fingerprintOf("a") != fingerprintOf("a") {
+		t.Fatalf("SecretsFingerprint(...): want the same fingerprint for the same secret values")
+	}
+	if fingerprintOf("a") == fingerprintOf("b") {
+		t.Fatalf("SecretsFingerprint(...): want a different fingerprint after the secret was rotated")
+	}
+
+	got, err := SecretsFingerprint(context.Background(), &test.MockClient{}, nil)
+	if diff := cmp.Diff("", got); diff != "" || err != nil {
+		t.Fatalf("SecretsFingerprint(...): want an empty fingerprint without references, got %q, %v", got, err)
+	}
+}
@@ -0,0 +1,111 @@
+package jq
+
+import (
+	"bytes"
+	"crypto/md5" // #nosec G501 -- md5 is offered for checksums and cache keys, not for security.
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/itchyny/gojq"
+)
+
+// compilerOptions define the functions available in every jq query, on top of the jq builtins.
+// gojq doesn't support custom @formats, so the hash helpers are exposed as functions: `.body | sha256`.
+var compilerOptions = []gojq.CompilerOption{
+	gojq.WithFunction("sha256", 0, 0, hashFunction("sha256", func(b []byte) []byte {
+		sum := sha256.Sum256(b)
+		return sum[:]
+	})),
+	gojq.WithFunction("md5", 0, 0, hashFunction("md5", func(b []byte) []byte {
+		sum := md5.Sum(b) // #nosec G401
+		return sum[:]
+	})),
+	gojq.WithFunction("pathEncode", 0, 0, pathEncode),
+	gojq.WithFunction("jwtDecode", 0, 0, jwtDecode),
+	gojq.WithFunction("_urlvalue", 0, 0, urlValue),
+}
+
+// pathEncode escapes its input so it can be used as a single URL path segment, e.g. an ID containing a slash:
+// `.payload.baseUrl + "/" + (.response.body.id | pathEncode)`. Numbers are encoded in plain notation.
+func pathEncode(input interface{}, _ []interface{}) interface{} {
+	switch v := input.(type) {
+	case string:
+		return url.PathEscape(v)
+	case int, float64:
+		value, _ := FormatURLValue(v)
+		return url.PathEscape(value)
+	default:
+		return fmt.Errorf("pathEncode cannot be applied to: %v, input must be a string or a number", input)
+	}
+}
+
+// hashFunction returns a jq function hashing its string input and returning the digest as lowercase hex.
+func hashFunction(name string, hash func([]byte) []byte) func(interface{}, []interface{}) interface{} {
+	return func(input interface{}, _ []interface{}) interface{} {
+		str, ok := input.(string)
+		if !ok {
+			return fmt.Errorf("%s cannot be applied to: %v, input must be a string", name, input)
+		}
+
+		return hex.EncodeToString(hash([]byte(str)))
+	}
+}
+
+// jwtDecode decodes the payload of the JWT it is applied to into the object of its claims, e.g.
+// `.body.access_token | jwtDecode | .exp`. The signature isn't verified, so the claims must not be trusted for
+// authorization decisions.
+func jwtDecode(input interface{}, _ []interface{}) interface{} {
+	token, ok := input.(string)
+	if !ok {
+		return fmt.Errorf("jwtDecode cannot be applied to: %v, input must be a string", input)
+	}
+
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("jwtDecode cannot decode the token: a JWT is made of 3 parts separated by dots, but it has %d", len(parts))
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return fmt.Errorf("jwtDecode cannot decode the payload of the token: %s", err.Error())
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+
+	var claims map[string]interface{}
+	if err := decoder.Decode(&claims); err != nil || claims == nil {
+		return errors.New("jwtDecode cannot decode the payload of the token: it isn't a JSON object")
+	}
+
+	return integerClaims(claims)
+}
+
+// integerClaims returns the decoded claims with their integer numbers, such as exp, as ints so they stay exact,
+// and the other numbers as floats.
+func integerClaims(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return int(i)
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = integerClaims(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = integerClaims(item)
+		}
+	}
+
+	return value
+}
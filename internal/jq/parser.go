@@ -1,7 +1,9 @@
 package jq
 
 import (
+	"encoding/json"
 	"fmt"
+	"math/big"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -19,14 +21,31 @@ const (
 
 var mutex = &sync.Mutex{}
 
-func runJQQuery(jqQuery string, obj interface{}) (interface{}, error) {
+// compileJQQuery compiles the query, along with the given function definitions, which take precedence over the
+// builtins of the same name.
+func compileJQQuery(jqQuery string, funcDefs ...*gojq.FuncDef) (*gojq.Code, error) {
 	query, err := gojq.Parse(jqQuery)
 	if err != nil {
 		return nil, err
 	}
+	query.FuncDefs = append(append([]*gojq.FuncDef(nil), funcDefs...), query.FuncDefs...)
+
+	code, err := gojq.Compile(query, compilerOptions...)
+	if err != nil {
+		return nil, errors.Errorf(errInvalidQuery, jqQuery, err.Error())
+	}
+
+	return code, nil
+}
+
+func runJQQuery(jqQuery string, obj interface{}) (interface{}, error) {
+	code, err := compileJQQuery(jqQuery)
+	if err != nil {
+		return nil, err
+	}
 
 	mutex.Lock()
-	queryRes, ok := query.Run(obj).Next()
+	queryRes, ok := code.Run(obj).Next()
 	mutex.Unlock()
 
 	if !ok {
@@ -41,6 +60,56 @@ func runJQQuery(jqQuery string, obj interface{}) (interface{}, error) {
 	return queryRes, nil
 }
 
+// ParseInterface runs the query and returns its result as is.
+func ParseInterface(jqQuery string, obj interface{}) (interface{}, error) {
+	return runJQQuery(jqQuery, obj)
+}
+
+// ParseAll runs the query and returns every result it yields, in order. A query yielding no result fails, like
+// it does for the other parsers.
+func ParseAll(jqQuery string, obj interface{}) ([]interface{}, error) {
+	return parseAll(jqQuery, obj)
+}
+
+// ParseAllURL runs the query of a URL like ParseAll, rendering the values it turns into strings for a URL, with
+// tostring or by string interpolation, as FormatURLValue does. A null value fails the query with an error
+// wrapping ErrNullURLValue.
+func ParseAllURL(jqQuery string, obj interface{}) ([]interface{}, error) {
+	return parseAll(jqQuery, obj, urlFuncDefs...)
+}
+
+func parseAll(jqQuery string, obj interface{}, funcDefs ...*gojq.FuncDef) ([]interface{}, error) {
+	code, err := compileJQQuery(jqQuery, funcDefs...)
+	if err != nil {
+		return nil, err
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	var results []interface{}
+	iter := code.Run(obj)
+	for {
+		queryRes, ok := iter.Next()
+		if !ok {
+			break
+		}
+		if err, ok := queryRes.(error); ok {
+			if IsNullURLValue(err) {
+				return nil, errors.Wrap(ErrNullURLValue, jqQuery)
+			}
+			return nil, errors.Errorf(errInvalidQuery, jqQuery, err.Error())
+		}
+		results = append(results, queryRes)
+	}
+
+	if len(results) == 0 {
+		return nil, errors.Errorf(errQueryFailed, jqQuery)
+	}
+
+	return results, nil
+}
+
 func ParseString(jqQuery string, obj interface{}) (string, error) {
 	queryRes, err := runJQQuery(jqQuery, obj)
 	if err != nil {
@@ -55,6 +124,43 @@ func ParseString(jqQuery string, obj interface{}) (string, error) {
 	return str, nil
 }
 
+// ParseScalar runs the query and renders its result as a string. Strings are returned as is, while numbers
+// and booleans are returned in their JSON form.
+func ParseScalar(jqQuery string, obj interface{}) (string, error) {
+	queryRes, err := runJQQuery(jqQuery, obj)
+	if err != nil {
+		return "", err
+	}
+
+	switch v := queryRes.(type) {
+	case string:
+		return v, nil
+	case bool, int, float64, *big.Int:
+		return fmt.Sprint(v), nil
+	default:
+		return "", errors.Errorf(errStringParseFailed, fmt.Sprint(queryRes))
+	}
+}
+
+// ParseJSON runs the query and renders its result as JSON. String results are returned as is.
+func ParseJSON(jqQuery string, obj interface{}) (string, error) {
+	queryRes, err := runJQQuery(jqQuery, obj)
+	if err != nil {
+		return "", err
+	}
+
+	if str, ok := queryRes.(string); ok {
+		return str, nil
+	}
+
+	data, err := json.Marshal(queryRes)
+	if err != nil {
+		return "", errors.Errorf(errResultParseFailed, err.Error())
+	}
+
+	return string(data), nil
+}
+
 func ParseBool(jqQuery string, obj interface{}) (bool, error) {
 	queryRes, err := runJQQuery(jqQuery, obj)
 	if err != nil {
@@ -88,30 +194,57 @@ func ParseMapInterface(jqQuery string, obj interface{}) (map[string]interface{},
 	return nil, errors.Errorf(errMapParseFailed, fmt.Sprint(queryRes))
 }
 
+// ParseMapStrings runs the queries of every key and returns their string results. Queries that fail to run are
+// kept as literal values. Queries returning an array of strings add each of them as a separate value, so a
+// multi-value header such as Set-Cookie can be forwarded as is. Null and empty results are omitted, and so are keys
+// left without any value, so an optional header can be dropped by making its expression return null.
 func ParseMapStrings(keyToJQQueries map[string][]string, obj interface{}) (map[string][]string, error) {
 	result := make(map[string][]string, len(keyToJQQueries))
 
 	for key, jqQueries := range keyToJQQueries {
-		results := make([]string, len(jqQueries))
+		results := make([]string, 0, len(jqQueries))
+
+		for _, jqQuery := range jqQueries {
+			if jqQuery == "" {
+				continue
+			}
 
-		for i, jqQuery := range jqQueries {
 			queryRes, err := runJQQuery(jqQuery, obj)
 			if err != nil {
 				// Use the original query as a fallback
-				results[i] = jqQuery
+				results = append(results, jqQuery)
 				continue
 			}
 
-			str, ok := queryRes.(string)
+			if queryRes == nil {
+				continue
+			}
+
+			values, ok := queryRes.([]interface{})
 			if !ok {
-				// Raise an error if the result is not a string
-				return nil, errors.Errorf(errResultParseFailed, fmt.Sprint(queryRes))
+				values = []interface{}{queryRes}
 			}
 
-			results[i] = str
+			for _, value := range values {
+				if value == nil {
+					continue
+				}
+
+				str, ok := value.(string)
+				if !ok {
+					// Raise an error if the result is not a string
+					return nil, errors.Errorf(errResultParseFailed, fmt.Sprint(queryRes))
+				}
+
+				if str != "" {
+					results = append(results, str)
+				}
+			}
 		}
 
-		result[key] = results
+		if len(results) > 0 {
+			result[key] = results
+		}
 	}
 
 	return result, nil
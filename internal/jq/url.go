@@ -0,0 +1,58 @@
+package jq
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/itchyny/gojq"
+	"github.com/pkg/errors"
+)
+
+// ErrNullURLValue is returned when a null value is substituted into a URL, which would otherwise silently
+// target the wrong resource, such as .../users/null.
+var ErrNullURLValue = errors.New("null value substituted into the URL")
+
+// IsNullURLValue checks if the provided error indicates that a null value was substituted into a URL.
+func IsNullURLValue(err error) bool {
+	return errors.Is(err, ErrNullURLValue)
+}
+
+// urlFuncDefs are defined in the queries of URLs, so that the values they turn into strings are rendered by
+// FormatURLValue.
+var urlFuncDefs = func() []*gojq.FuncDef {
+	query, err := gojq.Parse("def tostring: _urlvalue; .")
+	if err != nil {
+		panic(err)
+	}
+	return query.FuncDefs
+}()
+
+// FormatURLValue renders a value substituted into a URL. Strings are kept as they are, numbers are written in
+// plain notation, so an integral ID such as 1e+06 is sent as 1000000, and objects and arrays in their JSON form.
+// A null value returns ErrNullURLValue.
+func FormatURLValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", ErrNullURLValue
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(v)
+		return string(data), err
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+// urlValue is the jq function rendering a value substituted into a URL, see FormatURLValue.
+func urlValue(input interface{}, _ []interface{}) interface{} {
+	value, err := FormatURLValue(input)
+	if err != nil {
+		return err
+	}
+
+	return value
+}
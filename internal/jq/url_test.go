@@ -0,0 +1,55 @@
+package jq
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func Test_ParseAllURL(t *testing.T) {
+	type want struct {
+		results []interface{}
+		err     error
+	}
+	cases := map[string]struct {
+		jqQuery  string
+		jqObject interface{}
+		want     want
+	}{
+		"IntegralNumberInPlainNotation": {
+			jqQuery:  `"/users/" + (.id | tostring)`,
+			jqObject: map[string]interface{}{"id": float64(1e21)},
+			want:     want{results: []interface{}{"/users/1000000000000000000000"}},
+		},
+		"InterpolatedString": {
+			jqQuery:  `"/api/\(.version)/users"`,
+			jqObject: map[string]interface{}{"version": "2.0"},
+			want:     want{results: []interface{}{"/api/2.0/users"}},
+		},
+		"ObjectInJSONForm": {
+			jqQuery:  `"/search?q=\(.filter)"`,
+			jqObject: map[string]interface{}{"filter": map[string]interface{}{"name": "jane"}},
+			want:     want{results: []interface{}{`/search?q={"name":"jane"}`}},
+		},
+		"NullValue": {
+			jqQuery:  `"/users/\(.id)"`,
+			jqObject: map[string]interface{}{},
+			want:     want{err: errors.Wrap(ErrNullURLValue, `"/users/\(.id)"`)},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ParseAllURL(tc.jqQuery, tc.jqObject)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("ParseAllURL(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.results, got); diff != "" {
+				t.Errorf("ParseAllURL(...): -want results, +got results: %s", diff)
+			}
+		})
+	}
+}
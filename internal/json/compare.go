@@ -0,0 +1,129 @@
+package json
+
+import (
+	"sort"
+	"strconv"
+)
+
+// ContainsLenient is like Contains, but coerces comparable scalars before comparing them, so the string "5"
+// equals the number 5, and the string "true" equals the boolean true. Nested objects and arrays must have the
+// same keys and lengths, with their values compared the same way.
+func ContainsLenient(container, containee map[string]interface{}) bool {
+	for key, value := range containee {
+		if containerValue, exists := container[key]; !exists || !lenientEqual(value, containerValue) {
+			return false
+		}
+	}
+	return true
+}
+
+// FindTypeMismatch returns the path of the first field present in both documents whose values have different
+// JSON types, such as a number in containee and a string in container. Nested objects and arrays are walked
+// in a stable order. It returns false if the types of every shared field match.
+func FindTypeMismatch(container, containee map[string]interface{}) (path string, containeeType string, containerType string, found bool) {
+	return findTypeMismatch("", container, containee)
+}
+
+func findTypeMismatch(prefix string, container, containee interface{}) (string, string, string, bool) {
+	containeeType, containerType := TypeOf(containee), TypeOf(container)
+	if containeeType != containerType {
+		return prefix, containeeType, containerType, true
+	}
+
+	switch expected := containee.(type) {
+	case map[string]interface{}:
+		actual := container.(map[string]interface{})
+		keys := make([]string, 0, len(expected))
+		for key := range expected {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			actualValue, exists := actual[key]
+			if !exists {
+				continue
+			}
+			if path, a, b, found := findTypeMismatch(prefix+"."+key, actualValue, expected[key]); found {
+				return path, a, b, true
+			}
+		}
+	case []interface{}:
+		actual := container.([]interface{})
+		for i := 0; i < len(expected) && i < len(actual); i++ {
+			if path, a, b, found := findTypeMismatch(prefix+"["+strconv.Itoa(i)+"]", actual[i], expected[i]); found {
+				return path, a, b, true
+			}
+		}
+	}
+
+	return "", "", "", false
+}
+
+// TypeOf returns the JSON type name of a decoded JSON value.
+func TypeOf(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return "unknown"
+	}
+}
+
+func lenientEqual(a, b interface{}) bool {
+	switch aValue := a.(type) {
+	case map[string]interface{}:
+		bValue, ok := b.(map[string]interface{})
+		if !ok || len(aValue) != len(bValue) {
+			return false
+		}
+		return ContainsLenient(bValue, aValue)
+	case []interface{}:
+		bValue, ok := b.([]interface{})
+		if !ok || len(aValue) != len(bValue) {
+			return false
+		}
+		for i := range aValue {
+			if !lenientEqual(aValue[i], bValue[i]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if deepEqual(a, b) {
+		return true
+	}
+
+	aScalar, aOk := scalarString(a)
+	bScalar, bOk := scalarString(b)
+	return aOk && bOk && aScalar == bScalar
+}
+
+// scalarString returns the canonical string form of a number, boolean or string. Strings holding a number are
+// parsed, so "5.0" and 5 share the same form; the strings "true" and "false" already match their booleans.
+func scalarString(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	case string:
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return strconv.FormatFloat(f, 'g', -1, 64), true
+		}
+		return v, true
+	default:
+		return "", false
+	}
+}
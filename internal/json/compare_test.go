@@ -0,0 +1,144 @@
+package json
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_ContainsLenient(t *testing.T) {
+	type args struct {
+		container map[string]interface{}
+		containee map[string]interface{}
+	}
+	type want struct {
+		result bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"StringMatchesNumber": {
+			args: args{
+				container: map[string]interface{}{"replicas": "5", "name": "a"},
+				containee: map[string]interface{}{"replicas": float64(5)},
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"NumberMatchesString": {
+			args: args{
+				container: map[string]interface{}{"replicas": float64(5)},
+				containee: map[string]interface{}{"replicas": "5.0"},
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"StringMatchesBool": {
+			args: args{
+				container: map[string]interface{}{"enabled": "true"},
+				containee: map[string]interface{}{"enabled": true},
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"BoolDoesNotMatchOtherString": {
+			args: args{
+				container: map[string]interface{}{"enabled": "yes"},
+				containee: map[string]interface{}{"enabled": true},
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"NestedValuesCoerced": {
+			args: args{
+				container: map[string]interface{}{"spec": map[string]interface{}{"ports": []interface{}{"80", "443"}}},
+				containee: map[string]interface{}{"spec": map[string]interface{}{"ports": []interface{}{float64(80), float64(443)}}},
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"DifferentValues": {
+			args: args{
+				container: map[string]interface{}{"replicas": "6"},
+				containee: map[string]interface{}{"replicas": float64(5)},
+			},
+			want: want{
+				result: false,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ContainsLenient(tc.args.container, tc.args.containee)
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("ContainsLenient(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_FindTypeMismatch(t *testing.T) {
+	type args struct {
+		container map[string]interface{}
+		containee map[string]interface{}
+	}
+	type want struct {
+		path          string
+		containeeType string
+		containerType string
+		found         bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"StringInsteadOfNumber": {
+			args: args{
+				container: map[string]interface{}{"replicas": "5"},
+				containee: map[string]interface{}{"replicas": float64(5)},
+			},
+			want: want{
+				path:          ".replicas",
+				containeeType: "number",
+				containerType: "string",
+				found:         true,
+			},
+		},
+		"StringInsteadOfBoolNested": {
+			args: args{
+				container: map[string]interface{}{"spec": map[string]interface{}{"items": []interface{}{map[string]interface{}{"enabled": "true"}}}},
+				containee: map[string]interface{}{"spec": map[string]interface{}{"items": []interface{}{map[string]interface{}{"enabled": true}}}},
+			},
+			want: want{
+				path:          ".spec.items[0].enabled",
+				containeeType: "boolean",
+				containerType: "string",
+				found:         true,
+			},
+		},
+		"TypesMatch": {
+			args: args{
+				container: map[string]interface{}{"replicas": float64(6), "extra": "x"},
+				containee: map[string]interface{}{"replicas": float64(5), "missing": true},
+			},
+			want: want{
+				found: false,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			path, containeeType, containerType, found := FindTypeMismatch(tc.args.container, tc.args.containee)
+			got := want{path: path, containeeType: containeeType, containerType: containerType, found: found}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Fatalf("FindTypeMismatch(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package json
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errItemsPathNotFound = "items path %s doesn't lead to an array"
+	errDecodeItems       = "cannot decode the items at %s"
+)
+
+// ContainsItem reports whether one of the items of the array found at path in the JSON document contains
+// containee, as decided by the contains function. Items that are not objects never match.
+func ContainsItem(body string, path FieldPath, containee map[string]interface{}, contains func(container, containee map[string]interface{}) bool) (bool, error) {
+	var document interface{}
+	if err := json.Unmarshal([]byte(body), &document); err != nil {
+		return false, errors.Wrapf(err, errDecodeItems, path)
+	}
+
+	items, err := ItemsAt(document, path)
+	if err != nil {
+		return false, err
+	}
+
+	for _, item := range items {
+		if object, ok := item.(map[string]interface{}); ok && contains(object, containee) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ItemsAt returns the array of items found at path in the decoded document.
+func ItemsAt(document interface{}, path FieldPath) ([]interface{}, error) {
+	for _, field := range path {
+		object, ok := document.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf(errItemsPathNotFound, path)
+		}
+		document = object[field]
+	}
+
+	items, ok := document.([]interface{})
+	if !ok {
+		return nil, errors.Errorf(errItemsPathNotFound, path)
+	}
+	return items, nil
+}
+
+// WithItems returns the decoded document with the array found at path replaced by items. The objects along the
+// path are copied rather than modified.
+func WithItems(document interface{}, path FieldPath, items []interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		if _, ok := document.([]interface{}); !ok {
+			return nil, errors.Errorf(errItemsPathNotFound, path)
+		}
+		return items, nil
+	}
+
+	object, ok := document.(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf(errItemsPathNotFound, path)
+	}
+
+	value, err := WithItems(object[path[0]], path[1:], items)
+	if err != nil {
+		return nil, errors.Errorf(errItemsPathNotFound, path)
+	}
+
+	copied := make(map[string]interface{}, len(object))
+	for key, fieldValue := range object {
+		copied[key] = fieldValue
+	}
+	copied[path[0]] = value
+	return copied, nil
+}
+
+// StreamContainsItem is like ContainsItem, but decodes the document from r one item at a time, so that neither
+// the document nor the array of items is held in memory as a whole. The fields outside of the path are skipped
+// without being decoded, and decoding stops at the first matching item.
+func StreamContainsItem(r io.Reader, path FieldPath, containee map[string]interface{}, contains func(container, containee map[string]interface{}) bool) (bool, error) {
+	decoder := json.NewDecoder(r)
+	for _, field := range path {
+		found, err := seekField(decoder, field)
+		if err != nil {
+			return false, errors.Wrapf(err, errDecodeItems, path)
+		}
+		if !found {
+			return false, errors.Errorf(errItemsPathNotFound, path)
+		}
+	}
+
+	token, err := decoder.Token()
+	if err != nil {
+		return false, errors.Wrapf(err, errDecodeItems, path)
+	}
+	if token != json.Delim('[') {
+		return false, errors.Errorf(errItemsPathNotFound, path)
+	}
+
+	for decoder.More() {
+		var item interface{}
+		if err := decoder.Decode(&item); err != nil {
+			return false, errors.Wrapf(err, errDecodeItems, path)
+		}
+		if object, ok := item.(map[string]interface{}); ok && contains(object, containee) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// seekField moves the decoder to the value of field in the object it is positioned at. It returns false if the
+// next value isn't an object or has no such field.
+func seekField(decoder *json.Decoder, field string) (bool, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return false, err
+	}
+	if token != json.Delim('{') {
+		return false, nil
+	}
+
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return false, err
+		}
+		if key == field {
+			return true, nil
+		}
+		if err := skipValue(decoder); err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// skipValue discards the next value of the decoder, reading nested objects and arrays token by token.
+func skipValue(decoder *json.Decoder) error {
+	depth := 0
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+
+		if depth == 0 {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package json
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func Test_ContainsItem(t *testing.T) {
+	type args struct {
+		body      string
+		path      FieldPath
+		containee map[string]interface{}
+	}
+	type want struct {
+		found bool
+		err   error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"MatchingItem": {
+			args: args{
+				body:      `{"total": 2, "metadata": {"page": [1, {"next": null}]}, "items": [{"name": "a", "size": 1}, {"name": "b", "size": 2}]}`,
+				path:      FieldPath{"items"},
+				containee: map[string]interface{}{"name": "b"},
+			},
+			want: want{found: true},
+		},
+		"NoMatchingItem": {
+			args: args{
+				body:      `{"items": [{"name": "a"}, "b", 3]}`,
+				path:      FieldPath{"items"},
+				containee: map[string]interface{}{"name": "b"},
+			},
+			want: want{found: false},
+		},
+		"NestedPath": {
+			args: args{
+				body:      `{"data": {"results": [{"name": "a"}]}}`,
+				path:      FieldPath{"data", "results"},
+				containee: map[string]interface{}{"name": "a"},
+			},
+			want: want{found: true},
+		},
+		"RootArray": {
+			args: args{
+				body:      `[{"name": "a"}]`,
+				path:      FieldPath{},
+				containee: map[string]interface{}{"name": "a"},
+			},
+			want: want{found: true},
+		},
+		"PathNotFound": {
+			args: args{
+				body:      `{"data": {"results": {"name": "a"}}}`,
+				path:      FieldPath{"data", "results"},
+				containee: map[string]interface{}{"name": "a"},
+			},
+			want: want{err: errors.Errorf(errItemsPathNotFound, FieldPath{"data", "results"})},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ContainsItem(tc.args.body, tc.args.path, tc.args.containee, Contains)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("ContainsItem(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.found, got); diff != "" {
+				t.Errorf("ContainsItem(...): -want result, +got result: %s", diff)
+			}
+
+			streamed, streamErr := StreamContainsItem(strings.NewReader(tc.args.body), tc.args.path, tc.args.containee, Contains)
+			if diff := cmp.Diff(tc.want.err, streamErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("StreamContainsItem(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.found, streamed); diff != "" {
+				t.Errorf("StreamContainsItem(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package json
+
+// DeepMerge returns the overrides merged over the defaults. Objects found in both are merged recursively, while
+// any other value of the overrides, including an array or null, replaces the default. Neither map is modified.
+func DeepMerge(defaults, overrides map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(defaults)+len(overrides))
+	for key, value := range defaults {
+		merged[key] = value
+	}
+
+	for key, override := range overrides {
+		defaultObject, defaultIsObject := merged[key].(map[string]interface{})
+		overrideObject, overrideIsObject := override.(map[string]interface{})
+		if defaultIsObject && overrideIsObject {
+			merged[key] = DeepMerge(defaultObject, overrideObject)
+			continue
+		}
+		merged[key] = override
+	}
+
+	return merged
+}
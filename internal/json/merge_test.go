@@ -0,0 +1,76 @@
+package json
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_DeepMerge(t *testing.T) {
+	type args struct {
+		defaults  map[string]interface{}
+		overrides map[string]interface{}
+	}
+	cases := map[string]struct {
+		args args
+		want map[string]interface{}
+	}{
+		"OverridesWin": {
+			args: args{
+				defaults:  map[string]interface{}{"region": "eu-west-1", "tier": "standard"},
+				overrides: map[string]interface{}{"tier": "premium"},
+			},
+			want: map[string]interface{}{"region": "eu-west-1", "tier": "premium"},
+		},
+		"NestedObjectsMerged": {
+			args: args{
+				defaults: map[string]interface{}{
+					"payload": map[string]interface{}{
+						"baseUrl": "https://api.example.com",
+						"body":    map[string]interface{}{"region": "eu-west-1", "limits": map[string]interface{}{"cpu": "1", "memory": "1Gi"}},
+					},
+				},
+				overrides: map[string]interface{}{
+					"payload": map[string]interface{}{
+						"body": map[string]interface{}{"name": "john", "limits": map[string]interface{}{"memory": "2Gi"}},
+					},
+				},
+			},
+			want: map[string]interface{}{
+				"payload": map[string]interface{}{
+					"baseUrl": "https://api.example.com",
+					"body":    map[string]interface{}{"name": "john", "region": "eu-west-1", "limits": map[string]interface{}{"cpu": "1", "memory": "2Gi"}},
+				},
+			},
+		},
+		"ArraysReplaced": {
+			args: args{
+				defaults:  map[string]interface{}{"tags": []interface{}{"a", "b"}},
+				overrides: map[string]interface{}{"tags": []interface{}{"c"}},
+			},
+			want: map[string]interface{}{"tags": []interface{}{"c"}},
+		},
+		"ScalarReplacesObject": {
+			args: args{
+				defaults:  map[string]interface{}{"owner": map[string]interface{}{"team": "platform"}},
+				overrides: map[string]interface{}{"owner": "jane"},
+			},
+			want: map[string]interface{}{"owner": "jane"},
+		},
+		"NilDefaults": {
+			args: args{
+				overrides: map[string]interface{}{"name": "john"},
+			},
+			want: map[string]interface{}{"name": "john"},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+		t.Run(name, func(t *testing.T) {
+			got := DeepMerge(tc.args.defaults, tc.args.overrides)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("DeepMerge(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package json
+
+import (
+	"bufio"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// FormatNDJSON is the response format of bodies made of newline-delimited JSON records.
+	FormatNDJSON = "NDJSON"
+
+	errInvalidNDJSONRecord = "invalid NDJSON record on line %d"
+)
+
+// ParseNDJSON parses a newline-delimited JSON document into the list of its records. Blank lines,
+// including the one left by a trailing newline, are skipped.
+func ParseNDJSON(body string) ([]interface{}, error) {
+	records := []interface{}{}
+
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), len(body)+1)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var record interface{}
+		if err := json.Unmarshal([]byte(text), &record); err != nil {
+			return nil, errors.Wrapf(err, errInvalidNDJSONRecord, line)
+		}
+		records = append(records, record)
+	}
+
+	return records, scanner.Err()
+}
+
+// ResponseToMap converts an HTTP response to a JSON-compatible map exposed to jq filters. With the NDJSON
+// format, the body becomes the list of its records, with the Text format it stays a string, and with the Sniff
+// format it is parsed according to its Content-Type. Otherwise JSON bodies are converted to nested maps.
+func ResponseToMap(response interface{}, format string) (map[string]interface{}, error) {
+	responseMap, err := StructToMap(response)
+	if err != nil {
+		return nil, err
+	}
+
+	body, ok := responseMap["body"].(string)
+	if !ok {
+		ConvertJSONStringsToMaps(&responseMap)
+		return responseMap, nil
+	}
+
+	switch format {
+	case FormatNDJSON:
+		records, err := ParseNDJSON(body)
+		if err != nil {
+			return nil, err
+		}
+		responseMap["body"] = records
+	case FormatText:
+		return withTextBody(responseMap, body), nil
+	case FormatSniff:
+		parsed, isParsed, err := parseSniffedBody(responseMap, body)
+		if err != nil {
+			return nil, err
+		}
+		if !isParsed {
+			return withTextBody(responseMap, body), nil
+		}
+		responseMap["body"] = parsed
+	}
+
+	ConvertJSONStringsToMaps(&responseMap)
+	return responseMap, nil
+}
+
+// withTextBody converts the JSON strings of the response map, except its body which is kept as the given string.
+func withTextBody(responseMap map[string]interface{}, body string) map[string]interface{} {
+	delete(responseMap, "body")
+	ConvertJSONStringsToMaps(&responseMap)
+	responseMap["body"] = body
+	return responseMap
+}
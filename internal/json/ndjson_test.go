@@ -0,0 +1,156 @@
+package json
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func Test_ParseNDJSON(t *testing.T) {
+	type args struct {
+		body string
+	}
+	type want struct {
+		records []interface{}
+		err     error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"MultipleLines": {
+			args: args{
+				body: "{\"id\":1,\"level\":\"info\"}\n{\"id\":2,\"level\":\"error\"}",
+			},
+			want: want{
+				records: []interface{}{
+					map[string]interface{}{"id": float64(1), "level": "info"},
+					map[string]interface{}{"id": float64(2), "level": "error"},
+				},
+			},
+		},
+		"TrailingNewline": {
+			args: args{
+				body: "{\"id\":1}\n{\"id\":2}\n",
+			},
+			want: want{
+				records: []interface{}{
+					map[string]interface{}{"id": float64(1)},
+					map[string]interface{}{"id": float64(2)},
+				},
+			},
+		},
+		"BlankLinesAndCarriageReturns": {
+			args: args{
+				body: "{\"id\":1}\r\n\r\n\"done\"\r\n",
+			},
+			want: want{
+				records: []interface{}{
+					map[string]interface{}{"id": float64(1)},
+					"done",
+				},
+			},
+		},
+		"Empty": {
+			args: args{
+				body: "",
+			},
+			want: want{
+				records: []interface{}{},
+			},
+		},
+		"InvalidRecord": {
+			args: args{
+				body: "{\"id\":1}\n{\"id\":",
+			},
+			want: want{
+				err: errors.Wrapf(errors.New("unexpected end of JSON input"), errInvalidNDJSONRecord, 2),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ParseNDJSON(tc.args.body)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("ParseNDJSON(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.records, got); diff != "" {
+				t.Errorf("ParseNDJSON(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_ResponseToMap(t *testing.T) {
+	type response struct {
+		StatusCode int    `json:"statusCode"`
+		Body       string `json:"body"`
+	}
+	type args struct {
+		response response
+		format   string
+	}
+	type want struct {
+		result map[string]interface{}
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"JSONBody": {
+			args: args{
+				response: response{StatusCode: 200, Body: `{"id":1}`},
+			},
+			want: want{
+				result: map[string]interface{}{
+					"statusCode": float64(200),
+					"body":       map[string]interface{}{"id": float64(1)},
+				},
+			},
+		},
+		"NDJSONBody": {
+			args: args{
+				response: response{StatusCode: 200, Body: "{\"id\":1}\n{\"id\":2}\n"},
+				format:   FormatNDJSON,
+			},
+			want: want{
+				result: map[string]interface{}{
+					"statusCode": float64(200),
+					"body": []interface{}{
+						map[string]interface{}{"id": float64(1)},
+						map[string]interface{}{"id": float64(2)},
+					},
+				},
+			},
+		},
+		"SingleRecordNDJSONBodyIsStillAList": {
+			args: args{
+				response: response{StatusCode: 200, Body: `{"id":1}`},
+				format:   FormatNDJSON,
+			},
+			want: want{
+				result: map[string]interface{}{
+					"statusCode": float64(200),
+					"body":       []interface{}{map[string]interface{}{"id": float64(1)}},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got, err := ResponseToMap(tc.args.response, tc.args.format)
+			if err != nil {
+				t.Fatalf("ResponseToMap(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("ResponseToMap(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package json
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errInvalidFieldPath = "invalid path %s, it must be . or a path of object fields such as .items or .data.results"
+)
+
+var fieldPathRe = regexp.MustCompile(`^(\.[A-Za-z_][A-Za-z0-9_-]*)+$`)
+
+// FieldPath is a path in a JSON document, as a list of object fields. An empty path is the document itself.
+type FieldPath []string
+
+// ParseFieldPath parses a jq path made of object fields, such as .items or .data.results, or . for the
+// document itself.
+func ParseFieldPath(path string) (FieldPath, error) {
+	if path == "." {
+		return FieldPath{}, nil
+	}
+	if !fieldPathRe.MatchString(path) {
+		return nil, errors.Errorf(errInvalidFieldPath, path)
+	}
+	return strings.Split(path, ".")[1:], nil
+}
+
+func (p FieldPath) String() string {
+	return "." + strings.Join(p, ".")
+}
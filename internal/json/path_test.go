@@ -0,0 +1,46 @@
+package json
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func Test_ParseFieldPath(t *testing.T) {
+	type want struct {
+		path FieldPath
+		err  error
+	}
+	cases := map[string]struct {
+		path string
+		want want
+	}{
+		"Root": {
+			path: ".",
+			want: want{path: FieldPath{}},
+		},
+		"NestedFields": {
+			path: ".data.results",
+			want: want{path: FieldPath{"data", "results"}},
+		},
+		"FullJQFilter": {
+			path: ".items[] | select(.id)",
+			want: want{err: errors.Errorf(errInvalidFieldPath, ".items[] | select(.id)")},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ParseFieldPath(tc.path)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("ParseFieldPath(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.path, got); diff != "" {
+				t.Errorf("ParseFieldPath(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
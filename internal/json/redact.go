@@ -0,0 +1,49 @@
+package json
+
+import (
+	"encoding/json"
+)
+
+// RedactedValue replaces the values found at the redacted paths of a document.
+const RedactedValue = "****"
+
+// RedactPaths returns the JSON document with the value found at each of the given paths replaced by
+// RedactedValue. Arrays along a path apply the rest of it to each of their elements, so .users.password redacts
+// the password of every user. Paths missing from the document are ignored.
+func RedactPaths(document string, paths []FieldPath) (string, error) {
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(document), &parsed); err != nil {
+		return "", err
+	}
+
+	for _, path := range paths {
+		parsed = redactPath(parsed, path)
+	}
+
+	redacted, err := json.Marshal(parsed)
+	if err != nil {
+		return "", err
+	}
+
+	return string(redacted), nil
+}
+
+// redactPath replaces the value found at the path of the given value.
+func redactPath(value interface{}, path FieldPath) interface{} {
+	if len(path) == 0 {
+		return RedactedValue
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if inner, ok := v[path[0]]; ok {
+			v[path[0]] = redactPath(inner, path[1:])
+		}
+	case []interface{}:
+		for i, element := range v {
+			v[i] = redactPath(element, path)
+		}
+	}
+
+	return value
+}
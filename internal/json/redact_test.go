@@ -0,0 +1,77 @@
+package json
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_RedactPaths(t *testing.T) {
+	type args struct {
+		document string
+		paths    []FieldPath
+	}
+	type want struct {
+		document string
+		err      bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"TopLevelField": {
+			args: args{
+				document: `{"name":"john","password":"hunter2"}`,
+				paths:    []FieldPath{{"password"}},
+			},
+			want: want{document: `{"name":"john","password":"****"}`},
+		},
+		"NestedField": {
+			args: args{
+				document: `{"user":{"name":"john","identity":{"ssn":"078-05-1120","country":"US"}}}`,
+				paths:    []FieldPath{{"user", "identity", "ssn"}},
+			},
+			want: want{document: `{"user":{"identity":{"country":"US","ssn":"****"},"name":"john"}}`},
+		},
+		"FieldOfEveryElement": {
+			args: args{
+				document: `{"users":[{"name":"john","password":"a"},{"name":"jane","password":"b"},{"name":"joe"}]}`,
+				paths:    []FieldPath{{"users", "password"}},
+			},
+			want: want{document: `{"users":[{"name":"john","password":"****"},{"name":"jane","password":"****"},{"name":"joe"}]}`},
+		},
+		"WholeObjectRedacted": {
+			args: args{
+				document: `{"name":"john","credentials":{"key":"a","secret":"b"}}`,
+				paths:    []FieldPath{{"credentials"}},
+			},
+			want: want{document: `{"credentials":"****","name":"john"}`},
+		},
+		"MissingPathIgnored": {
+			args: args{
+				document: `{"name":"john"}`,
+				paths:    []FieldPath{{"user", "ssn"}},
+			},
+			want: want{document: `{"name":"john"}`},
+		},
+		"InvalidJSON": {
+			args: args{
+				document: `password=hunter2`,
+				paths:    []FieldPath{{"password"}},
+			},
+			want: want{err: true},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+		t.Run(name, func(t *testing.T) {
+			got, err := RedactPaths(tc.args.document, tc.args.paths)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("RedactPaths(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.document, got); diff != "" {
+				t.Errorf("RedactPaths(...): -want document, +got document: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package json
+
+import (
+	"encoding/json"
+	"mime"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// FormatText is the response format of bodies exposed as a string, whatever their content.
+	FormatText = "Text"
+	// FormatSniff is the response format of bodies parsed according to their Content-Type header, or to their
+	// first character when they have none.
+	FormatSniff = "Sniff"
+
+	ndjsonMediaType = "application/x-ndjson"
+
+	errInvalidJSONBody = "response body declared as %s is not valid JSON"
+)
+
+// sniffedFormat returns how the body of the given response map is parsed with the sniff format. A JSON media
+// type, such as application/json or application/problem+json, is parsed as JSON and application/x-ndjson as
+// NDJSON, while any other media type is kept as text. Without a Content-Type header, a body starting with { or
+// [ is parsed as JSON and any other body is kept as text.
+func sniffedFormat(responseMap map[string]interface{}, body string) (format string, declared string) {
+	contentType := headerValue(responseMap, "Content-Type")
+	if contentType == "" {
+		trimmed := strings.TrimSpace(body)
+		if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+			return "", ""
+		}
+		return FormatText, ""
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return FormatText, contentType
+	}
+
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		return "", mediaType
+	case mediaType == ndjsonMediaType:
+		return FormatNDJSON, mediaType
+	default:
+		return FormatText, mediaType
+	}
+}
+
+// parseSniffedBody parses the body with the sniff format, reporting false when it is kept as text. A body sniffed
+// as JSON that isn't valid JSON is kept as text, since only its first character was checked, but one declared as
+// JSON by its Content-Type is an error. An empty body is always kept as text.
+func parseSniffedBody(responseMap map[string]interface{}, body string) (interface{}, bool, error) {
+	if strings.TrimSpace(body) == "" {
+		return body, false, nil
+	}
+
+	format, declared := sniffedFormat(responseMap, body)
+	switch format {
+	case FormatText:
+		return body, false, nil
+	case FormatNDJSON:
+		records, err := ParseNDJSON(body)
+		if err != nil {
+			return nil, false, err
+		}
+		return records, true, nil
+	}
+
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(body), &parsed); err != nil {
+		if declared == "" {
+			return body, false, nil
+		}
+		return nil, false, errors.Wrapf(err, errInvalidJSONBody, declared)
+	}
+
+	return parsed, true, nil
+}
+
+// headerValue returns the first value of the named header of the response map, looked up regardless of case.
+func headerValue(responseMap map[string]interface{}, name string) string {
+	headers, _ := responseMap["headers"].(map[string]interface{})
+	for key, values := range headers {
+		if !strings.EqualFold(key, name) {
+			continue
+		}
+
+		if values, ok := values.([]interface{}); ok && len(values) > 0 {
+			value, _ := values[0].(string)
+			return value
+		}
+	}
+
+	return ""
+}
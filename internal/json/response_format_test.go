@@ -0,0 +1,135 @@
+package json
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func Test_ResponseToMap_ResponseFormats(t *testing.T) {
+	type response struct {
+		StatusCode int                 `json:"statusCode"`
+		Headers    map[string][]string `json:"headers"`
+		Body       string              `json:"body"`
+	}
+	type args struct {
+		response response
+		format   string
+	}
+	type want struct {
+		body interface{}
+		err  error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"TextKeepsJSONBody": {
+			args: args{
+				response: response{StatusCode: 200, Body: `{"id":1}`},
+				format:   FormatText,
+			},
+			want: want{
+				body: `{"id":1}`,
+			},
+		},
+		"SniffJSONWithoutContentType": {
+			args: args{
+				response: response{StatusCode: 200, Body: ` {"id":1}`},
+				format:   FormatSniff,
+			},
+			want: want{
+				body: map[string]interface{}{"id": float64(1)},
+			},
+		},
+		"SniffJSONArrayWithoutContentType": {
+			args: args{
+				response: response{StatusCode: 200, Body: `[{"id":1}]`},
+				format:   FormatSniff,
+			},
+			want: want{
+				body: []interface{}{map[string]interface{}{"id": float64(1)}},
+			},
+		},
+		"SniffPlaintextWithoutContentType": {
+			args: args{
+				response: response{StatusCode: 200, Body: "OK"},
+				format:   FormatSniff,
+			},
+			want: want{
+				body: "OK",
+			},
+		},
+		"SniffInvalidJSONWithoutContentType": {
+			args: args{
+				response: response{StatusCode: 200, Body: "[error] upstream unavailable"},
+				format:   FormatSniff,
+			},
+			want: want{
+				body: "[error] upstream unavailable",
+			},
+		},
+		"SniffHTMLContentType": {
+			args: args{
+				response: response{StatusCode: 502, Headers: map[string][]string{"content-type": {"text/html; charset=utf-8"}}, Body: `{"id":1}`},
+				format:   FormatSniff,
+			},
+			want: want{
+				body: `{"id":1}`,
+			},
+		},
+		"SniffJSONContentType": {
+			args: args{
+				response: response{StatusCode: 400, Headers: map[string][]string{"Content-Type": {"application/problem+json"}}, Body: `{"title":"Bad Request"}`},
+				format:   FormatSniff,
+			},
+			want: want{
+				body: map[string]interface{}{"title": "Bad Request"},
+			},
+		},
+		"SniffNDJSONContentType": {
+			args: args{
+				response: response{StatusCode: 200, Headers: map[string][]string{"Content-Type": {"application/x-ndjson"}}, Body: "{\"id\":1}\n{\"id\":2}\n"},
+				format:   FormatSniff,
+			},
+			want: want{
+				body: []interface{}{map[string]interface{}{"id": float64(1)}, map[string]interface{}{"id": float64(2)}},
+			},
+		},
+		"SniffEmptyBodyWithJSONContentType": {
+			args: args{
+				response: response{StatusCode: 204, Headers: map[string][]string{"Content-Type": {"application/json"}}},
+				format:   FormatSniff,
+			},
+			want: want{
+				body: "",
+			},
+		},
+		"SniffInvalidBodyWithJSONContentType": {
+			args: args{
+				response: response{StatusCode: 200, Headers: map[string][]string{"Content-Type": {"application/json"}}, Body: "OK"},
+				format:   FormatSniff,
+			},
+			want: want{
+				err: errors.Wrapf(errors.New("invalid character 'O' looking for beginning of value"), errInvalidJSONBody, "application/json"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+		t.Run(name, func(t *testing.T) {
+			got, err := ResponseToMap(tc.args.response, tc.args.format)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("ResponseToMap(...): -want error, +got error: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.body, got["body"]); diff != "" {
+				t.Errorf("ResponseToMap(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package json
+
+// UnorderedArray designates an array compared regardless of the order of its elements.
+type UnorderedArray struct {
+	// Path is the path of the array. Arrays along the path apply the rest of it to each of their elements.
+	Path FieldPath
+
+	// Key is the field identifying the elements of an array of objects. Elements are paired by key, and the
+	// desired element only has to be contained in the element it is paired with. When empty, elements are
+	// compared by value.
+	Key string
+}
+
+// ContainsUnordered returns a function like Contains, or ContainsLenient when lenient is set, that compares the
+// arrays at the given paths regardless of the order of their elements. The arrays must still have as many
+// elements, each element of one matching a distinct element of the other.
+func ContainsUnordered(arrays []UnorderedArray, lenient bool) func(container, containee map[string]interface{}) bool {
+	c := unorderedComparer{arrays: make(map[string]UnorderedArray, len(arrays)), lenient: lenient}
+	for _, array := range arrays {
+		c.arrays[array.Path.String()] = array
+	}
+
+	return func(container, containee map[string]interface{}) bool {
+		return c.contains("", container, containee)
+	}
+}
+
+type unorderedComparer struct {
+	arrays  map[string]UnorderedArray
+	lenient bool
+}
+
+func (c unorderedComparer) contains(path string, container, containee map[string]interface{}) bool {
+	for key, value := range containee {
+		if containerValue, exists := container[key]; !exists || !c.equal(path+"."+key, value, containerValue) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c unorderedComparer) equal(path string, a, b interface{}) bool {
+	switch aValue := a.(type) {
+	case map[string]interface{}:
+		bValue, ok := b.(map[string]interface{})
+		if !ok || len(aValue) != len(bValue) {
+			return false
+		}
+		return c.contains(path, bValue, aValue)
+	case []interface{}:
+		bValue, ok := b.([]interface{})
+		if !ok || len(aValue) != len(bValue) {
+			return false
+		}
+		if array, ok := c.arrays[path]; ok {
+			return c.equalUnordered(path, array.Key, aValue, bValue)
+		}
+		for i := range aValue {
+			if !c.equal(path, aValue[i], bValue[i]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if c.lenient {
+		return lenientEqual(a, b)
+	}
+	return deepEqual(a, b)
+}
+
+// equalUnordered pairs each element of a with a distinct element of b. With a key, objects are paired by the
+// value of their key and a must be contained in the element it is paired with; other elements are compared by
+// value.
+func (c unorderedComparer) equalUnordered(path, key string, a, b []interface{}) bool {
+	paired := make([]bool, len(b))
+	for _, aElement := range a {
+		match := -1
+		for i, bElement := range b {
+			if !paired[i] && c.elementMatches(path, key, aElement, bElement) {
+				match = i
+				break
+			}
+		}
+		if match < 0 {
+			return false
+		}
+		paired[match] = true
+	}
+	return true
+}
+
+func (c unorderedComparer) elementMatches(path, key string, a, b interface{}) bool {
+	aObject, aOk := a.(map[string]interface{})
+	bObject, bOk := b.(map[string]interface{})
+	if key == "" || !aOk || !bOk {
+		return c.equal(path, a, b)
+	}
+
+	aKey, aHasKey := aObject[key]
+	if !aHasKey {
+		return c.equal(path, a, b)
+	}
+
+	bKey, bHasKey := bObject[key]
+	return bHasKey && c.equal(path+"."+key, aKey, bKey) && c.contains(path, bObject, aObject)
+}
@@ -0,0 +1,108 @@
+package json
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_ContainsUnordered(t *testing.T) {
+	type args struct {
+		container string
+		containee string
+		arrays    []UnorderedArray
+		lenient   bool
+	}
+	type want struct {
+		contains bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ReorderedList": {
+			args: args{
+				container: `{"tags": ["b", "c", "a"]}`,
+				containee: `{"tags": ["a", "b", "c"]}`,
+				arrays:    []UnorderedArray{{Path: FieldPath{"tags"}}},
+			},
+			want: want{contains: true},
+		},
+		"ReorderedListWithoutDesignation": {
+			args: args{
+				container: `{"tags": ["b", "c", "a"]}`,
+				containee: `{"tags": ["a", "b", "c"]}`,
+				arrays:    []UnorderedArray{{Path: FieldPath{"labels"}}},
+			},
+			want: want{contains: false},
+		},
+		"ReorderedListWithDifferentDuplicates": {
+			args: args{
+				container: `{"tags": ["a", "b", "b"]}`,
+				containee: `{"tags": ["a", "a", "b"]}`,
+				arrays:    []UnorderedArray{{Path: FieldPath{"tags"}}},
+			},
+			want: want{contains: false},
+		},
+		"ReorderedListWithMissingElement": {
+			args: args{
+				container: `{"tags": ["b", "a"]}`,
+				containee: `{"tags": ["a", "b", "c"]}`,
+				arrays:    []UnorderedArray{{Path: FieldPath{"tags"}}},
+			},
+			want: want{contains: false},
+		},
+		"ListMatchedByID": {
+			args: args{
+				container: `{"rules": [{"id": 2, "port": 443, "createdAt": "today"}, {"id": 1, "port": 80, "createdAt": "today"}]}`,
+				containee: `{"rules": [{"id": 1, "port": 80}, {"id": 2, "port": 443}]}`,
+				arrays:    []UnorderedArray{{Path: FieldPath{"rules"}, Key: "id"}},
+			},
+			want: want{contains: true},
+		},
+		"ListMatchedByIDWithDrift": {
+			args: args{
+				container: `{"rules": [{"id": 2, "port": 8443}, {"id": 1, "port": 80}]}`,
+				containee: `{"rules": [{"id": 1, "port": 80}, {"id": 2, "port": 443}]}`,
+				arrays:    []UnorderedArray{{Path: FieldPath{"rules"}, Key: "id"}},
+			},
+			want: want{contains: false},
+		},
+		"ListMatchedByIDWithUnknownID": {
+			args: args{
+				container: `{"rules": [{"id": 3, "port": 443}, {"id": 1, "port": 80}]}`,
+				containee: `{"rules": [{"id": 1, "port": 80}, {"id": 2, "port": 443}]}`,
+				arrays:    []UnorderedArray{{Path: FieldPath{"rules"}, Key: "id"}},
+			},
+			want: want{contains: false},
+		},
+		"NestedListOfEveryElement": {
+			args: args{
+				container: `{"spec": {"rules": [{"id": 1, "ports": [443, 80]}]}}`,
+				containee: `{"spec": {"rules": [{"id": 1, "ports": [80, 443]}]}}`,
+				arrays:    []UnorderedArray{{Path: FieldPath{"spec", "rules", "ports"}}},
+			},
+			want: want{contains: true},
+		},
+		"ReorderedListLenient": {
+			args: args{
+				container: `{"ports": ["443", "80"]}`,
+				containee: `{"ports": [80, 443]}`,
+				arrays:    []UnorderedArray{{Path: FieldPath{"ports"}}},
+				lenient:   true,
+			},
+			want: want{contains: true},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			contains := ContainsUnordered(tc.args.arrays, tc.args.lenient)
+			got := contains(JsonStringToMap(tc.args.container), JsonStringToMap(tc.args.containee))
+			if diff := cmp.Diff(tc.want.contains, got); diff != "" {
+				t.Errorf("ContainsUnordered(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
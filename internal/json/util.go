@@ -44,6 +44,22 @@ func ConvertJSONStringsToMaps(merged *map[string]interface{}) {
 	}
 }
 
+// CanonicalizeJSONString returns a canonical form of a JSON document, with insignificant whitespace removed
+// and object keys sorted. Strings that are not valid JSON are returned unchanged.
+func CanonicalizeJSONString(jsonStr string) string {
+	var jsonData interface{}
+	if err := json.Unmarshal([]byte(jsonStr), &jsonData); err != nil {
+		return jsonStr
+	}
+
+	canonical, err := json.Marshal(jsonData)
+	if err != nil {
+		return jsonStr
+	}
+
+	return string(canonical)
+}
+
 func StructToMap(obj interface{}) (newMap map[string]interface{}, err error) {
 	data, err := json.Marshal(obj) // Convert to a json string
 
@@ -136,6 +136,45 @@ func Test_IsJSONString(t *testing.T) {
 	}
 }
 
+func Test_CanonicalizeJSONString(t *testing.T) {
+	type args struct {
+		jsonStr string
+	}
+	type want struct {
+		result string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"SortsKeysAndRemovesWhitespace": {
+			args: args{
+				jsonStr: `{ "username": "john_doe",
+					"email": "john.doe@example.com", "roles": ["admin", "dev"] }`,
+			},
+			want: want{
+				result: `{"email":"john.doe@example.com","roles":["admin","dev"],"username":"john_doe"}`,
+			},
+		},
+		"NotJSON": {
+			args: args{
+				jsonStr: "hi there",
+			},
+			want: want{
+				result: "hi there",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := CanonicalizeJSONString(tc.args.jsonStr)
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("CanonicalizeJSONString(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
 func Test_JsonStringToMap(t *testing.T) {
 	type args struct {
 		jsonStr string
@@ -0,0 +1,159 @@
+package killswitch
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	kubehandler "github.com/crossplane-contrib/provider-http/internal/kube-handler"
+)
+
+const (
+	// TypePaused resources have their writes held back by the kill switch of their ProviderConfig.
+	TypePaused xpv1.ConditionType = "Paused"
+
+	// ReasonKillSwitchEngaged indicates the kill switch holds back the writes of the resource.
+	ReasonKillSwitchEngaged xpv1.ConditionReason = "KillSwitchEngaged"
+	// ReasonKillSwitchReleased indicates the writes of the resource are sent again.
+	ReasonKillSwitchReleased xpv1.ConditionReason = "KillSwitchReleased"
+
+	// cacheTTL is how long the state of a kill switch ConfigMap is reused before it is read again. Engaging or
+	// releasing the switch reaches a resource within this delay, once the resource is next reconciled.
+	cacheTTL = 5 * time.Second
+
+	msgWritesPaused = "the kill switch of the ProviderConfig is engaged, no write request is sent"
+
+	errKillSwitchValue = "kill switch %s/%s: value of key %s should be a boolean, but is: %s"
+)
+
+// A Cache holds the state of the kill switch ConfigMaps read recently, shared across reconciles so that the
+// ConfigMaps aren't read again by every resource.
+type Cache struct {
+	states *httpClient.LRUCache[bool]
+}
+
+// NewCache returns a new, empty Cache.
+func NewCache() *Cache {
+	return &Cache{states: httpClient.NewLRUCache[bool](0, cacheTTL)}
+}
+
+// A Switch tells whether the writes of the resources using a ProviderConfig are paused.
+type Switch struct {
+	kube   client.Client
+	config apisv1alpha1.KillSwitch
+	cache  *Cache
+}
+
+// New returns the Switch of the given kill switch configuration, or nil if there is none. The cache may be
+// nil, in which case the ConfigMap is read on every check.
+func New(kube client.Client, config *apisv1alpha1.KillSwitch, cache *Cache) *Switch {
+	if config == nil {
+		return nil
+	}
+
+	return &Switch{kube: kube, config: *config, cache: cache}
+}
+
+// Engaged reports whether the writes are paused. A nil Switch is never engaged.
+func (s *Switch) Engaged(ctx context.Context) (bool, error) {
+	if s == nil {
+		return false, nil
+	}
+
+	if s.config.Engaged {
+		return true, nil
+	}
+
+	if s.config.ConfigMapRef == nil {
+		return false, nil
+	}
+
+	return s.configMapEngaged(ctx, *s.config.ConfigMapRef)
+}
+
+// Hold reports whether the writes of the resource are paused, setting its Paused condition accordingly. The
+// condition is only set back to false on a resource that was paused, so that others don't gain one.
+func (s *Switch) Hold(ctx context.Context, cr resource.Conditioned) (bool, error) {
+	engaged, err := s.Engaged(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if engaged {
+		cr.SetConditions(paused())
+		return true, nil
+	}
+
+	if IsPaused(cr) {
+		cr.SetConditions(released())
+	}
+
+	return false, nil
+}
+
+// IsPaused reports whether the last check of the kill switch held back the writes of the resource.
+func IsPaused(cr resource.Conditioned) bool {
+	return cr.GetCondition(TypePaused).Status == corev1.ConditionTrue
+}
+
+// configMapEngaged reports whether the value of the referenced ConfigMap key engages the switch, reusing the
+// value read by an earlier check while it is cached.
+func (s *Switch) configMapEngaged(ctx context.Context, ref apisv1alpha1.ConfigMapKeyRef) (bool, error) {
+	cacheKey := ref.Namespace + "/" + ref.Name + "/" + ref.Key
+	if s.cache != nil {
+		if engaged, ok := s.cache.states.Get(cacheKey); ok {
+			return engaged, nil
+		}
+	}
+
+	configMap, err := kubehandler.GetConfigMap(ctx, s.kube, ref.Name, ref.Namespace)
+	if err != nil && !kerrors.IsNotFound(errors.Cause(err)) {
+		return false, err
+	}
+
+	engaged := false
+	if value, ok := configMap.Data[ref.Key]; ok {
+		engaged, err = strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return false, errors.Errorf(errKillSwitchValue, ref.Namespace, ref.Name, ref.Key, value)
+		}
+	}
+
+	if s.cache != nil {
+		s.cache.states.Add(cacheKey, engaged)
+	}
+
+	return engaged, nil
+}
+
+// paused returns a condition indicating the kill switch holds back the writes of the resource.
+func paused() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePaused,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonKillSwitchEngaged,
+		Message:            msgWritesPaused,
+	}
+}
+
+// released returns a condition indicating the writes of the resource are no longer held back.
+func released() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePaused,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonKillSwitchReleased,
+	}
+}
@@ -0,0 +1,170 @@
+package killswitch
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+)
+
+var errBoom = errors.New("boom")
+
+var testConfigMapRef = &apisv1alpha1.ConfigMapKeyRef{Name: "kill-switch", Namespace: "crossplane-system", Key: "paused"}
+
+// mockConfigMap returns a get function finding a ConfigMap holding the given data, or none when it is nil.
+func mockConfigMap(data map[string]string, gets *int) test.MockGetFn {
+	return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+		*gets++
+		if data == nil {
+			return kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, testConfigMapRef.Name)
+		}
+		obj.(*corev1.ConfigMap).Data = data
+		return nil
+	}
+}
+
+func Test_Switch_Engaged(t *testing.T) {
+	type args struct {
+		config *apisv1alpha1.KillSwitch
+		data   map[string]string
+		getErr error
+	}
+	type want struct {
+		engaged bool
+		err     error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Unset": {
+			args: args{config: nil},
+			want: want{engaged: false},
+		},
+		"Engaged": {
+			args: args{config: &apisv1alpha1.KillSwitch{Engaged: true}},
+			want: want{engaged: true},
+		},
+		"ConfigMapTrue": {
+			args: args{config: &apisv1alpha1.KillSwitch{ConfigMapRef: testConfigMapRef}, data: map[string]string{"paused": " true\n"}},
+			want: want{engaged: true},
+		},
+		"ConfigMapFalse": {
+			args: args{config: &apisv1alpha1.KillSwitch{ConfigMapRef: testConfigMapRef}, data: map[string]string{"paused": "false"}},
+			want: want{engaged: false},
+		},
+		"ConfigMapKeyMissing": {
+			args: args{config: &apisv1alpha1.KillSwitch{ConfigMapRef: testConfigMapRef}, data: map[string]string{"other": "true"}},
+			want: want{engaged: false},
+		},
+		"ConfigMapMissing": {
+			args: args{config: &apisv1alpha1.KillSwitch{ConfigMapRef: testConfigMapRef}},
+			want: want{engaged: false},
+		},
+		"ConfigMapInvalidValue": {
+			args: args{config: &apisv1alpha1.KillSwitch{ConfigMapRef: testConfigMapRef}, data: map[string]string{"paused": "yes please"}},
+			want: want{err: errors.Errorf(errKillSwitchValue, "crossplane-system", "kill-switch", "paused", "yes please")},
+		},
+		"ConfigMapGetFails": {
+			args: args{config: &apisv1alpha1.KillSwitch{ConfigMapRef: testConfigMapRef}, getErr: errBoom},
+			want: want{err: errBoom},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			gets := 0
+			get := mockConfigMap(tc.args.data, &gets)
+			if tc.args.getErr != nil {
+				get = test.NewMockGetFn(tc.args.getErr)
+			}
+
+			s := New(&test.MockClient{MockGet: get}, tc.args.config, NewCache())
+			engaged, err := s.Engaged(context.Background())
+
+			if diff := cmp.Diff(tc.want.err, errors.Cause(err), test.EquateErrors()); diff != "" {
+				t.Fatalf("Engaged(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.engaged, engaged); diff != "" {
+				t.Errorf("Engaged(...): -want engaged, +got engaged: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_Switch_Engaged_Cached(t *testing.T) {
+	gets := 0
+	kube := &test.MockClient{MockGet: mockConfigMap(map[string]string{"paused": "true"}, &gets)}
+	config := &apisv1alpha1.KillSwitch{ConfigMapRef: testConfigMapRef}
+	cache := NewCache()
+
+	// Switches of different reconciles share the cache, so the ConfigMap is read once.
+	for i := 0; i < 3; i++ {
+		engaged, err := New(kube, config, cache).Engaged(context.Background())
+		if err != nil || !engaged {
+			t.Fatalf("Engaged(...): want engaged, got %t, error: %v", engaged, err)
+		}
+	}
+
+	if diff := cmp.Diff(1, gets); diff != "" {
+		t.Errorf("Engaged(...): -want ConfigMap reads, +got ConfigMap reads: %s", diff)
+	}
+}
+
+func Test_Switch_Hold(t *testing.T) {
+	type want struct {
+		held       bool
+		conditions []xpv1.Condition
+	}
+	cases := map[string]struct {
+		config     *apisv1alpha1.KillSwitch
+		conditions []xpv1.Condition
+		want       want
+	}{
+		"Paused": {
+			config: &apisv1alpha1.KillSwitch{Engaged: true},
+			want:   want{held: true, conditions: []xpv1.Condition{paused()}},
+		},
+		"Released": {
+			config:     &apisv1alpha1.KillSwitch{},
+			conditions: []xpv1.Condition{paused()},
+			want:       want{held: false, conditions: []xpv1.Condition{released()}},
+		},
+		"NeverPaused": {
+			config: nil,
+			want:   want{held: false},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha2.Request{}
+			cr.Status.SetConditions(tc.conditions...)
+
+			held, err := New(&test.MockClient{}, tc.config, nil).Hold(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("Hold(...): unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.want.held, held); diff != "" {
+				t.Errorf("Hold(...): -want held, +got held: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.conditions, cr.Status.Conditions, cmpopts.EquateEmpty(), cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("Hold(...): -want conditions, +got conditions: %s", diff)
+			}
+		})
+	}
+}
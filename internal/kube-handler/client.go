@@ -2,42 +2,112 @@ package kubehandler
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	errs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
-	errCreateSecret = "create secret failed"
-	errGetSecret    = "get secret failed"
-	errUpdateFailed = "update secret failed"
+	errCreateSecret    = "create secret failed"
+	errGetSecret       = "get secret failed"
+	errUpdateFailed    = "update secret failed"
+	errGetConfigMap    = "get configmap failed"
+	errCreateConfigMap = "create configmap failed"
+	errUpdateConfigMap = "update configmap failed"
 )
 
-// GetSecret retrieves a Kubernetes Secret from the cluster.
+// secretBackoff bounds the retries of the secret operations failing transiently, and of the updates conflicting
+// with a concurrent write of the secret.
+var secretBackoff = wait.Backoff{
+	Steps:    5,
+	Duration: 50 * time.Millisecond,
+	Factor:   2,
+	Jitter:   0.1,
+}
+
+// isTransient reports whether a failed API call may succeed if it is sent again as is.
+func isTransient(err error) bool {
+	return errs.IsServerTimeout(err) || errs.IsTimeout(err) || errs.IsTooManyRequests(err) || errs.IsServiceUnavailable(err) || errs.IsInternalError(err)
+}
+
+// GetSecret retrieves a Kubernetes Secret from the cluster, retrying briefly while the API fails transiently.
 func GetSecret(ctx context.Context, kubeClient client.Client, name string, namespace string) (*corev1.Secret, error) {
 	secret := &corev1.Secret{}
+	err := retry.OnError(secretBackoff, isTransient, func() error {
+		return kubeClient.Get(ctx, client.ObjectKey{
+			Namespace: namespace,
+			Name:      name,
+		}, secret)
+	})
+
+	if err != nil {
+		return &corev1.Secret{}, errors.Wrap(err, errGetSecret)
+	}
+
+	return secret, nil
+}
+
+// GetConfigMap retrieves a Kubernetes ConfigMap from the cluster.
+func GetConfigMap(ctx context.Context, kubeClient client.Client, name string, namespace string) (*corev1.ConfigMap, error) {
+	configMap := &corev1.ConfigMap{}
 	err := kubeClient.Get(ctx, client.ObjectKey{
 		Namespace: namespace,
 		Name:      name,
-	}, secret)
+	}, configMap)
 
 	if err != nil {
-		return &corev1.Secret{}, errors.Wrap(err, errGetSecret)
+		return &corev1.ConfigMap{}, errors.Wrap(err, errGetConfigMap)
 	}
 
-	return secret, nil
+	return configMap, nil
+}
+
+// SetConfigMapKey sets a key of a Kubernetes ConfigMap, creating the ConfigMap if it does not exist.
+func SetConfigMapKey(ctx context.Context, kubeClient client.Client, name string, namespace string, key string, value string) error {
+	configMap, err := GetConfigMap(ctx, kubeClient, name, namespace)
+	if err != nil && !errs.IsNotFound(errors.Cause(err)) {
+		return err
+	}
+
+	if err != nil {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+			},
+			Data: map[string]string{key: value},
+		}
+
+		return errors.Wrap(kubeClient.Create(ctx, configMap), errCreateConfigMap)
+	}
+
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	configMap.Data[key] = value
+
+	return errors.Wrap(kubeClient.Update(ctx, configMap), errUpdateConfigMap)
 }
 
 // GetOrCreateSecret retrieves a Kubernetes Secret from the cluster. If the secret does not exist, it creates a new one.
 func GetOrCreateSecret(ctx context.Context, kubeClient client.Client, name string, namespace string) (*corev1.Secret, error) {
+	return GetOrCreateOwnedSecret(ctx, kubeClient, name, namespace, nil)
+}
+
+// GetOrCreateOwnedSecret retrieves a Kubernetes Secret from the cluster. If the secret does not exist, it creates a
+// new one, owned by the given owner when there is one. The owner of an existing secret is left untouched.
+func GetOrCreateOwnedSecret(ctx context.Context, kubeClient client.Client, name string, namespace string, owner *metav1.OwnerReference) (*corev1.Secret, error) {
 	secret, err := GetSecret(ctx, kubeClient, name, namespace)
 	if err != nil {
 		if errs.IsNotFound(err) {
-			return createSecret(ctx, kubeClient, name, namespace)
+			return createSecret(ctx, kubeClient, name, namespace, owner)
 		}
 
 		return &corev1.Secret{}, err
@@ -46,9 +116,26 @@ func GetOrCreateSecret(ctx context.Context, kubeClient client.Client, name strin
 	return secret, nil
 }
 
-// UpdateSecret updates a Kubernetes Secret in the cluster.
-func UpdateSecret(ctx context.Context, kubeClient client.Client, secret *corev1.Secret) error {
-	err := kubeClient.Update(ctx, secret)
+// UpdateSecret sets the given data on a Kubernetes Secret and updates it in the cluster. When the update
+// conflicts with a concurrent write of the secret, the secret is read again and the data applied to its latest
+// version, so the keys written by others are kept. Transient API failures are retried briefly as well.
+func UpdateSecret(ctx context.Context, kubeClient client.Client, secret *corev1.Secret, data map[string][]byte) error {
+	err := retry.OnError(secretBackoff, func(err error) bool {
+		return errs.IsConflict(err) || isTransient(err)
+	}, func() error {
+		applySecretData(secret, data)
+		err := kubeClient.Update(ctx, secret)
+		if !errs.IsConflict(err) {
+			return err
+		}
+
+		latest := &corev1.Secret{}
+		if getErr := kubeClient.Get(ctx, client.ObjectKeyFromObject(secret), latest); getErr != nil {
+			return getErr
+		}
+		*secret = *latest
+		return err
+	})
 	if err != nil {
 		return errors.Wrap(err, errUpdateFailed)
 	}
@@ -56,15 +143,38 @@ func UpdateSecret(ctx context.Context, kubeClient client.Client, secret *corev1.
 	return nil
 }
 
-func createSecret(ctx context.Context, kubeClient client.Client, name string, namespace string) (*corev1.Secret, error) {
+// applySecretData sets the given data on the secret.
+func applySecretData(secret *corev1.Secret, data map[string][]byte) {
+	if len(data) == 0 {
+		return
+	}
+
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte, len(data))
+	}
+	for key, value := range data {
+		secret.Data[key] = value
+	}
+}
+
+func createSecret(ctx context.Context, kubeClient client.Client, name string, namespace string, owner *metav1.OwnerReference) (*corev1.Secret, error) {
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: namespace,
 			Name:      name,
 		},
 	}
+	if owner != nil {
+		secret.OwnerReferences = []metav1.OwnerReference{*owner}
+	}
 
-	err := kubeClient.Create(ctx, secret)
+	err := retry.OnError(secretBackoff, isTransient, func() error {
+		return kubeClient.Create(ctx, secret)
+	})
+	if errs.IsAlreadyExists(err) {
+		// The secret was created concurrently since it was found missing.
+		return GetSecret(ctx, kubeClient, name, namespace)
+	}
 	if err != nil {
 		return &corev1.Secret{}, errors.Wrap(err, errCreateSecret)
 	}
@@ -10,7 +10,9 @@ import (
 	"github.com/google/go-cmp/cmp"
 	errorspkg "github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -280,7 +282,7 @@ func Test_UpdateSecret(t *testing.T) {
 		tc := tc // Create local copies of loop variables
 
 		t.Run(name, func(t *testing.T) {
-			gotErr := UpdateSecret(context.Background(), tc.args.localKube, tc.args.secret)
+			gotErr := UpdateSecret(context.Background(), tc.args.localKube, tc.args.secret, nil)
 			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
 				t.Fatalf("UpdateSecret(...): -want error, +got error: %s", diff)
 			}
@@ -332,10 +334,126 @@ func Test_UpdateSecret_ErrorHandling(t *testing.T) {
 		},
 	}
 
-	err := UpdateSecret(context.Background(), kubeClient, secret)
+	err := UpdateSecret(context.Background(), kubeClient, secret, nil)
 
 	// Verify that the error returned is wrapped correctly
 	if err == nil || !errors.Is(err, errBoom) {
 		t.Errorf("UpdateSecret() expected error %v, got: %v", errBoom, err)
 	}
 }
+
+func Test_UpdateSecret_RetriesOnConflict(t *testing.T) {
+	conflict := kerrors.NewConflict(schema.GroupResource{Resource: "secrets"}, "specific-secret-name", errBoom)
+
+	type want struct {
+		err     error
+		updates int
+		data    map[string][]byte
+	}
+	cases := map[string]struct {
+		conflicts int
+		want      want
+	}{
+		"ConflictThenSuccess": {
+			conflicts: 1,
+			want: want{
+				updates: 2,
+				data: map[string][]byte{
+					"computed-key":   []byte("computed-value"),
+					"concurrent-key": []byte("concurrent-value"),
+				},
+			},
+		},
+		"ConflictsExhausted": {
+			conflicts: 10,
+			want: want{
+				err:     errorspkg.Wrap(conflict, errUpdateFailed),
+				updates: 5,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var updates int
+			var updated map[string][]byte
+			kubeClient := &test.MockClient{
+				MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+					// The latest version holds a key written concurrently since the secret was read.
+					*obj.(*corev1.Secret) = *createSpecificSecret("specific-secret-name", "specific-secret-namespace", "concurrent-key", "concurrent-value")
+					return nil
+				},
+				MockUpdate: func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+					updates++
+					if updates <= tc.conflicts {
+						return conflict
+					}
+					updated = obj.(*corev1.Secret).Data
+					return nil
+				},
+			}
+
+			secret := createSpecificSecret("specific-secret-name", "specific-secret-namespace", "stale-key", "stale-value")
+			gotErr := UpdateSecret(context.Background(), kubeClient, secret, map[string][]byte{"computed-key": []byte("computed-value")})
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("UpdateSecret(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.updates, updates); diff != "" {
+				t.Errorf("UpdateSecret(...): -want updates, +got updates: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.data, updated); diff != "" {
+				t.Errorf("UpdateSecret(...): -want data, +got data: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_GetOrCreateSecret_Retries(t *testing.T) {
+	cases := map[string]struct {
+		getErrs   []error
+		createErr error
+		wantErr   bool
+	}{
+		"TransientGetErrorRetried": {
+			getErrs: []error{kerrors.NewServiceUnavailable("unavailable"), nil},
+		},
+		"CreatedConcurrently": {
+			getErrs:   []error{kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "specific-secret-name"), nil},
+			createErr: kerrors.NewAlreadyExists(schema.GroupResource{Resource: "secrets"}, "specific-secret-name"),
+		},
+		"PermanentGetErrorNotRetried": {
+			getErrs: []error{errBoom, nil},
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var gets int
+			kubeClient := &test.MockClient{
+				MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+					err := tc.getErrs[gets]
+					gets++
+					if err == nil {
+						*obj.(*corev1.Secret) = *createSpecificSecret("specific-secret-name", "specific-secret-namespace", "specific-key", "specific-value")
+					}
+					return err
+				},
+				MockCreate: test.NewMockCreateFn(tc.createErr),
+			}
+
+			secret, err := GetOrCreateSecret(context.Background(), kubeClient, "specific-secret-name", "specific-secret-namespace")
+			if diff := cmp.Diff(tc.wantErr, err != nil); diff != "" {
+				t.Fatalf("GetOrCreateSecret(...): -want error, +got error: %s", diff)
+			}
+			if tc.wantErr {
+				return
+			}
+			if diff := cmp.Diff("specific-value", string(secret.Data["specific-key"])); diff != "" {
+				t.Errorf("GetOrCreateSecret(...): -want value, +got value: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+	kubehandler "github.com/crossplane-contrib/provider-http/internal/kube-handler"
+)
+
+const (
+	errGetAuthorization     = "cannot get the authorization of the notifications"
+	errAuthorizationKey     = "key %s not found in secret %s/%s"
+	errNotify               = "Warning, couldn't notify %s of event %s, error: %s"
+	errUnexpectedStatus     = "unexpected status code %d"
+	notificationSendTimeout = 10 * time.Second
+)
+
+// Resource identifies the resource an event happened to.
+type Resource struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace,omitempty"`
+	UID        string `json:"uid"`
+}
+
+// Outcome describes the outcome of the request an event is about.
+type Outcome struct {
+	Method     string `json:"method,omitempty"`
+	StatusCode int    `json:"statusCode,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Payload is the JSON body of a notification.
+type Payload struct {
+	Event    apisv1alpha1.NotificationEvent `json:"event"`
+	Time     metav1.Time                    `json:"time"`
+	Resource Resource                       `json:"resource"`
+	Outcome  Outcome                        `json:"outcome"`
+}
+
+// A Notifier sends the events configured by the notifications of a ProviderConfig to their webhook.
+// A nil Notifier notifies nothing.
+type Notifier struct {
+	logger        logging.Logger
+	http          *http.Client
+	url           string
+	authorization string
+	events        map[apisv1alpha1.NotificationEvent]bool
+	inFlight      sync.WaitGroup
+}
+
+// New returns a Notifier for the given notifications, or nil when there are none.
+func New(ctx context.Context, kube client.Client, logger logging.Logger, config *apisv1alpha1.Notifications) (*Notifier, error) {
+	if config == nil || config.URL == "" {
+		return nil, nil
+	}
+
+	authorization, err := getAuthorization(ctx, kube, config.AuthorizationSecretRef)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetAuthorization)
+	}
+
+	events := make(map[apisv1alpha1.NotificationEvent]bool, len(config.Events))
+	for _, event := range config.Events {
+		events[event] = true
+	}
+
+	return &Notifier{
+		logger:        logger,
+		http:          &http.Client{Timeout: notificationSendTimeout},
+		url:           config.URL,
+		authorization: authorization,
+		events:        events,
+	}, nil
+}
+
+func getAuthorization(ctx context.Context, kube client.Client, ref *xpv1.SecretKeySelector) (string, error) {
+	if ref == nil {
+		return "", nil
+	}
+
+	secret, err := kubehandler.GetSecret(ctx, kube, ref.Name, ref.Namespace)
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", errors.Errorf(errAuthorizationKey, ref.Key, ref.Namespace, ref.Name)
+	}
+
+	return string(value), nil
+}
+
+// Notify sends the event about the given resource to the webhook in the background, if the webhook is
+// notified of it. Failing to deliver the notification is only logged.
+func (n *Notifier) Notify(event apisv1alpha1.NotificationEvent, gvk schema.GroupVersionKind, obj metav1.Object, outcome Outcome) {
+	if n == nil || !n.events[event] {
+		return
+	}
+
+	payload := Payload{
+		Event: event,
+		Time:  metav1.Now(),
+		Resource: Resource{
+			APIVersion: gvk.GroupVersion().String(),
+			Kind:       gvk.Kind,
+			Name:       obj.GetName(),
+			Namespace:  obj.GetNamespace(),
+			UID:        string(obj.GetUID()),
+		},
+		Outcome: outcome,
+	}
+
+	n.inFlight.Add(1)
+	go func() {
+		defer n.inFlight.Done()
+		if err := n.send(payload); err != nil {
+			n.logger.Info(fmt.Sprintf(errNotify, n.url, event, err.Error()))
+		}
+	}()
+}
+
+func (n *Notifier) send(payload Payload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	// The notification outlives the reconcile that triggered it, so it isn't bound to its context.
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if n.authorization != "" {
+		request.Header.Set("Authorization", n.authorization)
+	}
+
+	response, err := n.http.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return errors.Errorf(errUnexpectedStatus, response.StatusCode)
+	}
+
+	return nil
+}
+
+// wait blocks until the notifications sent so far are delivered or failed.
+func (n *Notifier) wait() {
+	n.inFlight.Wait()
+}
@@ -0,0 +1,208 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+)
+
+var testGVK = schema.GroupVersionKind{Group: "http.crossplane.io", Version: "v1alpha2", Kind: "Request"}
+
+var testResource = &metav1.ObjectMeta{Name: "user", Namespace: "default", UID: "1234"}
+
+type delivery struct {
+	authorization string
+	payload       Payload
+}
+
+func Test_Notify(t *testing.T) {
+	type args struct {
+		events []apisv1alpha1.NotificationEvent
+		event  apisv1alpha1.NotificationEvent
+		status int
+	}
+	type want struct {
+		deliveries []delivery
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldDeliverConfiguredEvent": {
+			args: args{
+				events: []apisv1alpha1.NotificationEvent{apisv1alpha1.NotificationEventCreateFailed},
+				event:  apisv1alpha1.NotificationEventCreateFailed,
+				status: http.StatusOK,
+			},
+			want: want{
+				deliveries: []delivery{
+					{
+						authorization: "Bearer secret",
+						payload: Payload{
+							Event: apisv1alpha1.NotificationEventCreateFailed,
+							Resource: Resource{
+								APIVersion: "http.crossplane.io/v1alpha2",
+								Kind:       "Request",
+								Name:       "user",
+								Namespace:  "default",
+								UID:        "1234",
+							},
+							Outcome: Outcome{Method: http.MethodPost, StatusCode: http.StatusInternalServerError, Error: "boom"},
+						},
+					},
+				},
+			},
+		},
+		"ShouldSkipEventNotConfigured": {
+			args: args{
+				events: []apisv1alpha1.NotificationEvent{apisv1alpha1.NotificationEventDeleteFailed},
+				event:  apisv1alpha1.NotificationEventCreateFailed,
+				status: http.StatusOK,
+			},
+			want: want{},
+		},
+		"ShouldTolerateFailingWebhook": {
+			args: args{
+				events: []apisv1alpha1.NotificationEvent{apisv1alpha1.NotificationEventCreateFailed},
+				event:  apisv1alpha1.NotificationEventCreateFailed,
+				status: http.StatusServiceUnavailable,
+			},
+			want: want{
+				deliveries: []delivery{
+					{
+						authorization: "Bearer secret",
+						payload: Payload{
+							Event: apisv1alpha1.NotificationEventCreateFailed,
+							Resource: Resource{
+								APIVersion: "http.crossplane.io/v1alpha2",
+								Kind:       "Request",
+								Name:       "user",
+								Namespace:  "default",
+								UID:        "1234",
+							},
+							Outcome: Outcome{Method: http.MethodPost, StatusCode: http.StatusInternalServerError, Error: "boom"},
+						},
+					},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var mu sync.Mutex
+			var got []delivery
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				d := delivery{authorization: r.Header.Get("Authorization")}
+				if err := json.NewDecoder(r.Body).Decode(&d.payload); err != nil {
+					t.Errorf("Decode(...): unexpected error: %s", err)
+				}
+				mu.Lock()
+				got = append(got, d)
+				mu.Unlock()
+				w.WriteHeader(tc.args.status)
+			}))
+			defer server.Close()
+
+			kube := &test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+					obj.(*corev1.Secret).Data = map[string][]byte{"token": []byte("Bearer secret")}
+					return nil
+				},
+			}
+			n, err := New(context.Background(), kube, logging.NewNopLogger(), &apisv1alpha1.Notifications{
+				URL:    server.URL,
+				Events: tc.args.events,
+				AuthorizationSecretRef: &xpv1.SecretKeySelector{
+					SecretReference: xpv1.SecretReference{Name: "webhook", Namespace: "crossplane-system"},
+					Key:             "token",
+				},
+			})
+			if err != nil {
+				t.Fatalf("New(...): unexpected error: %s", err)
+			}
+
+			n.Notify(tc.args.event, testGVK, testResource, Outcome{Method: http.MethodPost, StatusCode: http.StatusInternalServerError, Error: "boom"})
+			n.wait()
+
+			if diff := cmp.Diff(tc.want.deliveries, got, cmp.AllowUnexported(delivery{}), cmpopts.IgnoreFields(Payload{}, "Time")); diff != "" {
+				t.Errorf("Notify(...): -want deliveries, +got deliveries: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_New(t *testing.T) {
+	type args struct {
+		config *apisv1alpha1.Notifications
+	}
+	type want struct {
+		notifier bool
+		err      bool
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldNotNotifyWithoutConfig": {
+			args: args{},
+			want: want{notifier: false},
+		},
+		"ShouldNotifyWithoutAuthorization": {
+			args: args{
+				config: &apisv1alpha1.Notifications{URL: "http://example.com", Events: []apisv1alpha1.NotificationEvent{apisv1alpha1.NotificationEventCreateFailed}},
+			},
+			want: want{notifier: true},
+		},
+		"ShouldFailWhenAuthorizationKeyIsMissing": {
+			args: args{
+				config: &apisv1alpha1.Notifications{
+					URL:    "http://example.com",
+					Events: []apisv1alpha1.NotificationEvent{apisv1alpha1.NotificationEventCreateFailed},
+					AuthorizationSecretRef: &xpv1.SecretKeySelector{
+						SecretReference: xpv1.SecretReference{Name: "webhook", Namespace: "crossplane-system"},
+						Key:             "missing",
+					},
+				},
+			},
+			want: want{err: true},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			kube := &test.MockClient{MockGet: test.NewMockGetFn(nil)}
+			got, err := New(context.Background(), kube, logging.NewNopLogger(), tc.args.config)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("New(...): -want error, +got error: %s (error: %v)", diff, err)
+			}
+			if diff := cmp.Diff(tc.want.notifier, got != nil); diff != "" {
+				t.Errorf("New(...): -want notifier, +got notifier: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_Notify_NilNotifier(t *testing.T) {
+	var n *Notifier
+	n.Notify(apisv1alpha1.NotificationEventCreateFailed, testGVK, testResource, Outcome{})
+}
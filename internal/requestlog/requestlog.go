@@ -0,0 +1,189 @@
+package requestlog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+const (
+	reasonRequestSent event.Reason = "RequestSent"
+
+	errWriteEntry = "Warning, couldn't write the request log entry of %s %s, error: %s"
+)
+
+// Resource identifies the resource a request was sent for.
+type Resource struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace,omitempty"`
+	UID        string `json:"uid"`
+}
+
+// Entry is the record of a request in the request log. The request is the one recorded in the status of the
+// resource, whose values taken from secrets are left as placeholders, and the response is only described by
+// its status code.
+type Entry struct {
+	Time           metav1.Time         `json:"time"`
+	ProviderConfig string              `json:"providerConfig"`
+	Resource       Resource            `json:"resource"`
+	Method         string              `json:"method"`
+	URL            string              `json:"url"`
+	Headers        map[string][]string `json:"headers,omitempty"`
+	Body           string              `json:"body,omitempty"`
+	StatusCode     int                 `json:"statusCode,omitempty"`
+	DurationMillis int64               `json:"durationMillis"`
+	Error          string              `json:"error,omitempty"`
+}
+
+// A Sink writes the entries of a request log. The object is the resource the request was sent for.
+type Sink interface {
+	Write(obj runtime.Object, entry Entry) error
+}
+
+// stdout is shared by every request log writing to the standard output, so that the lines written by
+// concurrent reconciles don't interleave.
+var stdout = NewJSONLinesSink(os.Stdout)
+
+// New returns the Sink configured by the given request log, or nil when it is disabled. The recorder records
+// the entries written to the Events sink.
+func New(config *apisv1alpha1.RequestLog, recorder event.Recorder) Sink {
+	if config == nil || !config.Enabled {
+		return nil
+	}
+
+	if config.Sink == apisv1alpha1.RequestLogSinkEvents {
+		return NewEventSink(recorder)
+	}
+
+	return stdout
+}
+
+// jsonLinesSink writes each entry as a line of JSON.
+type jsonLinesSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewJSONLinesSink returns a Sink writing each entry to w as a line of JSON.
+func NewJSONLinesSink(w io.Writer) Sink {
+	return &jsonLinesSink{w: w}
+}
+
+func (s *jsonLinesSink) Write(_ runtime.Object, entry Entry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.w.Write(append(line, '\n'))
+	return err
+}
+
+// eventSink records each entry as an event of the resource the request was sent for.
+type eventSink struct {
+	recorder event.Recorder
+}
+
+// NewEventSink returns a Sink recording each entry as a normal event of the resource, summarizing the request
+// and its outcome. The headers and body of the request aren't part of the event.
+func NewEventSink(recorder event.Recorder) Sink {
+	return &eventSink{recorder: recorder}
+}
+
+func (s *eventSink) Write(obj runtime.Object, entry Entry) error {
+	if s.recorder == nil {
+		return nil
+	}
+
+	outcome := fmt.Sprintf("status code %d", entry.StatusCode)
+	if entry.Error != "" {
+		outcome = "error: " + entry.Error
+	}
+
+	s.recorder.Event(obj, event.Normal(reasonRequestSent, fmt.Sprintf("%s %s returned %s in %dms", entry.Method, entry.URL, outcome, entry.DurationMillis)))
+	return nil
+}
+
+// client writes an entry to its sink for every request sent by the client it wraps.
+type client struct {
+	httpClient.Client
+	sink           Sink
+	logger         logging.Logger
+	providerConfig string
+	gvk            schema.GroupVersionKind
+	obj            resource.Object
+}
+
+// WrapClient returns a client logging every request it sends for the given resource of the given
+// ProviderConfig to the sink. Failing to write an entry is only logged. A nil sink leaves the client unwrapped.
+func WrapClient(h httpClient.Client, sink Sink, logger logging.Logger, providerConfig string, gvk schema.GroupVersionKind, obj resource.Object) httpClient.Client {
+	if sink == nil {
+		return h
+	}
+
+	return &client{
+		Client:         h,
+		sink:           sink,
+		logger:         logger,
+		providerConfig: providerConfig,
+		gvk:            gvk,
+		obj:            obj,
+	}
+}
+
+func (c *client) SendRequest(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (httpClient.HttpDetails, error) {
+	details, err := c.Client.SendRequest(ctx, method, url, body, headers, skipTLSVerify)
+
+	entry := c.entry(method, url, details, err)
+	if writeErr := c.sink.Write(c.obj, entry); writeErr != nil {
+		c.logger.Info(fmt.Sprintf(errWriteEntry, entry.Method, entry.URL, writeErr.Error()))
+	}
+
+	return details, err
+}
+
+// entry returns the entry recording the given request and its outcome. Its headers and body are the ones of
+// the details, which leave the values taken from secrets as placeholders.
+func (c *client) entry(method, url string, details httpClient.HttpDetails, err error) Entry {
+	entry := Entry{
+		Time:           metav1.Now(),
+		ProviderConfig: c.providerConfig,
+		Resource: Resource{
+			APIVersion: c.gvk.GroupVersion().String(),
+			Kind:       c.gvk.Kind,
+			Name:       c.obj.GetName(),
+			Namespace:  c.obj.GetNamespace(),
+			UID:        string(c.obj.GetUID()),
+		},
+		Method:         method,
+		URL:            url,
+		Headers:        details.HttpRequest.Headers,
+		Body:           details.HttpRequest.Body,
+		StatusCode:     details.HttpResponse.StatusCode,
+		DurationMillis: details.Duration.Milliseconds(),
+	}
+
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	return entry
+}
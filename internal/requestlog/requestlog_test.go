@@ -0,0 +1,203 @@
+package requestlog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+var errBoom = errors.New("boom")
+
+type mockClient struct {
+	details httpClient.HttpDetails
+	err     error
+}
+
+func (m *mockClient) SendRequest(_ context.Context, _ string, _ string, _, _ httpClient.Data, _ bool) (httpClient.HttpDetails, error) {
+	return m.details, m.err
+}
+
+type mockRecorder struct {
+	events []event.Event
+}
+
+func (r *mockRecorder) Event(_ runtime.Object, e event.Event) {
+	r.events = append(r.events, e)
+}
+
+func (r *mockRecorder) WithAnnotations(_ ...string) event.Recorder {
+	return r
+}
+
+func testRequest() *v1alpha2.Request {
+	cr := &v1alpha2.Request{}
+	cr.SetName("user")
+	cr.SetUID("2b3c")
+	return cr
+}
+
+func Test_New(t *testing.T) {
+	recorder := &mockRecorder{}
+	cases := map[string]struct {
+		config *apisv1alpha1.RequestLog
+		want   Sink
+	}{
+		"Unset": {
+			config: nil,
+			want:   nil,
+		},
+		"Disabled": {
+			config: &apisv1alpha1.RequestLog{Sink: apisv1alpha1.RequestLogSinkEvents},
+			want:   nil,
+		},
+		"DefaultsToStdout": {
+			config: &apisv1alpha1.RequestLog{Enabled: true},
+			want:   stdout,
+		},
+		"Events": {
+			config: &apisv1alpha1.RequestLog{Enabled: true, Sink: apisv1alpha1.RequestLogSinkEvents},
+			want:   &eventSink{recorder: recorder},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got := New(tc.config, recorder)
+			if want, ok := tc.want.(*eventSink); ok {
+				if got, ok := got.(*eventSink); !ok || got.recorder != want.recorder {
+					t.Errorf("New(...): want an event sink using the recorder, got %v", got)
+				}
+				return
+			}
+			if got != tc.want {
+				t.Errorf("New(...): want sink %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func Test_WrapClient(t *testing.T) {
+	type want struct {
+		entry Entry
+		err   error
+	}
+	cases := map[string]struct {
+		client *mockClient
+		want   want
+	}{
+		"Response": {
+			client: &mockClient{details: httpClient.HttpDetails{
+				HttpRequest: httpClient.HttpRequest{
+					Method:  "POST",
+					URL:     "https://api.example.com/users",
+					Body:    `{"password":"{{ creds:default:password }}"}`,
+					Headers: map[string][]string{"Authorization": {"Bearer {{ creds:default:token }}"}},
+				},
+				HttpResponse: httpClient.HttpResponse{StatusCode: 201, Body: `{"token":"sensitive"}`},
+				Duration:     42 * time.Millisecond,
+			}},
+			want: want{entry: Entry{
+				ProviderConfig: "default",
+				Resource:       Resource{APIVersion: v1alpha2.RequestGroupVersionKind.GroupVersion().String(), Kind: v1alpha2.RequestKind, Name: "user", UID: "2b3c"},
+				Method:         "POST",
+				URL:            "https://api.example.com/users",
+				Body:           `{"password":"{{ creds:default:password }}"}`,
+				Headers:        map[string][]string{"Authorization": {"Bearer {{ creds:default:token }}"}},
+				StatusCode:     201,
+				DurationMillis: 42,
+			}},
+		},
+		"Error": {
+			client: &mockClient{err: errBoom},
+			want: want{
+				entry: Entry{
+					ProviderConfig: "default",
+					Resource:       Resource{APIVersion: v1alpha2.RequestGroupVersionKind.GroupVersion().String(), Kind: v1alpha2.RequestKind, Name: "user", UID: "2b3c"},
+					Method:         "POST",
+					URL:            "https://api.example.com/users",
+					Error:          errBoom.Error(),
+				},
+				err: errBoom,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			h := WrapClient(tc.client, NewJSONLinesSink(&out), logging.NewNopLogger(), "default", v1alpha2.RequestGroupVersionKind, testRequest())
+
+			body := httpClient.Data{Encrypted: "", Decrypted: `{"password":"secret"}`}
+			_, gotErr := h.SendRequest(context.Background(), "POST", "https://api.example.com/users", body, httpClient.Data{}, false)
+			if diff := cmp.Diff(tc.want.err, gotErr, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("SendRequest(...): -want error, +got error: %s", diff)
+			}
+
+			if strings.Contains(out.String(), "secret") || strings.Contains(out.String(), "sensitive") {
+				t.Errorf("SendRequest(...): the request log holds sensitive values: %s", out.String())
+			}
+
+			var got Entry
+			if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+				t.Fatalf("SendRequest(...): the request log isn't a JSON line: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.entry, got, cmpopts.IgnoreFields(Entry{}, "Time")); diff != "" {
+				t.Errorf("SendRequest(...): -want entry, +got entry: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_WrapClient_NoSink(t *testing.T) {
+	h := &mockClient{}
+	if got := WrapClient(h, nil, logging.NewNopLogger(), "default", v1alpha2.RequestGroupVersionKind, testRequest()); got != h {
+		t.Errorf("WrapClient(...): want the client left unwrapped without a sink")
+	}
+}
+
+func Test_EventSink(t *testing.T) {
+	cases := map[string]struct {
+		entry Entry
+		want  string
+	}{
+		"Response": {
+			entry: Entry{Method: "GET", URL: "https://api.example.com/users/1", StatusCode: 200, DurationMillis: 12, Body: "ignored"},
+			want:  "GET https://api.example.com/users/1 returned status code 200 in 12ms",
+		},
+		"Error": {
+			entry: Entry{Method: "GET", URL: "https://api.example.com/users/1", Error: "connection refused", DurationMillis: 3},
+			want:  "GET https://api.example.com/users/1 returned error: connection refused in 3ms",
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			recorder := &mockRecorder{}
+			if err := NewEventSink(recorder).Write(testRequest(), tc.entry); err != nil {
+				t.Fatalf("Write(...): unexpected error: %s", err)
+			}
+
+			want := []event.Event{event.Normal(reasonRequestSent, tc.want)}
+			if diff := cmp.Diff(want, recorder.events); diff != "" {
+				t.Errorf("Write(...): -want events, +got events: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"strings"
+)
+
+// compressedBodyPrefix marks a body stored gzipped and base64 encoded.
+const compressedBodyPrefix = "gzip+base64:"
+
+// StatusBodyCompressor is implemented by resources that may store their response body compressed.
+type StatusBodyCompressor interface {
+	StatusBodyCompressionThreshold() *int64
+}
+
+// CompressBody returns the body gzipped and base64 encoded behind the compressed body prefix when it is larger
+// than the threshold. Bodies below the threshold, or that wouldn't get smaller, are returned as is.
+func CompressBody(body string, threshold *int64) string {
+	if threshold == nil || int64(len(body)) <= *threshold {
+		return body
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(body)); err != nil {
+		return body
+	}
+	if err := zw.Close(); err != nil {
+		return body
+	}
+
+	compressed := compressedBodyPrefix + base64.StdEncoding.EncodeToString(buf.Bytes())
+	if len(compressed) >= len(body) {
+		return body
+	}
+
+	return compressed
+}
+
+// DecompressBody returns the original form of a body stored by CompressBody. Bodies that aren't compressed, or
+// can't be decompressed, are returned as is.
+func DecompressBody(body string) string {
+	encoded, ok := strings.CutPrefix(body, compressedBodyPrefix)
+	if !ok {
+		return body
+	}
+
+	compressed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return body
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return body
+	}
+
+	decompressed, err := io.ReadAll(zr)
+	if err != nil {
+		return body
+	}
+
+	return string(decompressed)
+}
+
+// storedBody returns the body as it is stored in the status of the resource.
+func storedBody(resource interface{}, body string) string {
+	if compressor, ok := resource.(StatusBodyCompressor); ok {
+		return CompressBody(body, compressor.StatusBodyCompressionThreshold())
+	}
+
+	return body
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_CompressBody(t *testing.T) {
+	large := `{"items": "` + strings.Repeat("item,", 200) + `"}`
+	threshold := int64(64)
+
+	type args struct {
+		body      string
+		threshold *int64
+	}
+	type want struct {
+		compressed bool
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldCompressBodyAboveThreshold": {
+			args: args{
+				body:      large,
+				threshold: &threshold,
+			},
+			want: want{compressed: true},
+		},
+		"ShouldKeepBodyBelowThreshold": {
+			args: args{
+				body:      `{"id": "123"}`,
+				threshold: &threshold,
+			},
+			want: want{compressed: false},
+		},
+		"ShouldKeepBodyWhenThresholdIsUnset": {
+			args: args{
+				body: large,
+			},
+			want: want{compressed: false},
+		},
+		"ShouldKeepBodyThatWouldNotGetSmaller": {
+			args: args{
+				body:      "q8Zk3Lw0Xv9Tn2Rb7Hc1Ym5Ps4Dg6Fj0Ea8Wu3Io9Kx2Nl7Mz1Vt5Qh4Cs6Bp0Gr8Jy3Of",
+				threshold: &threshold,
+			},
+			want: want{compressed: false},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got := CompressBody(tc.args.body, tc.args.threshold)
+			if diff := cmp.Diff(tc.want.compressed, strings.HasPrefix(got, compressedBodyPrefix)); diff != "" {
+				t.Fatalf("CompressBody(...): -want compressed, +got compressed: %s", diff)
+			}
+			if diff := cmp.Diff(tc.args.body, DecompressBody(got)); diff != "" {
+				t.Errorf("DecompressBody(CompressBody(...)): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_DecompressBody(t *testing.T) {
+	cases := map[string]struct {
+		body string
+		want string
+	}{
+		"ShouldKeepUncompressedBody": {
+			body: `{"id": "123"}`,
+			want: `{"id": "123"}`,
+		},
+		"ShouldKeepInvalidCompressedBody": {
+			body: compressedBodyPrefix + "not-base64!",
+			want: compressedBodyPrefix + "not-base64!",
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, DecompressBody(tc.body)); diff != "" {
+				t.Errorf("DecompressBody(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
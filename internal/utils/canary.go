@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"hash/fnv"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+)
+
+const (
+	errInvalidCanarySelector = "invalid canary selector"
+)
+
+// InCanaryCohort reports whether the object belongs to the cohort of the canary rollout. Objects are never in
+// the cohort of a missing or disabled canary.
+func InCanaryCohort(canary *apisv1alpha1.CanaryRollout, obj metav1.Object) (bool, error) {
+	if canary == nil || !canary.Enabled {
+		return false, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(&canary.Selector)
+	if err != nil {
+		return false, errors.Wrap(err, errInvalidCanarySelector)
+	}
+
+	if !selector.Matches(labels.Set(obj.GetLabels())) {
+		return false, nil
+	}
+
+	if canary.Weight == nil {
+		return true, nil
+	}
+
+	return canaryBucket(string(obj.GetUID())) < *canary.Weight, nil
+}
+
+// canaryBucket deterministically maps an identifier to a bucket between 0 and 99.
+func canaryBucket(id string) int32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(id))
+	return int32(h.Sum32() % 100)
+}
@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+)
+
+func Test_InCanaryCohort(t *testing.T) {
+	weight := int32(50)
+	canarySelector := metav1.LabelSelector{MatchLabels: map[string]string{"rollout": "canary"}}
+
+	// The UIDs hash to buckets 39 and 91 respectively.
+	lowBucket := types.UID("b3f7e2d1-0c4a-4e6b-8f9d-1a2b3c4d5e6f")
+	highBucket := types.UID("6a1c0f3e-7c1d-4b8e-9d3a-2f5e1b7c9a01")
+
+	type args struct {
+		canary *apisv1alpha1.CanaryRollout
+		labels map[string]string
+		uid    types.UID
+	}
+	type want struct {
+		result bool
+		err    error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoCanary": {
+			args: args{
+				labels: map[string]string{"rollout": "canary"},
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"DisabledCanary": {
+			args: args{
+				canary: &apisv1alpha1.CanaryRollout{Selector: canarySelector},
+				labels: map[string]string{"rollout": "canary"},
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"SelectedWithoutWeight": {
+			args: args{
+				canary: &apisv1alpha1.CanaryRollout{Enabled: true, Selector: canarySelector},
+				labels: map[string]string{"rollout": "canary"},
+				uid:    highBucket,
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"NotSelected": {
+			args: args{
+				canary: &apisv1alpha1.CanaryRollout{Enabled: true, Selector: canarySelector},
+				labels: map[string]string{"rollout": "stable"},
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"SelectedWithinWeight": {
+			args: args{
+				canary: &apisv1alpha1.CanaryRollout{Enabled: true, Selector: canarySelector, Weight: &weight},
+				labels: map[string]string{"rollout": "canary"},
+				uid:    lowBucket,
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"SelectedOutsideWeight": {
+			args: args{
+				canary: &apisv1alpha1.CanaryRollout{Enabled: true, Selector: canarySelector, Weight: &weight},
+				labels: map[string]string{"rollout": "canary"},
+				uid:    highBucket,
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"InvalidSelector": {
+			args: args{
+				canary: &apisv1alpha1.CanaryRollout{
+					Enabled: true,
+					Selector: metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "rollout", Operator: "Unknown"},
+					}},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errors.New(`"Unknown" is not a valid label selector operator`), errInvalidCanarySelector),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			obj := &metav1.ObjectMeta{Labels: tc.args.labels, UID: tc.args.uid}
+			got, gotErr := InCanaryCohort(tc.args.canary, obj)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("InCanaryCohort(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("InCanaryCohort(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
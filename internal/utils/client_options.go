@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+const (
+	defaultResponseCacheTTL = time.Second
+)
+
+// ClientOptions returns the http client options configured by the given ProviderConfig.
+func ClientOptions(pc *apisv1alpha1.ProviderConfig, hostLimiter *httpClient.HostLimiter, responseCache *httpClient.ResponseCache) []httpClient.ClientOption {
+	opts := []httpClient.ClientOption{}
+
+	if pc.Spec.MaxInFlightRequestsPerHost != nil && hostLimiter != nil {
+		opts = append(opts, httpClient.WithHostLimit(hostLimiter, int(*pc.Spec.MaxInFlightRequestsPerHost)))
+	}
+
+	if ttl := ResponseCacheTTL(pc); ttl > 0 && responseCache != nil {
+		opts = append(opts, httpClient.WithResponseCache(responseCache, ttl))
+	}
+
+	if pc.Spec.SourceAddress != "" {
+		opts = append(opts, httpClient.WithSourceAddress(pc.Spec.SourceAddress))
+	}
+
+	if pc.Spec.UnixSocketPath != "" {
+		opts = append(opts, httpClient.WithUnixSocket(pc.Spec.UnixSocketPath))
+	}
+
+	if pc.Spec.TLSServerName != "" {
+		opts = append(opts, httpClient.WithTLSServerName(pc.Spec.TLSServerName))
+	}
+
+	if pc.Spec.CABundle != "" {
+		opts = append(opts, httpClient.WithCABundle(pc.Spec.CABundle))
+	}
+
+	if len(pc.Spec.TLSPinnedPublicKeys) > 0 {
+		pins := make([]string, 0, len(pc.Spec.TLSPinnedPublicKeys))
+		for _, pin := range pc.Spec.TLSPinnedPublicKeys {
+			pins = append(pins, string(pin))
+		}
+		opts = append(opts, httpClient.WithPinnedPublicKeys(pins))
+	}
+
+	if t := pc.Spec.Timeouts; t != nil {
+		opts = append(opts, httpClient.WithTimeouts(duration(t.Connect), duration(t.TLSHandshake), duration(t.ResponseHeader), duration(t.Total)))
+	}
+
+	if pc.Spec.CompressionNegotiation != nil && !*pc.Spec.CompressionNegotiation {
+		opts = append(opts, httpClient.WithoutCompressionNegotiation())
+	}
+
+	if pc.Spec.MaxRequestHeaderBytes != nil {
+		opts = append(opts, httpClient.WithMaxHeaderBytes(int(*pc.Spec.MaxRequestHeaderBytes)))
+	}
+
+	if pc.Spec.BearerTokenFile != "" {
+		opts = append(opts, httpClient.WithBearerTokenFile(pc.Spec.BearerTokenFile))
+	}
+
+	return opts
+}
+
+// duration returns the given duration, or zero when it is unset.
+func duration(d *metav1.Duration) time.Duration {
+	if d == nil {
+		return 0
+	}
+	return d.Duration
+}
+
+// ResponseCacheTTL returns how long GET responses are reused according to the given ProviderConfig.
+func ResponseCacheTTL(pc *apisv1alpha1.ProviderConfig) time.Duration {
+	if pc.Spec.ResponseCacheTTL != nil {
+		return pc.Spec.ResponseCacheTTL.Duration
+	}
+	return defaultResponseCacheTTL
+}
@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"net/http"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+// IsCreateConflict reports whether the response to a request means the object already exists,
+// which is the case for a conflict response to a POST request under the createOrConflict strategy.
+func IsCreateConflict(forProvider v1alpha2.RequestParameters, method string, statusCode int) bool {
+	if forProvider.CreateStrategy != v1alpha2.CreateStrategyCreateOrConflict || method != http.MethodPost {
+		return false
+	}
+
+	conflictStatusCode := http.StatusConflict
+	if forProvider.ConflictStatusCode != nil {
+		conflictStatusCode = int(*forProvider.ConflictStatusCode)
+	}
+
+	return statusCode == conflictStatusCode
+}
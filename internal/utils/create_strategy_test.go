@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+func Test_IsCreateConflict(t *testing.T) {
+	customConflict := int32(http.StatusUnprocessableEntity)
+
+	type args struct {
+		forProvider v1alpha2.RequestParameters
+		method      string
+		statusCode  int
+	}
+	type want struct {
+		result bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"DefaultConflict": {
+			args: args{
+				forProvider: v1alpha2.RequestParameters{CreateStrategy: v1alpha2.CreateStrategyCreateOrConflict},
+				method:      http.MethodPost,
+				statusCode:  http.StatusConflict,
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"CustomConflict": {
+			args: args{
+				forProvider: v1alpha2.RequestParameters{
+					CreateStrategy:     v1alpha2.CreateStrategyCreateOrConflict,
+					ConflictStatusCode: &customConflict,
+				},
+				method:     http.MethodPost,
+				statusCode: http.StatusUnprocessableEntity,
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"OtherStatusCode": {
+			args: args{
+				forProvider: v1alpha2.RequestParameters{CreateStrategy: v1alpha2.CreateStrategyCreateOrConflict},
+				method:      http.MethodPost,
+				statusCode:  http.StatusBadRequest,
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"NotPOST": {
+			args: args{
+				forProvider: v1alpha2.RequestParameters{CreateStrategy: v1alpha2.CreateStrategyCreateOrConflict},
+				method:      http.MethodPut,
+				statusCode:  http.StatusConflict,
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"ObserveFirst": {
+			args: args{
+				forProvider: v1alpha2.RequestParameters{},
+				method:      http.MethodPost,
+				statusCode:  http.StatusConflict,
+			},
+			want: want{
+				result: false,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsCreateConflict(tc.args.forProvider, tc.args.method, tc.args.statusCode)
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("IsCreateConflict(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"net/http"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+// actionMethods are the standard methods of the actions, whose mappings run them unless another mapping is
+// designated for the action.
+var actionMethods = map[v1alpha2.MappingAction]string{
+	v1alpha2.MappingActionCreate:  http.MethodPost,
+	v1alpha2.MappingActionObserve: http.MethodGet,
+	v1alpha2.MappingActionUpdate:  http.MethodPut,
+	v1alpha2.MappingActionDelete:  http.MethodDelete,
+}
+
+// MappingForAction returns the mapping that runs the action of the given standard method: POST creates, GET
+// observes, PUT updates and DELETE deletes. A mapping designated for the action takes precedence over the
+// mapping of the standard method, so that custom methods such as PURGE can run it.
+func MappingForAction(forProvider v1alpha2.RequestParameters, method string) (v1alpha2.Mapping, bool) {
+	for _, mapping := range forProvider.Mappings {
+		if mapping.Action != "" && actionMethods[mapping.Action] == method {
+			return mapping, true
+		}
+	}
+
+	for _, mapping := range forProvider.Mappings {
+		if mapping.Action == "" && mapping.Method == method {
+			return mapping, true
+		}
+	}
+
+	return v1alpha2.Mapping{}, false
+}
+
+// ActionMethod returns the standard method of the action run by the mapping of the given method, so that the
+// responses to custom methods are handled like the ones of the action they run. Methods without a designated
+// mapping are returned unchanged.
+func ActionMethod(forProvider v1alpha2.RequestParameters, method string) string {
+	for _, mapping := range forProvider.Mappings {
+		if mapping.Method == method && mapping.Action != "" {
+			return actionMethods[mapping.Action]
+		}
+	}
+
+	return method
+}
+
+// MappingActionOf returns the action the mapping runs for: the one it is designated for, or the one of its
+// standard method otherwise. Custom methods without a designated action run for none.
+func MappingActionOf(mapping v1alpha2.Mapping) v1alpha2.MappingAction {
+	if mapping.Action != "" {
+		return mapping.Action
+	}
+
+	for action, method := range actionMethods {
+		if method == mapping.Method {
+			return action
+		}
+	}
+
+	return ""
+}
+
+// SentActionMethod returns the standard method of the action a request of the given method was sent for. The
+// recorded action tells apart the mappings sharing the method, such as a POST query observing the object and the
+// POST creating it; requests recorded without one are handled like ActionMethod does.
+func SentActionMethod(forProvider v1alpha2.RequestParameters, method string, action v1alpha2.MappingAction) string {
+	if actionMethod, ok := actionMethods[action]; ok {
+		return actionMethod
+	}
+
+	return ActionMethod(forProvider, method)
+}
@@ -0,0 +1,137 @@
+package utils
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+var (
+	testPurgeMapping = v1alpha2.Mapping{Method: "PURGE", URL: ".payload.baseUrl", Action: v1alpha2.MappingActionDelete}
+	testLinkMapping  = v1alpha2.Mapping{Method: "LINK", URL: ".payload.baseUrl"}
+)
+
+func Test_MappingForAction(t *testing.T) {
+	type args struct {
+		mappings []v1alpha2.Mapping
+		method   string
+	}
+	type want struct {
+		mapping v1alpha2.Mapping
+		ok      bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"StandardMethod": {
+			args: args{
+				mappings: []v1alpha2.Mapping{testPostMapping, testGetMapping},
+				method:   http.MethodGet,
+			},
+			want: want{mapping: testGetMapping, ok: true},
+		},
+		"CustomMethodDesignatedForTheAction": {
+			args: args{
+				mappings: []v1alpha2.Mapping{{Method: http.MethodDelete}, testPurgeMapping},
+				method:   http.MethodDelete,
+			},
+			want: want{mapping: testPurgeMapping, ok: true},
+		},
+		"CustomMethodWithoutAction": {
+			args: args{
+				mappings: []v1alpha2.Mapping{testPostMapping, testLinkMapping},
+				method:   "LINK",
+			},
+			want: want{mapping: testLinkMapping, ok: true},
+		},
+		"StandardMethodDesignatedForAnotherAction": {
+			args: args{
+				mappings: []v1alpha2.Mapping{{Method: http.MethodPost, Action: v1alpha2.MappingActionUpdate}},
+				method:   http.MethodPost,
+			},
+			want: want{mapping: v1alpha2.Mapping{}, ok: false},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got, ok := MappingForAction(v1alpha2.RequestParameters{Mappings: tc.args.mappings}, tc.args.method)
+			if diff := cmp.Diff(tc.want.ok, ok); diff != "" {
+				t.Fatalf("MappingForAction(...): -want ok, +got ok: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mapping, got); diff != "" {
+				t.Errorf("MappingForAction(...): -want mapping, +got mapping: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_ActionMethod(t *testing.T) {
+	forProvider := v1alpha2.RequestParameters{Mappings: []v1alpha2.Mapping{testPostMapping, testPurgeMapping, testLinkMapping}}
+	cases := map[string]struct {
+		method string
+		want   string
+	}{
+		"StandardMethod":            {method: http.MethodPost, want: http.MethodPost},
+		"DesignatedCustomMethod":    {method: "PURGE", want: http.MethodDelete},
+		"CustomMethodWithoutAction": {method: "LINK", want: "LINK"},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, ActionMethod(forProvider, tc.method)); diff != "" {
+				t.Errorf("ActionMethod(...): -want method, +got method: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_MappingActionOf(t *testing.T) {
+	cases := map[string]struct {
+		mapping v1alpha2.Mapping
+		want    v1alpha2.MappingAction
+	}{
+		"StandardMethod":            {mapping: testPostMapping, want: v1alpha2.MappingActionCreate},
+		"DesignatedCustomMethod":    {mapping: testPurgeMapping, want: v1alpha2.MappingActionDelete},
+		"DesignatedStandardMethod":  {mapping: v1alpha2.Mapping{Method: http.MethodPost, Action: v1alpha2.MappingActionObserve}, want: v1alpha2.MappingActionObserve},
+		"CustomMethodWithoutAction": {mapping: testLinkMapping, want: ""},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, MappingActionOf(tc.mapping)); diff != "" {
+				t.Errorf("MappingActionOf(...): -want action, +got action: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SentActionMethod(t *testing.T) {
+	queryMapping := v1alpha2.Mapping{Method: http.MethodPost, URL: ".payload.baseUrl", Action: v1alpha2.MappingActionObserve}
+	forProvider := v1alpha2.RequestParameters{Mappings: []v1alpha2.Mapping{testPostMapping, queryMapping}}
+	cases := map[string]struct {
+		method string
+		action v1alpha2.MappingAction
+		want   string
+	}{
+		"CreatingPost":       {method: http.MethodPost, action: v1alpha2.MappingActionCreate, want: http.MethodPost},
+		"ObservingPost":      {method: http.MethodPost, action: v1alpha2.MappingActionObserve, want: http.MethodGet},
+		"RecordedWithoutOne": {method: http.MethodPost, want: http.MethodGet},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, SentActionMethod(forProvider, tc.method, tc.action)); diff != "" {
+				t.Errorf("SentActionMethod(...): -want method, +got method: %s", diff)
+			}
+		})
+	}
+}
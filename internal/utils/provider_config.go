@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+)
+
+const (
+	errGetProviderConfig   = "cannot get ProviderConfig %s"
+	errProviderConfigCycle = "ProviderConfig inheritance cycle detected: %s"
+)
+
+// ResolveProviderConfig returns the named ProviderConfig merged with the chain of base ProviderConfigs it inherits
+// from. A setting is taken from the closest ProviderConfig in the chain that sets it, starting from the named one.
+func ResolveProviderConfig(ctx context.Context, kube client.Client, name string) (*apisv1alpha1.ProviderConfig, error) {
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := kube.Get(ctx, types.NamespacedName{Name: name}, pc); err != nil {
+		return nil, errors.Wrapf(err, errGetProviderConfig, name)
+	}
+
+	chain := []string{name}
+	ref := pc.Spec.BaseProviderConfigRef
+	for ref != nil {
+		for _, visited := range chain {
+			if visited == ref.Name {
+				return nil, errors.Errorf(errProviderConfigCycle, strings.Join(append(chain, ref.Name), " -> "))
+			}
+		}
+		chain = append(chain, ref.Name)
+
+		base := &apisv1alpha1.ProviderConfig{}
+		if err := kube.Get(ctx, types.NamespacedName{Name: ref.Name}, base); err != nil {
+			return nil, errors.Wrapf(err, errGetProviderConfig, ref.Name)
+		}
+
+		inheritProviderConfigSpec(&pc.Spec, base.Spec)
+		ref = base.Spec.BaseProviderConfigRef
+	}
+
+	return pc, nil
+}
+
+// inheritProviderConfigSpec fills the settings left unset in spec with the ones of base.
+func inheritProviderConfigSpec(spec *apisv1alpha1.ProviderConfigSpec, base apisv1alpha1.ProviderConfigSpec) {
+	if spec.MaxInFlightRequestsPerHost == nil {
+		spec.MaxInFlightRequestsPerHost = base.MaxInFlightRequestsPerHost
+	}
+
+	if spec.ResponseCacheTTL == nil {
+		spec.ResponseCacheTTL = base.ResponseCacheTTL
+	}
+
+	if spec.SourceAddress == "" {
+		spec.SourceAddress = base.SourceAddress
+	}
+
+	if spec.UnixSocketPath == "" {
+		spec.UnixSocketPath = base.UnixSocketPath
+	}
+
+	if spec.TLSServerName == "" {
+		spec.TLSServerName = base.TLSServerName
+	}
+
+	if spec.TLSPinnedPublicKeys == nil {
+		spec.TLSPinnedPublicKeys = base.TLSPinnedPublicKeys
+	}
+
+	if spec.CABundle == "" {
+		spec.CABundle = base.CABundle
+	}
+
+	if spec.BearerTokenFile == "" {
+		spec.BearerTokenFile = base.BearerTokenFile
+	}
+
+	if spec.CompressionNegotiation == nil {
+		spec.CompressionNegotiation = base.CompressionNegotiation
+	}
+
+	if spec.MaxRequestHeaderBytes == nil {
+		spec.MaxRequestHeaderBytes = base.MaxRequestHeaderBytes
+	}
+
+	if spec.Timeouts == nil {
+		spec.Timeouts = base.Timeouts
+	}
+
+	if spec.Canary == nil {
+		spec.Canary = base.Canary
+	}
+
+	if spec.Notifications == nil {
+		spec.Notifications = base.Notifications
+	}
+
+	if spec.RequestLog == nil {
+		spec.RequestLog = base.RequestLog
+	}
+
+	if spec.KillSwitch == nil {
+		spec.KillSwitch = base.KillSwitch
+	}
+}
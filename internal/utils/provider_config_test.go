@@ -0,0 +1,169 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func providerConfig(name string, maxInFlight *int32, base string) apisv1alpha1.ProviderConfig {
+	pc := apisv1alpha1.ProviderConfig{}
+	pc.SetName(name)
+	pc.Spec.Credentials.Source = xpv1.CredentialsSourceNone
+	pc.Spec.MaxInFlightRequestsPerHost = maxInFlight
+	if base != "" {
+		pc.Spec.BaseProviderConfigRef = &xpv1.Reference{Name: base}
+	}
+	return pc
+}
+
+func withBearerTokenFile(pc apisv1alpha1.ProviderConfig, path string) apisv1alpha1.ProviderConfig {
+	pc.Spec.BearerTokenFile = path
+	return pc
+}
+
+func mockGetProviderConfigs(pcs ...apisv1alpha1.ProviderConfig) test.MockGetFn {
+	return func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+		for _, pc := range pcs {
+			if pc.GetName() == key.Name {
+				*obj.(*apisv1alpha1.ProviderConfig) = pc
+				return nil
+			}
+		}
+		return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+	}
+}
+
+func Test_ResolveProviderConfig(t *testing.T) {
+	type args struct {
+		kube client.Client
+		name string
+	}
+	type want struct {
+		maxInFlight     *int32
+		bearerTokenFile string
+		err             error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoBase": {
+			args: args{
+				kube: &test.MockClient{MockGet: mockGetProviderConfigs(
+					providerConfig("child", int32Ptr(3), ""),
+				)},
+				name: "child",
+			},
+			want: want{
+				maxInFlight: int32Ptr(3),
+			},
+		},
+		"InheritsFromBaseChain": {
+			args: args{
+				kube: &test.MockClient{MockGet: mockGetProviderConfigs(
+					providerConfig("child", nil, "team"),
+					providerConfig("team", nil, "base"),
+					providerConfig("base", int32Ptr(5), ""),
+				)},
+				name: "child",
+			},
+			want: want{
+				maxInFlight: int32Ptr(5),
+			},
+		},
+		"ChildOverridesBase": {
+			args: args{
+				kube: &test.MockClient{MockGet: mockGetProviderConfigs(
+					providerConfig("child", int32Ptr(1), "base"),
+					providerConfig("base", int32Ptr(5), ""),
+				)},
+				name: "child",
+			},
+			want: want{
+				maxInFlight: int32Ptr(1),
+			},
+		},
+		"InheritsBearerTokenFile": {
+			args: args{
+				kube: &test.MockClient{MockGet: mockGetProviderConfigs(
+					providerConfig("child", nil, "base"),
+					withBearerTokenFile(providerConfig("base", nil, ""), "/var/run/secrets/tokens/api-token"),
+				)},
+				name: "child",
+			},
+			want: want{
+				bearerTokenFile: "/var/run/secrets/tokens/api-token",
+			},
+		},
+		"ChildOverridesBaseBearerTokenFile": {
+			args: args{
+				kube: &test.MockClient{MockGet: mockGetProviderConfigs(
+					withBearerTokenFile(providerConfig("child", nil, "base"), "/var/run/secrets/tokens/child-token"),
+					withBearerTokenFile(providerConfig("base", nil, ""), "/var/run/secrets/tokens/api-token"),
+				)},
+				name: "child",
+			},
+			want: want{
+				bearerTokenFile: "/var/run/secrets/tokens/child-token",
+			},
+		},
+		"Cycle": {
+			args: args{
+				kube: &test.MockClient{MockGet: mockGetProviderConfigs(
+					providerConfig("child", nil, "base"),
+					providerConfig("base", nil, "child"),
+				)},
+				name: "child",
+			},
+			want: want{
+				err: errors.Errorf(errProviderConfigCycle, "child -> base -> child"),
+			},
+		},
+		"BaseNotFound": {
+			args: args{
+				kube: &test.MockClient{MockGet: mockGetProviderConfigs(
+					providerConfig("child", nil, "missing"),
+				)},
+				name: "child",
+			},
+			want: want{
+				err: errors.Wrapf(kerrors.NewNotFound(schema.GroupResource{}, "missing"), errGetProviderConfig, "missing"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ResolveProviderConfig(context.Background(), tc.args.kube, tc.args.name)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("ResolveProviderConfig(...): -want error, +got error: %s", diff)
+			}
+
+			if gotErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.want.maxInFlight, got.Spec.MaxInFlightRequestsPerHost); diff != "" {
+				t.Fatalf("ResolveProviderConfig(...): -want maxInFlightRequestsPerHost, +got maxInFlightRequestsPerHost: %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.bearerTokenFile, got.Spec.BearerTokenFile); diff != "" {
+				t.Fatalf("ResolveProviderConfig(...): -want bearerTokenFile, +got bearerTokenFile: %s", diff)
+			}
+		})
+	}
+}
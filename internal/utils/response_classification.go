@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+)
+
+const (
+	errClassificationCondition = "response classification condition should return a boolean, but returned error: %s"
+	errConvertResponse         = "failed to convert response to map"
+)
+
+// ClassifyResponse returns the outcome of the response to a request sent with the given method. The first
+// response classification rule matching the response decides it; otherwise the outcome is derived from the
+// status code. Responses that are neither successes nor errors, such as redirects, have no outcome.
+func ClassifyResponse(forProvider v1alpha2.RequestParameters, method string, response httpClient.HttpResponse) (v1alpha2.ResponseOutcome, error) {
+	outcome, matched, err := ClassifyResponseByRules(forProvider, method, response)
+	if err != nil || matched {
+		return outcome, err
+	}
+
+	return ClassifyStatusCode(response.StatusCode), nil
+}
+
+// ClassifyResponseByRules returns the outcome of the first response classification rule matching the response
+// to a request sent with the given method. It reports false if no rule matches.
+func ClassifyResponseByRules(forProvider v1alpha2.RequestParameters, method string, response httpClient.HttpResponse) (v1alpha2.ResponseOutcome, bool, error) {
+	for _, rule := range forProvider.ResponseClassification {
+		matches, err := ruleMatches(rule, method, response)
+		if err != nil {
+			return "", false, err
+		}
+
+		if matches {
+			return rule.Outcome, true, nil
+		}
+	}
+
+	return "", false, nil
+}
+
+// ClassifyStatusCode returns the outcome derived from the status code of a response.
+func ClassifyStatusCode(statusCode int) v1alpha2.ResponseOutcome {
+	switch {
+	case IsHTTPSuccess(statusCode):
+		return v1alpha2.ResponseOutcomeSuccess
+	case statusCode == http.StatusNotFound:
+		return v1alpha2.ResponseOutcomeNotFound
+	case IsHTTPError(statusCode):
+		return v1alpha2.ResponseOutcomeTerminalError
+	default:
+		return ""
+	}
+}
+
+// ruleMatches reports whether the response matches the status codes, methods and condition of the rule.
+func ruleMatches(rule v1alpha2.ResponseClassificationRule, method string, response httpClient.HttpResponse) (bool, error) {
+	if !slices.Contains(rule.StatusCodes, int32(response.StatusCode)) {
+		return false, nil
+	}
+
+	if len(rule.Methods) > 0 && !slices.Contains(rule.Methods, method) {
+		return false, nil
+	}
+
+	if rule.Condition == "" {
+		return true, nil
+	}
+
+	responseMap, err := json_util.StructToMap(response)
+	if err != nil {
+		return false, errors.Wrap(err, errConvertResponse)
+	}
+
+	json_util.ConvertJSONStringsToMaps(&responseMap)
+
+	matches, err := jq.ParseBool(rule.Condition, responseMap)
+	if err != nil {
+		return false, errors.Errorf(errClassificationCondition, err.Error())
+	}
+
+	return matches, nil
+}
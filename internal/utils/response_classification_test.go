@@ -0,0 +1,131 @@
+package utils
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_ClassifyResponse(t *testing.T) {
+	forProvider := v1alpha2.RequestParameters{
+		ResponseClassification: []v1alpha2.ResponseClassificationRule{
+			{StatusCodes: []int32{http.StatusNotFound}, Methods: []string{http.MethodDelete}, Outcome: v1alpha2.ResponseOutcomeSuccess},
+			{StatusCodes: []int32{http.StatusUnprocessableEntity, http.StatusServiceUnavailable}, Outcome: v1alpha2.ResponseOutcomeRetryableError},
+			{StatusCodes: []int32{http.StatusOK}, Condition: `.body.deleted == true`, Outcome: v1alpha2.ResponseOutcomeNotFound},
+		},
+	}
+
+	type args struct {
+		forProvider v1alpha2.RequestParameters
+		method      string
+		response    httpClient.HttpResponse
+	}
+	type want struct {
+		result v1alpha2.ResponseOutcome
+		err    error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"DefaultSuccess": {
+			args: args{
+				method:   http.MethodGet,
+				response: httpClient.HttpResponse{StatusCode: http.StatusCreated},
+			},
+			want: want{result: v1alpha2.ResponseOutcomeSuccess},
+		},
+		"DefaultNotFound": {
+			args: args{
+				method:   http.MethodGet,
+				response: httpClient.HttpResponse{StatusCode: http.StatusNotFound},
+			},
+			want: want{result: v1alpha2.ResponseOutcomeNotFound},
+		},
+		"DefaultTerminalError": {
+			args: args{
+				method:   http.MethodPost,
+				response: httpClient.HttpResponse{StatusCode: http.StatusInternalServerError},
+			},
+			want: want{result: v1alpha2.ResponseOutcomeTerminalError},
+		},
+		"DefaultNoOutcome": {
+			args: args{
+				method:   http.MethodGet,
+				response: httpClient.HttpResponse{StatusCode: http.StatusFound},
+			},
+			want: want{result: ""},
+		},
+		"RuleForMethod": {
+			args: args{
+				forProvider: forProvider,
+				method:      http.MethodDelete,
+				response:    httpClient.HttpResponse{StatusCode: http.StatusNotFound},
+			},
+			want: want{result: v1alpha2.ResponseOutcomeSuccess},
+		},
+		"RuleForOtherMethod": {
+			args: args{
+				forProvider: forProvider,
+				method:      http.MethodGet,
+				response:    httpClient.HttpResponse{StatusCode: http.StatusNotFound},
+			},
+			want: want{result: v1alpha2.ResponseOutcomeNotFound},
+		},
+		"RuleForAnyMethod": {
+			args: args{
+				forProvider: forProvider,
+				method:      http.MethodPut,
+				response:    httpClient.HttpResponse{StatusCode: http.StatusServiceUnavailable},
+			},
+			want: want{result: v1alpha2.ResponseOutcomeRetryableError},
+		},
+		"RuleConditionMet": {
+			args: args{
+				forProvider: forProvider,
+				method:      http.MethodGet,
+				response:    httpClient.HttpResponse{StatusCode: http.StatusOK, Body: `{"id":"123","deleted":true}`},
+			},
+			want: want{result: v1alpha2.ResponseOutcomeNotFound},
+		},
+		"RuleConditionNotMet": {
+			args: args{
+				forProvider: forProvider,
+				method:      http.MethodGet,
+				response:    httpClient.HttpResponse{StatusCode: http.StatusOK, Body: `{"id":"123","deleted":false}`},
+			},
+			want: want{result: v1alpha2.ResponseOutcomeSuccess},
+		},
+		"RuleConditionNotBoolean": {
+			args: args{
+				forProvider: v1alpha2.RequestParameters{
+					ResponseClassification: []v1alpha2.ResponseClassificationRule{
+						{StatusCodes: []int32{http.StatusOK}, Condition: `.body.id`, Outcome: v1alpha2.ResponseOutcomeNotFound},
+					},
+				},
+				method:   http.MethodGet,
+				response: httpClient.HttpResponse{StatusCode: http.StatusOK, Body: `{"id":"123"}`},
+			},
+			want: want{err: errors.Errorf(errClassificationCondition, "failed to parse string: 123")},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ClassifyResponse(tc.args.forProvider, tc.args.method, tc.args.response)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("ClassifyResponse(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("ClassifyResponse(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
@@ -2,8 +2,10 @@ package utils
 
 import (
 	"context"
+	"time"
 
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -19,6 +21,9 @@ type RequestResource struct {
 	HttpResponse   httpClient.HttpResponse
 	HttpRequest    httpClient.HttpRequest
 	LocalClient    client.Client
+
+	// Duration is how long the request took, as measured by the client.
+	Duration time.Duration
 }
 
 func (rr *RequestResource) SetStatusCode() SetRequestStatusFunc {
@@ -45,7 +50,7 @@ func (rr *RequestResource) SetBody() SetRequestStatusFunc {
 	return func() {
 		if resp, ok := rr.Resource.(ResponseSetter); ok {
 			if rr.HttpResponse.Body != "" {
-				resp.SetBody(rr.HttpResponse.Body)
+				resp.SetBody(storedBody(rr.Resource, rr.HttpResponse.Body))
 			}
 		}
 	}
@@ -58,6 +63,9 @@ func (rr *RequestResource) SetRequestDetails() SetRequestStatusFunc {
 				resp.SetRequestDetails(rr.HttpRequest.URL, rr.HttpRequest.Method, rr.HttpRequest.Body, rr.HttpRequest.Headers)
 			}
 		}
+		if setter, ok := rr.Resource.(RequestActionSetter); ok && rr.HttpRequest.Method != "" {
+			setter.SetRequestAction(rr.HttpRequest.Action)
+		}
 	}
 }
 
@@ -80,7 +88,68 @@ func (rr *RequestResource) SetLastReconcileTime() SetRequestStatusFunc {
 func (rr *RequestResource) SetCache() SetRequestStatusFunc {
 	return func() {
 		if cached, ok := rr.Resource.(CacheSetter); ok {
-			cached.SetCache(rr.HttpResponse.StatusCode, rr.HttpResponse.Headers, rr.HttpResponse.Body)
+			cached.SetCache(rr.HttpResponse.StatusCode, rr.HttpResponse.Headers, storedBody(rr.Resource, rr.HttpResponse.Body))
+		}
+	}
+}
+
+func (rr *RequestResource) SetLastAppliedBody() SetRequestStatusFunc {
+	return func() {
+		if lastApplied, ok := rr.Resource.(LastAppliedBodySetter); ok {
+			lastApplied.SetLastAppliedBody(json_util.CanonicalizeJSONString(rr.HttpRequest.Body))
+		}
+	}
+}
+
+func (rr *RequestResource) SetSecretsFingerprint(fingerprint string) SetRequestStatusFunc {
+	return func() {
+		if setter, ok := rr.Resource.(SecretsFingerprintSetter); ok {
+			setter.SetSecretsFingerprint(fingerprint)
+		}
+	}
+}
+
+func (rr *RequestResource) SetLastAppliedGeneration(generation int64) SetRequestStatusFunc {
+	return func() {
+		if setter, ok := rr.Resource.(LastAppliedGenerationSetter); ok {
+			setter.SetLastAppliedGeneration(generation)
+		}
+	}
+}
+
+func (rr *RequestResource) SetOperation(url string) SetRequestStatusFunc {
+	return func() {
+		if setter, ok := rr.Resource.(OperationSetter); ok {
+			setter.SetOperation(url, rr.HttpRequest.Method)
+		}
+	}
+}
+
+func (rr *RequestResource) SetMultiStatusFailures(failures []string) SetRequestStatusFunc {
+	return func() {
+		if setter, ok := rr.Resource.(MultiStatusFailuresSetter); ok {
+			setter.SetMultiStatusFailures(failures)
+		}
+	}
+}
+
+func (rr *RequestResource) SetLastAction(action string) SetRequestStatusFunc {
+	return func() {
+		if setter, ok := rr.Resource.(LastActionSetter); ok {
+			setter.SetLastAction(action)
+		}
+	}
+}
+
+// RecordLatency records the latency of the request in the resource's status, unless no request was sent.
+func (rr *RequestResource) RecordLatency(succeeded bool) SetRequestStatusFunc {
+	return func() {
+		if rr.HttpRequest.Method == "" {
+			return
+		}
+
+		if recorder, ok := rr.Resource.(LatencyRecorder); ok {
+			recorder.RecordLatency(rr.Duration, succeeded, time.Now())
 		}
 	}
 }
@@ -93,6 +162,14 @@ func (rr *RequestResource) SetError(err error) SetRequestStatusFunc {
 	}
 }
 
+func (rr *RequestResource) SetErrorDetails(code, message string) SetRequestStatusFunc {
+	return func() {
+		if setter, ok := rr.Resource.(ErrorDetailsSetter); ok {
+			setter.SetErrorDetails(code, message)
+		}
+	}
+}
+
 func (rr *RequestResource) ResetFailures() SetRequestStatusFunc {
 	return func() {
 		if resetter, ok := rr.Resource.(ResetFailures); ok {
@@ -101,6 +178,15 @@ func (rr *RequestResource) ResetFailures() SetRequestStatusFunc {
 	}
 }
 
+// ResetFailureCount returns a setter resetting the failures counter of the resource without clearing its error.
+func (rr *RequestResource) ResetFailureCount() SetRequestStatusFunc {
+	return func() {
+		if resetter, ok := rr.Resource.(FailureCountResetter); ok {
+			resetter.ResetFailureCount()
+		}
+	}
+}
+
 type ResponseSetter interface {
 	SetStatusCode(statusCode int)
 	SetHeaders(headers map[string][]string)
@@ -111,10 +197,42 @@ type CacheSetter interface {
 	SetCache(statusCode int, headers map[string][]string, body string)
 }
 
+type LastAppliedBodySetter interface {
+	SetLastAppliedBody(body string)
+}
+
+type LatencyRecorder interface {
+	RecordLatency(attempt time.Duration, succeeded bool, now time.Time)
+}
+
+type SecretsFingerprintSetter interface {
+	SetSecretsFingerprint(fingerprint string)
+}
+
+type LastAppliedGenerationSetter interface {
+	SetLastAppliedGeneration(generation int64)
+}
+
+type OperationSetter interface {
+	SetOperation(url, method string)
+}
+
+type MultiStatusFailuresSetter interface {
+	SetMultiStatusFailures(failures []string)
+}
+
+type LastActionSetter interface {
+	SetLastAction(action string)
+}
+
 type SyncedSetter interface {
 	SetSynced(synced bool)
 }
 
+type ErrorDetailsSetter interface {
+	SetErrorDetails(code, message string)
+}
+
 type ErrorSetter interface {
 	SetError(err error)
 }
@@ -123,6 +241,10 @@ type ResetFailures interface {
 	ResetFailures()
 }
 
+type FailureCountResetter interface {
+	ResetFailureCount()
+}
+
 type LastReconcileTimeSetter interface {
 	SetLastReconcileTime()
 }
@@ -131,6 +253,10 @@ type RequestDetailsSetter interface {
 	SetRequestDetails(url, method, body string, headers map[string][]string)
 }
 
+type RequestActionSetter interface {
+	SetRequestAction(action string)
+}
+
 func SetRequestResourceStatus(rr RequestResource, statusFuncs ...SetRequestStatusFunc) error {
 	for _, updateStatusFunc := range statusFuncs {
 		updateStatusFunc()
@@ -3,11 +3,13 @@ package utils
 import (
 	"context"
 	"testing"
+	"time"
 
 	v1alpha1_disposable "github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
 	v1alpha1_request "github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
@@ -275,3 +277,99 @@ func Test_DisposableRequest_SetRequestResourceStatus(t *testing.T) {
 		})
 	}
 }
+
+func Test_RecordLatency(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	type attempt struct {
+		duration  time.Duration
+		succeeded bool
+		// at is when the attempt finished, relative to now.
+		at time.Duration
+	}
+	type args struct {
+		measurement v1alpha1_request.LatencyMeasurement
+		attempts    []attempt
+	}
+	type want struct {
+		latency *v1alpha1_request.RequestLatency
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"RollingAverage": {
+			args: args{
+				attempts: []attempt{
+					{duration: 100 * time.Millisecond, succeeded: true},
+					{duration: 300 * time.Millisecond, succeeded: true},
+				},
+			},
+			want: want{
+				latency: &v1alpha1_request.RequestLatency{
+					Last:    metav1.Duration{Duration: 300 * time.Millisecond},
+					Average: metav1.Duration{Duration: 200 * time.Millisecond},
+					Samples: 2,
+				},
+			},
+		},
+		"TotalIncludesRetries": {
+			args: args{
+				attempts: []attempt{
+					{duration: 200 * time.Millisecond, succeeded: false, at: -10 * time.Second},
+					{duration: 100 * time.Millisecond, succeeded: false, at: -5 * time.Second},
+					{duration: 100 * time.Millisecond, succeeded: true},
+				},
+			},
+			want: want{
+				latency: &v1alpha1_request.RequestLatency{
+					Last:    metav1.Duration{Duration: 10*time.Second + 200*time.Millisecond},
+					Average: metav1.Duration{Duration: 10*time.Second + 200*time.Millisecond},
+					Samples: 1,
+				},
+			},
+		},
+		"TotalWhileRetrying": {
+			args: args{
+				attempts: []attempt{
+					{duration: 200 * time.Millisecond, succeeded: false},
+				},
+			},
+			want: want{
+				latency: &v1alpha1_request.RequestLatency{
+					RetryingSince: &metav1.Time{Time: now.Add(-200 * time.Millisecond)},
+				},
+			},
+		},
+		"PerAttempt": {
+			args: args{
+				measurement: v1alpha1_request.LatencyMeasurementPerAttempt,
+				attempts: []attempt{
+					{duration: 200 * time.Millisecond, succeeded: false, at: -10 * time.Second},
+					{duration: 100 * time.Millisecond, succeeded: true},
+				},
+			},
+			want: want{
+				latency: &v1alpha1_request.RequestLatency{
+					Last:    metav1.Duration{Duration: 100 * time.Millisecond},
+					Average: metav1.Duration{Duration: 150 * time.Millisecond},
+					Samples: 2,
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1_request.Request{}
+			cr.Spec.ForProvider.LatencyMeasurement = tc.args.measurement
+
+			for _, a := range tc.args.attempts {
+				cr.RecordLatency(a.duration, a.succeeded, now.Add(a.at))
+			}
+
+			if diff := cmp.Diff(tc.want.latency, cr.Status.Latency); diff != "" {
+				t.Errorf("RecordLatency(...): -want latency, +got latency: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"context"
+	"time"
+)
+
+type shutdownGracePeriodKey struct{}
+
+// WithShutdownGracePeriod returns a context whose descendants may drain their in-flight requests for up to the
+// given grace period once it is cancelled, such as when the provider is asked to shut down.
+func WithShutdownGracePeriod(ctx context.Context, gracePeriod time.Duration) context.Context {
+	return context.WithValue(ctx, shutdownGracePeriodKey{}, gracePeriod)
+}
+
+// DrainOnShutdown returns a context that outlives the cancellation of the given one by the shutdown grace period
+// it carries, so that a request in flight when the provider shuts down can complete, along with the status
+// updates recording it, rather than leaving the external resource half-created. The deadline of the given
+// context still applies. The returned function must be called once the work is done.
+func DrainOnShutdown(ctx context.Context) (context.Context, context.CancelFunc) {
+	gracePeriod, ok := ctx.Value(shutdownGracePeriodKey{}).(time.Duration)
+	if !ok || gracePeriod <= 0 {
+		return ctx, func() {}
+	}
+
+	drained := context.WithoutCancel(ctx)
+	cancelDeadline := context.CancelFunc(func() {})
+	if deadline, ok := ctx.Deadline(); ok {
+		drained, cancelDeadline = context.WithDeadline(drained, deadline)
+	}
+	drained, cancel := context.WithCancel(drained)
+
+	stop := context.AfterFunc(ctx, func() {
+		timer := time.NewTimer(gracePeriod)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+			cancel()
+		case <-drained.Done():
+		}
+	})
+
+	return drained, func() {
+		stop()
+		cancel()
+		cancelDeadline()
+	}
+}
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_DrainOnShutdown(t *testing.T) {
+	type args struct {
+		gracePeriod time.Duration
+		timeout     time.Duration
+	}
+	type want struct {
+		aliveAfterCancel bool
+		aliveAfterGrace  bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoGracePeriod": {
+			args: args{},
+			want: want{},
+		},
+		"DrainedForTheGracePeriod": {
+			args: args{
+				gracePeriod: 100 * time.Millisecond,
+			},
+			want: want{
+				aliveAfterCancel: true,
+			},
+		},
+		"DeadlineStillApplies": {
+			args: args{
+				gracePeriod: time.Hour,
+				timeout:     50 * time.Millisecond,
+			},
+			want: want{
+				aliveAfterCancel: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			parent, cancelParent := context.WithCancel(context.Background())
+			defer cancelParent()
+
+			ctx := parent
+			if tc.args.gracePeriod > 0 {
+				ctx = WithShutdownGracePeriod(ctx, tc.args.gracePeriod)
+			}
+			if tc.args.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.args.timeout)
+				defer cancel()
+			}
+
+			drained, done := DrainOnShutdown(ctx)
+			defer done()
+
+			cancelParent()
+			if diff := cmp.Diff(tc.want.aliveAfterCancel, drained.Err() == nil); diff != "" {
+				t.Errorf("DrainOnShutdown(...): -want alive after cancel, +got alive after cancel: %s", diff)
+			}
+
+			time.Sleep(200 * time.Millisecond)
+			if diff := cmp.Diff(tc.want.aliveAfterGrace, drained.Err() == nil); diff != "" {
+				t.Errorf("DrainOnShutdown(...): -want alive after grace period, +got alive after grace period: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_DrainOnShutdown_Done(t *testing.T) {
+	drained, done := DrainOnShutdown(WithShutdownGracePeriod(context.Background(), time.Hour))
+	done()
+
+	if drained.Err() == nil {
+		t.Errorf("DrainOnShutdown(...): want the context cancelled once done")
+	}
+}
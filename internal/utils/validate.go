@@ -1,15 +1,21 @@
 package utils
 
 import (
+	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
+
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 )
 
 const (
-	errEmptyMethod = "no method is specified"
-	ErrInvalidURL  = "invalid url %s"
-	ErrStatusCode  = "HTTP %s request failed with status code: %s"
+	errEmptyMethod   = "no method is specified"
+	ErrInvalidMethod = "invalid method %q, methods must be HTTP tokens such as GET or PURGE"
+	ErrInvalidURL    = "invalid url %s"
+	ErrStatusCode    = "HTTP %s request failed with status code: %s"
 )
 
 func IsRequestValid(method string, url string) error {
@@ -17,6 +23,10 @@ func IsRequestValid(method string, url string) error {
 		return errors.New(errEmptyMethod)
 	}
 
+	if !IsMethodValid(method) {
+		return errors.Errorf(ErrInvalidMethod, method)
+	}
+
 	if !IsUrlValid(url) {
 		return errors.Errorf(ErrInvalidURL, url)
 	}
@@ -24,6 +34,18 @@ func IsRequestValid(method string, url string) error {
 	return nil
 }
 
+// StatusCodeError returns the error of a request sent with the given method that got a response with an error
+// status code. The error of a 431 Request Header Fields Too Large response wraps httpClient.ErrHeadersTooLarge,
+// so that it can be told apart from the other failures.
+func StatusCodeError(method string, statusCode int) error {
+	err := errors.Errorf(ErrStatusCode, method, strconv.Itoa(statusCode))
+	if statusCode == http.StatusRequestHeaderFieldsTooLarge {
+		return errors.Wrap(httpClient.ErrHeadersTooLarge, err.Error())
+	}
+
+	return err
+}
+
 // IsHTTPSuccess checks if an HTTP status code indicates success.
 func IsHTTPSuccess(statusCode int) bool {
 	return statusCode >= 200 && statusCode < 300
@@ -38,3 +60,17 @@ func IsUrlValid(input string) bool {
 	u, err := url.ParseRequestURI(input)
 	return err == nil && u.Scheme != "" && u.Host != ""
 }
+
+// IsMethodValid reports whether method is a token, as net/http requires of request methods. Methods beyond the
+// standard ones, such as PURGE or the WebDAV methods, are accepted.
+func IsMethodValid(method string) bool {
+	return method != "" && strings.IndexFunc(method, isNotTokenChar) == -1
+}
+
+// isNotTokenChar reports whether r isn't allowed in a token, as defined by RFC 7230.
+func isNotTokenChar(r rune) bool {
+	if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+		return false
+	}
+	return !strings.ContainsRune("!#$%&'*+-.^_`|~", r)
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 )
 
 func Test_IsRequestValid(t *testing.T) {
@@ -39,6 +41,24 @@ func Test_IsRequestValid(t *testing.T) {
 				err: errors.Errorf(ErrInvalidURL, "invalid-url"),
 			},
 		},
+		"CustomMethod": {
+			args: args{
+				method: "PROPFIND",
+				url:    "https://www.example.com",
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"InvalidMethod": {
+			args: args{
+				method: "GET ME",
+				url:    "https://www.example.com",
+			},
+			want: want{
+				err: errors.Errorf(ErrInvalidMethod, "GET ME"),
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -163,3 +183,34 @@ func Test_IsUrlValid(t *testing.T) {
 		})
 	}
 }
+
+func Test_StatusCodeError(t *testing.T) {
+	cases := map[string]struct {
+		statusCode int
+		want       error
+		tooLarge   bool
+	}{
+		"ServerError": {
+			statusCode: http.StatusInternalServerError,
+			want:       errors.Errorf(ErrStatusCode, http.MethodPost, "500"),
+		},
+		"HeaderFieldsTooLarge": {
+			statusCode: http.StatusRequestHeaderFieldsTooLarge,
+			want:       errors.Wrap(httpClient.ErrHeadersTooLarge, "HTTP POST request failed with status code: 431"),
+			tooLarge:   true,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			err := StatusCodeError(http.MethodPost, tc.statusCode)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("StatusCodeError(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.tooLarge, httpClient.IsHeadersTooLarge(err)); diff != "" {
+				t.Errorf("IsHeadersTooLarge(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}